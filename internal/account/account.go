@@ -0,0 +1,253 @@
+// Package account runs the pool of Claude accounts configured in
+// mayor/accounts.json. It records which tmux session runs on which account
+// and which accounts are cooling down after hitting a usage or rate limit,
+// and picks the least-loaded available account for new sessions and for
+// sessions the daemon rotates off a limited account.
+//
+// Runtime state lives in .runtime/accounts-state.json at the town root;
+// accounts.json itself stays user-edited configuration.
+package account
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// EnvConfigDir is the environment variable that selects a Claude account.
+const EnvConfigDir = "CLAUDE_CONFIG_DIR"
+
+// ErrNoAccount means every candidate account is cooling down.
+var ErrNoAccount = errors.New("every pool account is cooling down")
+
+// Status is the runtime state of one account.
+type Status struct {
+	// CooldownUntil is when the account's limit resets; zero if available.
+	CooldownUntil time.Time `json:"cooldown_until,omitempty"`
+
+	// Reason is the limit message that started the cooldown.
+	Reason string `json:"reason,omitempty"`
+
+	// LimitHits counts limits detected on the account.
+	LimitHits int `json:"limit_hits,omitempty"`
+
+	// LastLimit is when a limit was last detected.
+	LastLimit time.Time `json:"last_limit,omitempty"`
+}
+
+// State is the pool's runtime state.
+type State struct {
+	Accounts map[string]*Status `json:"accounts"`
+
+	// Sessions maps tmux session names to the account they run on.
+	Sessions map[string]string `json:"sessions"`
+}
+
+// StatePath returns the path of the pool's runtime state file.
+func StatePath(townRoot string) string {
+	return filepath.Join(constants.TownRuntimePath(townRoot), "accounts-state.json")
+}
+
+// Load reads the pool state, returning empty state if there is none.
+func Load(townRoot string) (*State, error) {
+	s := &State{}
+	data, err := os.ReadFile(StatePath(townRoot))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("reading account state: %w", err)
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, s); err != nil {
+			return nil, fmt.Errorf("parsing account state: %w", err)
+		}
+	}
+	if s.Accounts == nil {
+		s.Accounts = make(map[string]*Status)
+	}
+	if s.Sessions == nil {
+		s.Sessions = make(map[string]string)
+	}
+	return s, nil
+}
+
+// Update applies fn to the pool state under an exclusive lock and saves it,
+// so spawns and the daemon don't lose each other's changes. Nothing is
+// saved if fn returns an error.
+func Update(townRoot string, fn func(*State) error) error {
+	path := StatePath(townRoot)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating runtime dir: %w", err)
+	}
+	return util.WithFileLock(path, func() error {
+		s, err := Load(townRoot)
+		if err != nil {
+			return err
+		}
+		if err := fn(s); err != nil {
+			return err
+		}
+		return util.AtomicWriteJSON(path, s)
+	})
+}
+
+// status returns handle's status, creating it if needed.
+func (s *State) status(handle string) *Status {
+	st := s.Accounts[handle]
+	if st == nil {
+		st = &Status{}
+		s.Accounts[handle] = st
+	}
+	return st
+}
+
+// CoolingDown reports whether handle is waiting for a limit to reset.
+func (s *State) CoolingDown(handle string, now time.Time) bool {
+	st := s.Accounts[handle]
+	return st != nil && now.Before(st.CooldownUntil)
+}
+
+// MarkLimited starts a cooldown on handle until the limit resets.
+func (s *State) MarkLimited(handle string, limit *Limit, now time.Time) {
+	st := s.status(handle)
+	st.CooldownUntil = limit.ResetAt
+	st.Reason = limit.Reason
+	st.LimitHits++
+	st.LastLimit = now
+}
+
+// ClearCooldown makes handle available again.
+func (s *State) ClearCooldown(handle string) {
+	if st := s.Accounts[handle]; st != nil {
+		st.CooldownUntil = time.Time{}
+		st.Reason = ""
+	}
+}
+
+// Assign records that session runs on handle.
+func (s *State) Assign(session, handle string) {
+	s.Sessions[session] = handle
+}
+
+// SessionsOn returns the sessions running on handle, sorted.
+func (s *State) SessionsOn(handle string) []string {
+	var sessions []string
+	for session, h := range s.Sessions {
+		if h == handle {
+			sessions = append(sessions, session)
+		}
+	}
+	sort.Strings(sessions)
+	return sessions
+}
+
+// Prune forgets sessions that are no longer alive.
+func (s *State) Prune(alive func(session string) bool) {
+	for session := range s.Sessions {
+		if !alive(session) {
+			delete(s.Sessions, session)
+		}
+	}
+}
+
+// Select picks the account for a new session from candidates: the one with
+// the fewest sessions among those not cooling down, preferring preferred
+// (the default account) and then handle order on ties. If every candidate
+// is cooling down it returns ErrNoAccount, naming the earliest reset.
+func Select(candidates []string, s *State, preferred string, now time.Time) (string, error) {
+	best := ""
+	bestLoad := 0
+	var soonest time.Time
+	for _, handle := range sortedCandidates(candidates, preferred) {
+		if s.CoolingDown(handle, now) {
+			if until := s.Accounts[handle].CooldownUntil; soonest.IsZero() || until.Before(soonest) {
+				soonest = until
+			}
+			continue
+		}
+		load := len(s.SessionsOn(handle))
+		if best == "" || load < bestLoad {
+			best, bestLoad = handle, load
+		}
+	}
+	if best == "" {
+		if soonest.IsZero() {
+			return "", fmt.Errorf("no accounts to choose from")
+		}
+		return "", fmt.Errorf("%w (next reset %s)", ErrNoAccount, soonest.Format(time.Kitchen))
+	}
+	return best, nil
+}
+
+// sortedCandidates orders candidates with preferred first, then by handle.
+func sortedCandidates(candidates []string, preferred string) []string {
+	sorted := append([]string(nil), candidates...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if (sorted[i] == preferred) != (sorted[j] == preferred) {
+			return sorted[i] == preferred
+		}
+		return sorted[i] < sorted[j]
+	})
+	return sorted
+}
+
+// Resolve picks the account for a new session. GT_ACCOUNT and an explicit
+// account flag behave as in config.ResolveAccountConfigDir; otherwise, if
+// the town has an account pool, the least-loaded pool account that isn't
+// cooling down is used instead of the default. Returns empty strings when
+// no accounts are configured.
+func Resolve(townRoot, accountFlag string) (configDir, handle string, err error) {
+	accountsPath := constants.MayorAccountsPath(townRoot)
+	cfg, err := config.LoadAccountsConfig(accountsPath)
+	if err != nil || len(cfg.Pool) == 0 || os.Getenv("GT_ACCOUNT") != "" || accountFlag != "" {
+		return config.ResolveAccountConfigDir(accountsPath, accountFlag)
+	}
+
+	state, err := Load(townRoot)
+	if err != nil {
+		return "", "", err
+	}
+	handle, err = Select(cfg.Pool, state, cfg.Default, time.Now())
+	if err != nil {
+		return "", "", err
+	}
+	return config.ExpandAccountConfigDir(cfg.GetAccount(handle)), handle, nil
+}
+
+// Assign records that session runs on handle. It is a no-op for sessions
+// without an account.
+func Assign(townRoot, session, handle string) error {
+	if handle == "" {
+		return nil
+	}
+	return Update(townRoot, func(s *State) error {
+		s.Assign(session, handle)
+		return nil
+	})
+}
+
+// HandleForConfigDir returns the account whose config dir is dir, or "".
+func HandleForConfigDir(cfg *config.AccountsConfig, dir string) string {
+	for handle, acct := range cfg.Accounts {
+		if filepath.Clean(config.ExpandAccountConfigDir(&acct)) == filepath.Clean(dir) {
+			return handle
+		}
+	}
+	return ""
+}
+
+// ExportCommand prefixes an agent startup command with CLAUDE_CONFIG_DIR,
+// so the agent runs on the account even when the command is sent to a
+// shell that predates the tmux session environment.
+func ExportCommand(configDir, command string) string {
+	if configDir == "" {
+		return command
+	}
+	return fmt.Sprintf("export %s=%s && %s", EnvConfigDir, util.ShellQuote(configDir), command)
+}
@@ -0,0 +1,135 @@
+package account
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+)
+
+func TestSelect_LeastLoadedAvailable(t *testing.T) {
+	now := time.Now()
+	s := &State{Accounts: map[string]*Status{}, Sessions: map[string]string{
+		"gt-gastown-toast": "work",
+		"gt-gastown-nux":   "work",
+		"gt-beads-ace":     "spare",
+	}}
+
+	if got, err := Select([]string{"work", "spare", "extra"}, s, "work", now); err != nil || got != "extra" {
+		t.Errorf("Select = %q, %v; want extra (no sessions)", got, err)
+	}
+
+	// Ties go to the default account
+	if got, _ := Select([]string{"spare", "work"}, &State{Accounts: map[string]*Status{}, Sessions: map[string]string{}}, "work", now); got != "work" {
+		t.Errorf("Select tie = %q, want default work", got)
+	}
+
+	s.MarkLimited("extra", &Limit{Reason: "usage limit reached", ResetAt: now.Add(time.Hour)}, now)
+	if got, _ := Select([]string{"work", "spare", "extra"}, s, "work", now); got != "spare" {
+		t.Errorf("Select with extra cooling = %q, want spare", got)
+	}
+	if !s.CoolingDown("extra", now) || s.CoolingDown("extra", now.Add(2*time.Hour)) {
+		t.Error("extra should cool down for an hour")
+	}
+
+	s.MarkLimited("work", &Limit{ResetAt: now.Add(time.Hour)}, now)
+	s.MarkLimited("spare", &Limit{ResetAt: now.Add(time.Hour)}, now)
+	if _, err := Select([]string{"work", "spare", "extra"}, s, "work", now); !errors.Is(err, ErrNoAccount) {
+		t.Errorf("Select with all cooling: err = %v, want ErrNoAccount", err)
+	}
+}
+
+func TestUpdateAndPrune(t *testing.T) {
+	town := t.TempDir()
+	if err := Assign(town, "gt-gastown-toast", "work"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Assign(town, "gt-gastown-crew-max", "spare"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Assign(town, "gt-mayor", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	err := Update(town, func(s *State) error {
+		s.Prune(func(session string) bool { return session != "gt-gastown-toast" })
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := Load(town)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Sessions) != 1 || s.Sessions["gt-gastown-crew-max"] != "spare" {
+		t.Errorf("Sessions = %v, want only crew max on spare", s.Sessions)
+	}
+}
+
+func TestResolve_Pool(t *testing.T) {
+	town := t.TempDir()
+	t.Setenv("GT_ACCOUNT", "")
+	cfg := config.NewAccountsConfig()
+	cfg.Accounts["work"] = config.Account{ConfigDir: "/accts/work"}
+	cfg.Accounts["spare"] = config.Account{ConfigDir: "/accts/spare"}
+	cfg.Default = "work"
+	if err := config.SaveAccountsConfig(constants.MayorAccountsPath(town), cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	// Without a pool, the default account is used regardless of load
+	if err := Assign(town, "gt-gastown-toast", "work"); err != nil {
+		t.Fatal(err)
+	}
+	if _, handle, err := Resolve(town, ""); err != nil || handle != "work" {
+		t.Errorf("Resolve without pool = %q, %v; want work", handle, err)
+	}
+
+	cfg.Pool = []string{"work", "spare"}
+	if err := config.SaveAccountsConfig(constants.MayorAccountsPath(town), cfg); err != nil {
+		t.Fatal(err)
+	}
+	dir, handle, err := Resolve(town, "")
+	if err != nil || handle != "spare" || dir != "/accts/spare" {
+		t.Errorf("Resolve with pool = %q, %q, %v; want spare", dir, handle, err)
+	}
+
+	// An explicit account wins over the pool
+	if _, handle, _ := Resolve(town, "work"); handle != "work" {
+		t.Errorf("Resolve(work) = %q", handle)
+	}
+
+	if got := HandleForConfigDir(cfg, "/accts/spare/"); got != "spare" {
+		t.Errorf("HandleForConfigDir = %q, want spare", got)
+	}
+}
+
+func TestExportCommand(t *testing.T) {
+	if got := ExportCommand("", "exec claude"); got != "exec claude" {
+		t.Errorf("ExportCommand without dir = %q", got)
+	}
+	got := ExportCommand("/home/me/.claude-accounts/my work", "exec claude")
+	if want := "export CLAUDE_CONFIG_DIR='/home/me/.claude-accounts/my work' && exec claude"; got != want {
+		t.Errorf("ExportCommand = %q, want %q", got, want)
+	}
+}
+
+func TestStatePath(t *testing.T) {
+	town := t.TempDir()
+	if err := Assign(town, "gt-mayor", "work"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(town, ".runtime", "accounts-state.json")); err != nil {
+		t.Errorf("state not written under .runtime: %v", err)
+	}
+	if !strings.HasSuffix(StatePath(town), "accounts-state.json") {
+		t.Errorf("StatePath = %s", StatePath(town))
+	}
+}
@@ -0,0 +1,117 @@
+package account
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cooldowns used when a limit message doesn't say when the limit resets.
+const (
+	DefaultUsageCooldown = time.Hour
+	DefaultRateCooldown  = 5 * time.Minute
+)
+
+// Limit is a usage or rate limit reported in an agent's pane.
+type Limit struct {
+	// Reason is the matched limit message.
+	Reason string
+
+	// ResetAt is when the account can be used again.
+	ResetAt time.Time
+}
+
+// Claude prints limit messages at the start of a line, or after the "⎿"
+// gutter of a result. Matching only there keeps the same words in a file
+// being read, grep output or a test log from counting as a limit.
+const claudeLinePrefix = `^\s*(?:⎿\s*)?`
+
+var (
+	// "Claude AI usage limit reached|1767225600" (reset as a unix time)
+	epochLimitRe = regexp.MustCompile(claudeLinePrefix + `Claude AI usage limit reached\|(\d{9,})`)
+
+	// "Claude usage limit reached. Your limit will reset at 5pm (America/New_York)."
+	// "5-hour limit reached ∙ resets 3:30pm"
+	usageLimitRe = regexp.MustCompile(`(?i)` + claudeLinePrefix +
+		`(?:claude (?:ai )?)?(usage limit reached|(?:\d+-hour|weekly|session|opus|sonnet) limit reached|you've hit your limit|(?:you're )?out of extra usage)`)
+	resetRe = regexp.MustCompile(`(?i)resets?(?: at)? (\d{1,2})(?::(\d{2}))?\s*(am|pm)(?:\s*\(([^)]+)\))?`)
+
+	// "API Error: 429 {"type":"error","error":{"type":"rate_limit_error",...}}"
+	rateLimitRe = regexp.MustCompile(`(?i)` + claudeLinePrefix + `api error: 429\b`)
+)
+
+// DetectLimit looks for a usage or rate limit message from Claude in
+// captured pane output (the last screenful is enough) and works out when it
+// resets. The bottom-most message wins. Returns nil if there is none.
+func DetectLimit(output string, now time.Time) *Limit {
+	lines := strings.Split(output, "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		// A reset time may have wrapped onto the next line
+		rest := lines[i]
+		if i+1 < len(lines) {
+			rest += " " + lines[i+1]
+		}
+		if limit := detectLimitLine(lines[i], rest, now); limit != nil {
+			return limit
+		}
+	}
+	return nil
+}
+
+// detectLimitLine checks one pane line; rest is the line plus the next one,
+// searched for the reset time.
+func detectLimitLine(line, rest string, now time.Time) *Limit {
+	if m := epochLimitRe.FindStringSubmatch(line); m != nil {
+		if secs, err := strconv.ParseInt(m[1], 10, 64); err == nil {
+			return &Limit{Reason: "usage limit reached", ResetAt: time.Unix(secs, 0)}
+		}
+	}
+
+	if m := usageLimitRe.FindStringSubmatch(line); m != nil {
+		limit := &Limit{Reason: strings.ToLower(m[1]), ResetAt: now.Add(DefaultUsageCooldown)}
+		if reset, ok := parseReset(rest, now); ok {
+			limit.ResetAt = reset
+		}
+		return limit
+	}
+
+	if rateLimitRe.MatchString(line) {
+		return &Limit{Reason: "rate limited", ResetAt: now.Add(DefaultRateCooldown)}
+	}
+	return nil
+}
+
+// parseReset finds a "resets 3pm (Zone)" time of day and returns its next
+// occurrence after now.
+func parseReset(output string, now time.Time) (time.Time, bool) {
+	m := resetRe.FindStringSubmatch(output)
+	if m == nil {
+		return time.Time{}, false
+	}
+	hour, _ := strconv.Atoi(m[1])
+	minute := 0
+	if m[2] != "" {
+		minute, _ = strconv.Atoi(m[2])
+	}
+	if hour < 1 || hour > 12 || minute > 59 {
+		return time.Time{}, false
+	}
+	hour %= 12
+	if strings.EqualFold(m[3], "pm") {
+		hour += 12
+	}
+
+	loc := now.Location()
+	if m[4] != "" {
+		if l, err := time.LoadLocation(m[4]); err == nil {
+			loc = l
+		}
+	}
+	local := now.In(loc)
+	reset := time.Date(local.Year(), local.Month(), local.Day(), hour, minute, 0, 0, loc)
+	if !reset.After(now) {
+		reset = reset.AddDate(0, 0, 1)
+	}
+	return reset, true
+}
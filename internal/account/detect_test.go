@@ -0,0 +1,80 @@
+package account
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDetectLimit(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("no tzdata")
+	}
+	now := time.Date(2026, 3, 2, 14, 0, 0, 0, ny)
+
+	tests := []struct {
+		name   string
+		output string
+		reason string
+		reset  time.Time
+	}{
+		{
+			name:   "usage limit with zone",
+			output: "> fix the bug\n  ⎿  Claude usage limit reached. Your limit will reset at 5pm (America/New_York).\n",
+			reason: "usage limit reached",
+			reset:  time.Date(2026, 3, 2, 17, 0, 0, 0, ny),
+		},
+		{
+			name:   "session limit resets tomorrow",
+			output: "5-hour limit reached ∙ resets 9:30am",
+			reason: "5-hour limit reached",
+			reset:  time.Date(2026, 3, 3, 9, 30, 0, 0, ny),
+		},
+		{
+			name:   "epoch reset",
+			output: "Claude AI usage limit reached|1772485200",
+			reason: "usage limit reached",
+			reset:  time.Unix(1772485200, 0),
+		},
+		{
+			name:   "no reset time",
+			output: "Weekly limit reached",
+			reason: "weekly limit reached",
+			reset:  now.Add(DefaultUsageCooldown),
+		},
+		{
+			name:   "rate limited",
+			output: `API Error: 429 {"type":"error","error":{"type":"rate_limit_error"}}`,
+			reason: "rate limited",
+			reset:  now.Add(DefaultRateCooldown),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limit := DetectLimit(tt.output, now)
+			if limit == nil {
+				t.Fatal("no limit detected")
+			}
+			if limit.Reason != tt.reason {
+				t.Errorf("Reason = %q, want %q", limit.Reason, tt.reason)
+			}
+			if !limit.ResetAt.Equal(tt.reset) {
+				t.Errorf("ResetAt = %v, want %v", limit.ResetAt, tt.reset)
+			}
+		})
+	}
+
+	for _, output := range []string{
+		"> gt prime\n  Running tests... retry limit reached in fixture",
+		// grep and file views of this package
+		"internal/account/detect.go:35:\trateLimitRe = regexp.MustCompile(`(?i)(api error: 429|rate_limit_error)`)",
+		"     31\t\t// \"5-hour limit reached ∙ resets 3:30pm\"",
+		// A test log and the agent talking about limits
+		"    client_test.go:40: got {\"type\":\"rate_limit_error\"}\n--- FAIL: TestRetry (0.00s)",
+		"● The usage limit reached message is matched in detect.go.",
+	} {
+		if limit := DetectLimit(output, now); limit != nil {
+			t.Errorf("DetectLimit(%q) = %+v, want nil", output, limit)
+		}
+	}
+}
@@ -175,6 +175,7 @@ type ListOptions struct {
 	Parent     string // filter by parent ID
 	Assignee   string // filter by assignee (e.g., "gastown/Toast")
 	NoAssignee bool   // filter for issues with no assignee
+	Tag        string // filter by tag/label (e.g., "escalation")
 }
 
 // CreateOptions specifies options for creating an issue.
@@ -299,6 +300,9 @@ func (b *Beads) List(opts ListOptions) ([]*Issue, error) {
 	if opts.NoAssignee {
 		args = append(args, "--no-assignee")
 	}
+	if opts.Tag != "" {
+		args = append(args, "--tag="+opts.Tag)
+	}
 
 	out, err := b.run(args...)
 	if err != nil {
@@ -825,14 +829,15 @@ func (b *Beads) IsBeadsRepo() bool {
 // AgentFields holds structured fields for agent beads.
 // These are stored as "key: value" lines in the description.
 type AgentFields struct {
-	RoleType          string // polecat, witness, refinery, deacon, mayor
-	Rig               string // Rig name (empty for global agents like mayor/deacon)
-	AgentState        string // spawning, working, done, stuck
-	HookBead          string // Currently pinned work bead ID
-	RoleBead          string // Role definition bead ID (canonical location; may not exist yet)
-	CleanupStatus     string // ZFC: polecat self-reports git state (clean, has_uncommitted, has_stash, has_unpushed)
-	ActiveMR          string // Currently active merge request bead ID (for traceability)
-	NotificationLevel string // DND mode: verbose, normal, muted (default: normal)
+	RoleType          string   // polecat, witness, refinery, deacon, mayor
+	Rig               string   // Rig name (empty for global agents like mayor/deacon)
+	AgentState        string   // spawning, working, done, stuck
+	HookBead          string   // Currently pinned work bead ID
+	RoleBead          string   // Role definition bead ID (canonical location; may not exist yet)
+	CleanupStatus     string   // ZFC: polecat self-reports git state (clean, has_uncommitted, has_stash, has_unpushed)
+	ActiveMR          string   // Currently active merge request bead ID (for traceability)
+	NotificationLevel string   // DND mode: verbose, normal, muted (default: normal)
+	Capabilities      []string // What the agent can work on (go, docker, account:work) for gt sling --auto
 }
 
 // Notification level constants
@@ -891,6 +896,10 @@ func FormatAgentDescription(title string, fields *AgentFields) string {
 		lines = append(lines, "notification_level: null")
 	}
 
+	if len(fields.Capabilities) > 0 {
+		lines = append(lines, fmt.Sprintf("capabilities: %s", strings.Join(fields.Capabilities, ", ")))
+	}
+
 	return strings.Join(lines, "\n")
 }
 
@@ -932,6 +941,8 @@ func ParseAgentFields(description string) *AgentFields {
 			fields.ActiveMR = value
 		case "notification_level":
 			fields.NotificationLevel = value
+		case "capabilities":
+			fields.Capabilities = splitCapabilities(value)
 		}
 	}
 
@@ -1046,6 +1057,20 @@ func (b *Beads) UpdateAgentCleanupStatus(id string, cleanupStatus string) error
 	return b.Update(id, UpdateOptions{Description: &description})
 }
 
+// UpdateAgentCapabilities replaces the capabilities field in an agent bead.
+func (b *Beads) UpdateAgentCapabilities(id string, capabilities []string) error {
+	issue, err := b.Show(id)
+	if err != nil {
+		return err
+	}
+
+	fields := ParseAgentFields(issue.Description)
+	fields.Capabilities = capabilities
+
+	description := FormatAgentDescription(issue.Title, fields)
+	return b.Update(id, UpdateOptions{Description: &description})
+}
+
 // UpdateAgentActiveMR updates the active_mr field in an agent bead.
 // This links the agent to their current merge request for traceability.
 // Pass empty string to clear the field (e.g., after merge completes).
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -319,6 +320,28 @@ source_issue: gt-pqr`,
 				SourceIssue: "gt-pqr",
 			},
 		},
+		{
+			name: "trace parent",
+			issue: &Issue{
+				Description: `branch: polecat/Nux/gt-stu
+trace_parent: 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01`,
+			},
+			wantFields: &MRFields{
+				Branch:      "polecat/Nux/gt-stu",
+				TraceParent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			},
+		},
+		{
+			name: "stacked on parent MR",
+			issue: &Issue{
+				Description: `branch: polecat/Toast/gt-vwx
+parent_mr: gt-mr-stu`,
+			},
+			wantFields: &MRFields{
+				Branch:   "polecat/Toast/gt-vwx",
+				ParentMR: "gt-mr-stu",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -357,6 +380,12 @@ source_issue: gt-pqr`,
 			if fields.CloseReason != tt.wantFields.CloseReason {
 				t.Errorf("CloseReason = %q, want %q", fields.CloseReason, tt.wantFields.CloseReason)
 			}
+			if fields.TraceParent != tt.wantFields.TraceParent {
+				t.Errorf("TraceParent = %q, want %q", fields.TraceParent, tt.wantFields.TraceParent)
+			}
+			if fields.ParentMR != tt.wantFields.ParentMR {
+				t.Errorf("ParentMR = %q, want %q", fields.ParentMR, tt.wantFields.ParentMR)
+			}
 		})
 	}
 }
@@ -883,6 +912,59 @@ func TestAttachmentFieldsRoundTrip(t *testing.T) {
 	}
 }
 
+// TestEscalationFieldsRoundTrip tests that format/parse preserves all fields.
+func TestEscalationFieldsRoundTrip(t *testing.T) {
+	original := &EscalationFields{
+		Category:    "decision",
+		Severity:    "HIGH",
+		From:        "gastown/polecats/toast",
+		Tier:        "deacon",
+		TierSince:   "2026-01-15T10:00:00Z",
+		Rig:         "gastown",
+		Options:     []string{"JWT tokens", "Session cookies", "OAuth2"},
+		Recommended: "JWT tokens",
+		Timeout:     "2h",
+		Fallback:    "JWT tokens",
+		Issue:       "gt-xyz",
+		Gate:        "gt-gate-1",
+	}
+
+	parsed := ParseEscalationFields(&Issue{Description: FormatEscalationFields(original)})
+	if parsed == nil {
+		t.Fatal("round-trip parse returned nil")
+	}
+	if !reflect.DeepEqual(parsed, original) {
+		t.Errorf("round-trip mismatch:\ngot  %+v\nwant %+v", parsed, original)
+	}
+}
+
+// TestParseEscalationFieldsLegacy tests the pre-structured escalation header.
+func TestParseEscalationFieldsLegacy(t *testing.T) {
+	issue := &Issue{Description: "Escalation from: mayor\nSeverity: CRITICAL\n\nDisk full"}
+	fields := ParseEscalationFields(issue)
+	if fields == nil {
+		t.Fatal("expected fields from legacy header")
+	}
+	if fields.From != "mayor" || fields.Severity != "CRITICAL" {
+		t.Errorf("got From=%q Severity=%q", fields.From, fields.Severity)
+	}
+}
+
+// TestSetEscalationFields tests updating fields while preserving details.
+func TestSetEscalationFields(t *testing.T) {
+	issue := &Issue{Description: "Escalation from: mayor\nSeverity: HIGH\n\nDisk full on host-1"}
+	got := SetEscalationFields(issue, &EscalationFields{From: "mayor", Severity: "HIGH", Answer: "expand volume"})
+	want := "severity: HIGH\nfrom: mayor\nanswer: expand volume\n\nDisk full on host-1"
+	if got != want {
+		t.Errorf("SetEscalationFields =\n%q\nwant\n%q", got, want)
+	}
+
+	// nil fields leaves just the details
+	if details := SetEscalationFields(issue, nil); details != "Disk full on host-1" {
+		t.Errorf("details = %q", details)
+	}
+}
+
 // TestResolveBeadsDir tests the redirect following logic.
 func TestResolveBeadsDir(t *testing.T) {
 	// Create temp directory structure
@@ -1490,3 +1572,44 @@ func TestDelegationTerms(t *testing.T) {
 		t.Errorf("parsed.CreditShare = %d, want %d", parsed.CreditShare, terms.CreditShare)
 	}
 }
+
+func TestParseSparsePaths(t *testing.T) {
+	issue := &Issue{
+		Description: "Fix the API client.\n\npaths: svc/api, libs/http  libs/json\n",
+		Labels:      []string{"bug", "path:svc/api", "path:tools/gen"},
+	}
+	want := []string{"svc/api", "libs/http", "libs/json", "tools/gen"}
+	if got := ParseSparsePaths(issue); !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseSparsePaths = %v, want %v", got, want)
+	}
+
+	if got := ParseSparsePaths(&Issue{Description: "no paths here"}); got != nil {
+		t.Errorf("ParseSparsePaths(none) = %v, want nil", got)
+	}
+	if got := ParseSparsePaths(nil); got != nil {
+		t.Errorf("ParseSparsePaths(nil) = %v, want nil", got)
+	}
+}
+
+func TestParseCapabilitiesAndRequirements(t *testing.T) {
+	fields := &AgentFields{RoleType: "crew", Rig: "gastown", AgentState: "idle", Capabilities: []string{"go", "account:work"}}
+	agent := &Issue{
+		Description: FormatAgentDescription("Crew worker max", fields),
+		Labels:      []string{"capability:docker"},
+	}
+	if got := ParseAgentFields(agent.Description).Capabilities; !reflect.DeepEqual(got, fields.Capabilities) {
+		t.Errorf("round-tripped capabilities = %v, want %v", got, fields.Capabilities)
+	}
+	want := []string{"go", "account:work", "docker"}
+	if got := ParseCapabilities(agent); !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseCapabilities = %v, want %v", got, want)
+	}
+
+	work := &Issue{Labels: []string{"bug", "needs:go", "needs:runtime:claude", "needs:"}}
+	if got := ParseRequirements(work); !reflect.DeepEqual(got, []string{"go", "runtime:claude"}) {
+		t.Errorf("ParseRequirements = %v", got)
+	}
+	if got := ParseRequirements(&Issue{Labels: []string{"bug"}}); got != nil {
+		t.Errorf("ParseRequirements(none) = %v, want nil", got)
+	}
+}
@@ -0,0 +1,84 @@
+package beads
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// ConvoyCancelledLabel marks a convoy closed by gt convoy cancel.
+const ConvoyCancelledLabel = "cancelled"
+
+// ConvoyBlocker is a convoy that another convoy waits on (gt convoy create --after).
+type ConvoyBlocker struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	Labels string `json:"labels"` // Comma-separated
+}
+
+// Cancelled reports whether the blocker was closed by gt convoy cancel.
+func (b ConvoyBlocker) Cancelled() bool {
+	return slices.Contains(strings.Split(b.Labels, ","), ConvoyCancelledLabel)
+}
+
+// Landed reports whether the blocker finished its work. A cancelled convoy
+// never landed, so convoys waiting on it keep waiting.
+func (b ConvoyBlocker) Landed() bool {
+	return (b.Status == "closed" || b.Status == "tombstone") && !b.Cancelled()
+}
+
+// ConvoyBlockers returns the convoys a convoy waits on. It queries the
+// town's beads database directly, since bd has no query for it.
+func ConvoyBlockers(townBeads, convoyID string) ([]ConvoyBlocker, error) {
+	dbPath := filepath.Join(townBeads, "beads.db")
+	safeConvoyID := strings.ReplaceAll(convoyID, "'", "''")
+	// #nosec G204 -- sqlite3 path is from trusted config, convoyID is escaped
+	queryCmd := exec.Command("sqlite3", "-json", dbPath,
+		fmt.Sprintf(`SELECT i.id, i.status, COALESCE((SELECT group_concat(l.label) FROM labels l WHERE l.issue_id = i.id), '') AS labels
+FROM dependencies d JOIN issues i ON i.id = d.depends_on_id
+WHERE d.issue_id = '%s' AND d.type = 'blocks' AND i.issue_type = 'convoy'`, safeConvoyID))
+
+	var stdout, stderr bytes.Buffer
+	queryCmd.Stdout = &stdout
+	queryCmd.Stderr = &stderr
+	if err := queryCmd.Run(); err != nil {
+		return nil, fmt.Errorf("querying convoy blockers: %w (%s)", err, strings.TrimSpace(stderr.String()))
+	}
+
+	// sqlite3 -json prints nothing for an empty result
+	if len(bytes.TrimSpace(stdout.Bytes())) == 0 {
+		return nil, nil
+	}
+	var blockers []ConvoyBlocker
+	if err := json.Unmarshal(stdout.Bytes(), &blockers); err != nil {
+		return nil, fmt.Errorf("parsing convoy blockers: %w", err)
+	}
+	return blockers, nil
+}
+
+// ConvoyWaitingOn returns the IDs of the convoys a convoy waits on that have
+// not landed, sorted. Returns nil if the blockers can't be read.
+func ConvoyWaitingOn(townBeads, convoyID string) []string {
+	blockers, err := ConvoyBlockers(townBeads, convoyID)
+	if err != nil {
+		return nil
+	}
+	return waitingOn(blockers)
+}
+
+// waitingOn returns the IDs of blockers that have not landed, sorted.
+func waitingOn(blockers []ConvoyBlocker) []string {
+	var waiting []string
+	for _, b := range blockers {
+		if !b.Landed() {
+			waiting = append(waiting, b.ID)
+		}
+	}
+	sort.Strings(waiting)
+	return waiting
+}
@@ -0,0 +1,23 @@
+package beads
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConvoyWaitingOn(t *testing.T) {
+	blockers := []ConvoyBlocker{
+		{ID: "hq-cv-b", Status: "open"},
+		{ID: "hq-cv-landed", Status: "closed"},
+		{ID: "hq-cv-a", Status: "in_progress"},
+		{ID: "hq-cv-cancelled", Status: "closed", Labels: "paused,cancelled"},
+		{ID: "hq-cv-labeled", Status: "closed", Labels: "release"},
+	}
+	want := []string{"hq-cv-a", "hq-cv-b", "hq-cv-cancelled"}
+	if got := waitingOn(blockers); !reflect.DeepEqual(got, want) {
+		t.Errorf("waitingOn = %v, want %v", got, want)
+	}
+	if got := waitingOn(nil); got != nil {
+		t.Errorf("waitingOn(nil) = %v, want nil", got)
+	}
+}
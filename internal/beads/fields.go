@@ -177,6 +177,12 @@ type MRFields struct {
 	// Convoy tracking (for priority scoring - convoy starvation prevention)
 	ConvoyID        string // Parent convoy ID if part of a convoy
 	ConvoyCreatedAt string // Convoy creation time (ISO 8601) for starvation prevention
+
+	// Tracing
+	TraceParent string // W3C traceparent of the span that submitted this MR
+
+	// Stacking
+	ParentMR string // MR this one is stacked on (its branch builds on the parent's)
 }
 
 // ParseMRFields extracts structured merge-request fields from an issue's description.
@@ -251,6 +257,12 @@ func ParseMRFields(issue *Issue) *MRFields {
 		case "convoy_created_at", "convoy-created-at", "convoycreatedat":
 			fields.ConvoyCreatedAt = value
 			hasFields = true
+		case "trace_parent", "trace-parent", "traceparent":
+			fields.TraceParent = value
+			hasFields = true
+		case "parent_mr", "parent-mr", "parentmr":
+			fields.ParentMR = value
+			hasFields = true
 		}
 	}
 
@@ -315,6 +327,12 @@ func FormatMRFields(fields *MRFields) string {
 	if fields.ConvoyCreatedAt != "" {
 		lines = append(lines, "convoy_created_at: "+fields.ConvoyCreatedAt)
 	}
+	if fields.TraceParent != "" {
+		lines = append(lines, "trace_parent: "+fields.TraceParent)
+	}
+	if fields.ParentMR != "" {
+		lines = append(lines, "parent_mr: "+fields.ParentMR)
+	}
 
 	return strings.Join(lines, "\n")
 }
@@ -361,6 +379,12 @@ func SetMRFields(issue *Issue, fields *MRFields) string {
 		"convoy_created_at":  true,
 		"convoy-created-at":  true,
 		"convoycreatedat":    true,
+		"trace_parent":       true,
+		"trace-parent":       true,
+		"traceparent":        true,
+		"parent_mr":          true,
+		"parent-mr":          true,
+		"parentmr":           true,
 	}
 
 	// Collect non-MR lines from existing description
@@ -411,6 +435,91 @@ func SetMRFields(issue *Issue, fields *MRFields) string {
 	return formatted + "\n\n" + strings.Join(otherLines, "\n")
 }
 
+// ParseSparsePaths extracts the directories an issue's work touches, used to
+// narrow sparse worktrees: a "paths:" description line (comma- or
+// space-separated) and "path:<dir>" labels. Returns nil if none are named.
+func ParseSparsePaths(issue *Issue) []string {
+	if issue == nil {
+		return nil
+	}
+
+	var paths []string
+	seen := make(map[string]bool)
+	add := func(p string) {
+		p = strings.TrimSpace(p)
+		if p != "" && !seen[p] {
+			seen[p] = true
+			paths = append(paths, p)
+		}
+	}
+
+	for _, line := range strings.Split(issue.Description, "\n") {
+		line = strings.TrimSpace(line)
+		colonIdx := strings.Index(line, ":")
+		if colonIdx == -1 || !strings.EqualFold(strings.TrimSpace(line[:colonIdx]), "paths") {
+			continue
+		}
+		for _, p := range strings.FieldsFunc(line[colonIdx+1:], func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t'
+		}) {
+			add(p)
+		}
+	}
+
+	for _, label := range issue.Labels {
+		if strings.HasPrefix(label, "path:") {
+			add(strings.TrimPrefix(label, "path:"))
+		}
+	}
+
+	return paths
+}
+
+// Capability labels: "needs:<capability>" on a work bead names what the
+// agent working it must have; "capability:<capability>" on an agent bead
+// declares what the agent has (dogs keep their metadata in labels).
+const (
+	NeedsLabelPrefix      = "needs:"
+	CapabilityLabelPrefix = "capability:"
+)
+
+// ParseRequirements returns the capabilities an issue's needs:<capability>
+// labels require, or nil if it names none.
+func ParseRequirements(issue *Issue) []string {
+	if issue == nil {
+		return nil
+	}
+	var needs []string
+	for _, label := range issue.Labels {
+		if need, ok := strings.CutPrefix(label, NeedsLabelPrefix); ok && need != "" {
+			needs = append(needs, need)
+		}
+	}
+	return needs
+}
+
+// ParseCapabilities returns what an agent bead declares it can work on: its
+// capabilities field plus any capability:<capability> labels.
+func ParseCapabilities(issue *Issue) []string {
+	if issue == nil {
+		return nil
+	}
+	caps := ParseAgentFields(issue.Description).Capabilities
+	for _, label := range issue.Labels {
+		if c, ok := strings.CutPrefix(label, CapabilityLabelPrefix); ok && c != "" {
+			caps = append(caps, c)
+		}
+	}
+	return caps
+}
+
+// splitCapabilities splits a comma- or space-separated capability list.
+func splitCapabilities(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+}
+
 // SynthesisFields holds structured fields for synthesis beads.
 // These fields track the synthesis step in a convoy workflow.
 type SynthesisFields struct {
@@ -492,6 +601,172 @@ func FormatSynthesisFields(fields *SynthesisFields) string {
 	return strings.Join(lines, "\n")
 }
 
+// EscalationFields holds the structured fields for an escalation bead.
+// These fields are stored as key: value lines in the issue description,
+// followed by free-form details.
+type EscalationFields struct {
+	Category    string   // decision, help, blocked, failed, emergency, gate_timeout, lifecycle
+	Severity    string   // CRITICAL, HIGH, MEDIUM
+	From        string   // Agent that escalated; answers are mailed here
+	Tier        string   // Tier currently handling it (witness, deacon, mayor, overseer)
+	TierSince   string   // RFC3339 time the current tier was notified
+	Rig         string   // Rig the escalation came from (for witness routing)
+	Options     []string // Enumerated answer options (decision escalations)
+	Recommended string   // Recommended option
+	Timeout     string   // Per-tier answer timeout (e.g., "2h")
+	Fallback    string   // Option applied when the last tier times out
+	Issue       string   // Related work issue
+	Gate        string   // Gate the originating agent parked on
+	Answer      string   // Chosen option or free-form answer
+	AnsweredBy  string   // Who answered ("timeout" for fallback)
+	AnsweredAt  string   // RFC3339 answer time
+}
+
+// escalationOptionSep separates options on the options: line.
+const escalationOptionSep = " | "
+
+// ParseEscalationFields extracts escalation fields from an issue's description.
+// Also understands the legacy "Escalation from:" / "Severity:" header.
+// Returns nil if no escalation fields are found.
+func ParseEscalationFields(issue *Issue) *EscalationFields {
+	if issue == nil || issue.Description == "" {
+		return nil
+	}
+
+	fields := &EscalationFields{}
+	hasFields := false
+
+	for _, line := range strings.Split(issue.Description, "\n") {
+		line = strings.TrimSpace(line)
+		colonIdx := strings.Index(line, ":")
+		if colonIdx == -1 {
+			continue
+		}
+
+		key := strings.ToLower(strings.TrimSpace(line[:colonIdx]))
+		value := strings.TrimSpace(line[colonIdx+1:])
+		if value == "" {
+			continue
+		}
+
+		switch key {
+		case "category":
+			fields.Category = value
+		case "severity":
+			fields.Severity = value
+		case "from", "escalation from", "escalated_by":
+			fields.From = value
+		case "tier":
+			fields.Tier = value
+		case "tier_since":
+			fields.TierSince = value
+		case "rig":
+			fields.Rig = value
+		case "options":
+			for _, opt := range strings.Split(value, strings.TrimSpace(escalationOptionSep)) {
+				if opt = strings.TrimSpace(opt); opt != "" {
+					fields.Options = append(fields.Options, opt)
+				}
+			}
+		case "recommended":
+			fields.Recommended = value
+		case "timeout":
+			fields.Timeout = value
+		case "fallback":
+			fields.Fallback = value
+		case "issue":
+			fields.Issue = value
+		case "gate":
+			fields.Gate = value
+		case "answer":
+			fields.Answer = value
+		case "answered_by":
+			fields.AnsweredBy = value
+		case "answered_at":
+			fields.AnsweredAt = value
+		default:
+			continue
+		}
+		hasFields = true
+	}
+
+	if !hasFields {
+		return nil
+	}
+	return fields
+}
+
+// FormatEscalationFields formats EscalationFields as a string suitable for an
+// issue description. Only non-empty fields are included.
+func FormatEscalationFields(fields *EscalationFields) string {
+	if fields == nil {
+		return ""
+	}
+
+	var lines []string
+	add := func(key, value string) {
+		if value != "" {
+			lines = append(lines, key+": "+value)
+		}
+	}
+	add("category", fields.Category)
+	add("severity", fields.Severity)
+	add("from", fields.From)
+	add("tier", fields.Tier)
+	add("tier_since", fields.TierSince)
+	add("rig", fields.Rig)
+	add("options", strings.Join(fields.Options, escalationOptionSep))
+	add("recommended", fields.Recommended)
+	add("timeout", fields.Timeout)
+	add("fallback", fields.Fallback)
+	add("issue", fields.Issue)
+	add("gate", fields.Gate)
+	add("answer", fields.Answer)
+	add("answered_by", fields.AnsweredBy)
+	add("answered_at", fields.AnsweredAt)
+
+	return strings.Join(lines, "\n")
+}
+
+// SetEscalationFields updates an issue's description with the given escalation
+// fields. Existing field lines (including the legacy header) are replaced;
+// other content is preserved after a blank line.
+func SetEscalationFields(issue *Issue, fields *EscalationFields) string {
+	formatted := FormatEscalationFields(fields)
+	if issue == nil || issue.Description == "" {
+		return formatted
+	}
+
+	escalationKeys := map[string]bool{
+		"category": true, "severity": true, "from": true, "escalation from": true,
+		"escalated_by": true, "tier": true, "tier_since": true, "rig": true,
+		"options": true, "recommended": true, "timeout": true, "fallback": true,
+		"issue": true, "gate": true, "answer": true, "answered_by": true,
+		"answered_at": true,
+	}
+
+	var otherLines []string
+	for _, line := range strings.Split(issue.Description, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if colonIdx := strings.Index(trimmed, ":"); colonIdx != -1 {
+			key := strings.ToLower(strings.TrimSpace(trimmed[:colonIdx]))
+			if escalationKeys[key] {
+				continue
+			}
+		}
+		otherLines = append(otherLines, line)
+	}
+
+	other := strings.TrimSpace(strings.Join(otherLines, "\n"))
+	if other == "" {
+		return formatted
+	}
+	if formatted == "" {
+		return other
+	}
+	return formatted + "\n\n" + other
+}
+
 // RoleConfig holds structured lifecycle configuration for role beads.
 // These fields are stored as "key: value" lines in the role bead description.
 // This enables agents to self-register their lifecycle configuration,
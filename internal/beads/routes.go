@@ -161,6 +161,33 @@ func GetPrefixForRig(townRoot, rigName string) string {
 	return "gt" // Default prefix
 }
 
+// GetRigForIssue returns the rig that owns an issue, based on the issue ID's
+// prefix and the route's path (e.g., "gastown/mayor/rig" -> "gastown").
+// Returns "" for town-level issues or prefixes with no route.
+func GetRigForIssue(townRoot, issueID string) string {
+	routes, err := LoadRoutes(filepath.Join(townRoot, ".beads"))
+	if err != nil {
+		return ""
+	}
+
+	// Longest matching prefix wins (e.g., "gt-wisp-" over "gt-")
+	var best Route
+	for _, r := range routes {
+		if strings.HasPrefix(issueID, r.Prefix) && len(r.Prefix) > len(best.Prefix) {
+			best = r
+		}
+	}
+	if best.Prefix == "" {
+		return ""
+	}
+
+	rig := strings.SplitN(best.Path, "/", 2)[0]
+	if rig == "." || rig == "" {
+		return ""
+	}
+	return rig
+}
+
 // FindConflictingPrefixes checks for duplicate prefixes in routes.
 // Returns a map of prefix -> list of paths that use it.
 func FindConflictingPrefixes(beadsDir string) (map[string][]string, error) {
@@ -52,6 +52,40 @@ func TestGetPrefixForRig_NoRoutesFile(t *testing.T) {
 	}
 }
 
+func TestGetRigForIssue(t *testing.T) {
+	tmpDir := t.TempDir()
+	beadsDir := filepath.Join(tmpDir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	routesContent := `{"prefix": "gt-", "path": "gastown/mayor/rig"}
+{"prefix": "bd-", "path": "beads/mayor/rig"}
+{"prefix": "hq-", "path": "."}
+`
+	if err := os.WriteFile(filepath.Join(beadsDir, "routes.jsonl"), []byte(routesContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		issue    string
+		expected string
+	}{
+		{"gt-abc", "gastown"},
+		{"bd-xyz", "beads"},
+		{"hq-cv-1", ""}, // town-level
+		{"zz-123", ""},  // no route
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.issue, func(t *testing.T) {
+			if got := GetRigForIssue(tmpDir, tc.issue); got != tc.expected {
+				t.Errorf("GetRigForIssue(%q) = %q, want %q", tc.issue, got, tc.expected)
+			}
+		})
+	}
+}
+
 func TestAgentBeadIDsWithPrefix(t *testing.T) {
 	tests := []struct {
 		name     string
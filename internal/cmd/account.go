@@ -2,14 +2,19 @@ package cmd
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/account"
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
 	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
@@ -30,11 +35,17 @@ var accountCmd = &cobra.Command{
 This enables switching between accounts (e.g., personal vs work) with
 easy account selection per spawn or globally.
 
+With an account pool ('gt account pool'), new sessions are spread across
+the pool's accounts, and the daemon restarts sessions whose account hits a
+usage or rate limit on another pool account until the limit resets.
+
 Commands:
   gt account list              List registered accounts
   gt account add <handle>      Add a new account
   gt account default <handle>  Set the default account
-  gt account status            Show current account info`,
+  gt account pool [handle...]  Show or set the rotation pool
+  gt account reset <handle>    Clear an account's cooldown
+  gt account status            Show current account, utilization and cooldowns`,
 }
 
 var accountListCmd = &cobra.Command{
@@ -251,19 +262,72 @@ func runAccountDefault(cmd *cobra.Command, args []string) error {
 
 var accountStatusCmd = &cobra.Command{
 	Use:   "status",
-	Short: "Show current account info",
-	Long: `Show which Claude Code account would be used for new sessions.
+	Short: "Show current account, utilization and cooldowns",
+	Long: `Show which Claude Code account would be used for new sessions, and how
+busy each account is.
 
-Displays the currently resolved account based on:
+The account for new sessions is resolved from:
 1. GT_ACCOUNT environment variable (highest priority)
-2. Default account from config
+2. The least-loaded pool account that isn't cooling down (if a pool is set)
+3. Default account from config
+
+Each account is listed with the sessions running on it and, if it hit a
+usage or rate limit, when it becomes available again.
 
 Examples:
   gt account status           # Show current account
+  gt account status --json    # Per-account utilization as JSON
   GT_ACCOUNT=work gt account status  # Show with env override`,
 	RunE: runAccountStatus,
 }
 
+var accountPoolClear bool
+
+var accountPoolCmd = &cobra.Command{
+	Use:   "pool [handle...]",
+	Short: "Show or set the account rotation pool",
+	Long: `Show or set the accounts in the rotation pool.
+
+New sessions started without --account or GT_ACCOUNT go to the pool account
+with the fewest running sessions. When the daemon sees a usage or rate limit
+message in a session, it marks that account as cooling down until the
+limit's reset time and restarts the session on another pool account.
+
+Without a pool, sessions use the default account and limited sessions are
+only reported.
+
+Examples:
+  gt account pool                  # Show the pool
+  gt account pool work spare       # Rotate across work and spare
+  gt account pool --clear          # Disable the pool`,
+	RunE: runAccountPool,
+}
+
+var accountResetCmd = &cobra.Command{
+	Use:   "reset <handle>",
+	Short: "Clear an account's cooldown",
+	Long: `Make an account available again before its detected limit resets.
+
+Use this when a limit was lifted early (e.g. after upgrading the plan) or a
+limit message was detected by mistake.
+
+Examples:
+  gt account reset work`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAccountReset,
+}
+
+// AccountStatusItem is an account's utilization in status output.
+type AccountStatusItem struct {
+	Handle        string     `json:"handle"`
+	InPool        bool       `json:"in_pool"`
+	IsDefault     bool       `json:"is_default"`
+	Sessions      []string   `json:"sessions"`
+	CooldownUntil *time.Time `json:"cooldown_until,omitempty"`
+	Reason        string     `json:"reason,omitempty"`
+	LimitHits     int        `json:"limit_hits"`
+}
+
 func runAccountStatus(cmd *cobra.Command, args []string) error {
 	townRoot, err := workspace.FindFromCwd()
 	if err != nil {
@@ -273,9 +337,24 @@ func runAccountStatus(cmd *cobra.Command, args []string) error {
 	accountsPath := constants.MayorAccountsPath(townRoot)
 
 	// Resolve account (empty flag since we want to show default resolution)
-	configDir, handle, err := config.ResolveAccountConfigDir(accountsPath, "")
-	if err != nil {
-		return fmt.Errorf("resolving account: %w", err)
+	configDir, handle, resolveErr := account.Resolve(townRoot, "")
+	if resolveErr != nil && !errors.Is(resolveErr, account.ErrNoAccount) {
+		return fmt.Errorf("resolving account: %w", resolveErr)
+	}
+
+	if accountJSON {
+		items, err := accountStatusItems(townRoot)
+		if err != nil {
+			return err
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(items)
+	}
+
+	if errors.Is(resolveErr, account.ErrNoAccount) {
+		fmt.Printf("%s New sessions cannot start: %v\n\n", style.Warning.Render("⚠"), resolveErr)
+		return printAccountUtilization(townRoot)
 	}
 
 	if handle == "" {
@@ -311,16 +390,168 @@ func runAccountStatus(cmd *cobra.Command, args []string) error {
 
 	if envAccount != "" {
 		fmt.Printf("\n%s\n", style.Dim.Render("(set via GT_ACCOUNT environment variable)"))
+	} else if len(cfg.Pool) > 0 {
+		fmt.Printf("\n%s\n", style.Dim.Render("(least-loaded available pool account)"))
 	} else if handle == cfg.Default {
 		fmt.Printf("\n%s\n", style.Dim.Render("(default account)"))
 	}
 
+	fmt.Println()
+	return printAccountUtilization(townRoot)
+}
+
+// accountStatusItems reports every account's sessions and cooldown.
+func accountStatusItems(townRoot string) ([]AccountStatusItem, error) {
+	cfg, err := config.LoadAccountsConfig(constants.MayorAccountsPath(townRoot))
+	if err != nil {
+		return nil, fmt.Errorf("loading accounts config: %w", err)
+	}
+	state, err := account.Load(townRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only count sessions that are still running
+	t := tmux.NewTmux()
+	state.Prune(func(sess string) bool {
+		alive, _ := t.HasSession(sess)
+		return alive
+	})
+
+	inPool := make(map[string]bool, len(cfg.Pool))
+	for _, h := range cfg.Pool {
+		inPool[h] = true
+	}
+	now := time.Now()
+	items := []AccountStatusItem{}
+	for handle := range cfg.Accounts {
+		item := AccountStatusItem{
+			Handle:    handle,
+			InPool:    inPool[handle],
+			IsDefault: handle == cfg.Default,
+			Sessions:  state.SessionsOn(handle),
+		}
+		if item.Sessions == nil {
+			item.Sessions = []string{}
+		}
+		if st := state.Accounts[handle]; st != nil {
+			item.LimitHits = st.LimitHits
+			if state.CoolingDown(handle, now) {
+				until := st.CooldownUntil
+				item.CooldownUntil = &until
+				item.Reason = st.Reason
+			}
+		}
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Handle < items[j].Handle })
+	return items, nil
+}
+
+func printAccountUtilization(townRoot string) error {
+	items, err := accountStatusItems(townRoot)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", style.Bold.Render("Accounts"))
+	for _, item := range items {
+		marks := ""
+		if item.InPool {
+			marks += " " + style.Dim.Render("[pool]")
+		}
+		if item.IsDefault {
+			marks += " " + style.Dim.Render("(default)")
+		}
+
+		state := style.Success.Render("available")
+		if item.CooldownUntil != nil {
+			state = style.Warning.Render(fmt.Sprintf("cooling down until %s (%s)",
+				item.CooldownUntil.Local().Format("Jan 2 15:04"), item.Reason))
+		}
+		fmt.Printf("  %-12s %2d session(s)  %s%s\n", item.Handle, len(item.Sessions), state, marks)
+		if item.LimitHits > 0 {
+			fmt.Printf("  %-12s %s\n", "", style.Dim.Render(fmt.Sprintf("%d limit(s) hit", item.LimitHits)))
+		}
+	}
+	return nil
+}
+
+func runAccountPool(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil {
+		return fmt.Errorf("finding town root: %w", err)
+	}
+	if accountPoolClear && len(args) > 0 {
+		return fmt.Errorf("--clear cannot be combined with account handles")
+	}
+
+	accountsPath := constants.MayorAccountsPath(townRoot)
+	cfg, err := config.LoadAccountsConfig(accountsPath)
+	if err != nil {
+		return fmt.Errorf("loading accounts config: %w", err)
+	}
+
+	if len(args) == 0 && !accountPoolClear {
+		if len(cfg.Pool) == 0 {
+			fmt.Println("No account pool: sessions use the default account and are not rotated.")
+			fmt.Println("\nTo rotate across accounts:")
+			fmt.Println("  gt account pool <handle> <handle>...")
+			return nil
+		}
+		fmt.Printf("Account pool: %s\n", strings.Join(cfg.Pool, ", "))
+		return nil
+	}
+
+	for _, handle := range args {
+		if _, exists := cfg.Accounts[handle]; !exists {
+			return fmt.Errorf("account '%s' not found", handle)
+		}
+	}
+	cfg.Pool = args
+	if err := config.SaveAccountsConfig(accountsPath, cfg); err != nil {
+		return fmt.Errorf("saving accounts config: %w", err)
+	}
+
+	if len(cfg.Pool) == 0 {
+		fmt.Println("Account pool cleared")
+	} else {
+		fmt.Printf("Account pool set to %s\n", strings.Join(cfg.Pool, ", "))
+	}
+	return nil
+}
+
+func runAccountReset(cmd *cobra.Command, args []string) error {
+	handle := args[0]
+
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil {
+		return fmt.Errorf("finding town root: %w", err)
+	}
+	cfg, err := config.LoadAccountsConfig(constants.MayorAccountsPath(townRoot))
+	if err != nil {
+		return fmt.Errorf("loading accounts config: %w", err)
+	}
+	if cfg.GetAccount(handle) == nil {
+		return fmt.Errorf("account '%s' not found", handle)
+	}
+
+	if err := account.Update(townRoot, func(s *account.State) error {
+		s.ClearCooldown(handle)
+		return nil
+	}); err != nil {
+		return fmt.Errorf("updating account state: %w", err)
+	}
+
+	fmt.Printf("Account '%s' is available\n", handle)
 	return nil
 }
 
 func init() {
 	// Add flags
 	accountListCmd.Flags().BoolVar(&accountJSON, "json", false, "Output as JSON")
+	accountStatusCmd.Flags().BoolVar(&accountJSON, "json", false, "Output as JSON")
+	accountPoolCmd.Flags().BoolVar(&accountPoolClear, "clear", false, "Remove all accounts from the pool")
 
 	accountAddCmd.Flags().StringVar(&accountEmail, "email", "", "Account email address")
 	accountAddCmd.Flags().StringVar(&accountDescription, "desc", "", "Account description")
@@ -330,6 +561,8 @@ func init() {
 	accountCmd.AddCommand(accountAddCmd)
 	accountCmd.AddCommand(accountDefaultCmd)
 	accountCmd.AddCommand(accountStatusCmd)
+	accountCmd.AddCommand(accountPoolCmd)
+	accountCmd.AddCommand(accountResetCmd)
 
 	rootCmd.AddCommand(accountCmd)
 }
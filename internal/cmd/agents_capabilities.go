@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var agentsCapabilitiesCmd = &cobra.Command{
+	Use:   "capabilities <agent> [capability...]",
+	Short: "Show or set the capabilities an agent declares for routing",
+	Long: `Show or set the capabilities recorded on an agent's bead.
+
+gt sling --auto routes a bead to an agent whose capabilities cover all of
+the bead's needs:<capability> labels. Rig-wide capabilities come from the
+rig's settings ("capabilities"); use this command for what a single crew
+member or dog adds, such as an account or local tooling.
+
+With capabilities, replaces the agent's list; with none, shows it.
+
+Examples:
+  gt agents capabilities gastown/crew/max
+  gt agents capabilities gastown/crew/max go docker account:work
+  gt agents capabilities deacon/dogs/alpha terraform
+  gt agents capabilities gastown/crew/max --clear`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runAgentsCapabilities,
+}
+
+var agentsCapabilitiesClear bool
+
+func init() {
+	agentsCapabilitiesCmd.Flags().BoolVar(&agentsCapabilitiesClear, "clear", false, "Remove all declared capabilities")
+	agentsCmd.AddCommand(agentsCapabilitiesCmd)
+}
+
+func runAgentsCapabilities(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	address, caps := args[0], args[1:]
+	setting := len(caps) > 0 || agentsCapabilitiesClear
+	if len(caps) > 0 && agentsCapabilitiesClear {
+		return fmt.Errorf("--clear cannot be combined with capabilities")
+	}
+
+	bd := beads.New(townRoot)
+
+	// Dogs keep their metadata in labels
+	if dogName, isDog := IsDogTarget(address); isDog && dogName != "" {
+		issue, err := bd.FindDogAgentBead(dogName)
+		if err != nil {
+			return err
+		}
+		if issue == nil {
+			return fmt.Errorf("no agent bead for dog %s", dogName)
+		}
+		if setting {
+			var opts beads.UpdateOptions
+			for _, label := range issue.Labels {
+				if strings.HasPrefix(label, beads.CapabilityLabelPrefix) {
+					opts.RemoveLabels = append(opts.RemoveLabels, label)
+				}
+			}
+			for _, c := range caps {
+				opts.AddLabels = append(opts.AddLabels, beads.CapabilityLabelPrefix+c)
+			}
+			if err := bd.Update(issue.ID, opts); err != nil {
+				return fmt.Errorf("updating %s: %w", issue.ID, err)
+			}
+		}
+		return printAgentCapabilities(address, caps, setting, issue)
+	}
+
+	beadID := agentIDToBeadID(address)
+	if beadID == "" {
+		return fmt.Errorf("unknown agent address %q", address)
+	}
+	issue, err := bd.Show(beadID)
+	if err != nil {
+		return fmt.Errorf("reading agent bead %s: %w", beadID, err)
+	}
+	if setting {
+		if err := bd.UpdateAgentCapabilities(beadID, caps); err != nil {
+			return fmt.Errorf("updating %s: %w", beadID, err)
+		}
+	}
+	return printAgentCapabilities(address, caps, setting, issue)
+}
+
+func printAgentCapabilities(address string, caps []string, set bool, issue *beads.Issue) error {
+	if set {
+		if len(caps) == 0 {
+			fmt.Printf("%s Cleared capabilities for %s\n", style.SuccessPrefix, address)
+		} else {
+			fmt.Printf("%s %s: %s\n", style.SuccessPrefix, address, strings.Join(caps, ", "))
+		}
+		return nil
+	}
+
+	caps = beads.ParseCapabilities(issue)
+	if len(caps) == 0 {
+		fmt.Printf("%s declares no capabilities %s\n", address, style.Dim.Render("(rig settings still apply)"))
+		return nil
+	}
+	fmt.Printf("%s: %s\n", style.Bold.Render(address), strings.Join(caps, ", "))
+	return nil
+}
@@ -536,6 +536,8 @@ func formatSource(source string) string {
 		return style.Dim.Render("[log]")
 	case "events":
 		return style.Warning.Render("[events]")
+	case "mq":
+		return style.Info.Render("[mq]")
 	default:
 		return fmt.Sprintf("[%s]", source)
 	}
@@ -16,6 +16,9 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/forecast"
+	"github.com/steveyegge/gastown/internal/notify"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tui/convoy"
 	"github.com/steveyegge/gastown/internal/workspace"
@@ -69,6 +72,9 @@ var (
 	convoyListTree     bool
 	convoyInteractive  bool
 	convoyStrandedJSON bool
+	convoyAfter        []string
+	convoyStatusGraph  bool
+	convoyListETA      bool
 )
 
 var convoyCmd = &cobra.Command{
@@ -107,7 +113,11 @@ COMMANDS:
   create    Create a convoy tracking specified issues
   add       Add issues to an existing convoy (reopens if closed)
   status    Show convoy progress, tracked issues, and active workers
-  list      List convoys (the dashboard view)`,
+  list      List convoys (the dashboard view)
+  schedule  Dispatch ready work to rigs with free polecat capacity
+  pause     Stop dispatch and park the convoy's polecats on a gate
+  resume    Resume dispatch and wake parked polecats
+  cancel    Release issues, drop their MRs, nuke clean polecats, close`,
 }
 
 var convoyCreateCmd = &cobra.Command{
@@ -122,7 +132,12 @@ Examples:
   gt convoy create "Deploy v2.0" gt-abc bd-xyz
   gt convoy create "Release prep" gt-abc --notify           # defaults to mayor/
   gt convoy create "Release prep" gt-abc --notify ops/      # notify ops/
-  gt convoy create "Feature rollout" gt-a gt-b gt-c --molecule mol-release`,
+  gt convoy create "Feature rollout" gt-a gt-b gt-c --molecule mol-release
+  gt convoy create "Frontend" gt-x --after hq-cv-abc        # starts after hq-cv-abc lands
+
+With --after, the new convoy is blocked by the given convoy(s): its tracked
+issues are not ready for dispatch (stranded detection, the scheduler) until
+every convoy it waits on has landed.`,
 	Args: cobra.MinimumNArgs(1),
 	RunE: runConvoyCreate,
 }
@@ -133,7 +148,16 @@ var convoyStatusCmd = &cobra.Command{
 	Long: `Show detailed status for a convoy.
 
 Displays convoy metadata, tracked issues, and completion progress.
-Without an ID, shows status of all active convoys.`,
+Without an ID, shows status of all active convoys.
+
+With --graph, renders the convoy dependency DAG (from 'create --after') in
+stages with per-convoy progress. Given an ID, only that convoy's upstream
+and downstream convoys are shown.
+
+Examples:
+  gt convoy status hq-cv-abc
+  gt convoy status --graph
+  gt convoy status hq-cv-abc --graph`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runConvoyStatus,
 }
@@ -148,7 +172,11 @@ Examples:
   gt convoy list --all        # All convoys (open + closed)
   gt convoy list --status=closed  # Recently landed
   gt convoy list --tree       # Show convoy + child status tree
-  gt convoy list --json`,
+  gt convoy list --eta        # Forecast when each convoy lands
+  gt convoy list --json
+
+ETAs are ranges forecast from the last two weeks of cycle times and
+throughput per rig (see 'gt stats').`,
 	RunE: runConvoyList,
 }
 
@@ -204,15 +232,18 @@ func init() {
 	convoyCreateCmd.Flags().StringVar(&convoyMolecule, "molecule", "", "Associated molecule ID")
 	convoyCreateCmd.Flags().StringVar(&convoyNotify, "notify", "", "Address to notify on completion (default: mayor/ if flag used without value)")
 	convoyCreateCmd.Flags().Lookup("notify").NoOptDefVal = "mayor/"
+	convoyCreateCmd.Flags().StringSliceVar(&convoyAfter, "after", nil, "Convoy that must land before this one starts (repeatable)")
 
 	// Status flags
 	convoyStatusCmd.Flags().BoolVar(&convoyStatusJSON, "json", false, "Output as JSON")
+	convoyStatusCmd.Flags().BoolVar(&convoyStatusGraph, "graph", false, "Show the convoy dependency graph with progress")
 
 	// List flags
 	convoyListCmd.Flags().BoolVar(&convoyListJSON, "json", false, "Output as JSON")
 	convoyListCmd.Flags().StringVar(&convoyListStatus, "status", "", "Filter by status (open, closed)")
 	convoyListCmd.Flags().BoolVar(&convoyListAll, "all", false, "Show all convoys (open and closed)")
 	convoyListCmd.Flags().BoolVar(&convoyListTree, "tree", false, "Show convoy + child status tree")
+	convoyListCmd.Flags().BoolVar(&convoyListETA, "eta", false, "Forecast when each convoy lands")
 
 	// Interactive TUI flag (on parent command)
 	convoyCmd.Flags().BoolVarP(&convoyInteractive, "interactive", "i", false, "Interactive tree view")
@@ -261,6 +292,13 @@ func runConvoyCreate(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	// Validate --after convoys before creating anything
+	for _, afterID := range convoyAfter {
+		if _, err := loadConvoy(filepath.Dir(townBeads), afterID); err != nil {
+			return fmt.Errorf("--after: %w", err)
+		}
+	}
+
 	// Create convoy issue in town beads
 	description := fmt.Sprintf("Convoy tracking %d issues", len(trackedIssues))
 	if convoyNotify != "" {
@@ -310,6 +348,18 @@ func runConvoyCreate(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Add blocking relations for convoys this one waits on
+	var after []string
+	for _, afterID := range convoyAfter {
+		depCmd := exec.Command("bd", "dep", "add", convoyID, afterID, "--type=blocks")
+		depCmd.Dir = townBeads
+		if err := depCmd.Run(); err != nil {
+			style.PrintWarning("couldn't add dependency on %s: %v", afterID, err)
+		} else {
+			after = append(after, afterID)
+		}
+	}
+
 	// Output
 	fmt.Printf("%s Created convoy 🚚 %s\n\n", style.Bold.Render("✓"), convoyID)
 	fmt.Printf("  Name:     %s\n", name)
@@ -323,9 +373,20 @@ func runConvoyCreate(cmd *cobra.Command, args []string) error {
 	if convoyMolecule != "" {
 		fmt.Printf("  Molecule: %s\n", convoyMolecule)
 	}
+	if len(after) > 0 {
+		fmt.Printf("  After:    %s\n", strings.Join(after, ", "))
+	}
 
 	fmt.Printf("\n  %s\n", style.Dim.Render("Convoy auto-closes when all tracked issues complete"))
 
+	sendOutboundNotification(filepath.Dir(townBeads), notify.Notification{
+		Event:    notify.EventConvoyCreated,
+		Severity: notify.SeverityLow,
+		Title:    fmt.Sprintf("Convoy created: %s", name),
+		Body:     fmt.Sprintf("Tracking %d issues", trackedCount),
+		Convoy:   convoyID,
+	})
+
 	return nil
 }
 
@@ -572,6 +633,7 @@ func getBlockedIssueIDs() map[string]bool {
 // An issue is ready if:
 // - status = "open" (not in_progress, closed, hooked)
 // - not in blocked set
+// - its convoy is not waiting on another convoy to land
 // - no assignee OR assignee session is dead
 func isReadyIssue(t trackedIssueInfo, blockedIssues map[string]bool) bool {
 	// Must be open status (not in_progress, closed, hooked)
@@ -584,6 +646,11 @@ func isReadyIssue(t trackedIssueInfo, blockedIssues map[string]bool) bool {
 		return false
 	}
 
+	// Convoy must not be waiting on an upstream convoy
+	if len(t.ConvoyWaitingOn) > 0 {
+		return false
+	}
+
 	// Check assignee
 	if t.Assignee == "" {
 		return true // No assignee = ready
@@ -666,8 +733,17 @@ func checkAndCloseCompletedConvoys(townBeads string) ([]struct{ ID, Title string
 	return closed, nil
 }
 
-// notifyConvoyCompletion sends a notification if the convoy has a notify address.
+// notifyConvoyCompletion sends a notification if the convoy has a notify address,
+// and pushes a convoy_landed event to any configured outbound sinks.
 func notifyConvoyCompletion(townBeads, convoyID, title string) {
+	sendOutboundNotification(filepath.Dir(townBeads), notify.Notification{
+		Event:    notify.EventConvoyLanded,
+		Severity: notify.SeverityMedium,
+		Title:    fmt.Sprintf("Convoy landed: %s", title),
+		Body:     "All tracked issues are now closed.",
+		Convoy:   convoyID,
+	})
+
 	// Get convoy description to find notify address
 	showArgs := []string{"show", convoyID, "--json"}
 	showCmd := exec.Command("bd", showArgs...)
@@ -712,6 +788,9 @@ func runConvoyStatus(cmd *cobra.Command, args []string) error {
 
 	// If no ID provided, show all active convoys
 	if len(args) == 0 {
+		if convoyStatusGraph {
+			return showConvoyGraph(townBeads, "")
+		}
 		return showAllConvoyStatus(townBeads)
 	}
 
@@ -726,6 +805,10 @@ func runConvoyStatus(cmd *cobra.Command, args []string) error {
 		convoyID = resolved
 	}
 
+	if convoyStatusGraph {
+		return showConvoyGraph(townBeads, convoyID)
+	}
+
 	// Get convoy details
 	showArgs := []string{"show", convoyID, "--json"}
 	showCmd := exec.Command("bd", showArgs...)
@@ -768,6 +851,14 @@ func runConvoyStatus(cmd *cobra.Command, args []string) error {
 	}
 
 	tracked := getTrackedIssues(townBeads, convoyID)
+	waitingOn := beads.ConvoyWaitingOn(townBeads, convoyID)
+
+	var eta *forecast.ETA
+	if convoy.Status != "closed" {
+		townRoot := filepath.Dir(townBeads)
+		e := convoyETA(townRoot, forecast.Load(townRoot, forecast.DefaultWindow, time.Now()), tracked)
+		eta = &e
+	}
 
 	// Count completed
 	completed := 0
@@ -785,6 +876,8 @@ func runConvoyStatus(cmd *cobra.Command, args []string) error {
 			Tracked   []trackedIssueInfo `json:"tracked"`
 			Completed int                `json:"completed"`
 			Total     int                `json:"total"`
+			WaitingOn []string           `json:"waiting_on,omitempty"`
+			ETA       *forecast.ETA      `json:"eta,omitempty"`
 		}
 		out := jsonStatus{
 			ID:        convoy.ID,
@@ -793,6 +886,8 @@ func runConvoyStatus(cmd *cobra.Command, args []string) error {
 			Tracked:   tracked,
 			Completed: completed,
 			Total:     len(tracked),
+			WaitingOn: waitingOn,
+			ETA:       eta,
 		}
 		enc := json.NewEncoder(os.Stdout)
 		enc.SetIndent("", "  ")
@@ -803,10 +898,16 @@ func runConvoyStatus(cmd *cobra.Command, args []string) error {
 	fmt.Printf("🚚 %s %s\n\n", style.Bold.Render(convoy.ID+":"), convoy.Title)
 	fmt.Printf("  Status:    %s\n", formatConvoyStatus(convoy.Status))
 	fmt.Printf("  Progress:  %d/%d completed\n", completed, len(tracked))
+	if eta != nil && eta.Remaining > 0 {
+		fmt.Printf("  ETA:       %s %s\n", eta, style.Dim.Render(fmt.Sprintf("(%d left)", eta.Remaining)))
+	}
 	fmt.Printf("  Created:   %s\n", convoy.CreatedAt)
 	if convoy.ClosedAt != "" {
 		fmt.Printf("  Closed:    %s\n", convoy.ClosedAt)
 	}
+	if len(waitingOn) > 0 {
+		fmt.Printf("  Waiting:   %s\n", style.Warning.Render("on "+strings.Join(waitingOn, ", ")))
+	}
 
 	if len(tracked) > 0 {
 		fmt.Printf("\n  %s\n", style.Bold.Render("Tracked Issues:"))
@@ -920,10 +1021,40 @@ func runConvoyList(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("parsing convoy list: %w", err)
 	}
 
+	// Forecast landing times for open convoys
+	etas := make(map[string]forecast.ETA)
+	if convoyListETA {
+		townRoot := filepath.Dir(townBeads)
+		history := forecast.Load(townRoot, forecast.DefaultWindow, time.Now())
+		for _, c := range convoys {
+			if c.Status != "closed" {
+				etas[c.ID] = convoyETA(townRoot, history, getTrackedIssues(townBeads, c.ID))
+			}
+		}
+	}
+
 	if convoyListJSON {
 		enc := json.NewEncoder(os.Stdout)
 		enc.SetIndent("", "  ")
-		return enc.Encode(convoys)
+		if !convoyListETA {
+			return enc.Encode(convoys)
+		}
+		type convoyWithETA struct {
+			ID        string        `json:"id"`
+			Title     string        `json:"title"`
+			Status    string        `json:"status"`
+			CreatedAt string        `json:"created_at"`
+			ETA       *forecast.ETA `json:"eta,omitempty"`
+		}
+		out := make([]convoyWithETA, 0, len(convoys))
+		for _, c := range convoys {
+			row := convoyWithETA{ID: c.ID, Title: c.Title, Status: c.Status, CreatedAt: c.CreatedAt}
+			if eta, ok := etas[c.ID]; ok {
+				row.ETA = &eta
+			}
+			out = append(out, row)
+		}
+		return enc.Encode(out)
 	}
 
 	if len(convoys) == 0 {
@@ -940,7 +1071,11 @@ func runConvoyList(cmd *cobra.Command, args []string) error {
 	fmt.Printf("%s\n\n", style.Bold.Render("Convoys"))
 	for i, c := range convoys {
 		status := formatConvoyStatus(c.Status)
-		fmt.Printf("  %d. 🚚 %s: %s %s\n", i+1, c.ID, c.Title, status)
+		line := fmt.Sprintf("  %d. 🚚 %s: %s %s", i+1, c.ID, c.Title, status)
+		if eta, ok := etas[c.ID]; ok {
+			line += "  " + style.Dim.Render("ETA "+eta.String())
+		}
+		fmt.Println(line)
 	}
 	fmt.Printf("\nUse 'gt convoy status <id>' or 'gt convoy status <n>' for detailed view.\n")
 
@@ -1022,9 +1157,15 @@ type trackedIssueInfo struct {
 	Status    string `json:"status"`
 	Type      string `json:"dependency_type"`
 	IssueType string `json:"issue_type"`
+	Priority  int    `json:"priority"`
+	CreatedAt string `json:"created_at,omitempty"`
 	Assignee  string `json:"assignee,omitempty"`   // Assigned agent (e.g., gastown/polecats/goose)
 	Worker    string `json:"worker,omitempty"`     // Worker currently assigned (e.g., gastown/nux)
 	WorkerAge string `json:"worker_age,omitempty"` // How long worker has been on this issue
+
+	// ConvoyWaitingOn lists unlanded convoys the tracking convoy waits on.
+	// Non-empty means the issue is held back from dispatch.
+	ConvoyWaitingOn []string `json:"convoy_waiting_on,omitempty"`
 }
 
 // getTrackedIssues queries SQLite directly to get issues tracked by a convoy.
@@ -1095,6 +1236,8 @@ func getTrackedIssues(townBeads, convoyID string) []trackedIssueInfo {
 			info.Title = details.Title
 			info.Status = details.Status
 			info.IssueType = details.IssueType
+			info.Priority = details.Priority
+			info.CreatedAt = details.CreatedAt
 			info.Assignee = details.Assignee
 		} else {
 			info.Title = "(external)"
@@ -1110,6 +1253,15 @@ func getTrackedIssues(townBeads, convoyID string) []trackedIssueInfo {
 		tracked = append(tracked, info)
 	}
 
+	// Hold back every issue while the convoy waits on an upstream convoy
+	if len(tracked) > 0 {
+		if waitingOn := beads.ConvoyWaitingOn(townBeads, convoyID); len(waitingOn) > 0 {
+			for i := range tracked {
+				tracked[i].ConvoyWaitingOn = waitingOn
+			}
+		}
+	}
+
 	return tracked
 }
 
@@ -1119,6 +1271,8 @@ type issueDetails struct {
 	Title     string
 	Status    string
 	IssueType string
+	Priority  int
+	CreatedAt string
 	Assignee  string
 }
 
@@ -1154,6 +1308,8 @@ func getIssueDetailsBatch(issueIDs []string) map[string]*issueDetails {
 		Title     string `json:"title"`
 		Status    string `json:"status"`
 		IssueType string `json:"issue_type"`
+		Priority  int    `json:"priority"`
+		CreatedAt string `json:"created_at"`
 		Assignee  string `json:"assignee"`
 	}
 	if err := json.Unmarshal(stdout.Bytes(), &issues); err != nil {
@@ -1166,6 +1322,8 @@ func getIssueDetailsBatch(issueIDs []string) map[string]*issueDetails {
 			Title:     issue.Title,
 			Status:    issue.Status,
 			IssueType: issue.IssueType,
+			Priority:  issue.Priority,
+			CreatedAt: issue.CreatedAt,
 			Assignee:  issue.Assignee,
 		}
 	}
@@ -1190,6 +1348,8 @@ func getIssueDetails(issueID string) *issueDetails {
 		Title     string `json:"title"`
 		Status    string `json:"status"`
 		IssueType string `json:"issue_type"`
+		Priority  int    `json:"priority"`
+		CreatedAt string `json:"created_at"`
 		Assignee  string `json:"assignee"`
 	}
 	if err := json.Unmarshal(stdout.Bytes(), &issues); err != nil || len(issues) == 0 {
@@ -1201,6 +1361,8 @@ func getIssueDetails(issueID string) *issueDetails {
 		Title:     issues[0].Title,
 		Status:    issues[0].Status,
 		IssueType: issues[0].IssueType,
+		Priority:  issues[0].Priority,
+		CreatedAt: issues[0].CreatedAt,
 		Assignee:  issues[0].Assignee,
 	}
 }
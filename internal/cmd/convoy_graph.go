@@ -0,0 +1,270 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// convoyEdge is a "waits on" relation between two convoys.
+type convoyEdge struct {
+	From       string `json:"issue_id"`
+	To         string `json:"depends_on_id"`
+	FromTitle  string `json:"from_title"`
+	FromStatus string `json:"from_status"`
+	ToTitle    string `json:"to_title"`
+	ToStatus   string `json:"to_status"`
+	ToLabels   string `json:"to_labels"` // Comma-separated
+}
+
+// getConvoyEdges returns every convoy-to-convoy blocking relation.
+func getConvoyEdges(townBeads string) ([]convoyEdge, error) {
+	dbPath := filepath.Join(townBeads, "beads.db")
+	queryCmd := exec.Command("sqlite3", "-json", dbPath,
+		`SELECT d.issue_id, d.depends_on_id, a.title AS from_title, a.status AS from_status, b.title AS to_title, b.status AS to_status,
+COALESCE((SELECT group_concat(l.label) FROM labels l WHERE l.issue_id = b.id), '') AS to_labels
+FROM dependencies d JOIN issues a ON a.id = d.issue_id JOIN issues b ON b.id = d.depends_on_id
+WHERE d.type = 'blocks' AND a.issue_type = 'convoy' AND b.issue_type = 'convoy'`)
+
+	var stdout, stderr bytes.Buffer
+	queryCmd.Stdout = &stdout
+	queryCmd.Stderr = &stderr
+	if err := queryCmd.Run(); err != nil {
+		return nil, fmt.Errorf("querying convoy dependencies: %w (%s)", err, strings.TrimSpace(stderr.String()))
+	}
+
+	// sqlite3 -json prints nothing for an empty result
+	if len(bytes.TrimSpace(stdout.Bytes())) == 0 {
+		return nil, nil
+	}
+	var edges []convoyEdge
+	if err := json.Unmarshal(stdout.Bytes(), &edges); err != nil {
+		return nil, fmt.Errorf("parsing convoy dependencies: %w", err)
+	}
+	return edges, nil
+}
+
+// layerConvoyGraph assigns each convoy to a stage: convoys that wait on
+// nothing are stage 0, and every other convoy sits one stage after the
+// latest convoy it waits on. after maps a convoy to the convoys it waits on;
+// entries outside ids are ignored. Returns an error on a cycle.
+func layerConvoyGraph(ids []string, after map[string][]string) ([][]string, error) {
+	inGraph := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		inGraph[id] = true
+	}
+
+	stage := make(map[string]int, len(ids))
+	remaining := append([]string(nil), ids...)
+	for len(remaining) > 0 {
+		var next []string
+		progressed := false
+		for _, id := range remaining {
+			level, ready := 0, true
+			for _, dep := range after[id] {
+				if !inGraph[dep] {
+					continue
+				}
+				depStage, ok := stage[dep]
+				if !ok {
+					ready = false
+					break
+				}
+				if depStage+1 > level {
+					level = depStage + 1
+				}
+			}
+			if ready {
+				stage[id] = level
+				progressed = true
+			} else {
+				next = append(next, id)
+			}
+		}
+		if !progressed {
+			sort.Strings(next)
+			return nil, fmt.Errorf("convoy dependency cycle among: %s", strings.Join(next, ", "))
+		}
+		remaining = next
+	}
+
+	var stages [][]string
+	for id, level := range stage {
+		for len(stages) <= level {
+			stages = append(stages, nil)
+		}
+		stages[level] = append(stages[level], id)
+	}
+	for _, s := range stages {
+		sort.Strings(s)
+	}
+	return stages, nil
+}
+
+// convoyGraphNode is one convoy in the dependency graph.
+type convoyGraphNode struct {
+	ID        string   `json:"id"`
+	Title     string   `json:"title"`
+	Status    string   `json:"status"`
+	Completed int      `json:"completed"`
+	Total     int      `json:"total"`
+	Cancelled bool     `json:"cancelled,omitempty"` // Closed by gt convoy cancel
+	After     []string `json:"after,omitempty"`
+	WaitingOn []string `json:"waiting_on,omitempty"`
+}
+
+// showConvoyGraph renders the convoy DAG. With rootID, only convoys
+// upstream or downstream of it are shown.
+func showConvoyGraph(townBeads, rootID string) error {
+	edges, err := getConvoyEdges(townBeads)
+	if err != nil {
+		return err
+	}
+
+	nodes := make(map[string]*convoyGraphNode)
+	after := make(map[string][]string)
+	before := make(map[string][]string)
+	addNode := func(id, title, status string) {
+		if _, ok := nodes[id]; !ok {
+			nodes[id] = &convoyGraphNode{ID: id, Title: title, Status: status}
+		}
+	}
+	for _, e := range edges {
+		addNode(e.From, e.FromTitle, e.FromStatus)
+		addNode(e.To, e.ToTitle, e.ToStatus)
+		nodes[e.To].Cancelled = beads.ConvoyBlocker{Labels: e.ToLabels}.Cancelled()
+		after[e.From] = append(after[e.From], e.To)
+		before[e.To] = append(before[e.To], e.From)
+	}
+
+	if rootID != "" {
+		if _, ok := nodes[rootID]; !ok {
+			convoy, err := loadConvoy(filepath.Dir(townBeads), rootID)
+			if err != nil {
+				return err
+			}
+			addNode(convoy.ID, convoy.Title, convoy.Status)
+		}
+		keep := make(map[string]bool)
+		walkConvoyGraph(rootID, after, keep)
+		walkConvoyGraph(rootID, before, keep)
+		for id := range nodes {
+			if !keep[id] {
+				delete(nodes, id)
+			}
+		}
+	} else {
+		// Include open convoys with no dependencies so the graph is complete
+		b := beads.New(filepath.Dir(townBeads))
+		if open, err := b.List(beads.ListOptions{Type: "convoy", Status: "open", Priority: -1}); err == nil {
+			for _, c := range open {
+				addNode(c.ID, c.Title, c.Status)
+			}
+		}
+		// Landed convoys only matter as context for open ones
+		for id, n := range nodes {
+			if n.Status == "closed" && !hasOpenDependent(id, before, nodes) {
+				delete(nodes, id)
+			}
+		}
+	}
+
+	ids := make([]string, 0, len(nodes))
+	for id, n := range nodes {
+		ids = append(ids, id)
+		for _, dep := range after[id] {
+			if _, ok := nodes[dep]; ok {
+				n.After = append(n.After, dep)
+			}
+		}
+		sort.Strings(n.After)
+		for _, dep := range n.After {
+			d := nodes[dep]
+			if landed := (d.Status == "closed" || d.Status == "tombstone") && !d.Cancelled; !landed {
+				n.WaitingOn = append(n.WaitingOn, dep)
+			}
+		}
+		for _, t := range getTrackedIssues(townBeads, id) {
+			n.Total++
+			if t.Status == "closed" {
+				n.Completed++
+			}
+		}
+	}
+
+	stages, err := layerConvoyGraph(ids, after)
+	if err != nil {
+		return err
+	}
+
+	if convoyStatusJSON {
+		type jsonGraph struct {
+			Stages  [][]string         `json:"stages"`
+			Convoys []*convoyGraphNode `json:"convoys"`
+		}
+		out := jsonGraph{Stages: stages}
+		for _, stage := range stages {
+			for _, id := range stage {
+				out.Convoys = append(out.Convoys, nodes[id])
+			}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(out)
+	}
+
+	if len(ids) == 0 {
+		fmt.Println("No active convoys.")
+		return nil
+	}
+
+	fmt.Printf("%s\n", style.Bold.Render("🚚 Convoy Graph"))
+	for i, stage := range stages {
+		fmt.Printf("\n  %s\n", style.Bold.Render(fmt.Sprintf("Stage %d", i+1)))
+		for _, id := range stage {
+			n := nodes[id]
+			percent := 0
+			if n.Total > 0 {
+				percent = n.Completed * 100 / n.Total
+			}
+			fmt.Printf("    %s %s: %s  %d/%d %s\n",
+				formatConvoyStatus(n.Status), n.ID, n.Title, n.Completed, n.Total, style.ProgressBar(percent, 10))
+			switch {
+			case len(n.WaitingOn) > 0:
+				fmt.Printf("        %s\n", style.Warning.Render("⏳ waiting on "+strings.Join(n.WaitingOn, ", ")))
+			case len(n.After) > 0:
+				fmt.Printf("        %s\n", style.Dim.Render("after "+strings.Join(n.After, ", ")+" (landed)"))
+			}
+		}
+	}
+	return nil
+}
+
+// walkConvoyGraph marks every convoy reachable from id along next.
+func walkConvoyGraph(id string, next map[string][]string, seen map[string]bool) {
+	if seen[id] {
+		return
+	}
+	seen[id] = true
+	for _, n := range next[id] {
+		walkConvoyGraph(n, next, seen)
+	}
+}
+
+// hasOpenDependent reports whether an open convoy in nodes waits on id.
+func hasOpenDependent(id string, before map[string][]string, nodes map[string]*convoyGraphNode) bool {
+	for _, dep := range before[id] {
+		if n, ok := nodes[dep]; ok && n.Status != "closed" {
+			return true
+		}
+	}
+	return false
+}
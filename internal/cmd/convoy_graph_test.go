@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLayerConvoyGraph(t *testing.T) {
+	// api and schema run first; web waits on api; docs waits on web and schema
+	after := map[string][]string{
+		"hq-cv-web":  {"hq-cv-api"},
+		"hq-cv-docs": {"hq-cv-web", "hq-cv-schema"},
+		"hq-cv-api":  {"hq-cv-gone"}, // not in the graph, ignored
+	}
+	ids := []string{"hq-cv-docs", "hq-cv-web", "hq-cv-schema", "hq-cv-api"}
+
+	stages, err := layerConvoyGraph(ids, after)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{
+		{"hq-cv-api", "hq-cv-schema"},
+		{"hq-cv-web"},
+		{"hq-cv-docs"},
+	}
+	if !reflect.DeepEqual(stages, want) {
+		t.Errorf("stages = %v, want %v", stages, want)
+	}
+}
+
+func TestLayerConvoyGraphCycle(t *testing.T) {
+	after := map[string][]string{
+		"hq-cv-a": {"hq-cv-b"},
+		"hq-cv-b": {"hq-cv-a"},
+	}
+	_, err := layerConvoyGraph([]string{"hq-cv-a", "hq-cv-b", "hq-cv-c"}, after)
+	if err == nil || !strings.Contains(err.Error(), "hq-cv-a, hq-cv-b") {
+		t.Errorf("expected cycle error naming hq-cv-a and hq-cv-b, got %v", err)
+	}
+}
+
+func TestIsReadyIssueConvoyWaiting(t *testing.T) {
+	ready := trackedIssueInfo{ID: "gt-abc", Status: "open"}
+	if !isReadyIssue(ready, nil) {
+		t.Fatal("open unassigned issue should be ready")
+	}
+
+	waiting := ready
+	waiting.ConvoyWaitingOn = []string{"hq-cv-api"}
+	if isReadyIssue(waiting, nil) {
+		t.Error("issue of a convoy waiting on another convoy should not be ready")
+	}
+}
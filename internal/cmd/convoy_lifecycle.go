@@ -0,0 +1,650 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/mrqueue"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/swarm"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// ConvoyPausedLabel marks a paused convoy, whose issues the scheduler must
+// not dispatch. gt convoy pause adds it and gt convoy resume removes it.
+const ConvoyPausedLabel = "paused"
+
+// Convoy description keys for lifecycle state.
+const (
+	convoyPauseGateKey = "Pause gate"
+	convoyCancelledKey = "Cancelled"
+)
+
+var (
+	convoyPauseMessage  string
+	convoyCancelReason  string
+	convoyCancelForce   bool
+	convoyCancelDryRun  bool
+	convoyResumeMessage string
+)
+
+var convoyPauseCmd = &cobra.Command{
+	Use:   "pause <convoy-id>",
+	Short: "Pause a convoy: stop dispatch and park its workers",
+	Long: `Pause a convoy.
+
+Pausing:
+  1. Stops the scheduler from dispatching the convoy's ready issues
+  2. Creates a gate for the pause and mails each polecat working on a
+     tracked issue to park on it ('gt park <gate>') at its next safe point
+
+Resume with 'gt convoy resume', which closes the gate and wakes the parked
+polecats.
+
+Examples:
+  gt convoy pause hq-cv-abc
+  gt convoy pause hq-cv-abc -m "Holding for the API freeze"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConvoyPause,
+}
+
+var convoyResumeCmd = &cobra.Command{
+	Use:   "resume <convoy-id>",
+	Short: "Resume a paused convoy",
+	Long: `Resume a paused convoy.
+
+Re-enables dispatch and closes the pause gate, sending wake mail to the
+polecats parked on it so they can 'gt resume'.
+
+Examples:
+  gt convoy resume hq-cv-abc`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConvoyResume,
+}
+
+var convoyCancelCmd = &cobra.Command{
+	Use:   "cancel <convoy-id>",
+	Short: "Cancel a convoy and clean up its work",
+	Long: `Cancel a convoy and clean up everything working on it.
+
+Cancelling:
+  1. Audits each assigned polecat's git state and nukes it if no code is
+     at risk (uncommitted changes, commits not on the rig's default branch,
+     or a clone that cannot be audited block the nuke unless --force).
+     Without --force, 'gt polecat nuke' runs its own safety checks too
+  2. Releases each unfinished tracked issue back to open (like 'gt release')
+     once nothing is working on it. An issue whose polecat was kept stays
+     assigned to it
+  3. Removes the issues' merge requests from their rig's merge queue
+  4. Closes the convoy with the reason recorded on the bead
+
+Examples:
+  gt convoy cancel hq-cv-abc --reason "Superseded by hq-cv-def"
+  gt convoy cancel hq-cv-abc --dry-run
+  gt convoy cancel hq-cv-abc --force   # Nuke polecats even with code at risk`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConvoyCancel,
+}
+
+func init() {
+	convoyPauseCmd.Flags().StringVarP(&convoyPauseMessage, "message", "m", "", "Why the convoy is paused (sent to workers)")
+	convoyResumeCmd.Flags().StringVarP(&convoyResumeMessage, "message", "m", "", "Note sent with the wake mail")
+	convoyCancelCmd.Flags().StringVarP(&convoyCancelReason, "reason", "r", "", "Why the convoy is cancelled (recorded on the bead)")
+	convoyCancelCmd.Flags().BoolVarP(&convoyCancelForce, "force", "f", false, "Nuke polecats even if the git audit finds code at risk (LOSES WORK)")
+	convoyCancelCmd.Flags().BoolVarP(&convoyCancelDryRun, "dry-run", "n", false, "Show what would be cleaned up")
+
+	convoyCmd.AddCommand(convoyPauseCmd)
+	convoyCmd.AddCommand(convoyResumeCmd)
+	convoyCmd.AddCommand(convoyCancelCmd)
+}
+
+// loadConvoy shows a convoy bead, checking its type.
+func loadConvoy(townRoot, convoyID string) (*beads.Issue, error) {
+	issue, err := beads.New(townRoot).Show(convoyID)
+	if err != nil {
+		return nil, fmt.Errorf("convoy '%s' not found", convoyID)
+	}
+	if issue.Type != "convoy" {
+		return nil, fmt.Errorf("'%s' is not a convoy (type: %s)", convoyID, issue.Type)
+	}
+	return issue, nil
+}
+
+// convoyDescriptionField returns the value of a "Key: value" line in a
+// convoy description (the format used for Notify: and Molecule:).
+func convoyDescriptionField(desc, key string) string {
+	for _, line := range strings.Split(desc, "\n") {
+		if strings.HasPrefix(line, key+": ") {
+			return strings.TrimSpace(strings.TrimPrefix(line, key+": "))
+		}
+	}
+	return ""
+}
+
+// setConvoyDescriptionField sets, replaces, or (with an empty value)
+// removes a "Key: value" line in a convoy description.
+func setConvoyDescriptionField(desc, key, value string) string {
+	var lines []string
+	for _, line := range strings.Split(desc, "\n") {
+		if strings.HasPrefix(line, key+": ") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if value != "" {
+		lines = append(lines, fmt.Sprintf("%s: %s", key, value))
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
+
+// convoyPolecat extracts the rig and polecat name from a tracked issue's
+// worker or assignee ("gastown/nux", "gastown/polecat/nux",
+// "gastown/polecats/nux"). Only names with a clone under <rig>/polecats/
+// count, so crew, the witness and the refinery are never treated as
+// polecats.
+func convoyPolecat(townRoot string, t trackedIssueInfo) (rigName, polecatName string, ok bool) {
+	for _, who := range []string{t.Worker, t.Assignee} {
+		parts := strings.Split(who, "/")
+		switch {
+		case len(parts) == 2:
+			rigName, polecatName = parts[0], parts[1]
+		case len(parts) == 3 && (parts[1] == "polecat" || parts[1] == "polecats"):
+			rigName, polecatName = parts[0], parts[2]
+		default:
+			continue
+		}
+		if !isPathSegment(rigName) || !isPathSegment(polecatName) {
+			continue
+		}
+		if info, err := os.Stat(filepath.Join(townRoot, rigName, "polecats", polecatName)); err == nil && info.IsDir() {
+			return rigName, polecatName, true
+		}
+	}
+	return "", "", false
+}
+
+// isPathSegment reports whether s is usable as a single path element.
+func isPathSegment(s string) bool {
+	return s != "" && s != "." && s != ".."
+}
+
+func runConvoyPause(cmd *cobra.Command, args []string) error {
+	convoyID := args[0]
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	townBeads := filepath.Join(townRoot, ".beads")
+
+	convoy, err := loadConvoy(townRoot, convoyID)
+	if err != nil {
+		return err
+	}
+	if convoy.Status == "closed" {
+		return fmt.Errorf("convoy %s is closed", convoyID)
+	}
+	if gate := convoyDescriptionField(convoy.Description, convoyPauseGateKey); gate != "" {
+		return fmt.Errorf("convoy %s is already paused (gate %s)", convoyID, gate)
+	}
+
+	// Stop dispatch first so nothing new starts while we park workers
+	if err := setConvoyPaused(townBeads, convoyID, true); err != nil {
+		return fmt.Errorf("pausing dispatch: %w", err)
+	}
+
+	gateID, err := createConvoyPauseGate(townRoot, convoyID, convoy.Title)
+	if err != nil {
+		return fmt.Errorf("creating pause gate: %w", err)
+	}
+	desc := setConvoyDescriptionField(convoy.Description, convoyPauseGateKey, gateID)
+	if err := beads.New(townRoot).Update(convoyID, beads.UpdateOptions{Description: &desc}); err != nil {
+		return fmt.Errorf("recording pause gate: %w", err)
+	}
+
+	fmt.Printf("%s Paused convoy %s\n", style.Bold.Render("⏸"), convoyID)
+	fmt.Printf("   Gate: %s\n", gateID)
+
+	// Ask working polecats to park on the gate
+	router := mail.NewRouter(townRoot)
+	subject := fmt.Sprintf("⏸ CONVOY PAUSED: %s", convoy.Title)
+	var parked []string
+	for _, t := range getTrackedIssues(townBeads, convoyID) {
+		if t.Status == "closed" || t.Status == "tombstone" {
+			continue
+		}
+		rigName, polecatName, ok := convoyPolecat(townRoot, t)
+		if !ok {
+			continue
+		}
+		var body strings.Builder
+		fmt.Fprintf(&body, "Convoy %s has been paused.\n\n", convoyID)
+		if convoyPauseMessage != "" {
+			fmt.Fprintf(&body, "Reason: %s\n\n", convoyPauseMessage)
+		}
+		fmt.Fprintf(&body, "At your next safe point, commit your work on %s and park:\n\n", t.ID)
+		fmt.Fprintf(&body, "  gt park %s -m \"<where you left off>\"\n\n", gateID)
+		body.WriteString("You'll get wake mail when the convoy resumes.")
+
+		addr := rigName + "/" + polecatName
+		if err := router.Send(&mail.Message{
+			From:     "mayor/",
+			To:       addr,
+			Subject:  subject,
+			Body:     body.String(),
+			Type:     mail.TypeTask,
+			Priority: mail.PriorityHigh,
+		}); err != nil {
+			style.PrintWarning("could not mail %s: %v", addr, err)
+			continue
+		}
+		parked = append(parked, addr)
+	}
+
+	if len(parked) > 0 {
+		fmt.Printf("   Asked to park: %s\n", strings.Join(parked, ", "))
+	} else {
+		fmt.Printf("   %s\n", style.Dim.Render("No active polecats to park"))
+	}
+
+	payload := map[string]interface{}{"convoy": convoyID, "gate": gateID, "workers": parked}
+	if convoyPauseMessage != "" {
+		payload["reason"] = convoyPauseMessage
+	}
+	_ = events.LogFeed(events.TypeConvoyPaused, detectSender(), payload)
+	return nil
+}
+
+// createConvoyPauseGate creates a human gate for parked convoy workers.
+func createConvoyPauseGate(townRoot, convoyID, title string) (string, error) {
+	gateCmd := exec.Command("bd", "gate", "create", //nolint:gosec // G204: args are constructed internally
+		"--await", "human:convoy-"+convoyID,
+		"--title", fmt.Sprintf("Convoy paused: %s", title),
+		"--json")
+	gateCmd.Dir = townRoot
+	var stdout, stderr bytes.Buffer
+	gateCmd.Stdout = &stdout
+	gateCmd.Stderr = &stderr
+	if err := gateCmd.Run(); err != nil {
+		return "", fmt.Errorf("bd gate create: %w (%s)", err, strings.TrimSpace(stderr.String()))
+	}
+
+	var gate struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &gate); err == nil && gate.ID != "" {
+		return gate.ID, nil
+	}
+
+	// Older bd prints "Created gate: <id>"
+	output := strings.TrimSpace(stdout.String())
+	if parts := strings.Split(output, ": "); len(parts) >= 2 {
+		return strings.TrimSpace(parts[len(parts)-1]), nil
+	}
+	return "", fmt.Errorf("could not parse gate ID from: %s", output)
+}
+
+// releaseConvoyPauseGate closes a pause gate and, if wake is set, mails
+// its waiters.
+func releaseConvoyPauseGate(townRoot, gateID, reason string, wake bool) error {
+	closeCmd := exec.Command("bd", "gate", "close", gateID, "--reason", reason) //nolint:gosec // G204: gate ID is from the convoy bead
+	closeCmd.Dir = townRoot
+	if out, err := closeCmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("closing gate %s: %s", gateID, strings.TrimSpace(string(out)))
+	}
+	if !wake {
+		return nil
+	}
+	wakeCmd := exec.Command("gt", "gate", "wake", gateID) //nolint:gosec // G204: gate ID is from the convoy bead
+	wakeCmd.Dir = townRoot
+	if out, err := wakeCmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("waking gate %s: %s", gateID, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+func runConvoyResume(cmd *cobra.Command, args []string) error {
+	convoyID := args[0]
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	townBeads := filepath.Join(townRoot, ".beads")
+
+	convoy, err := loadConvoy(townRoot, convoyID)
+	if err != nil {
+		return err
+	}
+	gateID := convoyDescriptionField(convoy.Description, convoyPauseGateKey)
+	if gateID == "" && !hasConvoyLabel(convoy, ConvoyPausedLabel) {
+		return fmt.Errorf("convoy %s is not paused", convoyID)
+	}
+
+	if err := setConvoyPaused(townBeads, convoyID, false); err != nil {
+		return fmt.Errorf("resuming dispatch: %w", err)
+	}
+	fmt.Printf("%s Resumed convoy %s\n", style.Bold.Render("▶"), convoyID)
+
+	if gateID == "" {
+		return nil
+	}
+
+	reason := "Convoy resumed"
+	if convoyResumeMessage != "" {
+		reason += ": " + convoyResumeMessage
+	}
+	if err := releaseConvoyPauseGate(townRoot, gateID, reason, true); err != nil {
+		style.PrintWarning("%v", err)
+	} else {
+		fmt.Printf("   Woke workers parked on %s\n", gateID)
+	}
+
+	desc := setConvoyDescriptionField(convoy.Description, convoyPauseGateKey, "")
+	if err := beads.New(townRoot).Update(convoyID, beads.UpdateOptions{Description: &desc}); err != nil {
+		style.PrintWarning("could not clear pause gate on %s: %v", convoyID, err)
+	}
+
+	_ = events.LogFeed(events.TypeConvoyResumed, detectSender(), map[string]interface{}{
+		"convoy": convoyID,
+		"gate":   gateID,
+	})
+	return nil
+}
+
+func hasConvoyLabel(issue *beads.Issue, label string) bool {
+	for _, l := range issue.Labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
+
+// convoyCancelOutcome records cleanup for one tracked issue.
+type convoyCancelOutcome struct {
+	IssueID    string
+	Released   bool // Released, or would be on a dry run
+	MRsRemoved []string
+	Polecat    string
+	Audit      *swarm.GitAuditResult
+	Nuked      bool
+	Errors     []string
+}
+
+func runConvoyCancel(cmd *cobra.Command, args []string) error {
+	convoyID := args[0]
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	townBeads := filepath.Join(townRoot, ".beads")
+
+	convoy, err := loadConvoy(townRoot, convoyID)
+	if err != nil {
+		return err
+	}
+	if convoy.Status == "closed" {
+		return fmt.Errorf("convoy %s is already closed", convoyID)
+	}
+
+	reason := convoyCancelReason
+	if reason == "" {
+		reason = "cancelled"
+	}
+
+	// Stop dispatch before tearing anything down
+	if !convoyCancelDryRun {
+		if err := setConvoyPaused(townBeads, convoyID, true); err != nil {
+			return fmt.Errorf("pausing dispatch: %w", err)
+		}
+	}
+
+	var open []trackedIssueInfo
+	issueSet := make(map[string]bool)
+	for _, t := range getTrackedIssues(townBeads, convoyID) {
+		if t.Status == "closed" || t.Status == "tombstone" {
+			continue
+		}
+		open = append(open, t)
+		issueSet[t.ID] = true
+	}
+
+	mrsByIssue := findConvoyMRs(townRoot, convoyID, issueSet)
+	b := beads.New(townRoot)
+
+	var outcomes []convoyCancelOutcome
+	atRisk := 0
+	for _, t := range open {
+		o := convoyCancelOutcome{IssueID: t.ID}
+
+		// 1. Audit and nuke the polecat
+		stopped := true
+		if rigName, polecatName, ok := convoyPolecat(townRoot, t); ok {
+			o.Polecat = rigName + "/" + polecatName
+			audit := auditConvoyPolecat(townRoot, rigName, polecatName)
+			o.Audit = &audit
+			if audit.CodeAtRisk {
+				atRisk++
+			}
+			stopped = !audit.CodeAtRisk || convoyCancelForce
+			if !convoyCancelDryRun && stopped {
+				if err := nukeConvoyPolecat(townRoot, o.Polecat); err != nil {
+					o.Errors = append(o.Errors, fmt.Sprintf("nuke %s: %v", o.Polecat, err))
+					stopped = false
+				} else {
+					o.Nuked = true
+				}
+			}
+		}
+
+		// 2. Release the issue, unless a kept polecat still holds its work
+		o.Released = stopped
+		if !convoyCancelDryRun && stopped {
+			if err := b.ReleaseWithReason(t.ID, "convoy "+convoyID+" cancelled: "+reason); err != nil {
+				o.Errors = append(o.Errors, fmt.Sprintf("release: %v", err))
+				o.Released = false
+			}
+		}
+
+		// 3. Remove its merge requests
+		for _, ref := range mrsByIssue[t.ID] {
+			if !convoyCancelDryRun {
+				if err := mrqueue.New(filepath.Join(townRoot, ref.rig)).Remove(ref.id); err != nil {
+					o.Errors = append(o.Errors, fmt.Sprintf("remove MR %s: %v", ref.id, err))
+					continue
+				}
+			}
+			o.MRsRemoved = append(o.MRsRemoved, ref.id)
+		}
+
+		outcomes = append(outcomes, o)
+	}
+
+	printConvoyCancel(convoyID, outcomes)
+	if convoyCancelDryRun {
+		fmt.Printf("\n%s Would close convoy %s: %s\n", style.Dim.Render("○"), convoyID, reason)
+		return nil
+	}
+
+	// 4. Close the convoy with the reason recorded
+	desc := setConvoyDescriptionField(convoy.Description, convoyCancelledKey, reason)
+	if gateID := convoyDescriptionField(convoy.Description, convoyPauseGateKey); gateID != "" {
+		// Parked workers were nuked or released; close the gate without waking
+		if err := releaseConvoyPauseGate(townRoot, gateID, "Convoy cancelled", false); err != nil {
+			style.PrintWarning("%v", err)
+		}
+		desc = setConvoyDescriptionField(desc, convoyPauseGateKey, "")
+	}
+	if err := b.Update(convoyID, beads.UpdateOptions{
+		Description:  &desc,
+		AddLabels:    []string{beads.ConvoyCancelledLabel},
+		RemoveLabels: []string{ConvoyPausedLabel},
+	}); err != nil {
+		style.PrintWarning("could not record cancel reason on %s: %v", convoyID, err)
+	}
+	if err := b.CloseWithReason("Cancelled: "+reason, convoyID); err != nil {
+		return fmt.Errorf("closing convoy: %w", err)
+	}
+
+	fmt.Printf("\n%s Cancelled convoy %s: %s\n", style.SuccessPrefix, convoyID, reason)
+	if atRisk > 0 && !convoyCancelForce {
+		fmt.Printf("%s %d polecat(s) kept because code is at risk, with their issues still assigned; push or discard their work, then 'gt polecat nuke'\n",
+			style.WarningPrefix, atRisk)
+	}
+
+	_ = events.LogFeed(events.TypeConvoyCancelled, detectSender(), map[string]interface{}{
+		"convoy": convoyID,
+		"reason": reason,
+		"issues": len(outcomes),
+	})
+	return nil
+}
+
+// mrRef locates a merge request in a rig's queue.
+type mrRef struct {
+	rig string
+	id  string
+}
+
+// findConvoyMRs finds queued MRs for the convoy's issues across all rigs,
+// matched by source issue or convoy ID.
+func findConvoyMRs(townRoot, convoyID string, issues map[string]bool) map[string][]mrRef {
+	result := make(map[string][]mrRef)
+	rigsConfig, err := config.LoadRigsConfig(filepath.Join(townRoot, "mayor", "rigs.json"))
+	if err != nil {
+		return result
+	}
+	for rigName := range rigsConfig.Rigs {
+		mrs, err := mrqueue.New(filepath.Join(townRoot, rigName)).List()
+		if err != nil {
+			continue
+		}
+		for _, mr := range mrs {
+			if issues[mr.SourceIssue] || (mr.ConvoyID == convoyID && mr.SourceIssue != "") {
+				result[mr.SourceIssue] = append(result[mr.SourceIssue], mrRef{rig: rigName, id: mr.ID})
+			}
+		}
+	}
+	return result
+}
+
+// auditConvoyPolecat checks a polecat's clone for work that a nuke would
+// lose. On top of swarm.AuditClone it counts commits missing from
+// origin/<default branch>, and treats any git error as code at risk: a clone
+// that cannot be audited is not known to be clean.
+func auditConvoyPolecat(townRoot, rigName, polecatName string) swarm.GitAuditResult {
+	clonePath := filepath.Join(townRoot, rigName, "polecats", polecatName)
+	audit := swarm.AuditClone(polecatName, clonePath)
+	if audit.CodeAtRisk {
+		return audit
+	}
+	atRisk := func(details string) swarm.GitAuditResult {
+		audit.CodeAtRisk = true
+		audit.Details = details
+		return audit
+	}
+
+	if _, err := convoyGitOutput(clonePath, "status", "--porcelain"); err != nil {
+		return atRisk("cannot check git state")
+	}
+	target := (&rig.Rig{Name: rigName, Path: filepath.Join(townRoot, rigName)}).DefaultBranch()
+	count, err := convoyGitOutput(clonePath, "rev-list", "--count", "origin/"+target+"..HEAD")
+	if err != nil {
+		return atRisk(fmt.Sprintf("cannot compare with origin/%s", target))
+	}
+	if count != "0" {
+		audit.HasUnpushed = true
+		return atRisk(fmt.Sprintf("%s commit(s) not on origin/%s", count, target))
+	}
+	return audit
+}
+
+func convoyGitOutput(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	return strings.TrimSpace(string(out)), err
+}
+
+// nukeConvoyPolecat destroys a polecat whose git audit passed. --force is
+// passed on only when the user gave it; otherwise nuke's own checks
+// (cleanup status, open MRs, work on hook) still apply and may keep it.
+func nukeConvoyPolecat(townRoot, address string) error {
+	args := []string{"polecat", "nuke", address}
+	if convoyCancelForce {
+		args = append(args, "--force")
+	}
+	nukeCmd := exec.Command("gt", args...) //nolint:gosec // G204: address is from beads
+	nukeCmd.Dir = townRoot
+	if out, err := nukeCmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+func printConvoyCancel(convoyID string, outcomes []convoyCancelOutcome) {
+	if len(outcomes) == 0 {
+		fmt.Printf("%s No unfinished issues in convoy %s\n", style.Dim.Render("○"), convoyID)
+		return
+	}
+
+	for _, o := range outcomes {
+		fmt.Printf("%s %s\n", style.ArrowPrefix, style.Bold.Render(o.IssueID))
+		switch {
+		case o.Released && convoyCancelDryRun:
+			fmt.Printf("    would release → open\n")
+		case o.Released:
+			fmt.Printf("    released → open\n")
+		case o.Polecat != "":
+			fmt.Printf("    stays assigned to %s\n", o.Polecat)
+		}
+		if len(o.MRsRemoved) > 0 {
+			verb := "removed"
+			if convoyCancelDryRun {
+				verb = "would remove"
+			}
+			fmt.Printf("    %s MR(s): %s\n", verb, strings.Join(o.MRsRemoved, ", "))
+		}
+		if o.Polecat != "" {
+			switch {
+			case o.Nuked:
+				fmt.Printf("    nuked %s\n", o.Polecat)
+			case o.Audit.CodeAtRisk && !convoyCancelForce:
+				fmt.Printf("    %s kept %s: %s\n", style.WarningPrefix, o.Polecat, o.Audit.Details)
+			case convoyCancelDryRun:
+				fmt.Printf("    would nuke %s (git audit clean)\n", o.Polecat)
+			}
+		}
+		for _, e := range o.Errors {
+			fmt.Printf("    %s %s\n", style.ErrorPrefix, e)
+		}
+	}
+}
+
+// setConvoyPaused adds or removes the convoy's paused label.
+func setConvoyPaused(townBeads, convoyID string, paused bool) error {
+	b := beads.New(filepath.Dir(townBeads))
+	issue, err := b.Show(convoyID)
+	if err != nil {
+		return fmt.Errorf("convoy '%s' not found", convoyID)
+	}
+	if issue.Type != "convoy" {
+		return fmt.Errorf("'%s' is not a convoy (type: %s)", convoyID, issue.Type)
+	}
+
+	opts := beads.UpdateOptions{RemoveLabels: []string{ConvoyPausedLabel}}
+	if paused {
+		opts = beads.UpdateOptions{AddLabels: []string{ConvoyPausedLabel}}
+	}
+	return b.Update(convoyID, opts)
+}
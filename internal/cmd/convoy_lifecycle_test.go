@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConvoyPolecat(t *testing.T) {
+	townRoot := t.TempDir()
+	for _, dir := range []string{"gastown/polecats/nux", "gastown/polecats/toast", "gastown/witness", "gastown/refinery"} {
+		if err := os.MkdirAll(filepath.Join(townRoot, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		worker, assignee  string
+		wantRig, wantName string
+		wantOK            bool
+	}{
+		{"gastown/polecat/nux", "", "gastown", "nux", true},
+		{"", "gastown/polecats/nux", "gastown", "nux", true},
+		{"", "gastown/toast", "gastown", "toast", true},
+		{"gastown/witness", "gastown/toast", "gastown", "toast", true},
+		{"", "gastown/witness", "", "", false},
+		{"", "gastown/refinery", "", "", false},
+		{"", "gastown/slit", "", "", false},
+		{"", "gastown/polecats/..", "", "", false},
+		{"", "gastown/crew/max", "", "", false},
+		{"", "mayor/", "", "", false},
+		{"", "", "", "", false},
+	}
+	for _, tt := range tests {
+		rig, name, ok := convoyPolecat(townRoot, trackedIssueInfo{Worker: tt.worker, Assignee: tt.assignee})
+		if rig != tt.wantRig || name != tt.wantName || ok != tt.wantOK {
+			t.Errorf("convoyPolecat(%q, %q) = (%q, %q, %v), want (%q, %q, %v)",
+				tt.worker, tt.assignee, rig, name, ok, tt.wantRig, tt.wantName, tt.wantOK)
+		}
+	}
+}
+
+func TestAuditConvoyPolecat(t *testing.T) {
+	townRoot := t.TempDir()
+	git := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	origin := filepath.Join(t.TempDir(), "origin.git")
+	git(townRoot, "init", "--bare", "-b", "main", origin)
+	clone := filepath.Join(townRoot, "gastown", "polecats", "nux")
+	if err := os.MkdirAll(filepath.Dir(clone), 0755); err != nil {
+		t.Fatal(err)
+	}
+	git(townRoot, "clone", origin, clone)
+	git(clone, "config", "user.email", "test@test.com")
+	git(clone, "config", "user.name", "Test User")
+	git(clone, "checkout", "-b", "main")
+	git(clone, "commit", "--allow-empty", "-m", "initial")
+	git(clone, "push", "origin", "main")
+
+	if audit := auditConvoyPolecat(townRoot, "gastown", "nux"); audit.CodeAtRisk {
+		t.Errorf("pushed clone: at risk (%s), want clean", audit.Details)
+	}
+
+	// A polecat branch without an upstream: @{u} fails, but the commit is
+	// still not on origin/main.
+	git(clone, "checkout", "-b", "polecat/nux")
+	git(clone, "commit", "--allow-empty", "-m", "work")
+	if audit := auditConvoyPolecat(townRoot, "gastown", "nux"); !audit.CodeAtRisk || !strings.Contains(audit.Details, "origin/main") {
+		t.Errorf("unpushed branch: audit = %+v, want at risk", audit)
+	}
+
+	if audit := auditConvoyPolecat(townRoot, "gastown", "slit"); !audit.CodeAtRisk {
+		t.Errorf("missing clone: audit = %+v, want at risk", audit)
+	}
+}
+
+func TestConvoyDescriptionField(t *testing.T) {
+	desc := "Convoy tracking 2 issues\nNotify: mayor/"
+
+	desc = setConvoyDescriptionField(desc, convoyPauseGateKey, "hq-gate-1")
+	if got := convoyDescriptionField(desc, convoyPauseGateKey); got != "hq-gate-1" {
+		t.Fatalf("pause gate = %q, want hq-gate-1", got)
+	}
+	if got := convoyDescriptionField(desc, "Notify"); got != "mayor/" {
+		t.Errorf("Notify = %q, existing lines must be kept", got)
+	}
+
+	desc = setConvoyDescriptionField(desc, convoyPauseGateKey, "hq-gate-2")
+	if got := convoyDescriptionField(desc, convoyPauseGateKey); got != "hq-gate-2" {
+		t.Errorf("replaced pause gate = %q, want hq-gate-2", got)
+	}
+
+	desc = setConvoyDescriptionField(desc, convoyPauseGateKey, "")
+	if desc != "Convoy tracking 2 issues\nNotify: mayor/" {
+		t.Errorf("cleared description = %q", desc)
+	}
+}
@@ -0,0 +1,262 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/scheduler"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	convoyScheduleDryRun bool
+	convoyScheduleJSON   bool
+	convoyScheduleRig    string
+)
+
+var convoyScheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Dispatch ready convoy work to rigs with free polecat capacity",
+	Long: `Dispatch ready issues from open convoys to rigs with free capacity.
+
+For each rig, free capacity is the configured maximum concurrency minus the
+polecats with live sessions. Ready issues (open, unblocked, no live worker -
+the same test as 'gt convoy stranded') are ranked by issue priority and
+convoy age, the same inputs the merge queue uses to order MRs, and slung to
+their rig until its capacity is used up.
+
+Concurrency is set per rig in <rig>/settings/config.json:
+  {"scheduler": {"max_polecats": 3}}
+
+The daemon runs this every heartbeat when mayor/config.json sets
+  {"daemon": {"convoy_scheduler": true}}
+
+Convoys paused with 'gt convoy pause' are skipped until 'gt convoy resume'.
+
+Examples:
+  gt convoy schedule --dry-run         # Preview decisions
+  gt convoy schedule                   # Sling ready work
+  gt convoy schedule --rig gastown     # Only dispatch to one rig`,
+	Args: cobra.NoArgs,
+	RunE: runConvoySchedule,
+}
+
+func init() {
+	convoyScheduleCmd.Flags().BoolVarP(&convoyScheduleDryRun, "dry-run", "n", false, "Show decisions without slinging")
+	convoyScheduleCmd.Flags().BoolVar(&convoyScheduleJSON, "json", false, "Output decisions as JSON")
+	convoyScheduleCmd.Flags().StringVar(&convoyScheduleRig, "rig", "", "Only dispatch to this rig")
+
+	convoyCmd.AddCommand(convoyScheduleCmd)
+}
+
+// scheduleResult is the JSON output of gt convoy schedule.
+type scheduleResult struct {
+	Capacity  map[string]scheduler.Capacity `json:"capacity"`
+	Decisions []scheduleDecision            `json:"decisions"`
+}
+
+// scheduleDecision is a scheduler decision plus the sling outcome.
+type scheduleDecision struct {
+	scheduler.Decision
+	Error string `json:"error,omitempty"`
+}
+
+func runConvoySchedule(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	townBeads := filepath.Join(townRoot, ".beads")
+
+	capacity, err := schedulerCapacity(townRoot)
+	if err != nil {
+		return err
+	}
+	if convoyScheduleRig != "" {
+		c, ok := capacity[convoyScheduleRig]
+		if !ok {
+			return fmt.Errorf("rig '%s' not found", convoyScheduleRig)
+		}
+		capacity = map[string]scheduler.Capacity{convoyScheduleRig: c}
+	}
+
+	candidates, err := scheduleCandidates(townRoot, townBeads)
+	if err != nil {
+		return err
+	}
+
+	plan := scheduler.Plan(candidates, capacity, time.Now())
+	result := scheduleResult{Capacity: capacity, Decisions: make([]scheduleDecision, 0, len(plan))}
+	for _, d := range plan {
+		if convoyScheduleRig != "" && d.Rig != convoyScheduleRig {
+			continue
+		}
+		sd := scheduleDecision{Decision: d}
+		if d.Action == scheduler.ActionSling && !convoyScheduleDryRun {
+			if err := slingScheduled(townRoot, d); err != nil {
+				sd.Error = err.Error()
+			}
+		}
+		result.Decisions = append(result.Decisions, sd)
+	}
+
+	if convoyScheduleJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	}
+
+	printSchedule(result)
+	return nil
+}
+
+// schedulerCapacity returns polecat capacity for every registered rig.
+func schedulerCapacity(townRoot string) (map[string]scheduler.Capacity, error) {
+	rigsConfig, err := config.LoadRigsConfig(filepath.Join(townRoot, "mayor", "rigs.json"))
+	if err != nil {
+		return nil, fmt.Errorf("loading rigs config: %w", err)
+	}
+
+	live := make(map[string]bool)
+	if sessions, err := tmux.NewTmux().ListSessions(); err == nil {
+		for _, s := range sessions {
+			live[s] = true
+		}
+	}
+
+	capacity := make(map[string]scheduler.Capacity, len(rigsConfig.Rigs))
+	for rigName := range rigsConfig.Rigs {
+		rigPath := filepath.Join(townRoot, rigName)
+		maxPolecats := config.DefaultSchedulerMaxPolecats
+		if settings, err := config.LoadRigSettings(config.RigSettingsPath(rigPath)); err == nil &&
+			settings.Scheduler != nil && settings.Scheduler.MaxPolecats > 0 {
+			maxPolecats = settings.Scheduler.MaxPolecats
+		}
+
+		active := 0
+		entries, _ := os.ReadDir(filepath.Join(rigPath, "polecats"))
+		for _, e := range entries {
+			if e.IsDir() && live[session.PolecatSessionName(rigName, e.Name())] {
+				active++
+			}
+		}
+		capacity[rigName] = scheduler.Capacity{Active: active, Max: maxPolecats}
+	}
+	return capacity, nil
+}
+
+// scheduleCandidates gathers ready issues from all open convoys.
+func scheduleCandidates(townRoot, townBeads string) ([]scheduler.Candidate, error) {
+	listCmd := exec.Command("bd", "list", "--type=convoy", "--status=open", "--json")
+	listCmd.Dir = townBeads
+	var stdout bytes.Buffer
+	listCmd.Stdout = &stdout
+	if err := listCmd.Run(); err != nil {
+		return nil, fmt.Errorf("listing convoys: %w", err)
+	}
+
+	var convoys []struct {
+		ID        string   `json:"id"`
+		CreatedAt string   `json:"created_at"`
+		Labels    []string `json:"labels"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &convoys); err != nil {
+		return nil, fmt.Errorf("parsing convoy list: %w", err)
+	}
+
+	blockedIssues := getBlockedIssueIDs()
+	var candidates []scheduler.Candidate
+	for _, convoy := range convoys {
+		convoyCreated, _ := time.Parse(time.RFC3339, convoy.CreatedAt)
+		paused := false
+		for _, l := range convoy.Labels {
+			if l == ConvoyPausedLabel {
+				paused = true
+			}
+		}
+
+		for _, t := range getTrackedIssues(townBeads, convoy.ID) {
+			if !isReadyIssue(t, blockedIssues) {
+				continue
+			}
+			issueCreated, _ := time.Parse(time.RFC3339, t.CreatedAt)
+			candidates = append(candidates, scheduler.Candidate{
+				IssueID:         t.ID,
+				Title:           t.Title,
+				Rig:             beads.GetRigForIssue(townRoot, t.ID),
+				Priority:        t.Priority,
+				IssueCreatedAt:  issueCreated,
+				ConvoyID:        convoy.ID,
+				ConvoyCreatedAt: convoyCreated,
+				ConvoyPaused:    paused,
+			})
+		}
+	}
+	return candidates, nil
+}
+
+// slingScheduled dispatches one issue to its rig via gt sling.
+func slingScheduled(townRoot string, d scheduler.Decision) error {
+	slingCmd := exec.Command("gt", "sling", d.IssueID, d.Rig) //nolint:gosec // G204: IDs come from beads
+	slingCmd.Dir = townRoot
+	out, err := slingCmd.CombinedOutput()
+	if err != nil {
+		msg := strings.TrimSpace(string(out))
+		if msg == "" {
+			msg = err.Error()
+		}
+		return fmt.Errorf("gt sling %s %s: %s", d.IssueID, d.Rig, msg)
+	}
+	return nil
+}
+
+func printSchedule(result scheduleResult) {
+	rigs := make([]string, 0, len(result.Capacity))
+	for rig := range result.Capacity {
+		rigs = append(rigs, rig)
+	}
+	sort.Strings(rigs)
+
+	fmt.Printf("%s\n", style.Bold.Render("Capacity:"))
+	for _, rig := range rigs {
+		c := result.Capacity[rig]
+		fmt.Printf("  %-16s %d/%d polecats (%d free)\n", rig, c.Active, c.Max, c.Free())
+	}
+	fmt.Println()
+
+	if len(result.Decisions) == 0 {
+		fmt.Printf("%s No ready convoy work\n", style.Dim.Render("○"))
+		return
+	}
+
+	verb := "Slung"
+	if convoyScheduleDryRun {
+		verb = "Would sling"
+	}
+	slung := 0
+	for _, d := range result.Decisions {
+		switch {
+		case d.Action == scheduler.ActionSling && d.Error != "":
+			fmt.Printf("%s %s → %s: %s\n", style.ErrorPrefix, d.IssueID, d.Rig, d.Error)
+		case d.Action == scheduler.ActionSling:
+			slung++
+			fmt.Printf("%s %s %s → %s  P%d  %s (convoy %s)\n", style.SuccessPrefix, verb, d.IssueID, d.Rig, d.Priority, d.Title, d.ConvoyID)
+		default:
+			fmt.Printf("%s %s  P%d  %s (convoy %s): %s\n", style.Dim.Render("○"), d.IssueID, d.Priority, d.Title, d.ConvoyID, d.Reason)
+		}
+	}
+	fmt.Printf("\n%s %d of %d ready issue(s)\n", verb, slung, len(result.Decisions))
+}
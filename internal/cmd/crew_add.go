@@ -109,6 +109,9 @@ func runCrewAdd(cmd *cobra.Command, args []string) error {
 				Rig:        rigName,
 				AgentState: "idle",
 				RoleBead:   beads.RoleBeadIDTown("crew"),
+				// Crew start with the rig's capabilities; add personal ones
+				// (accounts, tooling) with gt agents capabilities
+				Capabilities: config.RigCapabilities(townRoot, rigName),
 			}
 			desc := fmt.Sprintf("Crew worker %s in %s - human-managed persistent workspace.", name, rigName)
 			if _, err := bd.CreateAgentBead(crewID, desc, fields); err != nil {
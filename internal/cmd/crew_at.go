@@ -4,9 +4,11 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/account"
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
 	"github.com/steveyegge/gastown/internal/crew"
+	"github.com/steveyegge/gastown/internal/limits"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tmux"
 	"github.com/steveyegge/gastown/internal/workspace"
@@ -66,8 +68,7 @@ func runCrewAt(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("finding town root: %w", err)
 	}
-	accountsPath := constants.MayorAccountsPath(townRoot)
-	claudeConfigDir, accountHandle, err := config.ResolveAccountConfigDir(accountsPath, crewAccount)
+	claudeConfigDir, accountHandle, err := account.Resolve(townRoot, crewAccount)
 	if err != nil {
 		return fmt.Errorf("resolving account: %w", err)
 	}
@@ -150,10 +151,14 @@ func runCrewAt(cmd *cobra.Command, args []string) error {
 		// This gives cleaner lifecycle: Claude exits → session ends (no intermediate shell)
 		// Pass "gt prime" as initial prompt so Claude loads context immediately
 		// Export GT_ROLE and BD_ACTOR since tmux SetEnvironment only affects new panes
-		claudeCmd := config.BuildCrewStartupCommand(r.Name, name, r.Path, "gt prime")
+		claudeCmd := limits.WrapCommand(townRoot, r.Name, "crew", sessionID,
+			account.ExportCommand(claudeConfigDir, config.BuildCrewStartupCommand(r.Name, name, r.Path, "gt prime")))
 		if err := t.RespawnPane(paneID, claudeCmd); err != nil {
 			return fmt.Errorf("starting claude: %w", err)
 		}
+		if err := account.Assign(townRoot, sessionID, accountHandle); err != nil {
+			style.PrintWarning("could not record account: %v", err)
+		}
 
 		fmt.Printf("%s Created session for %s/%s\n",
 			style.Bold.Render("✓"), r.Name, name)
@@ -174,10 +179,15 @@ func runCrewAt(cmd *cobra.Command, args []string) error {
 			// Use respawn-pane to replace shell with Claude directly
 			// Pass "gt prime" as initial prompt so Claude loads context immediately
 			// Export GT_ROLE and BD_ACTOR since tmux SetEnvironment only affects new panes
-			claudeCmd := config.BuildCrewStartupCommand(r.Name, name, r.Path, "gt prime")
+			_ = t.SetEnvironment(sessionID, account.EnvConfigDir, claudeConfigDir)
+			claudeCmd := limits.WrapCommand(townRoot, r.Name, "crew", sessionID,
+				account.ExportCommand(claudeConfigDir, config.BuildCrewStartupCommand(r.Name, name, r.Path, "gt prime")))
 			if err := t.RespawnPane(paneID, claudeCmd); err != nil {
 				return fmt.Errorf("restarting claude: %w", err)
 			}
+			if err := account.Assign(townRoot, sessionID, accountHandle); err != nil {
+				style.PrintWarning("could not record account: %v", err)
+			}
 		}
 	}
 
@@ -13,6 +13,7 @@ import (
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
 	"github.com/steveyegge/gastown/internal/crew"
+	"github.com/steveyegge/gastown/internal/limits"
 	"github.com/steveyegge/gastown/internal/mail"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/style"
@@ -198,7 +199,8 @@ func runCrewRefresh(cmd *cobra.Command, args []string) error {
 	// Start claude with environment exports and beacon as initial prompt
 	// Refresh uses regular permissions (no --dangerously-skip-permissions)
 	// SessionStart hook handles context loading (gt prime --hook)
-	claudeCmd := config.BuildCrewStartupCommand(r.Name, name, r.Path, beacon)
+	claudeCmd := limits.WrapCommand(filepath.Dir(r.Path), r.Name, "crew", sessionID,
+		config.BuildCrewStartupCommand(r.Name, name, r.Path, beacon))
 	// Remove --dangerously-skip-permissions for refresh (interactive mode)
 	claudeCmd = strings.Replace(claudeCmd, " --dangerously-skip-permissions", "", 1)
 	if err := t.SendKeys(sessionID, claudeCmd); err != nil {
@@ -361,7 +363,8 @@ func runCrewRestart(cmd *cobra.Command, args []string) error {
 		// Start claude with environment exports and beacon as initial prompt
 		// SessionStart hook handles context loading (gt prime --hook)
 		// The startup protocol tells agent to check mail/hook, no explicit prompt needed
-		claudeCmd := config.BuildCrewStartupCommand(r.Name, name, r.Path, beacon)
+		claudeCmd := limits.WrapCommand(filepath.Dir(r.Path), r.Name, "crew", sessionID,
+			config.BuildCrewStartupCommand(r.Name, name, r.Path, beacon))
 		if err := t.SendKeys(sessionID, claudeCmd); err != nil {
 			fmt.Printf("Error starting claude for %s: %v\n", arg, err)
 			lastErr = err
@@ -518,7 +521,10 @@ func restartCrewSession(rigName, crewName, clonePath string) error {
 
 	// Start claude with environment exports and beacon as initial prompt
 	// SessionStart hook handles context loading (gt prime --hook)
-	claudeCmd := config.BuildCrewStartupCommand(rigName, crewName, "", beacon)
+	// clonePath is <town>/<rig>/crew/<name>
+	townRoot := filepath.Dir(filepath.Dir(filepath.Dir(clonePath)))
+	claudeCmd := limits.WrapCommand(townRoot, rigName, "crew", sessionID,
+		config.BuildCrewStartupCommand(rigName, crewName, "", beacon))
 	if err := t.SendKeys(sessionID, claudeCmd); err != nil {
 		return fmt.Errorf("starting claude: %w", err)
 	}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/daemon"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/workspace"
@@ -190,6 +191,9 @@ func runDaemonStatus(cmd *cobra.Command, args []string) error {
 					state.LastHeartbeat.Format("15:04:05"),
 					state.HeartbeatCount)
 			}
+			if state.MetricsAddr != "" {
+				fmt.Printf("  Metrics: http://%s/metrics\n", state.MetricsAddr)
+			}
 
 			// Check if binary is newer than process
 			if binaryModTime, err := getBinaryModTime(); err == nil {
@@ -257,8 +261,14 @@ func runDaemonRun(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("not in a Gas Town workspace: %w", err)
 	}
 
-	config := daemon.DefaultConfig(townRoot)
-	d, err := daemon.New(config)
+	daemonCfg := daemon.DefaultConfig(townRoot)
+	mayorConfigPath := filepath.Join(townRoot, "mayor", "config.json")
+	if mayorCfg, err := config.LoadMayorConfig(mayorConfigPath); err == nil && mayorCfg.Daemon != nil {
+		daemonCfg.MetricsAddr = mayorCfg.Daemon.MetricsAddr
+		daemonCfg.ConvoyScheduler = mayorCfg.Daemon.ConvoyScheduler
+		daemonCfg.Doctor = mayorCfg.Daemon.Doctor
+	}
+	d, err := daemon.New(daemonCfg)
 	if err != nil {
 		return fmt.Errorf("creating daemon: %w", err)
 	}
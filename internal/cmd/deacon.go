@@ -15,6 +15,7 @@ import (
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
 	"github.com/steveyegge/gastown/internal/deacon"
+	"github.com/steveyegge/gastown/internal/limits"
 	"github.com/steveyegge/gastown/internal/polecat"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/style"
@@ -135,6 +136,10 @@ The detection protocol:
 3. If no activity update, increment failure counter
 4. After N consecutive failures (default 3), recommend force-kill
 
+The session's resource usage is sampled too: an agent near its memory or
+process limit, or with new OOM kills, counts as a failure even if it responds,
+so one pinned against its limits is eventually restarted.
+
 Exit codes:
   0 - Agent responded or is in cooldown (no action needed)
   1 - Error occurred
@@ -181,6 +186,7 @@ var deaconHealthStateCmd = &cobra.Command{
 - Consecutive failure counts
 - Last ping and response times
 - Force-kill history and cooldowns
+- Resource limit breaches
 
 This helps the Deacon understand which agents may need attention.`,
 	RunE: runDeaconHealthState,
@@ -298,7 +304,8 @@ func startDeaconSession(t *tmux.Tmux, sessionName string) error {
 	// Restarts are handled by daemon via ensureDeaconRunning on each heartbeat
 	// The startup hook handles context loading automatically
 	// Export GT_ROLE and BD_ACTOR in the command since tmux SetEnvironment only affects new panes
-	if err := t.SendKeys(sessionName, config.BuildAgentStartupCommand("deacon", "deacon", "", "")); err != nil {
+	startCmd := limits.WrapCommand(townRoot, "", "deacon", sessionName, config.BuildAgentStartupCommand("deacon", "deacon", "", ""))
+	if err := t.SendKeys(sessionName, startCmd); err != nil {
 		return fmt.Errorf("sending command: %w", err)
 	}
 
@@ -634,6 +641,13 @@ func runDeaconHealthCheck(cmd *cobra.Command, args []string) error {
 		baselineTime = time.Time{}
 	}
 
+	// Sample resource usage before the ping wakes the agent up
+	var breaches []string
+	if usage, err := limits.ForSession(t, sessionName, agentAddressLimits(townRoot, agent)); err == nil {
+		breaches = usage.Breaches(agentState.OOMKills)
+		agentState.OOMKills = usage.OOMKills
+	}
+
 	// Record ping
 	agentState.RecordPing()
 
@@ -664,7 +678,13 @@ func runDeaconHealthCheck(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Record result
+	// Record result. A breach is reported but is not a failed check: a
+	// session working near its limits can still be responsive.
+	if len(breaches) > 0 {
+		agentState.RecordBreach(breaches)
+		fmt.Printf("%s Agent %s is at its resource limits: %s\n",
+			style.Dim.Render("⚠"), agent, strings.Join(breaches, "; "))
+	}
 	if responded {
 		agentState.RecordResponse()
 		if err := deacon.SaveHealthCheckState(townRoot, state); err != nil {
@@ -677,13 +697,12 @@ func runDeaconHealthCheck(cmd *cobra.Command, args []string) error {
 
 	// No response - record failure
 	agentState.RecordFailure()
+	fmt.Printf("%s Agent %s did not respond (consecutive failures: %d/%d)\n",
+		style.Dim.Render("⚠"), agent, agentState.ConsecutiveFailures, healthCheckFailures)
 	if err := deacon.SaveHealthCheckState(townRoot, state); err != nil {
 		style.PrintWarning("failed to save health check state: %v", err)
 	}
 
-	fmt.Printf("%s Agent %s did not respond (consecutive failures: %d/%d)\n",
-		style.Dim.Render("⚠"), agent, agentState.ConsecutiveFailures, healthCheckFailures)
-
 	// Check if force-kill threshold reached
 	if agentState.ShouldForceKill(healthCheckFailures) {
 		fmt.Printf("%s Agent %s should be force-killed\n", style.Bold.Render("✗"), agent)
@@ -810,6 +829,10 @@ func runDeaconHealthState(cmd *cobra.Command, args []string) error {
 
 		fmt.Printf("  Consecutive failures: %d\n", agentState.ConsecutiveFailures)
 		fmt.Printf("  Total force-kills: %d\n", agentState.ForceKillCount)
+		if agentState.LimitBreaches > 0 {
+			fmt.Printf("  Limit breaches: %d (last %s ago: %s)\n", agentState.LimitBreaches,
+				time.Since(agentState.LastBreachTime).Round(time.Second), agentState.LastBreach)
+		}
 
 		if !agentState.LastForceKillTime.IsZero() {
 			fmt.Printf("  Last force-kill: %s ago\n", time.Since(agentState.LastForceKillTime).Round(time.Second))
@@ -864,6 +887,21 @@ func agentAddressToIDs(address string) (beadID, sessionName string, err error) {
 	}
 }
 
+// agentAddressLimits returns the resource limits configured for the role of
+// the agent at address, or nil if none apply.
+func agentAddressLimits(townRoot, address string) *config.ResourceLimits {
+	parts := strings.Split(address, "/")
+	switch len(parts) {
+	case 1:
+		return config.ResolveResourceLimits(townRoot, "", address)
+	case 2:
+		return config.ResolveResourceLimits(townRoot, parts[0], parts[1])
+	case 3:
+		return config.ResolveResourceLimits(townRoot, parts[0], strings.TrimSuffix(parts[1], "s"))
+	}
+	return nil
+}
+
 // getAgentBeadUpdateTime gets the update time from an agent bead.
 func getAgentBeadUpdateTime(townRoot, beadID string) (time.Time, error) {
 	cmd := exec.Command("bd", "show", beadID, "--json")
@@ -1,11 +1,15 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/daemon"
 	"github.com/steveyegge/gastown/internal/doctor"
+	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
@@ -13,6 +17,14 @@ var (
 	doctorFix     bool
 	doctorVerbose bool
 	doctorRig     string
+	doctorJSON    bool
+	doctorSARIF   bool
+	doctorOnly    []string
+	doctorSkip    []string
+	doctorList    bool
+	doctorStrict  bool
+	doctorJobs    int
+	doctorTimeout time.Duration
 )
 
 var doctorCmd = &cobra.Command{
@@ -41,9 +53,17 @@ Cleanup checks (fixable):
   - orphan-processes         Detect orphaned Claude processes
   - wisp-gc                  Detect and clean abandoned wisps (>1h)
 
-Clone divergence checks:
+Clone and worktree checks:
   - persistent-role-branches Detect crew/witness/refinery not on main
   - clone-divergence         Detect clones significantly behind origin/main
+  - cross-rig-worktrees      Detect orphaned and stale cross-rig worktrees
+
+Sparse checkout checks:
+  - sparse-checkout          Validate sparse cone paths and partial clone filters
+
+Sandbox checks:
+  - polecat-sandbox          Validate sandbox settings and runtime availability
+  - resource-limits          Validate agent resource limits and host enforcement
 
 Rig checks (with --rig flag):
   - rig-is-git-repo          Verify rig is a valid git repository
@@ -67,23 +87,111 @@ Patrol checks:
   - patrol-plugins-accessible Verify plugin directories
   - patrol-roles-have-prompts Verify role prompts exist
 
+Custom checks (category "custom" unless set):
+  Town-specific checks are loaded from <town>/.gt/checks/. Each *.toml
+  file declares a shell command run in the town, every rig or every
+  polecat worktree, with its expected exit code and output pattern and an
+  optional fix command:
+
+    name = "pre-commit-hook"
+    scope = "rig"                 # town, rig or polecat
+    command = "test -x .git/hooks/pre-commit"
+    severity = "warning"          # error (default) or warning
+    fix = "cp $GT_TOWN_ROOT/hooks/pre-commit .git/hooks/"
+
+  Executable files are run as plugins: "<plugin> run" prints a JSON
+  result (or signals ok/warning/error via exit code 0/1/other), and
+  optional "describe" and "fix" actions provide metadata and fixes; a
+  plugin is named after its file.
+
 Use --fix to attempt automatic fixes for issues that support it.
-Use --rig to check a specific rig instead of the entire workspace.`,
+Use --rig to check a specific rig instead of the entire workspace.
+
+Checks run concurrently (--jobs) once the checks they depend on have
+passed; a check whose dependency errored is reported as skipped. Each check
+has a timeout based on its cost, which --timeout overrides. Fixes started
+by --fix are not subject to the timeout.
+
+Select checks by name or category with --only and --skip (comma-separated
+or repeated). Categories: workspace, infrastructure, beads, cleanup, git,
+sessions, sandbox, patrol, config, crew, hooks, rig, custom. Use --list to see
+every check with its category.
+
+Exit codes (for cron and CI):
+  0  No errors (warnings allowed unless --strict)
+  1  One or more checks reported an error (or a warning with --strict)
+  2  Doctor could not run (not in a workspace, unknown selector)
+
+Examples:
+  gt doctor                          # Run all checks
+  gt doctor --only git,beads         # Only git and beads checks
+  gt doctor --skip orphan-processes  # Everything but one check
+  gt doctor --json                   # Machine-readable report
+  gt doctor --sarif > doctor.sarif   # SARIF 2.1.0 for code scanning`,
 	RunE: runDoctor,
 }
 
+var (
+	doctorHistoryLimit int
+	doctorHistoryCheck string
+	doctorHistoryJSON  bool
+)
+
+var doctorHistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show recent check status transitions from background runs",
+	Long: `Show how doctor checks changed status across the daemon's background runs.
+
+Background runs are enabled in mayor/config.json:
+
+  "daemon": {
+    "doctor": {
+      "interval": "30m",
+      "checks": ["workspace", "git", "daemon"],
+      "auto_fix": ["orphan-sessions", "wisp-gc"],
+      "escalate": true
+    }
+  }
+
+Checks that get worse are mailed to the deacon; with "escalate", checks
+that regress to error are escalated instead. Failing checks listed in
+"auto_fix" (by name or category) are fixed without asking.
+
+Examples:
+  gt doctor history                   # Last 20 transitions
+  gt doctor history --check daemon    # Transitions of one check
+  gt doctor history --json`,
+	RunE: runDoctorHistory,
+}
+
 func init() {
 	doctorCmd.Flags().BoolVar(&doctorFix, "fix", false, "Attempt to automatically fix issues")
 	doctorCmd.Flags().BoolVarP(&doctorVerbose, "verbose", "v", false, "Show detailed output")
 	doctorCmd.Flags().StringVar(&doctorRig, "rig", "", "Check specific rig only")
+	doctorCmd.Flags().BoolVar(&doctorJSON, "json", false, "Output report as JSON")
+	doctorCmd.Flags().BoolVar(&doctorSARIF, "sarif", false, "Output report as SARIF 2.1.0")
+	doctorCmd.Flags().StringSliceVar(&doctorOnly, "only", nil, "Only run these checks or categories")
+	doctorCmd.Flags().StringSliceVar(&doctorSkip, "skip", nil, "Skip these checks or categories")
+	doctorCmd.Flags().BoolVar(&doctorList, "list", false, "List checks with their category and exit")
+	doctorCmd.Flags().BoolVar(&doctorStrict, "strict", false, "Exit non-zero on warnings too")
+	doctorCmd.Flags().IntVarP(&doctorJobs, "jobs", "j", doctor.DefaultParallelism, "Number of checks to run concurrently")
+	doctorCmd.Flags().DurationVar(&doctorTimeout, "timeout", 0, "Per-check timeout (default: based on check cost)")
+	doctorHistoryCmd.Flags().IntVarP(&doctorHistoryLimit, "limit", "n", 20, "Number of transitions to show (0 for all)")
+	doctorHistoryCmd.Flags().StringVar(&doctorHistoryCheck, "check", "", "Only show transitions of this check")
+	doctorHistoryCmd.Flags().BoolVar(&doctorHistoryJSON, "json", false, "Output as JSON")
+	doctorCmd.AddCommand(doctorHistoryCmd)
 	rootCmd.AddCommand(doctorCmd)
 }
 
 func runDoctor(cmd *cobra.Command, args []string) error {
+	if doctorJSON && doctorSARIF {
+		return doctorUsageError(cmd, fmt.Errorf("--json and --sarif are mutually exclusive"))
+	}
+
 	// Find town root
 	townRoot, err := workspace.FindFromCwdOrError()
 	if err != nil {
-		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+		return doctorUsageError(cmd, fmt.Errorf("not in a Gas Town workspace: %w", err))
 	}
 
 	// Create check context
@@ -117,6 +225,10 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 	d.Register(doctor.NewIdentityCollisionCheck())
 	d.Register(doctor.NewLinkedPaneCheck())
 	d.Register(doctor.NewThemeCheck())
+	d.Register(doctor.NewSparseCheck())
+	d.Register(doctor.NewSandboxCheck())
+	d.Register(doctor.NewLimitsCheck())
+	d.Register(doctor.NewCrossRigWorktreeCheck())
 
 	// Patrol system checks
 	d.Register(doctor.NewPatrolMoleculesExistCheck())
@@ -151,21 +263,129 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 		d.RegisterAll(doctor.RigChecks()...)
 	}
 
-	// Run checks
-	var report *doctor.Report
-	if doctorFix {
-		report = d.Fix(ctx)
-	} else {
-		report = d.Run(ctx)
+	// Town-specific checks from .gt/checks/
+	d.RegisterAll(doctor.LoadCustomChecks(townRoot, d.Checks())...)
+
+	if err := d.Filter(doctorOnly, doctorSkip); err != nil {
+		return doctorUsageError(cmd, err)
+	}
+
+	if doctorList {
+		for _, check := range d.Checks() {
+			fmt.Printf("  %-28s %-15s %s\n", check.Name(), style.Dim.Render(doctor.CategoryOf(check)), check.Description())
+		}
+		return nil
 	}
 
+	// Run checks
+	report := d.RunWithOptions(ctx, doctor.RunOptions{
+		Fix:         doctorFix,
+		Parallelism: doctorJobs,
+		Timeout:     doctorTimeout,
+	})
+
 	// Print report
-	report.Print(os.Stdout, doctorVerbose)
+	switch {
+	case doctorJSON:
+		if err := report.WriteJSON(os.Stdout); err != nil {
+			return doctorUsageError(cmd, fmt.Errorf("writing JSON: %w", err))
+		}
+	case doctorSARIF:
+		if err := report.WriteSARIF(os.Stdout, d.Checks(), Version); err != nil {
+			return doctorUsageError(cmd, fmt.Errorf("writing SARIF: %w", err))
+		}
+	default:
+		report.Print(os.Stdout, doctorVerbose)
+	}
 
 	// Exit with error code if there are errors
 	if report.HasErrors() {
 		return fmt.Errorf("doctor found %d error(s)", report.Summary.Errors)
 	}
+	if doctorStrict && report.HasWarnings() {
+		return fmt.Errorf("doctor found %d warning(s)", report.Summary.Warnings)
+	}
 
 	return nil
 }
+
+// doctorUsageError reports a failure to run doctor at all, which exits with
+// code 2 so scripts can tell it apart from failing checks (exit 1).
+func doctorUsageError(cmd *cobra.Command, err error) error {
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	return NewSilentExit(2)
+}
+
+func runDoctorHistory(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	state, err := daemon.LoadDoctorState(townRoot)
+	if err != nil {
+		return fmt.Errorf("loading doctor history: %w", err)
+	}
+
+	history := []daemon.DoctorTransition{}
+	for _, t := range state.History {
+		if doctorHistoryCheck == "" || t.Check == doctorHistoryCheck {
+			history = append(history, t)
+		}
+	}
+	if doctorHistoryLimit > 0 && len(history) > doctorHistoryLimit {
+		history = history[len(history)-doctorHistoryLimit:]
+	}
+
+	if doctorHistoryJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(history)
+	}
+
+	if state.LastRun.IsZero() {
+		fmt.Println("No background doctor runs yet.")
+		fmt.Println(style.Dim.Render("Enable them with daemon.doctor in mayor/config.json (see 'gt doctor history --help')."))
+		return nil
+	}
+	fmt.Printf("Last background run: %s\n\n", formatAge(state.LastRun))
+
+	if len(history) == 0 {
+		fmt.Println("No status changes recorded.")
+		return nil
+	}
+
+	// Newest first
+	for i := len(history) - 1; i >= 0; i-- {
+		t := history[i]
+		from := t.From
+		if from == "" {
+			from = "new"
+		}
+		line := fmt.Sprintf("%s  %-28s %s → %s", t.Time.Local().Format("Jan 02 15:04"), t.Check, from, doctorStatusStyle(t.To))
+		if t.Fixed {
+			line += " " + style.Dim.Render("(auto-fixed)")
+		}
+		fmt.Println(line)
+		if t.Message != "" {
+			fmt.Printf("    %s\n", style.Dim.Render(t.Message))
+		}
+	}
+	return nil
+}
+
+// doctorStatusStyle colors a doctor status name.
+func doctorStatusStyle(status string) string {
+	switch status {
+	case "ok":
+		return style.Success.Render(status)
+	case "warning":
+		return style.Warning.Render(status)
+	case "error":
+		return style.Error.Render(status)
+	default:
+		return status
+	}
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -13,6 +14,7 @@ import (
 	"github.com/steveyegge/gastown/internal/mail"
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tracing"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
@@ -78,7 +80,7 @@ func init() {
 	rootCmd.AddCommand(doneCmd)
 }
 
-func runDone(cmd *cobra.Command, args []string) error {
+func runDone(cmd *cobra.Command, args []string) (err error) {
 	// Handle --phase-complete flag (overrides --status)
 	var exitType string
 	if donePhaseComplete {
@@ -129,6 +131,18 @@ func runDone(cmd *cobra.Command, args []string) error {
 	}
 	worker := info.Worker
 
+	// Continue the trace started by gt sling (inherited via TRACEPARENT)
+	_, span := tracing.Start(context.Background(), "gt.done")
+	span.SetAttr(tracing.AttrBead, issueID)
+	span.SetAttr(tracing.AttrRig, rigName)
+	span.SetAttr(tracing.AttrPolecat, worker)
+	span.SetAttr(tracing.AttrBranch, branch)
+	span.SetAttr("gt.exit", exitType)
+	defer func() {
+		span.SetError(err)
+		span.End()
+	}()
+
 	// Determine polecat name from sender detection
 	sender := detectSender()
 	polecatName := ""
@@ -238,6 +252,11 @@ func runDone(cmd *cobra.Command, args []string) error {
 			description += "\nlast_conflict_sha: null"
 			description += "\nconflict_task_id: null"
 
+			// Carry trace context so the refinery can continue the trace
+			if tp := span.TraceParent(); tp != "" {
+				description += fmt.Sprintf("\ntrace_parent: %s", tp)
+			}
+
 			// Create MR bead (ephemeral wisp - will be cleaned up after merge)
 			mrIssue, err := bd.Create(beads.CreateOptions{
 				Title:       title,
@@ -261,6 +280,8 @@ func runDone(cmd *cobra.Command, args []string) error {
 			fmt.Printf("%s Work submitted to merge queue\n", style.Bold.Render("✓"))
 			fmt.Printf("  MR ID: %s\n", style.Bold.Render(mrID))
 		}
+		span.SetAttr(tracing.AttrMR, mrID)
+		span.SetAttr(tracing.AttrTarget, target)
 		fmt.Printf("  Source: %s\n", branch)
 		fmt.Printf("  Target: %s\n", target)
 		fmt.Printf("  Issue: %s\n", issueID)
@@ -315,6 +336,9 @@ func runDone(cmd *cobra.Command, args []string) error {
 		bodyLines = append(bodyLines, fmt.Sprintf("Gate: %s", doneGate))
 	}
 	bodyLines = append(bodyLines, fmt.Sprintf("Branch: %s", branch))
+	if tp := span.TraceParent(); tp != "" {
+		bodyLines = append(bodyLines, fmt.Sprintf("Trace-Parent: %s", tp))
+	}
 
 	doneNotification := &mail.Message{
 		To:      witnessAddr,
@@ -343,6 +367,7 @@ func runDone(cmd *cobra.Command, args []string) error {
 		fmt.Printf("%s Session self-terminating (--exit flag)\n", style.Bold.Render("→"))
 		fmt.Printf("  Witness will handle worktree cleanup.\n")
 		fmt.Printf("  Goodbye!\n")
+		span.End() // os.Exit skips deferred calls
 		os.Exit(0)
 	}
 
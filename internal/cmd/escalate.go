@@ -1,14 +1,19 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/escalation"
 	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/notify"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
@@ -32,11 +37,11 @@ const (
 var escalateCmd = &cobra.Command{
 	Use:     "escalate <topic>",
 	GroupID: GroupComm,
-	Short:   "Escalate an issue to the human overseer",
-	Long: `Escalate an issue to the human overseer for attention.
+	Short:   "Escalate an issue up the chain of command",
+	Long: `Escalate an issue for attention by the Deacon, Mayor, or human overseer.
 
 This is the structured escalation channel for Gas Town. Any agent can use this
-to request human intervention when automated resolution isn't possible.
+to request help or a decision when automated resolution isn't possible.
 
 Severity levels:
   CRITICAL (P0) - System-threatening, immediate attention required
@@ -46,23 +51,122 @@ Severity levels:
   MEDIUM   (P2) - Standard escalation, human attention at convenience
                   Examples: design decision needed, unclear requirements
 
-The escalation creates an audit trail bead and sends mail to the overseer
-with appropriate priority. All molecular algebra edge cases should escalate
-here rather than failing silently.
+Categories (--type) select the routing chain:
+  decision, help, failed, gate_timeout   Deacon → Mayor → Overseer
+  blocked                                Mayor → Overseer
+  emergency                              Overseer
+  lifecycle                              Witness → Mayor → Overseer
+Without --type the escalation goes straight to the overseer. Use --to to
+start further up the chain.
+
+Decision escalations carry enumerated --options with a --recommend'ed
+default. With --timeout, an unanswered escalation is forwarded to the next
+tier each time the timeout elapses; at the last tier the --fallback option
+is applied automatically. Pass --gate with the gate you parked on (gt park)
+and answering the escalation releases it.
+
+The escalation creates an audit trail bead in town beads and sends mail to
+the first tier with appropriate priority. All molecular algebra edge cases
+should escalate here rather than failing silently.
 
 Examples:
   gt escalate "Database migration failed"
   gt escalate -s CRITICAL "Data corruption detected in user table"
   gt escalate -s HIGH "Merge conflict cannot be resolved automatically"
-  gt escalate -s MEDIUM "Need clarification on API design" -m "Details here..."`,
+  gt escalate -s MEDIUM "Need clarification on API design" -m "Details here..."
+  gt escalate --type decision "Which auth approach?" \
+    --options "JWT,Sessions,OAuth" --recommend JWT \
+    --timeout 2h --fallback JWT --issue gt-abc --gate gt-gate-xyz
+
+Manage escalations:
+  gt escalate list                    # Open escalations
+  gt escalate answer hq-abc B         # Choose option B
+  gt escalate forward hq-abc          # Pass to the next tier
+  gt escalate close hq-abc --reason "Handled manually"`,
 	Args: cobra.MinimumNArgs(1),
 	RunE: runEscalate,
 }
 
+var escalateListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List escalations",
+	Long: `List escalations with their category, current tier, and options.
+
+By default only open escalations are shown.
+
+Examples:
+  gt escalate list
+  gt escalate list --category decision
+  gt escalate list --all --json`,
+	Args: cobra.NoArgs,
+	RunE: runEscalateList,
+}
+
+var escalateAnswerCmd = &cobra.Command{
+	Use:   "answer <escalation-id> <option>",
+	Short: "Answer a decision escalation",
+	Long: `Answer an escalation and close it.
+
+The option may be given by letter (A), number (1), or text. Escalations
+without options accept a free-form answer.
+
+Answering mails the originating agent and, if the escalation names a gate,
+closes the gate and wakes its waiters so parked work can resume.
+
+Examples:
+  gt escalate answer hq-abc A
+  gt escalate answer hq-abc JWT -m "Use RS256 keys"`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runEscalateAnswer,
+}
+
+var escalateCloseCmd = &cobra.Command{
+	Use:   "close <escalation-id>",
+	Short: "Close an escalation without choosing an option",
+	Long: `Close an escalation that was handled some other way.
+
+Closing mails the originating agent and releases any gate it parked on.
+
+Examples:
+  gt escalate close hq-abc --reason "Fixed the flaky runner"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runEscalateClose,
+}
+
+var escalateForwardCmd = &cobra.Command{
+	Use:   "forward <escalation-id>",
+	Short: "Forward an escalation to the next tier",
+	Long: `Forward an escalation you can't resolve to the next tier in its chain.
+
+The next tier is mailed the escalation and its timeout (if any) restarts.
+
+Examples:
+  gt escalate forward hq-abc
+  gt escalate forward hq-abc -m "Needs cross-rig coordination"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runEscalateForward,
+}
+
 var (
-	escalateSeverity string
-	escalateMessage  string
-	escalateDryRun   bool
+	escalateSeverity  string
+	escalateMessage   string
+	escalateDryRun    bool
+	escalateType      string
+	escalateTo        string
+	escalateOptions   string
+	escalateRecommend string
+	escalateTimeout   string
+	escalateFallback  string
+	escalateIssue     string
+	escalateGate      string
+
+	escalateListAll      bool
+	escalateListJSON     bool
+	escalateListCategory string
+
+	escalateAnswerMessage string
+	escalateCloseReason   string
+	escalateForwardReason string
 )
 
 func init() {
@@ -72,6 +176,39 @@ func init() {
 		"Additional details about the escalation")
 	escalateCmd.Flags().BoolVarP(&escalateDryRun, "dry-run", "n", false,
 		"Show what would be done without executing")
+	escalateCmd.Flags().StringVar(&escalateType, "type", "",
+		"Category: "+strings.Join(escalation.Categories, ", "))
+	escalateCmd.Flags().StringVar(&escalateTo, "to", "",
+		"Start at this tier (witness, deacon, mayor, overseer)")
+	escalateCmd.Flags().StringVar(&escalateOptions, "options", "",
+		"Comma-separated answer options (implies --type decision)")
+	escalateCmd.Flags().StringVar(&escalateRecommend, "recommend", "",
+		"Recommended option")
+	escalateCmd.Flags().StringVar(&escalateTimeout, "timeout", "",
+		"Forward to the next tier if unanswered for this long (e.g., 2h)")
+	escalateCmd.Flags().StringVar(&escalateFallback, "fallback", "",
+		"Option applied if the last tier times out (requires --timeout)")
+	escalateCmd.Flags().StringVar(&escalateIssue, "issue", "",
+		"Related issue ID")
+	escalateCmd.Flags().StringVar(&escalateGate, "gate", "",
+		"Gate to release when the escalation is answered")
+
+	escalateListCmd.Flags().BoolVar(&escalateListAll, "all", false, "Include closed escalations")
+	escalateListCmd.Flags().BoolVar(&escalateListJSON, "json", false, "Output as JSON")
+	escalateListCmd.Flags().StringVar(&escalateListCategory, "category", "", "Filter by category")
+
+	escalateAnswerCmd.Flags().StringVarP(&escalateAnswerMessage, "message", "m", "",
+		"Note to include with the answer")
+	escalateCloseCmd.Flags().StringVar(&escalateCloseReason, "reason", "",
+		"Why the escalation was closed")
+
+	escalateForwardCmd.Flags().StringVarP(&escalateForwardReason, "message", "m", "",
+		"Why the escalation is being forwarded")
+
+	escalateCmd.AddCommand(escalateListCmd)
+	escalateCmd.AddCommand(escalateAnswerCmd)
+	escalateCmd.AddCommand(escalateCloseCmd)
+	escalateCmd.AddCommand(escalateForwardCmd)
 	rootCmd.AddCommand(escalateCmd)
 }
 
@@ -85,14 +222,11 @@ func runEscalate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Map severity to mail priority
-	var priority mail.Priority
-	switch severity {
-	case SeverityCritical:
-		priority = mail.PriorityUrgent
-	case SeverityHigh:
-		priority = mail.PriorityHigh
-	default:
-		priority = mail.PriorityNormal
+	priority := escalation.Priority(severity)
+
+	fields, err := buildEscalationFields(severity)
+	if err != nil {
+		return err
 	}
 
 	// Find workspace
@@ -107,46 +241,47 @@ func runEscalate(cmd *cobra.Command, args []string) error {
 		agentID = "unknown"
 	}
 
+	fields.From = agentID
+	recipient := escalation.Address(fields.Tier, fields.Rig)
+
 	// Build mail subject with severity tag
 	subject := fmt.Sprintf("[%s] %s", severity, topic)
 
-	// Build mail body
-	var bodyParts []string
-	bodyParts = append(bodyParts, fmt.Sprintf("Escalated by: %s", agentID))
-	bodyParts = append(bodyParts, fmt.Sprintf("Severity: %s", severity))
-	if escalateMessage != "" {
-		bodyParts = append(bodyParts, "")
-		bodyParts = append(bodyParts, escalateMessage)
-	}
-	body := strings.Join(bodyParts, "\n")
-
 	// Dry run mode
 	if escalateDryRun {
 		fmt.Printf("Would create escalation:\n")
 		fmt.Printf("  Severity: %s\n", severity)
 		fmt.Printf("  Priority: %s\n", priority)
+		if fields.Category != "" {
+			fmt.Printf("  Category: %s (%s)\n", fields.Category, strings.Join(escalation.Chain(fields.Category), " → "))
+		}
 		fmt.Printf("  Subject:  %s\n", subject)
-		fmt.Printf("  Body:\n%s\n", indentText(body, "    "))
-		fmt.Printf("Would send mail to: overseer\n")
+		fmt.Printf("  Body:\n%s\n", indentText(escalation.Format("<new>", topic, fields, escalateMessage), "    "))
+		fmt.Printf("Would send mail to: %s\n", recipient)
 		return nil
 	}
 
 	// Create escalation bead for audit trail
-	beadID, err := createEscalationBead(topic, severity, agentID, escalateMessage)
+	fields.TierSince = time.Now().UTC().Format(time.RFC3339)
+	beadID, err := createEscalationBead(townRoot, topic, severity, fields, escalateMessage)
 	if err != nil {
+		// Structured escalations can't be answered or forwarded without a bead
+		if structuredEscalation(fields) {
+			return fmt.Errorf("creating escalation bead: %w", err)
+		}
 		// Non-fatal - escalation mail is more important
 		style.PrintWarning("could not create escalation bead: %v", err)
 	} else {
 		fmt.Printf("%s Created escalation bead: %s\n", style.Bold.Render("📋"), beadID)
 	}
 
-	// Send mail to overseer
+	// Send mail to the first tier
 	router := mail.NewRouter(townRoot)
 	msg := &mail.Message{
 		From:     agentID,
-		To:       "overseer",
+		To:       recipient,
 		Subject:  subject,
-		Body:     body,
+		Body:     escalation.Format(beadID, topic, fields, escalateMessage),
 		Priority: priority,
 	}
 
@@ -155,13 +290,27 @@ func runEscalate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Log to activity feed
-	payload := events.EscalationPayload("", agentID, "overseer", topic)
+	payload := events.EscalationPayload(fields.Rig, agentID, recipient, topic)
 	payload["severity"] = severity
+	if fields.Category != "" {
+		payload["category"] = fields.Category
+	}
 	if beadID != "" {
 		payload["bead"] = beadID
 	}
 	_ = events.LogFeed(events.TypeEscalationSent, agentID, payload)
 
+	// Push to outbound sinks (webhook, chat, email, desktop) if configured
+	sendOutboundNotification(townRoot, notify.Notification{
+		Event:    notify.EventEscalation,
+		Severity: escalationNotifySeverity(severity),
+		Title:    topic,
+		Body:     escalateMessage,
+		Rig:      fields.Rig,
+		Source:   agentID,
+		Bead:     beadID,
+	})
+
 	// Print confirmation with severity-appropriate styling
 	var emoji string
 	switch severity {
@@ -173,15 +322,233 @@ func runEscalate(cmd *cobra.Command, args []string) error {
 		emoji = "📢"
 	}
 
-	fmt.Printf("%s Escalation sent to overseer [%s]\n", emoji, severity)
+	fmt.Printf("%s Escalation sent to %s [%s]\n", emoji, recipient, severity)
 	fmt.Printf("   Topic: %s\n", topic)
 	if beadID != "" {
 		fmt.Printf("   Bead:  %s\n", beadID)
 	}
+	if len(fields.Options) > 0 {
+		fmt.Printf("   Options: %s\n", strings.Join(fields.Options, ", "))
+	}
+	if fields.Timeout != "" {
+		fmt.Printf("   Timeout: %s per tier\n", fields.Timeout)
+	}
+
+	return nil
+}
+
+// buildEscalationFields validates the structured escalation flags and
+// returns the initial bead fields.
+func buildEscalationFields(severity string) (*beads.EscalationFields, error) {
+	fields := &beads.EscalationFields{
+		Category: strings.ToLower(escalateType),
+		Severity: severity,
+		Rig:      os.Getenv("GT_RIG"),
+		Issue:    escalateIssue,
+		Gate:     escalateGate,
+	}
+
+	if escalateOptions != "" {
+		options, err := escalation.ParseOptions(escalateOptions)
+		if err != nil {
+			return nil, err
+		}
+		if len(options) < 2 {
+			return nil, fmt.Errorf("--options needs at least two choices")
+		}
+		fields.Options = options
+		if fields.Category == "" {
+			fields.Category = escalation.CategoryDecision
+		}
+	}
+	if !escalation.ValidCategory(fields.Category) {
+		return nil, fmt.Errorf("invalid --type %q: must be one of %s", escalateType, strings.Join(escalation.Categories, ", "))
+	}
+
+	if escalateRecommend != "" {
+		if len(fields.Options) == 0 {
+			return nil, fmt.Errorf("--recommend requires --options")
+		}
+		opt, err := escalation.ResolveOption(fields.Options, escalateRecommend)
+		if err != nil {
+			return nil, fmt.Errorf("--recommend: %w", err)
+		}
+		fields.Recommended = opt
+	}
+
+	if escalateTimeout != "" {
+		d, err := time.ParseDuration(escalateTimeout)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid --timeout %q: use a duration like 30m or 2h", escalateTimeout)
+		}
+		fields.Timeout = escalateTimeout
+	}
+	if escalateFallback != "" {
+		if fields.Timeout == "" {
+			return nil, fmt.Errorf("--fallback requires --timeout")
+		}
+		opt, err := escalation.ResolveOption(fields.Options, escalateFallback)
+		if err != nil {
+			return nil, fmt.Errorf("--fallback: %w", err)
+		}
+		fields.Fallback = opt
+	}
+
+	tier, err := escalation.StartTier(fields.Category, escalateTo)
+	if err != nil {
+		return nil, err
+	}
+	fields.Tier = tier
+	return fields, nil
+}
+
+// structuredEscalation reports whether an escalation uses features that
+// depend on its bead (answering, forwarding, gate release).
+func structuredEscalation(f *beads.EscalationFields) bool {
+	return len(f.Options) > 0 || f.Timeout != "" || f.Gate != "" || f.Tier != escalation.TierOverseer
+}
+
+func runEscalateList(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	list, err := escalation.NewManager(townRoot).List(escalateListAll, escalateListCategory)
+	if err != nil {
+		return fmt.Errorf("listing escalations: %w", err)
+	}
+
+	if escalateListJSON {
+		if list == nil {
+			list = []*escalation.Escalation{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(list)
+	}
+
+	if len(list) == 0 {
+		fmt.Printf("%s No escalations\n", style.Dim.Render("○"))
+		return nil
+	}
+
+	for _, e := range list {
+		f := e.Fields
+		category := f.Category
+		if category == "" {
+			category = "-"
+		}
+		status := ""
+		if e.Status == "closed" {
+			status = style.Dim.Render(" (closed)")
+		}
+		fmt.Printf("%s %s [%s] %s%s\n", style.Bold.Render(e.ID), category, f.Severity, e.Question, status)
+		fmt.Printf("    from %s → %s", f.From, f.Tier)
+		if f.Timeout != "" {
+			fmt.Printf(" (timeout %s", f.Timeout)
+			if f.Fallback != "" {
+				fmt.Printf(", fallback %s", f.Fallback)
+			}
+			fmt.Printf(")")
+		}
+		fmt.Println()
+		for i, opt := range f.Options {
+			marker := ""
+			if opt == f.Recommended {
+				marker = style.Dim.Render(" (recommended)")
+			}
+			fmt.Printf("    %s) %s%s\n", escalation.OptionLabel(i), opt, marker)
+		}
+		if f.Answer != "" {
+			fmt.Printf("    %s answered %q by %s\n", style.SuccessPrefix, f.Answer, f.AnsweredBy)
+		}
+	}
+	return nil
+}
+
+func runEscalateAnswer(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	by, err := detectAgentIdentity()
+	if err != nil {
+		by = "overseer"
+	}
+
+	res, err := escalation.NewManager(townRoot).Answer(args[0], strings.Join(args[1:], " "), by, escalateAnswerMessage)
+	if err != nil {
+		return err
+	}
+
+	e := res.Escalation
+	fmt.Printf("%s Answered %s: %s\n", style.SuccessPrefix, e.ID, e.Fields.Answer)
+	printEscalationResolution(res)
+	return nil
+}
+
+func runEscalateClose(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	by, err := detectAgentIdentity()
+	if err != nil {
+		by = "overseer"
+	}
+
+	res, err := escalation.NewManager(townRoot).Close(args[0], by, escalateCloseReason)
+	if err != nil {
+		return err
+	}
 
+	fmt.Printf("%s Closed %s\n", style.SuccessPrefix, res.Escalation.ID)
+	printEscalationResolution(res)
 	return nil
 }
 
+func runEscalateForward(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	by, err := detectAgentIdentity()
+	if err != nil {
+		by = "overseer"
+	}
+	reason := escalateForwardReason
+	if reason == "" {
+		reason = by + " could not resolve"
+	}
+
+	tier, err := escalation.NewManager(townRoot).ForwardNext(args[0], by, reason)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s Forwarded %s to %s\n", style.ArrowPrefix, args[0], tier)
+	return nil
+}
+
+// printEscalationResolution reports mail and gate follow-ups.
+func printEscalationResolution(res *escalation.Resolution) {
+	f := res.Escalation.Fields
+	if res.MailErr != nil {
+		style.PrintWarning("could not mail %s: %v", f.From, res.MailErr)
+	} else if f.From != "" && f.From != "unknown" {
+		fmt.Printf("   Notified: %s\n", f.From)
+	}
+	if res.GateErr != nil {
+		style.PrintWarning("could not release gate: %v", res.GateErr)
+	} else if f.Gate != "" {
+		fmt.Printf("   Released gate: %s\n", f.Gate)
+	}
+}
+
 // detectAgentIdentity returns the current agent's identity string.
 func detectAgentIdentity() (string, error) {
 	// Try GT_ROLE first
@@ -197,27 +564,31 @@ func detectAgentIdentity() (string, error) {
 	return agentID, nil
 }
 
-// createEscalationBead creates a bead to track the escalation.
-func createEscalationBead(topic, severity, from, details string) (string, error) {
+// createEscalationBead creates a town bead to track the escalation.
+func createEscalationBead(townRoot, topic, severity string, fields *beads.EscalationFields, details string) (string, error) {
 	// Use bd create to make the escalation bead
 	args := []string{
 		"create",
-		"--title", fmt.Sprintf("[ESCALATION] %s", topic),
+		"--title", escalation.TitlePrefix + topic,
 		"--type", "task", // Use task type since escalation isn't a standard type
 		"--priority", severityToBeadsPriority(severity),
 	}
 
 	// Add description with escalation metadata
-	desc := fmt.Sprintf("Escalation from: %s\nSeverity: %s\n", from, severity)
+	desc := beads.FormatEscalationFields(fields)
 	if details != "" {
-		desc += "\n" + details
+		desc += "\n\n" + details
 	}
 	args = append(args, "--description", desc)
 
-	// Add tag for filtering
-	args = append(args, "--tag", "escalation")
+	// Add tags for filtering
+	args = append(args, "--tag", escalation.Label)
+	if fields.Category != "" {
+		args = append(args, "--tag", fields.Category)
+	}
 
 	cmd := exec.Command("bd", args...)
+	cmd.Dir = townRoot
 	out, err := cmd.Output()
 	if err != nil {
 		return "", fmt.Errorf("bd create: %w", err)
@@ -244,6 +615,18 @@ func severityToBeadsPriority(severity string) string {
 	}
 }
 
+// escalationNotifySeverity maps escalation severity to notification severity.
+func escalationNotifySeverity(severity string) notify.Severity {
+	switch severity {
+	case SeverityCritical:
+		return notify.SeverityCritical
+	case SeverityHigh:
+		return notify.SeverityHigh
+	default:
+		return notify.SeverityMedium
+	}
+}
+
 // indentText indents each line of text with the given prefix.
 func indentText(text, prefix string) string {
 	lines := strings.Split(text, "\n")
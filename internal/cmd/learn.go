@@ -0,0 +1,443 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/knowledge"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// primeKnowledgeLimit caps the notes gt prime injects for a polecat.
+const primeKnowledgeLimit = 10
+
+var (
+	learnRig   string
+	learnTags  []string
+	learnPaths []string
+	learnBead  string
+	learnPin   bool
+	learnTag   string
+	learnJSON  bool
+	learnApply bool
+	learnFact  string
+)
+
+var learnCmd = &cobra.Command{
+	Use:     "learn <fact>",
+	GroupID: GroupWork,
+	Short:   "Record a fact in the rig's knowledge base",
+	Long: `Record something worth knowing about a rig so later polecats don't
+have to rediscover it: flaky tests, build quirks, where things live.
+
+Polecats are ephemeral; handoffs and seance reach one predecessor. The
+knowledge base is durable rig memory. gt prime injects the notes relevant
+to a polecat's hooked bead: notes whose tags match the bead's labels,
+notes whose paths overlap the bead's paths or the files the worktree
+changes, pinned notes, and general notes with neither tags nor paths.
+
+Learning a fact the rig already knows confirms it instead of adding a
+duplicate. The witness and refinery curate the notes with list, edit,
+merge, dedupe and forget.
+
+Examples:
+  gt learn "TestSyncRace is flaky under -race; rerun once" --tags flaky,tests
+  gt learn "Run make generate after editing api/*.proto" --paths api
+  gt learn list --tag flaky
+  gt learn dedupe --apply
+  gt learn forget kn-12`,
+	Args: cobra.ExactArgs(1),
+	RunE: runLearn,
+}
+
+var learnListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the rig's notes",
+	Args:  cobra.NoArgs,
+	RunE:  runLearnList,
+}
+
+var learnEditCmd = &cobra.Command{
+	Use:   "edit <id>",
+	Short: "Change a note's fact, tags, paths or pinning",
+	Long: `Change a note. Only the flags given are applied; --tags and --paths
+replace the note's current values.
+
+Examples:
+  gt learn edit kn-4 --fact "Integration tests need docker and redis"
+  gt learn edit kn-4 --tags tests,docker
+  gt learn edit kn-7 --pin`,
+	Args: cobra.ExactArgs(1),
+	RunE: runLearnEdit,
+}
+
+var learnForgetCmd = &cobra.Command{
+	Use:   "forget <id>...",
+	Short: "Remove notes that are wrong or stale",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runLearnForget,
+}
+
+var learnMergeCmd = &cobra.Command{
+	Use:   "merge <keep-id> <id>...",
+	Short: "Fold duplicate notes into one",
+	Long: `Fold notes into the first one: their tags, paths and confirmations
+move to it and they are removed. Edit the kept note afterwards if the
+wording should combine both.`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runLearnMerge,
+}
+
+var learnDedupeCmd = &cobra.Command{
+	Use:   "dedupe",
+	Short: "Find notes that say nearly the same thing",
+	Long: `List groups of notes whose wording mostly overlaps. With --apply, each
+group is merged into its oldest note.`,
+	Args: cobra.NoArgs,
+	RunE: runLearnDedupe,
+}
+
+func init() {
+	learnCmd.PersistentFlags().StringVar(&learnRig, "rig", "", "Rig (default: current rig)")
+	learnCmd.Flags().StringSliceVar(&learnTags, "tags", nil, "Tags matched against bead labels (comma-separated)")
+	learnCmd.Flags().StringSliceVar(&learnPaths, "paths", nil, "Directories or files the fact is about (comma-separated)")
+	learnCmd.Flags().StringVar(&learnBead, "bead", "", "Bead being worked when this was learned")
+	learnCmd.Flags().BoolVar(&learnPin, "pin", false, "Inject for every polecat regardless of relevance")
+
+	learnListCmd.Flags().StringVar(&learnTag, "tag", "", "Only notes with this tag")
+	learnListCmd.Flags().BoolVar(&learnJSON, "json", false, "Output as JSON")
+
+	learnEditCmd.Flags().StringVar(&learnFact, "fact", "", "New wording")
+	learnEditCmd.Flags().StringSliceVar(&learnTags, "tags", nil, "Replace tags")
+	learnEditCmd.Flags().StringSliceVar(&learnPaths, "paths", nil, "Replace paths")
+	learnEditCmd.Flags().BoolVar(&learnPin, "pin", false, "Pin (--pin=false to unpin)")
+
+	learnDedupeCmd.Flags().BoolVar(&learnApply, "apply", false, "Merge each group into its oldest note")
+
+	learnCmd.AddCommand(learnListCmd)
+	learnCmd.AddCommand(learnEditCmd)
+	learnCmd.AddCommand(learnForgetCmd)
+	learnCmd.AddCommand(learnMergeCmd)
+	learnCmd.AddCommand(learnDedupeCmd)
+	rootCmd.AddCommand(learnCmd)
+}
+
+// learnRigPath resolves the rig from --rig, the agent's role or the cwd.
+func learnRigPath() (string, error) {
+	rigName := learnRig
+	if rigName == "" {
+		if roleInfo, err := GetRole(); err == nil {
+			rigName = roleInfo.Rig
+		}
+	}
+	if rigName == "" {
+		townRoot, err := workspace.FindFromCwdOrError()
+		if err != nil {
+			return "", fmt.Errorf("not in a Gas Town workspace: %w", err)
+		}
+		rigName, err = inferRigFromCwd(townRoot)
+		if err != nil {
+			return "", fmt.Errorf("could not determine rig (use --rig flag): %w", err)
+		}
+	}
+
+	_, r, err := getRig(rigName)
+	if err != nil {
+		return "", err
+	}
+	return r.Path, nil
+}
+
+func runLearn(cmd *cobra.Command, args []string) error {
+	fact := strings.TrimSpace(args[0])
+	if fact == "" {
+		return fmt.Errorf("fact must not be empty")
+	}
+	rigPath, err := learnRigPath()
+	if err != nil {
+		return err
+	}
+
+	author := detectActor()
+	if author == "unknown" {
+		author = ""
+	}
+
+	var note *knowledge.Note
+	var confirmed bool
+	err = knowledge.Update(rigPath, func(s *knowledge.Store) error {
+		note, confirmed = s.Add(knowledge.Note{
+			Fact:   fact,
+			Tags:   learnTags,
+			Paths:  learnPaths,
+			Pinned: learnPin,
+			Author: author,
+			Bead:   learnBead,
+		}, time.Now())
+		if learnPin {
+			note.Pinned = true
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if confirmed {
+		fmt.Printf("%s Confirmed %s (seen %d times): %s\n", style.Bold.Render("✓"), note.ID, note.Confirmations+1, note.Fact)
+	} else {
+		fmt.Printf("%s Learned %s: %s\n", style.Bold.Render("✓"), note.ID, note.Fact)
+	}
+	return nil
+}
+
+func runLearnList(cmd *cobra.Command, args []string) error {
+	rigPath, err := learnRigPath()
+	if err != nil {
+		return err
+	}
+	store, err := knowledge.Load(rigPath)
+	if err != nil {
+		return err
+	}
+
+	notes := make([]*knowledge.Note, 0, len(store.Notes))
+	for _, n := range store.Notes {
+		if learnTag == "" || n.HasTag(learnTag) {
+			notes = append(notes, n)
+		}
+	}
+
+	if learnJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(notes)
+	}
+
+	if len(notes) == 0 {
+		fmt.Println(style.Dim.Render("No notes. Record one with: gt learn \"<fact>\""))
+		return nil
+	}
+	for _, n := range notes {
+		printKnowledgeNote(n)
+	}
+	return nil
+}
+
+// printKnowledgeNote prints a note for curation, with its metadata.
+func printKnowledgeNote(n *knowledge.Note) {
+	pin := ""
+	if n.Pinned {
+		pin = " 📌"
+	}
+	fmt.Printf("%s%s %s\n", style.Bold.Render(n.ID), pin, n.Fact)
+
+	var meta []string
+	if len(n.Tags) > 0 {
+		meta = append(meta, "tags: "+strings.Join(n.Tags, ", "))
+	}
+	if len(n.Paths) > 0 {
+		meta = append(meta, "paths: "+strings.Join(n.Paths, ", "))
+	}
+	if n.Confirmations > 0 {
+		meta = append(meta, fmt.Sprintf("confirmed %d×", n.Confirmations))
+	}
+	if n.Author != "" {
+		meta = append(meta, "by "+n.Author)
+	}
+	if n.Bead != "" {
+		meta = append(meta, "on "+n.Bead)
+	}
+	meta = append(meta, n.Updated.Format("2006-01-02"))
+	fmt.Printf("    %s\n", style.Dim.Render(strings.Join(meta, " • ")))
+}
+
+func runLearnEdit(cmd *cobra.Command, args []string) error {
+	rigPath, err := learnRigPath()
+	if err != nil {
+		return err
+	}
+
+	var note *knowledge.Note
+	err = knowledge.Update(rigPath, func(s *knowledge.Store) error {
+		note = s.Get(args[0])
+		if note == nil {
+			return fmt.Errorf("note %s not found", args[0])
+		}
+		if cmd.Flags().Changed("fact") {
+			if strings.TrimSpace(learnFact) == "" {
+				return fmt.Errorf("fact must not be empty")
+			}
+			note.Fact = strings.TrimSpace(learnFact)
+		}
+		if cmd.Flags().Changed("tags") {
+			note.Tags = learnTags
+		}
+		if cmd.Flags().Changed("paths") {
+			note.Paths = learnPaths
+		}
+		if cmd.Flags().Changed("pin") {
+			note.Pinned = learnPin
+		}
+		note.Updated = time.Now()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	printKnowledgeNote(note)
+	return nil
+}
+
+func runLearnForget(cmd *cobra.Command, args []string) error {
+	rigPath, err := learnRigPath()
+	if err != nil {
+		return err
+	}
+
+	err = knowledge.Update(rigPath, func(s *knowledge.Store) error {
+		for _, id := range args {
+			if s.Get(id) == nil {
+				return fmt.Errorf("note %s not found", id)
+			}
+		}
+		for _, id := range args {
+			s.Remove(id)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s Forgot %s\n", style.Bold.Render("✓"), strings.Join(args, ", "))
+	return nil
+}
+
+func runLearnMerge(cmd *cobra.Command, args []string) error {
+	rigPath, err := learnRigPath()
+	if err != nil {
+		return err
+	}
+
+	var kept *knowledge.Note
+	err = knowledge.Update(rigPath, func(s *knowledge.Store) error {
+		if err := s.Merge(args[0], args[1:], time.Now()); err != nil {
+			return err
+		}
+		kept = s.Get(args[0])
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s Merged %s into %s\n", style.Bold.Render("✓"), strings.Join(args[1:], ", "), args[0])
+	printKnowledgeNote(kept)
+	return nil
+}
+
+func runLearnDedupe(cmd *cobra.Command, args []string) error {
+	rigPath, err := learnRigPath()
+	if err != nil {
+		return err
+	}
+
+	var groups [][]*knowledge.Note
+	if learnApply {
+		err = knowledge.Update(rigPath, func(s *knowledge.Store) error {
+			groups = s.Duplicates(knowledge.DuplicateThreshold)
+			now := time.Now()
+			for _, group := range groups {
+				var dropIDs []string
+				for _, n := range group[1:] {
+					dropIDs = append(dropIDs, n.ID)
+				}
+				if err := s.Merge(group[0].ID, dropIDs, now); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	} else {
+		var store *knowledge.Store
+		if store, err = knowledge.Load(rigPath); err == nil {
+			groups = store.Duplicates(knowledge.DuplicateThreshold)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(groups) == 0 {
+		fmt.Println("No duplicate notes.")
+		return nil
+	}
+	for i, group := range groups {
+		if i > 0 {
+			fmt.Println()
+		}
+		for _, n := range group {
+			printKnowledgeNote(n)
+		}
+	}
+	fmt.Println()
+	if learnApply {
+		fmt.Printf("%s Merged %d group(s) into their oldest note\n", style.Bold.Render("✓"), len(groups))
+	} else {
+		fmt.Printf("%d group(s) of likely duplicates. Merge with 'gt learn merge <keep-id> <id>...' or 'gt learn dedupe --apply'.\n", len(groups))
+	}
+	return nil
+}
+
+// outputKnowledgeContext injects the rig notes relevant to a polecat's
+// hooked bead: matched by the bead's labels and by the paths the bead names
+// or the worktree changes.
+func outputKnowledgeContext(ctx RoleContext) {
+	if ctx.Role != RolePolecat || ctx.Rig == "" {
+		return
+	}
+	store, err := knowledge.Load(filepath.Join(ctx.TownRoot, ctx.Rig))
+	if err != nil || len(store.Notes) == 0 {
+		return
+	}
+
+	var labels, paths []string
+	b := beads.New(ctx.WorkDir)
+	hooked, err := b.List(beads.ListOptions{
+		Status:   beads.StatusHooked,
+		Assignee: getAgentIdentity(ctx),
+		Priority: -1,
+	})
+	if err == nil && len(hooked) > 0 {
+		labels = hooked[0].Labels
+		paths = beads.ParseSparsePaths(hooked[0])
+	}
+	g := git.NewGit(ctx.WorkDir)
+	if changed, err := g.ChangedFiles("origin/" + g.RemoteDefaultBranch()); err == nil {
+		paths = append(paths, changed...)
+	}
+
+	notes := store.Relevant(labels, paths, primeKnowledgeLimit)
+	if len(notes) == 0 {
+		return
+	}
+
+	fmt.Println()
+	fmt.Printf("%s\n\n", style.Bold.Render("## Rig Knowledge"))
+	fmt.Println("Learned by earlier polecats on this rig:")
+	for _, n := range notes {
+		fmt.Printf("- %s %s\n", n.Fact, style.Dim.Render("["+n.ID+"]"))
+	}
+	fmt.Println()
+	fmt.Println("Learn something others should know? `gt learn \"<fact>\" --tags <t> --paths <dir>`")
+}
@@ -10,6 +10,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/search"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
@@ -352,7 +354,10 @@ FLAGS:
   --archive         Include archived (closed) messages
   --json            Output as JSON
 
-By default, searches both subject and body text.
+By default, searches both subject and body text. Results are ranked by
+relevance to the words in the query (BM25, as in gt seance --search), then
+newest first. For town-wide search across all mail, sessions and beads,
+use gt seance --search.
 
 Examples:
   gt mail search "urgent"                    # Find messages with "urgent"
@@ -1743,6 +1748,9 @@ func runMailSearch(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("searching messages: %w", err)
 	}
 
+	// Most relevant first
+	messages = rankMessages(query, messages, mailSearchSubject, mailSearchBody)
+
 	// JSON output
 	if mailSearchJSON {
 		enc := json.NewEncoder(os.Stdout)
@@ -1788,6 +1796,38 @@ func runMailSearch(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// rankMessages orders messages by BM25 relevance to the words in query,
+// using the same scoring as gt seance --search. Messages the regex matched
+// without sharing a word with the query (e.g. "status.*check") keep their
+// newest-first order after the ranked ones.
+func rankMessages(query string, messages []*mail.Message, subjectOnly, bodyOnly bool) []*mail.Message {
+	docs := make([]*search.Document, len(messages))
+	for i, msg := range messages {
+		subject, body := msg.Subject, msg.Body
+		if subjectOnly {
+			body = ""
+		} else if bodyOnly {
+			subject = ""
+		}
+		docs[i] = search.NewDocument(search.KindMail, strconv.Itoa(i), subject, body)
+		docs[i].Time = msg.Timestamp
+	}
+
+	ranked := make([]*mail.Message, 0, len(messages))
+	seen := make(map[int]bool)
+	for _, h := range search.NewIndex(docs).Search(search.Query{Text: query}) {
+		i, _ := strconv.Atoi(h.ID)
+		ranked = append(ranked, messages[i])
+		seen[i] = true
+	}
+	for i, msg := range messages {
+		if !seen[i] {
+			ranked = append(ranked, msg)
+		}
+	}
+	return ranked
+}
+
 // runMailAnnounces lists announce channels or reads messages from a channel.
 func runMailAnnounces(cmd *cobra.Command, args []string) error {
 	// Find workspace
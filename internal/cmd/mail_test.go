@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/mail"
 )
 
 func TestMatchWorkerPattern(t *testing.T) {
@@ -381,3 +383,26 @@ func TestAnnounceMessageParsing(t *testing.T) {
 		})
 	}
 }
+
+func TestRankMessages(t *testing.T) {
+	now := time.Now()
+	messages := []*mail.Message{
+		{ID: "m1", Subject: "Status check", Body: "All quiet.", Timestamp: now},
+		{ID: "m2", Subject: "Auth retry", Body: "Retry storm on the auth client.", Timestamp: now.Add(-time.Hour)},
+		{ID: "m3", Subject: "Deploy", Body: "Auth service deployed.", Timestamp: now.Add(-2 * time.Hour)},
+	}
+
+	got := rankMessages("auth retry", messages, false, false)
+	want := []string{"m2", "m3", "m1"}
+	for i, id := range want {
+		if got[i].ID != id {
+			t.Fatalf("rankMessages() order = %s %s %s, want %v", got[0].ID, got[1].ID, got[2].ID, want)
+		}
+	}
+
+	// Subject-only search ignores the body when ranking.
+	got = rankMessages("auth", messages, true, false)
+	if got[0].ID != "m2" || got[1].ID != "m1" {
+		t.Errorf("subject-only order = %s %s %s, want m2 first, then unranked newest first", got[0].ID, got[1].ID, got[2].ID)
+	}
+}
@@ -22,6 +22,7 @@ var (
 	mqSubmitEpic      string
 	mqSubmitPriority  int
 	mqSubmitNoCleanup bool
+	mqSubmitParent    string
 
 	// Retry flags
 	mqRetryNow bool
@@ -85,6 +86,12 @@ Target branch auto-detection:
 
 This ensures batch work on epics automatically flows to integration branches.
 
+Stacked merge requests:
+  When your branch builds on another polecat's branch that hasn't merged
+  yet, submit with --parent <mr-id>. The MR inherits the parent's target and
+  merges only after the parent does; the Refinery then rebases it onto the
+  target. Rejecting the parent rejects everything stacked on it.
+
 Polecat auto-cleanup:
   When run from a polecat work branch (polecat/<worker>/<issue>), this command
   automatically triggers polecat shutdown after submitting the MR. The polecat
@@ -98,6 +105,7 @@ Examples:
   gt mq submit --issue gp-abc            # Explicit issue
   gt mq submit --epic gt-xyz             # Target integration branch explicitly
   gt mq submit --priority 0              # Override priority (P0)
+  gt mq submit --parent gt-mr-abc        # Stack on an unmerged MR
   gt mq submit --no-cleanup              # Submit without auto-cleanup`,
 	RunE: runMqSubmit,
 }
@@ -131,6 +139,9 @@ Output format:
   gt-mr-003   blocked      P1        polecat/Capable/gt-def    Capable 8m
               (waiting on gt-mr-001)
 
+Stacked MRs (submitted with --parent) are listed under their parent and
+drawn as trees below the table.
+
 Examples:
   gt mq list greenplace
   gt mq list greenplace --ready
@@ -148,6 +159,9 @@ var mqRejectCmd = &cobra.Command{
 This closes the MR with a 'rejected' status without merging.
 The source issue is NOT closed (work is not done).
 
+MRs stacked on the rejected MR are rejected with it, since their branches
+carry its commits.
+
 Examples:
   gt mq reject greenplace polecat/Nux/gp-xyz --reason "Does not meet requirements"
   gt mq reject greenplace mr-Nux-12345 --reason "Superseded by other work" --notify`,
@@ -260,6 +274,7 @@ func init() {
 	mqSubmitCmd.Flags().StringVar(&mqSubmitEpic, "epic", "", "Target epic's integration branch instead of main")
 	mqSubmitCmd.Flags().IntVarP(&mqSubmitPriority, "priority", "p", -1, "Override priority (0-4, default: inherit from issue)")
 	mqSubmitCmd.Flags().BoolVar(&mqSubmitNoCleanup, "no-cleanup", false, "Don't auto-cleanup after submit (for polecats)")
+	mqSubmitCmd.Flags().StringVar(&mqSubmitParent, "parent", "", "Stack on an unmerged MR whose branch this one builds on")
 
 	// Retry flags
 	mqRetryCmd.Flags().BoolVar(&mqRetryNow, "now", false, "Immediately process instead of waiting for refinery loop")
@@ -396,7 +411,7 @@ func runMQReject(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	result, err := mgr.RejectMR(mrIDOrBranch, mqRejectReason, mqRejectNotify)
+	result, stacked, err := mgr.RejectMR(mrIDOrBranch, mqRejectReason, mqRejectNotify)
 	if err != nil {
 		return fmt.Errorf("rejecting MR: %w", err)
 	}
@@ -409,6 +424,10 @@ func runMQReject(cmd *cobra.Command, args []string) error {
 		fmt.Printf("  Issue:  %s %s\n", result.IssueID, style.Dim.Render("(not closed - work not done)"))
 	}
 
+	for _, mr := range stacked {
+		fmt.Printf("  %s %s %s\n", style.Bold.Render("✗"), mr.ID, style.Dim.Render("(stacked on "+mr.ParentMR+")"))
+	}
+
 	if mqRejectNotify {
 		fmt.Printf("  %s\n", style.Dim.Render("Worker notified via mail"))
 	}
@@ -107,6 +107,19 @@ func runMQList(cmd *cobra.Command, args []string) error {
 		return scored[i].score > scored[j].score
 	})
 
+	// Keep stacked MRs directly below their parent
+	stackMRs := make([]*mrqueue.MR, len(scored))
+	for i, item := range scored {
+		stackMRs[i] = mrStackEntry(item.issue, item.fields)
+	}
+	stacks := mrqueue.BuildStacks(stackMRs)
+	order, depths := stackOrder(stacks, stackMRs)
+	stacked := make([]scoredIssue, len(scored))
+	for i, idx := range order {
+		stacked[i] = scored[idx]
+	}
+	scored = stacked
+
 	// Extract filtered issues for JSON output compatibility
 	var filtered []*beads.Issue
 	for _, s := range scored {
@@ -128,7 +141,7 @@ func runMQList(cmd *cobra.Command, args []string) error {
 
 	// Create styled table with SCORE column
 	table := style.NewTable(
-		style.Column{Name: "ID", Width: 12},
+		style.Column{Name: "ID", Width: 12 + 2*maxDepth(depths)},
 		style.Column{Name: "SCORE", Width: 7, Align: style.AlignRight},
 		style.Column{Name: "PRI", Width: 4},
 		style.Column{Name: "CONVOY", Width: 12},
@@ -137,8 +150,8 @@ func runMQList(cmd *cobra.Command, args []string) error {
 		style.Column{Name: "AGE", Width: 6, Align: style.AlignRight},
 	)
 
-	// Add rows using scored items (already sorted by score)
-	for _, item := range scored {
+	// Add rows using scored items (already sorted by score and stack)
+	for i, item := range scored {
 		issue := item.issue
 		fields := item.fields
 
@@ -197,17 +210,23 @@ func runMQList(cmd *cobra.Command, args []string) error {
 		// Calculate age
 		age := formatMRAge(issue.CreatedAt)
 
-		// Truncate ID if needed
+		// Truncate ID if needed, then indent stacked MRs under their parent
 		displayID := issue.ID
 		if len(displayID) > 12 {
 			displayID = displayID[:12]
 		}
+		displayID = strings.Repeat("  ", depths[i]) + displayID
 
 		table.AddRow(displayID, scoreStr, priority, convoyDisplay, branch, styledStatus, style.Dim.Render(age))
 	}
 
 	fmt.Print(table.Render())
 
+	// Show stacks as trees
+	if tree := renderMRStacks(stacks); tree != "" {
+		fmt.Printf("\n  %s\n%s", style.Bold.Render("Stacks:"), tree)
+	}
+
 	// Show blocking details below table
 	for _, item := range scored {
 		issue := item.issue
@@ -228,6 +247,77 @@ func runMQList(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// mrStackEntry is the part of an MR bead that places it in a stack.
+func mrStackEntry(issue *beads.Issue, fields *beads.MRFields) *mrqueue.MR {
+	createdAt, _ := time.Parse(time.RFC3339, issue.CreatedAt)
+	mr := &mrqueue.MR{ID: issue.ID, CreatedAt: createdAt}
+	if fields != nil {
+		mr.ParentMR = fields.ParentMR
+		mr.Branch = fields.Branch
+	}
+	return mr
+}
+
+// stackOrder returns the indexes of mrs in stack order, parent before
+// children, along with each position's depth in its stack.
+func stackOrder(stacks []*mrqueue.StackNode, mrs []*mrqueue.MR) (order, depths []int) {
+	index := make(map[string]int, len(mrs))
+	for i, mr := range mrs {
+		index[mr.ID] = i
+	}
+	for _, root := range stacks {
+		root.Walk(func(mr *mrqueue.MR, depth int) {
+			order = append(order, index[mr.ID])
+			depths = append(depths, depth)
+		})
+	}
+	return order, depths
+}
+
+// maxDepth returns the deepest stack position in depths.
+func maxDepth(depths []int) int {
+	deepest := 0
+	for _, d := range depths {
+		if d > deepest {
+			deepest = d
+		}
+	}
+	return deepest
+}
+
+// renderMRStacks draws the stacks that hold more than one MR as trees,
+// one MR per line with its branch. Returns "" when nothing is stacked.
+func renderMRStacks(stacks []*mrqueue.StackNode) string {
+	var sb strings.Builder
+	var draw func(n *mrqueue.StackNode, prefix string, last, root bool)
+	draw = func(n *mrqueue.StackNode, prefix string, last, root bool) {
+		branch := ""
+		if n.MR.Branch != "" {
+			branch = "  " + style.Dim.Render(n.MR.Branch)
+		}
+		childPrefix := prefix
+		switch {
+		case root:
+			sb.WriteString("    " + n.MR.ID + branch + "\n")
+		case last:
+			sb.WriteString("    " + prefix + "└─ " + n.MR.ID + branch + "\n")
+			childPrefix += "   "
+		default:
+			sb.WriteString("    " + prefix + "├─ " + n.MR.ID + branch + "\n")
+			childPrefix += "│  "
+		}
+		for i, child := range n.Children {
+			draw(child, childPrefix, i == len(n.Children)-1, false)
+		}
+	}
+	for _, root := range stacks {
+		if len(root.Children) > 0 {
+			draw(root, "", true, true)
+		}
+	}
+	return sb.String()
+}
+
 // formatMRAge formats the age of an MR from its created_at timestamp.
 func formatMRAge(createdAt string) string {
 	t, err := time.Parse(time.RFC3339, createdAt)
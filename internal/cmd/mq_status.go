@@ -4,12 +4,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/testhistory"
+	"github.com/steveyegge/gastown/internal/workspace"
 )
 
 // MRStatusOutput is the JSON output structure for gt mq status.
@@ -37,6 +40,26 @@ type MRStatusOutput struct {
 	// Dependencies
 	DependsOn []DependencyInfo `json:"depends_on,omitempty"`
 	Blocks    []DependencyInfo `json:"blocks,omitempty"`
+
+	// Tests from the refinery's last test run, if results were parsed
+	Tests *MRTestsOutput `json:"tests,omitempty"`
+}
+
+// MRTestsOutput is the outcome of the refinery's last test run for an MR.
+type MRTestsOutput struct {
+	RanAt   string       `json:"ran_at"`
+	Failed  []TestStatus `json:"failed,omitempty"`
+	Flaky   []TestStatus `json:"flaky,omitempty"` // Failed, then passed on rerun
+	Allowed bool         `json:"allowed,omitempty"`
+}
+
+// TestStatus is a test and what the rig's test history knows about it.
+type TestStatus struct {
+	ID          string  `json:"id"`
+	KnownFlaky  bool    `json:"known_flaky"`
+	Quarantined bool    `json:"quarantined"`
+	FlipRate    float64 `json:"flip_rate"`
+	Bead        string  `json:"bead,omitempty"`
 }
 
 // DependencyInfo represents a dependency or blocker.
@@ -95,6 +118,7 @@ func runMqStatus(cmd *cobra.Command, args []string) error {
 		output.Rig = mrFields.Rig
 		output.MergeCommit = mrFields.MergeCommit
 		output.CloseReason = mrFields.CloseReason
+		output.Tests = loadMRTests(mrFields.Rig, issue.ID)
 	}
 
 	// Add dependency info from the issue's Dependencies field
@@ -127,11 +151,55 @@ func runMqStatus(cmd *cobra.Command, args []string) error {
 	}
 
 	// Human-readable output
-	return printMqStatus(issue, mrFields)
+	return printMqStatus(issue, mrFields, output.Tests)
+}
+
+// loadMRTests reads an MR's last test run from its rig's test history.
+// Returns nil when the rig is unknown or has no report for the MR.
+func loadMRTests(rigName, mrID string) *MRTestsOutput {
+	if rigName == "" {
+		return nil
+	}
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil || townRoot == "" {
+		return nil
+	}
+	h, err := testhistory.Load(filepath.Join(townRoot, rigName))
+	if err != nil {
+		return nil
+	}
+	report := h.MRs[mrID]
+	if report == nil {
+		return nil
+	}
+
+	status := func(id string) TestStatus {
+		ts := TestStatus{ID: id}
+		if rec := h.Tests[id]; rec != nil {
+			ts.Quarantined = rec.Quarantined
+			ts.KnownFlaky = rec.Quarantined || rec.Bead != ""
+			ts.FlipRate = rec.FlipRate()
+			ts.Bead = rec.Bead
+		}
+		return ts
+	}
+	out := &MRTestsOutput{
+		RanAt:   report.Time.Format(time.RFC3339),
+		Allowed: report.Allowed,
+	}
+	for _, id := range report.Failed {
+		out.Failed = append(out.Failed, status(id))
+	}
+	for _, id := range report.Flaky {
+		ts := status(id)
+		ts.KnownFlaky = true
+		out.Flaky = append(out.Flaky, ts)
+	}
+	return out
 }
 
 // printMqStatus prints detailed MR status in human-readable format.
-func printMqStatus(issue *beads.Issue, mrFields *beads.MRFields) error {
+func printMqStatus(issue *beads.Issue, mrFields *beads.MRFields, tests *MRTestsOutput) error {
 	// Header
 	fmt.Printf("%s %s\n", style.Bold.Render("📋 Merge Request:"), issue.ID)
 	fmt.Printf("   %s\n\n", issue.Title)
@@ -186,6 +254,12 @@ func printMqStatus(issue *beads.Issue, mrFields *beads.MRFields) error {
 		}
 	}
 
+	// Tests from the refinery's last run
+	if tests != nil {
+		fmt.Printf("\n%s\n", style.Bold.Render("Tests"))
+		printMRTests(tests)
+	}
+
 	// Dependencies (what this MR is waiting on)
 	if len(issue.Dependencies) > 0 {
 		fmt.Printf("\n%s\n", style.Bold.Render("Waiting On"))
@@ -225,6 +299,39 @@ func printMqStatus(issue *beads.Issue, mrFields *beads.MRFields) error {
 	return nil
 }
 
+// printMRTests prints the outcome of an MR's test run.
+func printMRTests(tests *MRTestsOutput) {
+	fmt.Printf("   Last run: %s %s\n", tests.RanAt, formatTimeAgo(tests.RanAt))
+	if len(tests.Failed) == 0 {
+		fmt.Printf("   %s all tests passed\n", style.Success.Render("✓"))
+	}
+	for _, t := range tests.Failed {
+		fmt.Printf("   %s %s %s\n", style.Error.Render("✗"), t.ID, formatTestFlakiness(t))
+	}
+	for _, t := range tests.Flaky {
+		fmt.Printf("   %s %s %s\n", style.Warning.Render("↻"), t.ID, style.Dim.Render("passed on rerun"))
+	}
+	if tests.Allowed {
+		fmt.Printf("   %s\n", style.Dim.Render("Merged: only quarantined tests failed"))
+	}
+}
+
+// formatTestFlakiness describes what the test history knows about a failing test.
+func formatTestFlakiness(t TestStatus) string {
+	switch {
+	case t.Quarantined:
+		label := fmt.Sprintf("quarantined, known flaky (flip rate %.0f%%)", t.FlipRate*100)
+		if t.Bead != "" {
+			label += ", " + t.Bead
+		}
+		return style.Warning.Render("[" + label + "]")
+	case t.KnownFlaky:
+		return style.Warning.Render("[known flaky, " + t.Bead + "]")
+	default:
+		return style.Dim.Render("[not known flaky]")
+	}
+}
+
 // formatStatus formats the status with appropriate styling.
 func formatStatus(status string) string {
 	switch status {
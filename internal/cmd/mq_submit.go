@@ -134,6 +134,14 @@ func runMqSubmit(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// A stacked MR merges into its parent's target, after the parent
+	if mqSubmitParent != "" {
+		target, err = stackParentTarget(bd, mqSubmitParent, defaultBranch)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Get source issue for priority inheritance
 	var priority int
 	if mqSubmitPriority >= 0 {
@@ -149,23 +157,9 @@ func runMqSubmit(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Build MR bead title and description
-	title := fmt.Sprintf("Merge: %s", issueID)
-	description := fmt.Sprintf("branch: %s\ntarget: %s\nsource_issue: %s\nrig: %s",
-		branch, target, issueID, rigName)
-	if worker != "" {
-		description += fmt.Sprintf("\nworker: %s", worker)
-	}
-
-	// Create MR bead (ephemeral wisp - will be cleaned up after merge)
-	mrIssue, err := bd.Create(beads.CreateOptions{
-		Title:       title,
-		Type:        "merge-request",
-		Priority:    priority,
-		Description: description,
-	})
+	mrIssue, err := createMergeRequestBead(bd, rigName, branch, target, issueID, worker, mqSubmitParent, priority)
 	if err != nil {
-		return fmt.Errorf("creating merge request bead: %w", err)
+		return err
 	}
 
 	// Success output
@@ -178,6 +172,9 @@ func runMqSubmit(cmd *cobra.Command, args []string) error {
 		fmt.Printf("  Worker: %s\n", worker)
 	}
 	fmt.Printf("  Priority: P%d\n", priority)
+	if mqSubmitParent != "" {
+		fmt.Printf("  Stacked on: %s\n", mqSubmitParent)
+	}
 
 	// Auto-cleanup for polecats: if this is a polecat branch and cleanup not disabled,
 	// send lifecycle request and wait for termination
@@ -196,6 +193,65 @@ func runMqSubmit(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// createMergeRequestBead creates the merge-request bead that rigName's
+// refinery picks up from its queue. A non-empty parentMR stacks it on that
+// MR: the new MR depends on the parent, so it becomes ready only once the
+// parent has merged.
+func createMergeRequestBead(bd *beads.Beads, rigName, branch, target, issueID, worker, parentMR string, priority int) (*beads.Issue, error) {
+	// Build MR bead title and description
+	title := fmt.Sprintf("Merge: %s", issueID)
+	description := fmt.Sprintf("branch: %s\ntarget: %s\nsource_issue: %s\nrig: %s",
+		branch, target, issueID, rigName)
+	if worker != "" {
+		description += fmt.Sprintf("\nworker: %s", worker)
+	}
+	if parentMR != "" {
+		description += fmt.Sprintf("\nparent_mr: %s", parentMR)
+	}
+
+	// Create MR bead (ephemeral wisp - will be cleaned up after merge)
+	mrIssue, err := bd.Create(beads.CreateOptions{
+		Title:       title,
+		Type:        "merge-request",
+		Priority:    priority,
+		Description: description,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating merge request bead: %w", err)
+	}
+
+	if parentMR != "" {
+		if err := bd.AddDependency(mrIssue.ID, parentMR); err != nil {
+			// Without the dependency the refinery could merge it ahead of
+			// its parent, so don't leave it open in the queue.
+			if cerr := bd.CloseWithReason("rejected: could not stack on "+parentMR, mrIssue.ID); cerr != nil {
+				return nil, fmt.Errorf("stacking %s on %s: %w (closing it also failed: %v)", mrIssue.ID, parentMR, err, cerr)
+			}
+			return nil, fmt.Errorf("stacking %s on %s: %w", mrIssue.ID, parentMR, err)
+		}
+	}
+	return mrIssue, nil
+}
+
+// stackParentTarget checks that parentID is an open merge request and
+// returns its target branch, which MRs stacked on it inherit.
+func stackParentTarget(bd *beads.Beads, parentID, defaultBranch string) (string, error) {
+	parent, err := bd.Show(parentID)
+	if err != nil {
+		return "", fmt.Errorf("looking up parent MR %s: %w", parentID, err)
+	}
+	if parent.Type != "merge-request" {
+		return "", fmt.Errorf("%s is a %s, not a merge request", parentID, parent.Type)
+	}
+	if parent.Status == "closed" {
+		return "", fmt.Errorf("parent MR %s is already closed; submit against its target instead", parentID)
+	}
+	if fields := beads.ParseMRFields(parent); fields != nil && fields.Target != "" {
+		return fields.Target, nil
+	}
+	return defaultBranch, nil
+}
+
 // detectIntegrationBranch checks if an issue is a child of an epic that has an integration branch.
 // Returns the integration branch target (e.g., "integration/gt-epic") if found, or "" if not.
 func detectIntegrationBranch(bd *beads.Beads, g *git.Git, issueID string) (string, error) {
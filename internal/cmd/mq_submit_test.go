@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+func TestCreateMergeRequestBead_ClosesUnstackedMR(t *testing.T) {
+	binDir := t.TempDir()
+	logPath := filepath.Join(binDir, "bd.log")
+	script := `#!/bin/sh
+shift # --no-daemon
+echo "$@" >> "` + logPath + `"
+case "$1" in
+  create) echo '{"id":"gt-mr2","title":"Merge: gt-abc"}' ;;
+  dep) echo "dependency target not found" >&2; exit 1 ;;
+esac
+`
+	if err := os.WriteFile(filepath.Join(binDir, "bd"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	_, err := createMergeRequestBead(beads.New(t.TempDir()), "gastown", "polecat/nux", "main", "gt-abc", "nux", "gt-mr1", 2)
+	if err == nil || !strings.Contains(err.Error(), "stacking gt-mr2 on gt-mr1") {
+		t.Fatalf("createMergeRequestBead() error = %v, want stacking error", err)
+	}
+
+	log, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(log), "close gt-mr2") {
+		t.Errorf("MR bead left open after failed stacking; bd calls:\n%s", log)
+	}
+}
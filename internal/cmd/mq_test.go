@@ -1,9 +1,15 @@
 package cmd
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/mrqueue"
+	"github.com/steveyegge/gastown/internal/testhistory"
 )
 
 func TestAddIntegrationBranchField(t *testing.T) {
@@ -434,3 +440,79 @@ func TestFilterMRsByTarget_NoMRFields(t *testing.T) {
 		t.Errorf("filterMRsByTarget() should filter out issues without MR fields, got %d", len(got))
 	}
 }
+
+func TestStackOrder(t *testing.T) {
+	// Sorted by score: the child outscores its parent but must follow it.
+	mrs := []*mrqueue.MR{
+		{ID: "gt-mr-child", ParentMR: "gt-mr-parent", Branch: "polecat/b"},
+		{ID: "gt-mr-solo", Branch: "polecat/c"},
+		{ID: "gt-mr-parent", Branch: "polecat/a"},
+		{ID: "gt-mr-grandchild", ParentMR: "gt-mr-child", Branch: "polecat/d"},
+	}
+	stacks := mrqueue.BuildStacks(mrs)
+
+	order, depths := stackOrder(stacks, mrs)
+	var got []string
+	for i, idx := range order {
+		got = append(got, strings.Repeat(">", depths[i])+mrs[idx].ID)
+	}
+	want := "gt-mr-solo gt-mr-parent >gt-mr-child >>gt-mr-grandchild"
+	if strings.Join(got, " ") != want {
+		t.Errorf("stackOrder() = %v, want %s", got, want)
+	}
+	if maxDepth(depths) != 2 {
+		t.Errorf("maxDepth() = %d, want 2", maxDepth(depths))
+	}
+
+	tree := renderMRStacks(stacks)
+	for _, line := range []string{"gt-mr-parent", "└─ gt-mr-child", "   └─ gt-mr-grandchild"} {
+		if !strings.Contains(tree, line) {
+			t.Errorf("renderMRStacks() missing %q:\n%s", line, tree)
+		}
+	}
+	if strings.Contains(tree, "gt-mr-solo") {
+		t.Errorf("renderMRStacks() should skip unstacked MRs:\n%s", tree)
+	}
+}
+
+func TestLoadMRTests(t *testing.T) {
+	townRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(townRoot, "mayor"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(townRoot, "mayor", "town.json"), []byte(`{"type":"town","version":1,"name":"t"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	err := testhistory.Update(filepath.Join(townRoot, "gastown"), func(h *testhistory.History) error {
+		h.Tests["pkg.TestFlaky"] = &testhistory.TestRecord{Recent: "RRRR", Quarantined: true, Bead: "gt-bug1"}
+		h.Tests["pkg.TestBroken"] = &testhistory.TestRecord{Recent: "PPFF"}
+		h.SetReport(&testhistory.Report{
+			MR:     "gt-mr1",
+			Time:   time.Now(),
+			Failed: []string{"pkg.TestFlaky", "pkg.TestBroken"},
+			Flaky:  []string{"pkg.TestOther"},
+		})
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(townRoot)
+
+	if got := loadMRTests("gastown", "gt-mr2"); got != nil {
+		t.Errorf("loadMRTests(unknown MR) = %+v, want nil", got)
+	}
+	got := loadMRTests("gastown", "gt-mr1")
+	if got == nil || len(got.Failed) != 2 || len(got.Flaky) != 1 {
+		t.Fatalf("loadMRTests() = %+v", got)
+	}
+	if f := got.Failed[0]; !f.Quarantined || !f.KnownFlaky || f.Bead != "gt-bug1" || f.FlipRate != 1 {
+		t.Errorf("quarantined test status = %+v", f)
+	}
+	if f := got.Failed[1]; f.Quarantined || f.KnownFlaky {
+		t.Errorf("broken test status = %+v, want not flaky", f)
+	}
+	if !strings.Contains(formatTestFlakiness(got.Failed[0]), "quarantined") {
+		t.Errorf("formatTestFlakiness() = %q", formatTestFlakiness(got.Failed[0]))
+	}
+}
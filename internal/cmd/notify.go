@@ -1,11 +1,18 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/notify"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
@@ -23,22 +30,46 @@ Notification levels:
 
 Without arguments, shows the current notification level.
 
+Outbound notifications (webhooks, Slack, email, desktop) configured under
+"notifications" in settings/config.json follow the Mayor's level, since the
+Mayor is the overseer's seat: muted lets only CRITICAL escalations through,
+normal skips low-severity status events, verbose sends everything. They are
+delivered in the background, so a slow endpoint never holds up the command
+that raised them; delivery failures are logged to logs/notify.log.
+
 Examples:
   gt notify           # Show current level
   gt notify verbose   # Enable all notifications
   gt notify normal    # Default notification level
   gt notify muted     # Enable DND mode
+  gt notify --test    # Send a test notification to every configured sink
 
 Related: gt dnd - quick toggle for DND mode`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runNotify,
 }
 
+var notifyDeliverCmd = &cobra.Command{
+	Use:    "deliver <notification-json>",
+	Short:  "Deliver an outbound notification (internal)",
+	Hidden: true, // Started in the background by sendOutboundNotification
+	Args:   cobra.ExactArgs(1),
+	RunE:   runNotifyDeliver,
+}
+
+var notifyTest bool
+
 func init() {
+	notifyCmd.Flags().BoolVar(&notifyTest, "test", false, "Send a test notification to every configured sink")
+	notifyCmd.AddCommand(notifyDeliverCmd)
 	rootCmd.AddCommand(notifyCmd)
 }
 
 func runNotify(cmd *cobra.Command, args []string) error {
+	if notifyTest {
+		return runNotifyTest()
+	}
+
 	// Get current agent bead ID
 	cwd, err := os.Getwd()
 	if err != nil {
@@ -129,3 +160,132 @@ func showNotificationLevelDescription(level string) {
 		fmt.Printf("  %s\n", style.Dim.Render("Silent mode: notifications batched for later review"))
 	}
 }
+
+// outboundNotifyTimeout bounds total delivery time, including retries,
+// so a dead endpoint doesn't leave delivery processes piling up.
+const outboundNotifyTimeout = 30 * time.Second
+
+// sendOutboundNotification delivers n to the town's configured notification
+// sinks from a detached gt notify deliver, so retries against a slow or dead
+// endpoint never hold up the caller. Problems starting delivery are reported
+// as warnings; they never fail the caller.
+func sendOutboundNotification(townRoot string, n notify.Notification) {
+	d, err := notify.Load(townRoot)
+	if err != nil {
+		style.PrintWarning("notifications not sent: %v", err)
+		return
+	}
+	if !d.HasSinks() {
+		return
+	}
+
+	if n.Time.IsZero() {
+		n.Time = time.Now()
+	}
+	data, err := json.Marshal(n)
+	if err != nil {
+		style.PrintWarning("notifications not sent: %v", err)
+		return
+	}
+	gtPath, err := os.Executable()
+	if err != nil {
+		style.PrintWarning("notifications not sent: %v", err)
+		return
+	}
+
+	logDir := filepath.Join(townRoot, "logs")
+	if err := os.MkdirAll(logDir, 0755); err != nil {
+		style.PrintWarning("notifications not sent: %v", err)
+		return
+	}
+	logFile, err := os.OpenFile(filepath.Join(logDir, "notify.log"), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		style.PrintWarning("notifications not sent: %v", err)
+		return
+	}
+	defer logFile.Close()
+
+	cmd := exec.Command(gtPath, "notify", "deliver", string(data)) //nolint:gosec // G204: gtPath is our own binary
+	cmd.Dir = townRoot
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	// Own session, so the delivery outlives the command and its terminal
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	if err := cmd.Start(); err != nil {
+		style.PrintWarning("notifications not sent: %v", err)
+		return
+	}
+	_ = cmd.Process.Release()
+}
+
+// runNotifyDeliver delivers one notification, honoring the Mayor's
+// notification level (gt notify / gt dnd). Failures go to stderr, which
+// sendOutboundNotification points at logs/notify.log.
+func runNotifyDeliver(cmd *cobra.Command, args []string) error {
+	var n notify.Notification
+	if err := json.Unmarshal([]byte(args[0]), &n); err != nil {
+		return fmt.Errorf("parsing notification: %w", err)
+	}
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	d, err := notify.Load(townRoot)
+	if err != nil {
+		return err
+	}
+	if level, err := beads.New(townRoot).GetAgentNotificationLevel(beads.MayorBeadIDTown()); err == nil {
+		d.SetLevel(level)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), outboundNotifyTimeout)
+	defer cancel()
+	if _, err := d.Send(ctx, n); err != nil {
+		return fmt.Errorf("%s: delivering %s notification %q: %w", time.Now().Format(time.RFC3339), n.Event, n.Title, err)
+	}
+	return nil
+}
+
+// runNotifyTest sends a test notification to every sink, ignoring routes'
+// event filters and the DND level so each endpoint can be verified.
+func runNotifyTest() error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	d, err := notify.Load(townRoot)
+	if err != nil {
+		return err
+	}
+	if !d.HasSinks() {
+		fmt.Printf("No notification sinks configured %s\n", style.Dim.Render("(add \"notifications\" to settings/config.json)"))
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), outboundNotifyTimeout)
+	defer cancel()
+	deliveries, err := d.SendAll(ctx, notify.Notification{
+		Event:    notify.EventTest,
+		Severity: notify.SeverityLow,
+		Title:    "Gas Town test notification",
+		Body:     "If you can read this, the sink is configured correctly.",
+		Source:   "gt notify --test",
+	})
+
+	for _, del := range deliveries {
+		switch {
+		case del.Err != nil:
+			fmt.Printf("  %s %s: %v\n", style.ErrorPrefix, del.Sink, del.Err)
+		case del.Skipped != "":
+			fmt.Printf("  %s %s: skipped (%s)\n", style.WarningPrefix, del.Sink, del.Skipped)
+		default:
+			fmt.Printf("  %s %s\n", style.SuccessPrefix, del.Sink)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("some sinks failed")
+	}
+	return nil
+}
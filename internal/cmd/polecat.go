@@ -13,6 +13,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/limits"
 	"github.com/steveyegge/gastown/internal/polecat"
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/session"
@@ -618,12 +619,16 @@ type PolecatStatus struct {
 	Issue          string        `json:"issue,omitempty"`
 	ClonePath      string        `json:"clone_path"`
 	Branch         string        `json:"branch"`
+	SparseCone     []string      `json:"sparse_cone,omitempty"`
+	CloneFilter    string        `json:"partial_clone_filter,omitempty"`
 	SessionRunning bool          `json:"session_running"`
 	SessionID      string        `json:"session_id,omitempty"`
 	Attached       bool          `json:"attached,omitempty"`
 	Windows        int           `json:"windows,omitempty"`
 	CreatedAt      string        `json:"created_at,omitempty"`
 	LastActivity   string        `json:"last_activity,omitempty"`
+	Resources      *limits.Usage `json:"resources,omitempty"`
+	LimitBreaches  []string      `json:"limit_breaches,omitempty"`
 }
 
 func runPolecatStatus(cmd *cobra.Command, args []string) error {
@@ -655,6 +660,9 @@ func runPolecatStatus(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Sparse profile (non-fatal: shown as a full checkout if unreadable)
+	sparseCone, cloneFilter, _ := mgr.SparseProfile(polecatName)
+
 	// JSON output
 	if polecatStatusJSON {
 		status := PolecatStatus{
@@ -664,10 +672,16 @@ func runPolecatStatus(cmd *cobra.Command, args []string) error {
 			Issue:          p.Issue,
 			ClonePath:      p.ClonePath,
 			Branch:         p.Branch,
+			SparseCone:     sparseCone,
+			CloneFilter:    cloneFilter,
 			SessionRunning: sessInfo.Running,
 			SessionID:      sessInfo.SessionID,
 			Attached:       sessInfo.Attached,
 			Windows:        sessInfo.Windows,
+			Resources:      sessInfo.Resources,
+		}
+		if sessInfo.Resources != nil {
+			status.LimitBreaches = sessInfo.Resources.Breaches(0)
 		}
 		if !sessInfo.Created.IsZero() {
 			status.CreatedAt = sessInfo.Created.Format("2006-01-02 15:04:05")
@@ -708,6 +722,20 @@ func runPolecatStatus(cmd *cobra.Command, args []string) error {
 	fmt.Printf("  Clone:         %s\n", style.Dim.Render(p.ClonePath))
 	fmt.Printf("  Branch:        %s\n", style.Dim.Render(p.Branch))
 
+	// Sparse profile
+	if sparseCone != nil {
+		cone := "(top-level files only)"
+		if len(sparseCone) > 0 {
+			cone = strings.Join(sparseCone, ", ")
+		}
+		fmt.Printf("  Sparse:        %s\n", cone)
+	} else {
+		fmt.Printf("  Sparse:        %s\n", style.Dim.Render("full checkout"))
+	}
+	if cloneFilter != "" {
+		fmt.Printf("  Clone filter:  %s\n", cloneFilter)
+	}
+
 	// Session info
 	fmt.Println()
 	fmt.Printf("%s\n", style.Bold.Render("Session"))
@@ -737,6 +765,13 @@ func runPolecatStatus(cmd *cobra.Command, args []string) error {
 				sessInfo.LastActivity.Format("15:04:05"),
 				style.Dim.Render(ago))
 		}
+
+		if sessInfo.Resources != nil {
+			fmt.Printf("  Resources:     %s\n", sessInfo.Resources)
+			for _, breach := range sessInfo.Resources.Breaches(0) {
+				fmt.Printf("  %s\n", style.Warning.Render("⚠ "+breach))
+			}
+		}
 	} else {
 		fmt.Printf("  Status:        %s\n", style.Dim.Render("not running"))
 	}
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/polecat"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+var (
+	polecatPoolJSON bool
+	polecatPoolAll  bool
+)
+
+var polecatPoolCmd = &cobra.Command{
+	Use:   "pool [rig]",
+	Short: "Show the polecat pool (names in use, warm worktrees)",
+	Long: `Show a rig's polecat pool.
+
+Reports the names in use, how many warm worktrees are ready against the
+configured size, and how many spawns were served warm vs. created cold.
+
+A warm worktree is an idle, fully-provisioned polecat worktree (shared
+beads and runtime settings in place) kept at origin/<default-branch>.
+gt sling claims one and checks out a fresh branch instead of creating a
+worktree from scratch. Configure the pool size in settings/config.json:
+
+  "warm_pool": {"size": 2}
+
+The daemon refills pools on every heartbeat (gt polecat pool fill --all).
+
+Examples:
+  gt polecat pool greenplace
+  gt polecat pool --all --json
+  gt polecat pool fill greenplace`,
+	RunE: runPolecatPool,
+}
+
+var polecatPoolFillCmd = &cobra.Command{
+	Use:   "fill [rig]",
+	Short: "Refresh warm worktrees and top the pool up to its size",
+	Long: `Refresh a rig's warm worktrees and top the pool up to its configured size.
+
+Fetches origin, moves every warm worktree to the latest default branch,
+replaces any that can't be refreshed, and provisions or removes worktrees
+until the pool matches warm_pool.size. Rigs without a warm pool are skipped.
+
+Examples:
+  gt polecat pool fill greenplace
+  gt polecat pool fill --all`,
+	RunE: runPolecatPoolFill,
+}
+
+func init() {
+	polecatPoolCmd.Flags().BoolVar(&polecatPoolJSON, "json", false, "Output as JSON")
+	polecatPoolCmd.Flags().BoolVar(&polecatPoolAll, "all", false, "Show pools for all rigs")
+	polecatPoolFillCmd.Flags().BoolVar(&polecatPoolAll, "all", false, "Fill pools for all rigs")
+
+	polecatPoolCmd.AddCommand(polecatPoolFillCmd)
+	polecatCmd.AddCommand(polecatPoolCmd)
+}
+
+// poolRigs resolves the rigs a pool command applies to.
+func poolRigs(args []string) ([]*rig.Rig, error) {
+	if polecatPoolAll {
+		rigs, _, err := getAllRigs()
+		return rigs, err
+	}
+	if len(args) < 1 {
+		return nil, fmt.Errorf("rig name required (or use --all)")
+	}
+	_, r, err := getRig(args[0])
+	if err != nil {
+		return nil, err
+	}
+	return []*rig.Rig{r}, nil
+}
+
+func runPolecatPool(cmd *cobra.Command, args []string) error {
+	rigs, err := poolRigs(args)
+	if err != nil {
+		return err
+	}
+
+	type rigPool struct {
+		Rig string `json:"rig"`
+		polecat.PoolStatus
+	}
+	var pools []rigPool
+	for _, r := range rigs {
+		mgr := polecat.NewManager(r, git.NewGit(r.Path))
+		pools = append(pools, rigPool{Rig: r.Name, PoolStatus: mgr.PoolStatus()})
+	}
+
+	if polecatPoolJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(pools)
+	}
+
+	for _, p := range pools {
+		fmt.Printf("%s\n", style.Bold.Render("🐾 "+p.Rig))
+		fmt.Printf("  Active: %d", p.Active)
+		if len(p.Names) > 0 {
+			fmt.Printf(" %s", style.Dim.Render(fmt.Sprintf("%v", p.Names)))
+		}
+		fmt.Println()
+		if p.WarmTarget > 0 || p.Warm > 0 {
+			fmt.Printf("  Warm:   %d/%d ready\n", p.Warm, p.WarmTarget)
+		} else {
+			fmt.Printf("  Warm:   %s\n", style.Dim.Render("disabled"))
+		}
+		fmt.Printf("  Spawns: %d warm, %d cold\n", p.WarmClaims, p.ColdSpawns)
+	}
+	return nil
+}
+
+func runPolecatPoolFill(cmd *cobra.Command, args []string) error {
+	rigs, err := poolRigs(args)
+	if err != nil {
+		return err
+	}
+
+	var failed int
+	for _, r := range rigs {
+		mgr := polecat.NewManager(r, git.NewGit(r.Path))
+		if mgr.WarmSize() == 0 && mgr.PoolStatus().Warm == 0 {
+			continue
+		}
+		added, removed, err := mgr.FillWarmPool()
+		if err != nil {
+			failed++
+			fmt.Printf("%s %s: %v\n", style.ErrorPrefix, r.Name, err)
+			continue
+		}
+		fmt.Printf("%s %s: %d/%d warm (+%d, -%d)\n",
+			style.SuccessPrefix, r.Name, mgr.PoolStatus().Warm, mgr.WarmSize(), added, removed)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d rig(s) failed to fill", failed)
+	}
+	return nil
+}
@@ -2,12 +2,13 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
 	"strings"
 
+	"github.com/steveyegge/gastown/internal/account"
 	"github.com/steveyegge/gastown/internal/config"
-	"github.com/steveyegge/gastown/internal/constants"
 	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/polecat"
@@ -15,6 +16,7 @@ import (
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/tracing"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
@@ -34,17 +36,26 @@ func (s *SpawnedPolecatInfo) AgentID() string {
 
 // SlingSpawnOptions contains options for spawning a polecat via sling.
 type SlingSpawnOptions struct {
-	Force    bool   // Force spawn even if polecat has uncommitted work
-	Naked    bool   // No-tmux mode: skip session creation
-	Account  string // Claude Code account handle to use
-	Create   bool   // Create polecat if it doesn't exist (currently always true for sling)
-	HookBead string // Bead ID to set as hook_bead at spawn time (atomic assignment)
+	Force       bool   // Force spawn even if polecat has uncommitted work
+	Naked       bool   // No-tmux mode: skip session creation
+	Account     string // Claude Code account handle to use
+	Create      bool   // Create polecat if it doesn't exist (currently always true for sling)
+	HookBead    string // Bead ID to set as hook_bead at spawn time (atomic assignment)
+	TraceParent string // W3C traceparent of the slinging span (for end-to-end tracing)
 }
 
 // SpawnPolecatForSling creates a fresh polecat and optionally starts its session.
 // This is used by gt sling when the target is a rig name.
 // The caller (sling) handles hook attachment and nudging.
-func SpawnPolecatForSling(rigName string, opts SlingSpawnOptions) (*SpawnedPolecatInfo, error) {
+func SpawnPolecatForSling(rigName string, opts SlingSpawnOptions) (_ *SpawnedPolecatInfo, err error) {
+	_, span := tracing.Start(tracing.ContextWithParent(context.Background(), opts.TraceParent), "polecat.spawn")
+	span.SetAttr(tracing.AttrRig, rigName)
+	span.SetAttr(tracing.AttrBead, opts.HookBead)
+	defer func() {
+		span.SetError(err)
+		span.End()
+	}()
+
 	// Find workspace
 	townRoot, err := workspace.FindFromCwdOrError()
 	if err != nil {
@@ -75,6 +86,7 @@ func SpawnPolecatForSling(rigName string, opts SlingSpawnOptions) (*SpawnedPolec
 		return nil, fmt.Errorf("allocating polecat name: %w", err)
 	}
 	fmt.Printf("Allocated polecat: %s\n", polecatName)
+	span.SetAttr(tracing.AttrPolecat, polecatName)
 
 	// Check if polecat already exists (shouldn't happen - indicates stale state needing repair)
 	existingPolecat, err := polecatMgr.Get(polecatName)
@@ -100,10 +112,17 @@ func SpawnPolecatForSling(rigName string, opts SlingSpawnOptions) (*SpawnedPolec
 			return nil, fmt.Errorf("repairing stale polecat: %w", err)
 		}
 	} else if err == polecat.ErrPolecatNotFound {
-		// Create new polecat
-		fmt.Printf("Creating polecat %s...\n", polecatName)
-		if _, err = polecatMgr.AddWithOptions(polecatName, addOpts); err != nil {
-			return nil, fmt.Errorf("creating polecat: %w", err)
+		// Claim a pre-provisioned worktree from the warm pool, else create one
+		if _, err = polecatMgr.ClaimWarm(polecatName, addOpts); err == nil {
+			fmt.Printf("Claimed warm worktree for polecat %s\n", polecatName)
+		} else {
+			if err != polecat.ErrWarmPoolEmpty {
+				style.PrintWarning("could not claim warm worktree: %v", err)
+			}
+			fmt.Printf("Creating polecat %s...\n", polecatName)
+			if _, err = polecatMgr.AddWithOptions(polecatName, addOpts); err != nil {
+				return nil, fmt.Errorf("creating polecat: %w", err)
+			}
 		}
 	} else {
 		return nil, fmt.Errorf("getting polecat: %w", err)
@@ -137,8 +156,7 @@ func SpawnPolecatForSling(rigName string, opts SlingSpawnOptions) (*SpawnedPolec
 	}
 
 	// Resolve account for Claude config
-	accountsPath := constants.MayorAccountsPath(townRoot)
-	claudeConfigDir, accountHandle, err := config.ResolveAccountConfigDir(accountsPath, opts.Account)
+	claudeConfigDir, accountHandle, err := account.Resolve(townRoot, opts.Account)
 	if err != nil {
 		return nil, fmt.Errorf("resolving account: %w", err)
 	}
@@ -156,10 +174,14 @@ func SpawnPolecatForSling(rigName string, opts SlingSpawnOptions) (*SpawnedPolec
 		fmt.Printf("Starting session for %s/%s...\n", rigName, polecatName)
 		startOpts := session.StartOptions{
 			ClaudeConfigDir: claudeConfigDir,
+			TraceParent:     span.TraceParent(),
 		}
 		if err := sessMgr.Start(polecatName, startOpts); err != nil {
 			return nil, fmt.Errorf("starting session: %w", err)
 		}
+		if err := account.Assign(townRoot, sessMgr.SessionName(polecatName), accountHandle); err != nil {
+			style.PrintWarning("could not record account: %v", err)
+		}
 	}
 
 	// Get session name and pane
@@ -16,6 +16,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/checkpoint"
+	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
 	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/lock"
@@ -25,7 +26,12 @@ import (
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
-var primeHookMode bool
+var (
+	primeHookMode    bool
+	primeExplain     bool
+	primeSectionName string
+	primeFull        bool
+)
 
 // Role represents a detected agent role.
 type Role string
@@ -64,13 +70,33 @@ HOOK MODE (--hook):
   Claude Code sends JSON on stdin:
     {"session_id": "uuid", "transcript_path": "/path", "source": "startup|resume"}
 
-  Other agents can set GT_SESSION_ID environment variable instead.`,
+  Other agents can set GT_SESSION_ID environment variable instead.
+
+CONTEXT BUDGET:
+  The context is assembled from sections (role, handoff, attachment, hook,
+  molecule, checkpoint, knowledge, beads, mail, escalations, startup) and
+  fitted into a per-role token budget (default 20000; set context_budget in
+  settings/config.json). Over budget, the least important sections are
+  truncated or dropped first - mail, then bd prime output, then rig
+  knowledge, then molecule progress, then the handoff - and a note lists
+  what was cut. The role context, hooked work and startup directive are
+  never cut.
+
+  gt prime --explain           # Section sizes and what would be cut
+  gt prime --section handoff   # One section in full
+  gt prime --full              # Everything, ignoring the budget`,
 	RunE: runPrime,
 }
 
 func init() {
 	primeCmd.Flags().BoolVar(&primeHookMode, "hook", false,
 		"Hook mode: read session ID from stdin JSON (for LLM runtime hooks)")
+	primeCmd.Flags().BoolVar(&primeExplain, "explain", false,
+		"Show section sizes and what the context budget would cut")
+	primeCmd.Flags().StringVar(&primeSectionName, "section", "",
+		"Output one section in full (e.g., handoff, mail, molecule)")
+	primeCmd.Flags().BoolVar(&primeFull, "full", false,
+		"Ignore the context budget")
 	rootCmd.AddCommand(primeCmd)
 }
 
@@ -140,62 +166,120 @@ func runPrime(cmd *cobra.Command, args []string) error {
 		WorkDir:  cwd,
 	}
 
-	// Check and acquire identity lock for worker roles
-	if err := acquireIdentityLock(ctx); err != nil {
+	// --explain and --section only inspect the context; skip the session
+	// side effects (identity lock, agent state, session events).
+	inspectOnly := primeExplain || primeSectionName != ""
+
+	if !inspectOnly {
+		// Check and acquire identity lock for worker roles
+		if err := acquireIdentityLock(ctx); err != nil {
+			return err
+		}
+
+		// Ensure beads redirect exists for worktree-based roles
+		ensureBeadsRedirect(ctx)
+
+		// Report agent state as running (ZFC: agents self-report state)
+		reportAgentState(ctx, "running")
+
+		// Emit session_start event for seance discovery
+		emitSessionEvent(ctx)
+
+		// Output session metadata for seance discovery
+		outputSessionMetadata(ctx)
+	}
+
+	sections, err := collectPrimeSections(ctx, cwd)
+	if err != nil {
 		return err
 	}
 
-	// Ensure beads redirect exists for worktree-based roles
-	ensureBeadsRedirect(ctx)
+	if primeSectionName != "" {
+		for _, sec := range sections {
+			if sec.Name == primeSectionName {
+				fmt.Print(sec.Content)
+				return nil
+			}
+		}
+		return fmt.Errorf("no %q section in the current context", primeSectionName)
+	}
+
+	// Fit sections into the role's context budget
+	budget := 0
+	if !primeFull {
+		budget = config.ResolveContextBudget(townRoot, string(ctx.Role))
+	}
+	fits := fitPrimeSections(sections, budget)
 
-	// Report agent state as running (ZFC: agents self-report state)
-	reportAgentState(ctx, "running")
+	if primeExplain {
+		printPrimeExplain(fits, budget, ctx.Role)
+		return nil
+	}
 
-	// Emit session_start event for seance discovery
-	emitSessionEvent(ctx)
+	for _, f := range fits {
+		fmt.Print(f.Content)
+	}
+	printPrimeBudgetNote(fits, budget, ctx.Role)
 
-	// Output session metadata for seance discovery
-	outputSessionMetadata(ctx)
+	return nil
+}
+
+// collectPrimeSections assembles the prime context as named, prioritized
+// sections in output order. Empty sections are left out.
+func collectPrimeSections(ctx RoleContext, cwd string) ([]primeSection, error) {
+	var sections []primeSection
+	add := func(name string, priority int, fn func()) {
+		content := capturePrimeOutput(fn)
+		if strings.TrimSpace(content) != "" {
+			sections = append(sections, primeSection{Name: name, Priority: priority, Content: content})
+		}
+	}
 
 	// Output context
-	if err := outputPrimeContext(ctx); err != nil {
-		return err
+	var roleErr error
+	add("role", primePriorityRequired, func() { roleErr = outputPrimeContext(ctx) })
+	if roleErr != nil {
+		return nil, roleErr
 	}
 
 	// Output handoff content if present
-	outputHandoffContent(ctx)
+	add("handoff", primePriorityHandoff, func() { outputHandoffContent(ctx) })
 
 	// Output attachment status (for autonomous work detection)
-	outputAttachmentStatus(ctx)
+	add("attachment", primePriorityWork, func() { outputAttachmentStatus(ctx) })
 
 	// Check for slung work on hook (from gt sling)
 	// If found, we're in autonomous mode - skip normal startup directive
-	hasSlungWork := checkSlungWork(ctx)
+	var hasSlungWork bool
+	add("hook", primePriorityRequired, func() { hasSlungWork = checkSlungWork(ctx) })
 
 	// Output molecule context if working on a molecule step
-	outputMoleculeContext(ctx)
+	add("molecule", primePriorityMolecule, func() { outputMoleculeContext(ctx) })
 
 	// Output previous session checkpoint for crash recovery
-	outputCheckpointContext(ctx)
+	add("checkpoint", primePriorityWork, func() { outputCheckpointContext(ctx) })
+
+	// Output rig knowledge relevant to a polecat's hooked work
+	add("knowledge", primePriorityKnowledge, func() { outputKnowledgeContext(ctx) })
 
 	// Run bd prime to output beads workflow context
-	runBdPrime(cwd)
+	add("beads", primePriorityWorkflow, func() { runBdPrime(cwd) })
 
 	// Run gt mail check --inject to inject any pending mail
-	runMailCheckInject(cwd)
+	add("mail", primePriorityMail, func() { runMailCheckInject(cwd) })
 
 	// For Mayor, check for pending escalations
 	if ctx.Role == RoleMayor {
-		checkPendingEscalations(ctx)
+		add("escalations", primePriorityWork, func() { checkPendingEscalations(ctx) })
 	}
 
 	// Output startup directive for roles that should announce themselves
 	// Skip if in autonomous mode (slung work provides its own directive)
 	if !hasSlungWork {
-		outputStartupDirective(ctx)
+		add("startup", primePriorityRequired, func() { outputStartupDirective(ctx) })
 	}
 
-	return nil
+	return sections, nil
 }
 
 func detectRole(cwd, townRoot string) RoleInfo {
@@ -1465,8 +1549,8 @@ func checkPendingEscalations(ctx RoleContext) {
 	}
 	fmt.Println()
 
-	fmt.Println("**Action required:** Review escalations with `bd list --tag=escalation`")
-	fmt.Println("Close resolved ones with `bd close <id> --reason \"resolution\"`")
+	fmt.Println("**Action required:** Review escalations with `gt escalate list`")
+	fmt.Println("Answer decisions with `gt escalate answer <id> <option>`, or close with `gt escalate close <id> --reason \"resolution\"`")
 	fmt.Println()
 }
 
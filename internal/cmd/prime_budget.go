@@ -0,0 +1,205 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Prime section priorities. When the assembled context is over budget,
+// sections are cut starting from the highest number; required sections
+// (role context, hooked work, startup directive) are never cut.
+const (
+	primePriorityRequired  = iota // Role template, hooked work, startup directive
+	primePriorityWork             // Attachment, checkpoint, escalations
+	primePriorityHandoff          // Previous session's handoff
+	primePriorityMolecule         // Molecule and patrol progress
+	primePriorityKnowledge        // Rig knowledge notes
+	primePriorityWorkflow         // bd prime output
+	primePriorityMail             // Injected mail summary
+)
+
+// primeMinSectionTokens is the smallest useful remainder of a truncated
+// section; anything smaller is dropped outright.
+const primeMinSectionTokens = 100
+
+// primeSection is one block of gt prime output.
+type primeSection struct {
+	Name     string
+	Priority int
+	Content  string
+}
+
+// primeFit is how a section fared against the budget.
+type primeFit struct {
+	primeSection
+	Tokens     int    // Estimated tokens before fitting
+	KeptTokens int    // Estimated tokens after fitting
+	Status     string // kept, truncated or dropped
+}
+
+// estimateTokens approximates the token count of s (about four characters
+// per token for English text and code).
+func estimateTokens(s string) int {
+	return (len(s) + 3) / 4
+}
+
+// capturePrimeOutput runs fn with os.Stdout redirected and returns what it
+// printed. The prime output helpers print directly, so sections are
+// collected this way rather than threading a writer through all of them.
+func capturePrimeOutput(fn func()) string {
+	r, w, err := os.Pipe()
+	if err != nil {
+		fn()
+		return ""
+	}
+	orig := os.Stdout
+	os.Stdout = w
+
+	done := make(chan string)
+	go func() {
+		var buf bytes.Buffer
+		_, _ = io.Copy(&buf, r)
+		done <- buf.String()
+	}()
+
+	defer func() {
+		os.Stdout = orig
+	}()
+	fn()
+	_ = w.Close()
+	out := <-done
+	_ = r.Close()
+	return out
+}
+
+// fitPrimeSections trims sections to fit budget tokens (0 = unlimited).
+// The least important sections are cut first: truncated to what still fits
+// or, if too little would remain, dropped. Output order is preserved.
+func fitPrimeSections(sections []primeSection, budget int) []primeFit {
+	fits := make([]primeFit, len(sections))
+	total := 0
+	for i, s := range sections {
+		tokens := estimateTokens(s.Content)
+		fits[i] = primeFit{primeSection: s, Tokens: tokens, KeptTokens: tokens, Status: "kept"}
+		total += tokens
+	}
+	if budget <= 0 || total <= budget {
+		return fits
+	}
+
+	// Cut from the least important section; later sections first on ties.
+	order := make([]int, len(fits))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		pa, pb := fits[order[a]].Priority, fits[order[b]].Priority
+		if pa != pb {
+			return pa > pb
+		}
+		return order[a] > order[b]
+	})
+
+	over := total - budget
+	for _, i := range order {
+		if over <= 0 {
+			break
+		}
+		f := &fits[i]
+		if f.Priority == primePriorityRequired {
+			continue
+		}
+
+		keep := f.Tokens - over
+		if keep < primeMinSectionTokens {
+			over -= f.Tokens
+			f.Content = ""
+			f.KeptTokens = 0
+			f.Status = "dropped"
+			continue
+		}
+		f.Content = truncatePrimeSection(f.Name, f.Content, keep)
+		f.KeptTokens = estimateTokens(f.Content)
+		over -= f.Tokens - f.KeptTokens
+		f.Status = "truncated"
+	}
+	return fits
+}
+
+// truncatePrimeSection keeps whole lines from the top of content within
+// about tokens, ending with a note on how to read the rest.
+func truncatePrimeSection(name, content string, tokens int) string {
+	note := fmt.Sprintf("[... %s truncated to fit the context budget; run `gt prime --section %s` for the full text]\n", name, name)
+	limit := tokens*4 - len(note)
+
+	var b strings.Builder
+	for _, line := range strings.SplitAfter(content, "\n") {
+		if b.Len()+len(line) > limit {
+			break
+		}
+		b.WriteString(line)
+	}
+	if b.Len() > 0 && !strings.HasSuffix(b.String(), "\n") {
+		b.WriteString("\n")
+	}
+	b.WriteString(note)
+	return b.String()
+}
+
+// printPrimeBudgetNote tells the agent which sections were cut, so it
+// knows to fetch them if they turn out to matter.
+func printPrimeBudgetNote(fits []primeFit, budget int, role Role) {
+	var cut []primeFit
+	for _, f := range fits {
+		if f.Status != "kept" {
+			cut = append(cut, f)
+		}
+	}
+	if len(cut) == 0 {
+		return
+	}
+
+	fmt.Println()
+	fmt.Println("## Context Budget")
+	fmt.Printf("Some startup context was cut to fit the %d-token budget for %s:\n", budget, role)
+	for _, f := range cut {
+		if f.Status == "dropped" {
+			fmt.Printf("  - %s: dropped (~%d tokens)\n", f.Name, f.Tokens)
+		} else {
+			fmt.Printf("  - %s: truncated (~%d of %d tokens kept)\n", f.Name, f.KeptTokens, f.Tokens)
+		}
+	}
+	fmt.Println("Run `gt prime --section <name>` to read a section in full.")
+}
+
+// printPrimeExplain reports section sizes and what the budget would cut.
+func printPrimeExplain(fits []primeFit, budget int, role Role) {
+	total, kept := 0, 0
+	for _, f := range fits {
+		total += f.Tokens
+		kept += f.KeptTokens
+	}
+
+	budgetDesc := "unlimited"
+	if budget > 0 {
+		budgetDesc = fmt.Sprintf("%d tokens", budget)
+	}
+	fmt.Printf("Prime context for %s (budget: %s)\n\n", role, budgetDesc)
+	fmt.Printf("  %-12s %-9s %8s %8s  %s\n", "SECTION", "PRIORITY", "TOKENS", "KEPT", "STATUS")
+	for _, f := range fits {
+		priority := fmt.Sprintf("%d", f.Priority)
+		if f.Priority == primePriorityRequired {
+			priority = "required"
+		}
+		fmt.Printf("  %-12s %-9s %8d %8d  %s\n", f.Name, priority, f.Tokens, f.KeptTokens, f.Status)
+	}
+	fmt.Printf("\n  %-12s %-9s %8d %8d\n", "total", "", total, kept)
+	if budget > 0 && kept > budget {
+		fmt.Printf("\nRequired sections alone exceed the budget by ~%d tokens.\n", kept-budget)
+	}
+	fmt.Println("\nToken counts are estimates (~4 characters per token).")
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func primeTestContent(tokens int) string {
+	line := strings.Repeat("x", 39) + "\n" // 10 tokens
+	return strings.Repeat(line, tokens/10)
+}
+
+func TestFitPrimeSections_UnderBudget(t *testing.T) {
+	sections := []primeSection{
+		{Name: "role", Priority: primePriorityRequired, Content: primeTestContent(500)},
+		{Name: "mail", Priority: primePriorityMail, Content: primeTestContent(200)},
+	}
+	for _, budget := range []int{0, 700, 1000} {
+		for _, f := range fitPrimeSections(sections, budget) {
+			if f.Status != "kept" || f.KeptTokens != f.Tokens {
+				t.Errorf("budget %d: %s = %s (%d of %d)", budget, f.Name, f.Status, f.KeptTokens, f.Tokens)
+			}
+		}
+	}
+}
+
+func TestFitPrimeSections_CutsLeastImportantFirst(t *testing.T) {
+	sections := []primeSection{
+		{Name: "role", Priority: primePriorityRequired, Content: primeTestContent(1000)},
+		{Name: "handoff", Priority: primePriorityHandoff, Content: primeTestContent(500)},
+		{Name: "beads", Priority: primePriorityWorkflow, Content: primeTestContent(600)},
+		{Name: "mail", Priority: primePriorityMail, Content: primeTestContent(300)},
+		{Name: "startup", Priority: primePriorityRequired, Content: primeTestContent(100)},
+	}
+
+	// 2500 tokens against 1900: mail (300) goes entirely, then beads is
+	// truncated by the remaining 300; handoff is untouched.
+	fits := fitPrimeSections(sections, 1900)
+	want := map[string]string{
+		"role":    "kept",
+		"handoff": "kept",
+		"beads":   "truncated",
+		"mail":    "dropped",
+		"startup": "kept",
+	}
+	total := 0
+	for i, f := range fits {
+		if f.Name != sections[i].Name {
+			t.Fatalf("fits[%d] = %s, want %s (order must be preserved)", i, f.Name, sections[i].Name)
+		}
+		if f.Status != want[f.Name] {
+			t.Errorf("%s: status = %s, want %s", f.Name, f.Status, want[f.Name])
+		}
+		total += f.KeptTokens
+	}
+	if total > 1900 {
+		t.Errorf("kept %d tokens, want <= 1900", total)
+	}
+	if !strings.Contains(fits[2].Content, "gt prime --section beads") {
+		t.Errorf("truncated beads section lacks a pointer to the full text:\n%s", fits[2].Content)
+	}
+}
+
+func TestFitPrimeSections_NeverCutsRequired(t *testing.T) {
+	sections := []primeSection{
+		{Name: "role", Priority: primePriorityRequired, Content: primeTestContent(2000)},
+		{Name: "mail", Priority: primePriorityMail, Content: primeTestContent(50)},
+	}
+	fits := fitPrimeSections(sections, 1000)
+	if fits[0].Status != "kept" || fits[0].Content != sections[0].Content {
+		t.Errorf("required section was cut: %s", fits[0].Status)
+	}
+	if fits[1].Status != "dropped" || fits[1].Content != "" {
+		t.Errorf("mail = %s, want dropped", fits[1].Status)
+	}
+}
+
+func TestTruncatePrimeSection(t *testing.T) {
+	content := primeTestContent(1000)
+	got := truncatePrimeSection("handoff", content, 300)
+	if tokens := estimateTokens(got); tokens > 300 {
+		t.Errorf("truncated to %d tokens, want <= 300", tokens)
+	}
+	body := strings.TrimSuffix(got, got[strings.LastIndex(got[:len(got)-1], "\n")+1:])
+	if !strings.HasPrefix(content, body) {
+		t.Error("truncation should keep whole lines from the top")
+	}
+	if !strings.HasSuffix(got, "run `gt prime --section handoff` for the full text]\n") {
+		t.Errorf("missing truncation note: %q", got[len(got)-100:])
+	}
+}
@@ -1,11 +1,16 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/mrqueue"
 	"github.com/steveyegge/gastown/internal/refinery"
 	"github.com/steveyegge/gastown/internal/rig"
@@ -205,6 +210,81 @@ Examples:
 
 var refineryBlockedJSON bool
 
+var refineryVerifyCmd = &cobra.Command{
+	Use:   "verify [rig]",
+	Short: "Check the target branch still passes after merges",
+	Long: `Run the rig's test command on the target branch's head.
+
+If it fails, bisects the recent merges recorded in the merge queue event
+log, reverts the merge that broke the target, reopens the MR's source
+issue with the failure log, and sends MERGE_FAILED to the witness and the
+polecat. Tests run in a temporary clone of the rig's repo, so the
+refinery's worktree is left alone.
+
+The refinery patrol runs 'gt refinery verify --if-enabled' after every
+merge; --if-enabled does nothing unless merge_queue.post_merge_check is set
+in the rig's config.json.
+
+Exits non-zero if the target fails, whether or not it was reverted.
+
+Examples:
+  gt refinery verify
+  gt refinery verify greenplace --target develop
+  gt refinery verify --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runRefineryVerify,
+}
+
+var refineryTestCmd = &cobra.Command{
+	Use:   "test <mr-id>",
+	Short: "Run the rig's tests for an MR in the current checkout",
+	Long: `Run the rig's merge_queue.test_command in the current directory for an MR.
+
+When the test results can be parsed (merge_queue.test_results), failing
+tests are rerun on their own up to retry_flaky_tests times, and every
+outcome is recorded in the rig's test history. Tests that keep flaking
+are quarantined and get a bug bead. With allow_quarantined_failures set, a run whose only failures are
+quarantined tests passes. 'gt mq status <mr-id>' shows the result.
+
+Without parsable results, the whole command is retried instead.
+
+Run it from the checkout holding the rebased MR branch. Exits non-zero if
+the tests fail.
+
+Examples:
+  gt refinery test gt-abc123
+  gt refinery test gt-abc123 --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRefineryTest,
+}
+
+var refineryTestJSON bool
+
+var refineryRestackCmd = &cobra.Command{
+	Use:   "restack <mr-id>",
+	Short: "Move MRs stacked on a merged MR onto its target",
+	Long: `Move the MRs stacked on a merged MR onto the merged MR's target.
+
+Each child MR takes over its parent's target and parent, and its branch is
+rebased so it carries only its own commits, then force-pushed. A child
+whose rebase conflicts gets a conflict-resolution task, like any other
+conflicting MR.
+
+The refinery patrol runs this after every merge, before deleting the
+merged branch. Run it from the refinery's checkout.
+
+Examples:
+  gt refinery restack gt-abc123`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRefineryRestack,
+}
+
+var (
+	refineryVerifyTarget    string
+	refineryVerifyJSON      bool
+	refineryVerifyIfEnabled bool
+)
+
 func init() {
 	// Start flags
 	refineryStartCmd.Flags().BoolVar(&refineryForeground, "foreground", false, "Run in foreground (default: background)")
@@ -224,6 +304,14 @@ func init() {
 	// Blocked flags
 	refineryBlockedCmd.Flags().BoolVar(&refineryBlockedJSON, "json", false, "Output as JSON")
 
+	// Test flags
+	refineryTestCmd.Flags().BoolVar(&refineryTestJSON, "json", false, "Output as JSON")
+
+	// Verify flags
+	refineryVerifyCmd.Flags().StringVar(&refineryVerifyTarget, "target", "", "Branch to verify (default: rig's target branch)")
+	refineryVerifyCmd.Flags().BoolVar(&refineryVerifyJSON, "json", false, "Output as JSON")
+	refineryVerifyCmd.Flags().BoolVar(&refineryVerifyIfEnabled, "if-enabled", false, "Do nothing unless merge_queue.post_merge_check is set")
+
 	// Add subcommands
 	refineryCmd.AddCommand(refineryStartCmd)
 	refineryCmd.AddCommand(refineryStopCmd)
@@ -236,6 +324,9 @@ func init() {
 	refineryCmd.AddCommand(refineryUnclaimedCmd)
 	refineryCmd.AddCommand(refineryReadyCmd)
 	refineryCmd.AddCommand(refineryBlockedCmd)
+	refineryCmd.AddCommand(refineryTestCmd)
+	refineryCmd.AddCommand(refineryRestackCmd)
+	refineryCmd.AddCommand(refineryVerifyCmd)
 
 	rootCmd.AddCommand(refineryCmd)
 }
@@ -703,7 +794,194 @@ func runRefineryBlocked(cmd *cobra.Command, args []string) error {
 		if mr.BlockedBy != "" {
 			fmt.Printf("     Blocked by: %s\n", mr.BlockedBy)
 		}
+		if mr.ParentMR != "" {
+			fmt.Printf("     Stacked on: %s\n", mr.ParentMR)
+		}
+	}
+
+	return nil
+}
+
+// RefineryTestOutput is the JSON output of gt refinery test.
+type RefineryTestOutput struct {
+	MR     string         `json:"mr"`
+	Passed bool           `json:"passed"`
+	Error  string         `json:"error,omitempty"`
+	Tests  *MRTestsOutput `json:"tests,omitempty"`
+}
+
+func runRefineryTest(cmd *cobra.Command, args []string) error {
+	mrID := args[0]
+
+	_, r, rigName, err := getRefineryManager("")
+	if err != nil {
+		return err
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting current directory: %w", err)
+	}
+
+	eng := refinery.NewEngineer(r)
+	if err := eng.LoadConfig(); err != nil {
+		return fmt.Errorf("loading merge queue config: %w", err)
+	}
+	if eng.Config().TestCommand == "" {
+		return fmt.Errorf("no merge_queue.test_command configured for rig %s", r.Name)
+	}
+	eng.SetWorkDir(cwd)
+	if refineryTestJSON {
+		eng.SetOutput(io.Discard)
+	}
+
+	result := eng.RunTests(context.Background(), mrID)
+	output := RefineryTestOutput{
+		MR:     mrID,
+		Passed: result.Success,
+		Error:  result.Error,
+	}
+	if result.Tests != nil {
+		output.Tests = loadMRTests(rigName, mrID)
+	}
+
+	if refineryTestJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(output); err != nil {
+			return err
+		}
+	} else {
+		fmt.Println()
+		switch {
+		case output.Tests != nil:
+			printMRTests(output.Tests)
+		case result.Success:
+			fmt.Printf("%s tests passed\n", style.Success.Render("✓"))
+		}
+		if !result.Success {
+			fmt.Printf("%s %s\n", style.Error.Render("✗"), result.Error)
+		}
+	}
+
+	if !result.Success {
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		return NewSilentExit(1)
 	}
+	return nil
+}
 
+func runRefineryVerify(cmd *cobra.Command, args []string) error {
+	rigName := ""
+	if len(args) > 0 {
+		rigName = args[0]
+	}
+
+	_, r, _, err := getRefineryManager(rigName)
+	if err != nil {
+		return err
+	}
+
+	eng := refinery.NewEngineer(r)
+	if err := eng.LoadConfig(); err != nil {
+		return fmt.Errorf("loading merge queue config: %w", err)
+	}
+	if refineryVerifyIfEnabled && !eng.Config().PostMergeCheck {
+		if !refineryVerifyJSON {
+			fmt.Printf("%s\n", style.Dim.Render("merge_queue.post_merge_check is not set; skipping"))
+		}
+		return nil
+	}
+	if eng.Config().TestCommand == "" {
+		return fmt.Errorf("no merge_queue.test_command configured for rig %s", r.Name)
+	}
+	if r.GitURL == "" {
+		return fmt.Errorf("rig %s has no git_url", r.Name)
+	}
+	if refineryVerifyJSON {
+		eng.SetOutput(io.Discard)
+	}
+
+	// The bisect checks out old merges, and the target may be checked out
+	// in the refinery's worktree, so verify in a clone of our own.
+	tmpDir, err := os.MkdirTemp("", "gt-verify-")
+	if err != nil {
+		return fmt.Errorf("creating temp dir: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+	checkout := filepath.Join(tmpDir, r.Name)
+	reference := filepath.Join(r.Path, ".repo.git") // Shared with the rig's worktrees
+	if err := git.NewGit(tmpDir).CloneWithReference(r.GitURL, checkout, reference); err != nil {
+		return fmt.Errorf("cloning %s: %w", r.GitURL, err)
+	}
+	eng.SetWorkDir(checkout)
+
+	result := eng.VerifyTarget(context.Background(), refineryVerifyTarget)
+
+	if refineryVerifyJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(result); err != nil {
+			return err
+		}
+	} else {
+		printPostMergeResult(result)
+	}
+
+	if !result.Passed {
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		return NewSilentExit(1)
+	}
+	return nil
+}
+
+func printPostMergeResult(result *refinery.PostMergeResult) {
+	fmt.Println()
+	switch {
+	case result.Passed:
+		fmt.Printf("%s %s passes\n", style.Success.Render("✓"), result.Target)
+	case result.RevertCommit != "":
+		fmt.Printf("%s %s failed; reverted %s (%s) in %s\n",
+			style.Warning.Render("↩"), result.Target, result.Culprit.MRID,
+			result.Culprit.Branch, shortCommit(result.RevertCommit))
+		if result.Reopened {
+			fmt.Printf("  Reopened %s\n", result.Culprit.SourceIssue)
+		}
+		if len(result.Notified) > 0 {
+			fmt.Printf("  Notified %s\n", strings.Join(result.Notified, ", "))
+		}
+	default:
+		fmt.Printf("%s %s failed: %s\n", style.Error.Render("✗"), result.Target, result.Error)
+	}
+	if !result.Passed && result.Log != "" {
+		fmt.Println()
+		for _, line := range strings.Split(strings.TrimRight(result.Log, "\n"), "\n") {
+			fmt.Printf("  %s\n", style.Dim.Render(line))
+		}
+	}
+}
+
+func runRefineryRestack(cmd *cobra.Command, args []string) error {
+	mrID := args[0]
+
+	_, r, _, err := getRefineryManager("")
+	if err != nil {
+		return err
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting current directory: %w", err)
+	}
+
+	eng := refinery.NewEngineer(r)
+	eng.SetWorkDir(cwd)
+	children, err := eng.Restack(mrID)
+	if err != nil {
+		return err
+	}
+	if len(children) == 0 {
+		fmt.Printf("%s\n", style.Dim.Render("No MRs stacked on "+mrID))
+	}
 	return nil
 }
@@ -13,17 +13,21 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/search"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
 var (
-	seanceRole   string
-	seanceRig    string
-	seanceRecent int
-	seanceTalk   string
-	seancePrompt string
-	seanceJSON   bool
+	seanceRole    string
+	seanceRig     string
+	seanceRecent  int
+	seanceTalk    string
+	seancePrompt  string
+	seanceJSON    bool
+	seanceSearch  string
+	seanceKinds   []string
+	seanceReindex bool
 )
 
 var seanceCmd = &cobra.Command{
@@ -46,6 +50,16 @@ DISCOVERY:
   gt seance --rig gastown       # Filter by rig
   gt seance --recent 10         # Last N sessions
 
+SEARCH (find the right predecessor):
+  gt seance --search "auth retry logic"             # Rank sessions, mail, beads, handoffs
+  gt seance --search "backoff" --kind session,handoff
+  gt seance --search "flaky sync" --reindex         # Rebuild the index first
+
+Search uses an offline BM25 index over session transcripts, mail bodies,
+bead descriptions and handoff notes (~/gt/.runtime/search-index.json).
+Only sources that changed since the last search are re-read. Each hit
+links to its session, bead and convoy.
+
 THE SEANCE (talk to predecessor):
   gt seance --talk <session-id>              # Interactive conversation
   gt seance --talk <id> -p "Where is X?"     # One-shot question
@@ -62,10 +76,13 @@ Sessions are discovered from:
 func init() {
 	seanceCmd.Flags().StringVar(&seanceRole, "role", "", "Filter by role (crew, polecat, witness, etc.)")
 	seanceCmd.Flags().StringVar(&seanceRig, "rig", "", "Filter by rig name")
-	seanceCmd.Flags().IntVarP(&seanceRecent, "recent", "n", 20, "Number of recent sessions to show")
+	seanceCmd.Flags().IntVarP(&seanceRecent, "recent", "n", 20, "Number of recent sessions (or search hits) to show")
 	seanceCmd.Flags().StringVarP(&seanceTalk, "talk", "t", "", "Session ID to commune with")
 	seanceCmd.Flags().StringVarP(&seancePrompt, "prompt", "p", "", "One-shot prompt (with --talk)")
 	seanceCmd.Flags().BoolVar(&seanceJSON, "json", false, "Output as JSON")
+	seanceCmd.Flags().StringVarP(&seanceSearch, "search", "s", "", "Search sessions, mail, beads and handoffs")
+	seanceCmd.Flags().StringSliceVar(&seanceKinds, "kind", nil, "Limit --search to kinds (session, mail, bead, handoff)")
+	seanceCmd.Flags().BoolVar(&seanceReindex, "reindex", false, "Rebuild the search index from scratch")
 
 	rootCmd.AddCommand(seanceCmd)
 }
@@ -84,10 +101,126 @@ func runSeance(cmd *cobra.Command, args []string) error {
 		return runSeanceTalk(seanceTalk, seancePrompt)
 	}
 
+	if seanceSearch != "" {
+		return runSeanceSearch(seanceSearch)
+	}
+
 	// Otherwise, list discoverable sessions
 	return runSeanceList()
 }
 
+// seanceSearchItem is a search hit in JSON output.
+type seanceSearchItem struct {
+	Kind    string    `json:"kind"`
+	ID      string    `json:"id"`
+	Title   string    `json:"title"`
+	Actor   string    `json:"actor,omitempty"`
+	Time    time.Time `json:"time,omitempty"`
+	Session string    `json:"session,omitempty"`
+	Bead    string    `json:"bead,omitempty"`
+	Convoy  string    `json:"convoy,omitempty"`
+	Thread  string    `json:"thread,omitempty"`
+	Excerpt string    `json:"excerpt,omitempty"`
+	Score   float64   `json:"score"`
+}
+
+func runSeanceSearch(query string) error {
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil || townRoot == "" {
+		return fmt.Errorf("not in a Gas Town workspace")
+	}
+	for _, kind := range seanceKinds {
+		switch kind {
+		case search.KindSession, search.KindMail, search.KindBead, search.KindHandoff:
+		default:
+			return fmt.Errorf("unknown kind %q (want session, mail, bead or handoff)", kind)
+		}
+	}
+
+	idx, err := search.Update(townRoot, seanceReindex)
+	if err != nil {
+		return fmt.Errorf("updating search index: %w", err)
+	}
+
+	var hits []search.Hit
+	for _, h := range idx.Search(search.Query{Text: query, Kinds: seanceKinds}) {
+		actor := strings.ToLower(h.Actor)
+		if seanceRole != "" && !strings.Contains(actor, strings.ToLower(seanceRole)) {
+			continue
+		}
+		if seanceRig != "" && !strings.Contains(actor, strings.ToLower(seanceRig)) {
+			continue
+		}
+		hits = append(hits, h)
+		if seanceRecent > 0 && len(hits) == seanceRecent {
+			break
+		}
+	}
+
+	if seanceJSON {
+		items := make([]seanceSearchItem, 0, len(hits))
+		for _, h := range hits {
+			items = append(items, seanceSearchItem{
+				Kind: h.Kind, ID: h.ID, Title: h.Title, Actor: h.Actor, Time: h.Time,
+				Session: h.Session, Bead: h.Bead, Convoy: h.Convoy, Thread: h.Thread,
+				Excerpt: h.Excerpt, Score: h.Score,
+			})
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(items)
+	}
+
+	fmt.Printf("%s Search results for %q: %d hit(s) in %d indexed item(s)\n\n",
+		style.Bold.Render("🔍"), query, len(hits), len(idx.Docs))
+	if len(hits) == 0 {
+		fmt.Printf("  %s\n", style.Dim.Render("(no matches)"))
+		return nil
+	}
+
+	for i, h := range hits {
+		when := "-"
+		if !h.Time.IsZero() {
+			when = h.Time.Local().Format("2006-01-02 15:04")
+		}
+		fmt.Printf("%2d. %s %s  %s\n", i+1, style.Bold.Render("["+h.Kind+"]"), h.Title,
+			style.Dim.Render(fmt.Sprintf("%.1f", h.Score)))
+		meta := []string{when}
+		if h.Actor != "" {
+			meta = append(meta, h.Actor)
+		}
+		fmt.Printf("    %s\n", style.Dim.Render(strings.Join(meta, " • ")))
+		if h.Excerpt != "" {
+			fmt.Printf("    %s\n", h.Excerpt)
+		}
+
+		var links []string
+		if h.Session != "" {
+			links = append(links, "session "+h.Session)
+		}
+		if h.Kind != search.KindSession && h.Kind != search.KindBead {
+			links = append(links, h.ID)
+		}
+		if h.Bead != "" {
+			links = append(links, "bead "+h.Bead)
+		}
+		if h.Convoy != "" && h.Convoy != h.Bead {
+			links = append(links, "convoy "+h.Convoy)
+		}
+		if h.Thread != "" {
+			links = append(links, "thread "+h.Thread)
+		}
+		if len(links) > 0 {
+			fmt.Printf("    %s\n", style.Dim.Render("→ "+strings.Join(links, " • ")))
+		}
+		if h.Session != "" {
+			fmt.Printf("    %s\n", style.Dim.Render("gt seance --talk "+h.Session))
+		}
+		fmt.Println()
+	}
+	return nil
+}
+
 func runSeanceList() error {
 	townRoot, err := workspace.FindFromCwd()
 	if err != nil || townRoot == "" {
@@ -572,6 +572,13 @@ func runSessionStatus(cmd *cobra.Command, args []string) error {
 		fmt.Printf("  Uptime: %s\n", formatDuration(uptime))
 	}
 
+	if info.Resources != nil {
+		fmt.Printf("  Resources: %s\n", info.Resources)
+		for _, breach := range info.Resources.Breaches(0) {
+			fmt.Printf("  %s\n", style.Warning.Render("⚠ "+breach))
+		}
+	}
+
 	fmt.Printf("\nAttach with: %s\n", style.Dim.Render(fmt.Sprintf("gt session at %s/%s", rigName, polecatName)))
 	return nil
 }
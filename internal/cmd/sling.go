@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/base32"
 	"encoding/json"
@@ -18,6 +19,7 @@ import (
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/tracing"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
@@ -51,6 +53,16 @@ Target Resolution:
   gt sling gt-abc mayor                 # Mayor
   gt sling gt-abc deacon/dogs           # Auto-dispatch to idle dog
   gt sling gt-abc deacon/dogs/alpha     # Specific dog
+  gt sling gt-abc --auto                # Best rig/role for the bead's needs
+
+Capability Routing (--auto):
+  Beads declare requirements with needs:<capability> labels (needs:go,
+  needs:docker, needs:runtime:gemini). Rigs declare capabilities in their
+  settings ("capabilities"), plus runtime:<agent> from their agent preset;
+  crew and dogs can add their own (gt agents capabilities). --auto picks a
+  fresh polecat in the rig that owns the bead's prefix when it qualifies,
+  then polecats elsewhere or dogs, and crew only as a last resort or when
+  the bead is labeled role:crew. It prints every candidate and why.
 
 Spawning Options (when target is a rig):
   gt sling gp-abc greenplace --molecule mol-review  # Use specific workflow
@@ -111,6 +123,7 @@ var (
 	slingAccount  string // --account: Claude Code account handle to use
 	slingQuality  string // --quality: shorthand for polecat workflow (basic|shiny|chrome)
 	slingNoConvoy bool   // --no-convoy: skip auto-convoy creation
+	slingAuto     bool   // --auto: pick the target with the capability router
 )
 
 func init() {
@@ -129,11 +142,12 @@ func init() {
 	slingCmd.Flags().StringVar(&slingAccount, "account", "", "Claude Code account handle to use")
 	slingCmd.Flags().StringVarP(&slingQuality, "quality", "q", "", "Polecat workflow quality level (basic|shiny|chrome)")
 	slingCmd.Flags().BoolVar(&slingNoConvoy, "no-convoy", false, "Skip auto-convoy creation for single-issue sling")
+	slingCmd.Flags().BoolVar(&slingAuto, "auto", false, "Pick the rig and role whose capabilities cover the bead's needs: labels")
 
 	rootCmd.AddCommand(slingCmd)
 }
 
-func runSling(cmd *cobra.Command, args []string) error {
+func runSling(cmd *cobra.Command, args []string) (err error) {
 	// Polecats cannot sling - check early before writing anything
 	if polecatName := os.Getenv("GT_POLECAT"); polecatName != "" {
 		return fmt.Errorf("polecats cannot sling (use gt done for handoff)")
@@ -212,6 +226,30 @@ func runSling(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Root span for this bead's journey to merge. The polecat session and
+	// its MR inherit the trace via TRACEPARENT.
+	_, span := tracing.Start(context.Background(), "gt.sling")
+	span.SetAttr(tracing.AttrBead, beadID)
+	defer func() {
+		span.SetError(err)
+		span.End()
+	}()
+
+	// --auto: let the capability router pick the target
+	if slingAuto {
+		if len(args) > 1 {
+			return fmt.Errorf("--auto picks the target; don't name one")
+		}
+		if formulaName != "" {
+			return fmt.Errorf("--auto cannot be used with --on or --quality")
+		}
+		target, err := routeSlingTarget(townRoot, beadID)
+		if err != nil {
+			return err
+		}
+		args = append(args, target)
+	}
+
 	// Determine target agent (self or specified)
 	var targetAgent string
 	var targetPane string
@@ -265,8 +303,9 @@ func runSling(cmd *cobra.Command, args []string) error {
 					Force:    slingForce,
 					Naked:    slingNaked,
 					Account:  slingAccount,
-					Create:   slingCreate,
-					HookBead: beadID, // Set atomically at spawn time
+					Create:      slingCreate,
+					HookBead:    beadID, // Set atomically at spawn time
+					TraceParent: span.TraceParent(),
 				}
 				spawnInfo, spawnErr := SpawnPolecatForSling(rigName, spawnOpts)
 				if spawnErr != nil {
@@ -339,11 +378,13 @@ func runSling(cmd *cobra.Command, args []string) error {
 					// Log warning but don't fail - convoy is optional
 					fmt.Printf("%s Could not create auto-convoy: %v\n", style.Dim.Render("Warning:"), err)
 				} else {
+					span.SetAttr(tracing.AttrConvoy, convoyID)
 					fmt.Printf("%s Created convoy 🚚 %s\n", style.Bold.Render("→"), convoyID)
 					fmt.Printf("  Tracking: %s\n", beadID)
 				}
 			}
 		} else {
+			span.SetAttr(tracing.AttrConvoy, existingConvoy)
 			fmt.Printf("%s Already tracked by convoy %s\n", style.Dim.Render("○"), existingConvoy)
 		}
 	}
@@ -1276,6 +1317,11 @@ func runBatchSling(beadIDs []string, rigName string, townBeadsDir string) error
 
 	fmt.Printf("%s Batch slinging %d beads to rig '%s'...\n", style.Bold.Render("🎯"), len(beadIDs), rigName)
 
+	// One batch span; each polecat.spawn child carries its own bead ID
+	_, span := tracing.Start(context.Background(), "gt.sling.batch")
+	span.SetAttr(tracing.AttrRig, rigName)
+	defer span.End()
+
 	// Track results for summary
 	type slingResult struct {
 		beadID   string
@@ -1308,8 +1354,9 @@ func runBatchSling(beadIDs []string, rigName string, townBeadsDir string) error
 			Force:    slingForce,
 			Naked:    slingNaked,
 			Account:  slingAccount,
-			Create:   slingCreate,
-			HookBead: beadID, // Set atomically at spawn time
+			Create:      slingCreate,
+			HookBead:    beadID, // Set atomically at spawn time
+			TraceParent: span.TraceParent(),
 		}
 		spawnInfo, err := SpawnPolecatForSling(rigName, spawnOpts)
 		if err != nil {
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/dog"
+	"github.com/steveyegge/gastown/internal/router"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// routeSlingTarget picks the sling target for beadID with the capability
+// router and prints why. Fails when no rig or agent covers the bead's needs.
+func routeSlingTarget(townRoot, beadID string) (string, error) {
+	bd := beads.New(townRoot)
+	issue, err := bd.Show(beadID)
+	if err != nil {
+		return "", fmt.Errorf("reading %s: %w", beadID, err)
+	}
+
+	req := router.Request{
+		IssueID: beadID,
+		Needs:   beads.ParseRequirements(issue),
+		HomeRig: beads.GetRigForIssue(townRoot, beadID),
+		Role:    router.RoleFromLabels(issue.Labels),
+	}
+	candidates, err := routeCandidates(townRoot, bd)
+	if err != nil {
+		return "", err
+	}
+
+	decisions := router.Route(req, candidates)
+	printRouteDecisions(req, decisions)
+
+	best := router.Best(decisions)
+	if best == nil {
+		return "", fmt.Errorf("no rig or agent can take %s", beadID)
+	}
+	return best.Target, nil
+}
+
+// routeCandidates gathers a fresh polecat for every rig, plus existing crew
+// and dogs, with the capabilities they declare.
+func routeCandidates(townRoot string, bd *beads.Beads) ([]router.Candidate, error) {
+	capacity, err := schedulerCapacity(townRoot)
+	if err != nil {
+		return nil, err
+	}
+	rigNames := make([]string, 0, len(capacity))
+	for rigName := range capacity {
+		rigNames = append(rigNames, rigName)
+	}
+	sort.Strings(rigNames)
+
+	var candidates []router.Candidate
+	for _, rigName := range rigNames {
+		rigCaps := config.RigCapabilities(townRoot, rigName)
+		candidates = append(candidates, router.Candidate{
+			Target:       rigName,
+			Rig:          rigName,
+			Role:         router.RolePolecat,
+			Capabilities: rigCaps,
+			Free:         capacity[rigName].Free(),
+		})
+
+		entries, _ := os.ReadDir(filepath.Join(townRoot, rigName, "crew"))
+		prefix := beads.GetPrefixForRig(townRoot, rigName)
+		for _, e := range entries {
+			if !e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+				continue
+			}
+			c := router.Candidate{
+				Target:       fmt.Sprintf("%s/crew/%s", rigName, e.Name()),
+				Rig:          rigName,
+				Role:         router.RoleCrew,
+				Name:         e.Name(),
+				Capabilities: rigCaps,
+			}
+			if agent, err := bd.Show(beads.CrewBeadIDWithPrefix(prefix, rigName, e.Name())); err == nil {
+				c.Capabilities = mergeCapabilities(rigCaps, beads.ParseCapabilities(agent))
+				c.Busy = agent.HookBead
+				if c.Busy == "" {
+					c.Busy = beads.ParseAgentFields(agent.Description).HookBead
+				}
+			}
+			candidates = append(candidates, c)
+		}
+	}
+
+	rigsConfig, err := config.LoadRigsConfig(filepath.Join(townRoot, "mayor", "rigs.json"))
+	if err != nil {
+		return candidates, nil
+	}
+	dogs, err := dog.NewManager(townRoot, rigsConfig).List()
+	if err != nil || len(dogs) == 0 {
+		return candidates, nil
+	}
+	for _, d := range dogs {
+		c := router.Candidate{
+			Target: fmt.Sprintf("deacon/dogs/%s", d.Name),
+			Role:   router.RoleDog,
+			Name:   d.Name,
+		}
+		if d.State == dog.StateWorking {
+			c.Busy = d.Work
+			if c.Busy == "" {
+				c.Busy = "other work"
+			}
+		}
+		if agent, err := bd.FindDogAgentBead(d.Name); err == nil && agent != nil {
+			c.Capabilities = beads.ParseCapabilities(agent)
+		}
+		candidates = append(candidates, c)
+	}
+	return candidates, nil
+}
+
+// mergeCapabilities returns base plus any extra capabilities not already in it.
+func mergeCapabilities(base, extra []string) []string {
+	merged := append([]string(nil), base...)
+	for _, c := range extra {
+		if len(router.Missing([]string{c}, merged)) > 0 {
+			merged = append(merged, c)
+		}
+	}
+	return merged
+}
+
+func printRouteDecisions(req router.Request, decisions []router.Decision) {
+	needs := "nothing"
+	if len(req.Needs) > 0 {
+		needs = strings.Join(req.Needs, ", ")
+	}
+	home := req.HomeRig
+	if home == "" {
+		home = "town"
+	}
+	fmt.Printf("%s Routing %s (needs: %s; home: %s)\n", style.Bold.Render("🧭"), req.IssueID, needs, home)
+
+	for i, d := range decisions {
+		mark := style.Dim.Render("✗")
+		if d.Eligible {
+			mark = style.Success.Render("✓")
+		}
+		if i == 0 && d.Eligible {
+			mark = style.Bold.Render("→")
+		}
+		fmt.Printf("  %s %-28s %-8s %s\n", mark, d.Target, d.Role, style.Dim.Render(strings.Join(d.Reasons, "; ")))
+	}
+}
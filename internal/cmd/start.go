@@ -9,11 +9,13 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/account"
 	"github.com/steveyegge/gastown/internal/claude"
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
 	"github.com/steveyegge/gastown/internal/crew"
 	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/limits"
 	"github.com/steveyegge/gastown/internal/polecat"
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/session"
@@ -345,7 +347,9 @@ func ensureRefinerySession(rigName string, r *rig.Rig) (bool, error) {
 
 	// Launch Claude directly (no respawn loop - daemon handles restart)
 	// Export GT_ROLE and BD_ACTOR in the command since tmux SetEnvironment only affects new panes
-	if err := t.SendKeys(sessionName, config.BuildAgentStartupCommand("refinery", bdActor, "", "")); err != nil {
+	startCmd := limits.WrapCommand(filepath.Dir(r.Path), rigName, "refinery", sessionName,
+		config.BuildAgentStartupCommand("refinery", bdActor, "", ""))
+	if err := t.SendKeys(sessionName, startCmd); err != nil {
 		return false, fmt.Errorf("sending command: %w", err)
 	}
 
@@ -754,8 +758,7 @@ func runStartCrew(cmd *cobra.Command, args []string) error {
 	ensureDefaultBranch(worker.ClonePath, fmt.Sprintf("Crew workspace %s/%s", rigName, name), r.Path)
 
 	// Resolve account for Claude config
-	accountsPath := constants.MayorAccountsPath(townRoot)
-	claudeConfigDir, accountHandle, err := config.ResolveAccountConfigDir(accountsPath, startCrewAccount)
+	claudeConfigDir, accountHandle, err := account.Resolve(townRoot, startCrewAccount)
 	if err != nil {
 		return fmt.Errorf("resolving account: %w", err)
 	}
@@ -776,10 +779,14 @@ func runStartCrew(cmd *cobra.Command, args []string) error {
 		if !t.IsClaudeRunning(sessionID) {
 			// Claude has exited, restart it
 			fmt.Printf("Session exists, restarting Claude...\n")
-			claudeCmd := config.BuildCrewStartupCommand(rigName, name, r.Path, "")
+			claudeCmd := limits.WrapCommand(townRoot, rigName, "crew", sessionID,
+				account.ExportCommand(claudeConfigDir, config.BuildCrewStartupCommand(rigName, name, r.Path, "")))
 			if err := t.SendKeys(sessionID, claudeCmd); err != nil {
 				return fmt.Errorf("restarting claude: %w", err)
 			}
+			if err := account.Assign(townRoot, sessionID, accountHandle); err != nil {
+				style.PrintWarning("could not record account: %v", err)
+			}
 			// Wait for Claude to start, then prime
 			shells := constants.SupportedShells
 			if err := t.WaitForCommand(sessionID, shells, constants.ClaudeStartTimeout); err != nil {
@@ -818,10 +825,14 @@ func runStartCrew(cmd *cobra.Command, args []string) error {
 		}
 
 		// Start claude with skip permissions and proper env vars for seance
-		claudeCmd := config.BuildCrewStartupCommand(rigName, name, r.Path, "")
+		claudeCmd := limits.WrapCommand(townRoot, rigName, "crew", sessionID,
+			account.ExportCommand(claudeConfigDir, config.BuildCrewStartupCommand(rigName, name, r.Path, "")))
 		if err := t.SendKeys(sessionID, claudeCmd); err != nil {
 			return fmt.Errorf("starting claude: %w", err)
 		}
+		if err := account.Assign(townRoot, sessionID, accountHandle); err != nil {
+			style.PrintWarning("could not record account: %v", err)
+		}
 
 		// Wait for Claude to start
 		shells := constants.SupportedShells
@@ -961,7 +972,8 @@ func startCrewMember(rigName, crewName, townRoot string) error {
 	}
 
 	// Start claude with proper env vars for seance
-	claudeCmd := config.BuildCrewStartupCommand(rigName, crewName, r.Path, "")
+	claudeCmd := limits.WrapCommand(townRoot, rigName, "crew", sessionID,
+		config.BuildCrewStartupCommand(rigName, crewName, r.Path, ""))
 	if err := t.SendKeys(sessionID, claudeCmd); err != nil {
 		return fmt.Errorf("starting claude: %w", err)
 	}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/forecast"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	statsSince string
+	statsRig   string
+	statsJSON  bool
+)
+
+var statsCmd = &cobra.Command{
+	Use:     "stats",
+	GroupID: GroupDiag,
+	Short:   "Show throughput and cycle-time statistics",
+	Long: `Show throughput and cycle-time percentiles by rig and role.
+
+Cycle time runs from when an issue was slung (or created, if never slung)
+to when its work merged (or the worker ran 'gt done', or the issue closed).
+Data comes from the activity feed, each rig's merge queue event log, and
+closed issues in rig beads.
+
+These statistics drive the ETAs in 'gt convoy status' and 'gt convoy list --eta'.
+
+Examples:
+  gt stats                 # Last 14 days
+  gt stats --since 30d
+  gt stats --rig gastown
+  gt stats --json`,
+	RunE: runStats,
+}
+
+func init() {
+	statsCmd.Flags().StringVar(&statsSince, "since", "14d", "History window (e.g. 7d, 48h)")
+	statsCmd.Flags().StringVar(&statsRig, "rig", "", "Only include issues finished in this rig")
+	statsCmd.Flags().BoolVar(&statsJSON, "json", false, "Output as JSON")
+
+	rootCmd.AddCommand(statsCmd)
+}
+
+func runStats(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	window, err := parseDuration(statsSince)
+	if err != nil || window <= 0 {
+		return fmt.Errorf("invalid --since %q: use a duration like 7d or 48h", statsSince)
+	}
+
+	now := time.Now()
+	history := forecast.Load(townRoot, window, now)
+	if statsRig != "" {
+		var samples []forecast.Sample
+		for _, s := range history.Samples {
+			if s.Rig == statsRig {
+				samples = append(samples, s)
+			}
+		}
+		history = forecast.NewHistory(samples, window, now)
+	}
+
+	if statsJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(struct {
+			Window  string                    `json:"window"`
+			Overall forecast.Stats            `json:"overall"`
+			ByRig   map[string]forecast.Stats `json:"by_rig"`
+			ByRole  map[string]forecast.Stats `json:"by_role"`
+		}{statsSince, history.Overall, history.ByRig, history.ByRole})
+	}
+
+	fmt.Printf("%s %s\n\n", style.Bold.Render("📊 Cycle time"), style.Dim.Render("(last "+statsSince+")"))
+	if history.Overall.Count == 0 {
+		fmt.Printf("  %s No finished issues in this window\n", style.Dim.Render("○"))
+		return nil
+	}
+
+	printStatsTable("RIG", history.ByRig)
+	fmt.Println()
+	printStatsTable("ROLE", history.ByRole)
+	fmt.Println()
+	printStatsTable("TOTAL", map[string]forecast.Stats{"all": history.Overall})
+	return nil
+}
+
+// printStatsTable prints one row of statistics per group.
+func printStatsTable(label string, groups map[string]forecast.Stats) {
+	table := style.NewTable(
+		style.Column{Name: label, Width: 16},
+		style.Column{Name: "DONE", Width: 6, Align: style.AlignRight},
+		style.Column{Name: "PER DAY", Width: 8, Align: style.AlignRight},
+		style.Column{Name: "P50", Width: 6, Align: style.AlignRight},
+		style.Column{Name: "P90", Width: 6, Align: style.AlignRight},
+		style.Column{Name: "P95", Width: 6, Align: style.AlignRight},
+	)
+
+	keys := make([]string, 0, len(groups))
+	for k := range groups {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		s := groups[k]
+		table.AddRow(k,
+			fmt.Sprintf("%d", s.Count),
+			fmt.Sprintf("%.1f", s.Throughput),
+			forecast.FormatDuration(s.P50),
+			forecast.FormatDuration(s.P90),
+			forecast.FormatDuration(s.P95))
+	}
+	fmt.Print(table.Render())
+}
+
+// convoyETA forecasts when a convoy's unfinished tracked issues will land,
+// grouping them by the rig their prefix routes to.
+func convoyETA(townRoot string, history *forecast.History, tracked []trackedIssueInfo) forecast.ETA {
+	remaining := make(map[string]int)
+	for _, t := range tracked {
+		if t.Status == "closed" || t.Status == "tombstone" {
+			continue
+		}
+		remaining[beads.GetRigForIssue(townRoot, t.ID)]++
+	}
+	return history.Estimate(remaining)
+}
@@ -0,0 +1,258 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/mrqueue"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/timeline"
+	"github.com/steveyegge/gastown/internal/townlog"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// Timeline command flags
+var (
+	timelineConvoy  string
+	timelineBead    string
+	timelineAgent   string
+	timelineSince   string
+	timelineUntil   string
+	timelineFormat  string
+	timelineOutput  string
+	timelineNoBeads bool
+)
+
+var timelineCmd = &cobra.Command{
+	Use:     "timeline",
+	GroupID: GroupDiag,
+	Short:   "Unified, exportable timeline of town activity",
+	Long: `Merge every Gas Town event source into one time-ordered stream.
+
+Sources:
+  events   .events.jsonl activity feed (sling, hook, done, mail, ...)
+  townlog  logs/town.log agent lifecycle (spawn, crash, kill, ...)
+  mq       <rig>/.beads/mq_events.jsonl merge queue lifecycle
+  beads    issue created/closed times from town and rig beads
+
+All timestamps are normalized to UTC. Filter by convoy (the convoy plus
+its tracked issues), bead, agent prefix, or time window, then export.
+
+Formats:
+  text   Human-readable listing (default)
+  jsonl  One JSON object per line
+  csv    Spreadsheet-friendly, with header row
+  html   Self-contained swimlane view, one lane per agent
+
+Examples:
+  gt timeline --since 12h                             # Last 12 hours
+  gt timeline --convoy hq-cv-abc --since 1d -f html -o convoy.html
+  gt timeline --bead gt-xyz -f jsonl                  # One bead's history
+  gt timeline --agent gastown/polecats/ --since 2025-01-10T18:00:00Z --until 2025-01-11T08:00:00Z
+  gt timeline -f csv -o town.csv                      # Everything, as CSV`,
+	RunE: runTimeline,
+}
+
+func init() {
+	timelineCmd.Flags().StringVar(&timelineConvoy, "convoy", "", "Filter to a convoy and its tracked issues")
+	timelineCmd.Flags().StringVar(&timelineBead, "bead", "", "Filter to a single bead")
+	timelineCmd.Flags().StringVar(&timelineAgent, "agent", "", "Filter by agent prefix (e.g., gastown/, gastown/polecats/nux)")
+	timelineCmd.Flags().StringVar(&timelineSince, "since", "", "Start of window: duration ago (1h, 7d) or RFC3339 time")
+	timelineCmd.Flags().StringVar(&timelineUntil, "until", "", "End of window: duration ago (1h, 7d) or RFC3339 time")
+	timelineCmd.Flags().StringVarP(&timelineFormat, "format", "f", "text", "Output format: text, jsonl, csv, html")
+	timelineCmd.Flags().StringVarP(&timelineOutput, "output", "o", "", "Write to file instead of stdout")
+	timelineCmd.Flags().BoolVar(&timelineNoBeads, "no-beads", false, "Skip beads issue history (faster)")
+
+	rootCmd.AddCommand(timelineCmd)
+}
+
+func runTimeline(cmd *cobra.Command, args []string) error {
+	// Check --format before --output is created, so a typo doesn't
+	// truncate an existing file.
+	switch timelineFormat {
+	case "", "text", timeline.FormatJSONL, timeline.FormatCSV, timeline.FormatHTML:
+	default:
+		return fmt.Errorf("unknown format %q (use text, jsonl, csv, or html)", timelineFormat)
+	}
+
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	filter := timeline.Filter{Agent: timelineAgent}
+	if timelineSince != "" {
+		if filter.Since, err = parseTimelineTime(timelineSince); err != nil {
+			return fmt.Errorf("invalid --since: %w", err)
+		}
+	}
+	if timelineUntil != "" {
+		if filter.Until, err = parseTimelineTime(timelineUntil); err != nil {
+			return fmt.Errorf("invalid --until: %w", err)
+		}
+	}
+	if timelineBead != "" || timelineConvoy != "" {
+		filter.Beads = make(map[string]bool)
+	}
+	if timelineBead != "" {
+		filter.Beads[timelineBead] = true
+	}
+	if timelineConvoy != "" {
+		filter.Beads[timelineConvoy] = true
+		for _, t := range getTrackedIssues(filepath.Join(townRoot, ".beads"), timelineConvoy) {
+			filter.Beads[t.ID] = true
+		}
+	}
+
+	entries := filter.Apply(collectTimeline(townRoot, !timelineNoBeads))
+
+	out := os.Stdout
+	if timelineOutput != "" {
+		f, err := os.Create(timelineOutput)
+		if err != nil {
+			return fmt.Errorf("creating output file: %w", err)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	if timelineFormat == "" || timelineFormat == "text" {
+		if timelineOutput == "" && len(entries) == 0 {
+			fmt.Printf("%s No events match filter\n", style.Dim.Render("○"))
+			return nil
+		}
+		// A file gets plain text even when stdout is a terminal
+		printTimelineText(out, entries, timelineOutput != "")
+	} else {
+		title := "Gas Town Timeline"
+		if timelineConvoy != "" {
+			title += " — " + timelineConvoy
+		} else if timelineBead != "" {
+			title += " — " + timelineBead
+		}
+		if err := timeline.Write(out, timelineFormat, title, entries); err != nil {
+			return err
+		}
+	}
+
+	if timelineOutput != "" {
+		fmt.Printf("%s Wrote %d events to %s\n", style.Bold.Render("✓"), len(entries), timelineOutput)
+	}
+	return nil
+}
+
+// collectTimeline gathers and merges entries from every source in the town.
+// Sources that fail to load are reported on stderr and skipped.
+func collectTimeline(townRoot string, includeBeads bool) []timeline.Entry {
+	var sources [][]timeline.Entry
+
+	if evts, err := events.ReadEvents(townRoot); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not read events: %v\n", err)
+	} else {
+		sources = append(sources, timeline.FromEvents(evts))
+	}
+
+	if evts, err := townlog.ReadEvents(townRoot); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not read town log: %v\n", err)
+	} else {
+		sources = append(sources, timeline.FromTownlog(evts))
+	}
+
+	if includeBeads {
+		if issues, err := beads.New(townRoot).List(beads.ListOptions{Status: "all", Priority: -1}); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not query town beads: %v\n", err)
+		} else {
+			sources = append(sources, timeline.FromIssues("", issues))
+		}
+	}
+
+	rigs, _, err := getAllRigs()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not discover rigs: %v\n", err)
+	}
+	for _, r := range rigs {
+		mqEvents, err := mrqueue.NewEventLoggerFromRig(r.Path).ReadEvents()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not read %s merge queue events: %v\n", r.Name, err)
+		} else {
+			sources = append(sources, timeline.FromMQEvents(r.Name, mqEvents))
+		}
+
+		if includeBeads {
+			issues, err := beads.New(r.BeadsPath()).List(beads.ListOptions{Status: "all", Priority: -1})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: could not query %s beads: %v\n", r.Name, err)
+				continue
+			}
+			sources = append(sources, timeline.FromIssues(r.Name, issues))
+		}
+	}
+
+	return timeline.Merge(sources...)
+}
+
+// parseTimelineTime accepts either a duration ago ("1h", "7d") or an RFC3339 time.
+func parseTimelineTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	d, err := parseDuration(s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is neither a duration nor an RFC3339 time", s)
+	}
+	return time.Now().Add(-d), nil
+}
+
+// printTimelineText renders the timeline for humans, grouped by date in local
+// time. plain drops the styling, for output that isn't going to a terminal.
+func printTimelineText(w io.Writer, entries []timeline.Entry, plain bool) {
+	printf := func(format string, args ...interface{}) {
+		line := fmt.Sprintf(format, args...)
+		if plain {
+			line = style.StripAnsi(line)
+		}
+		_, _ = io.WriteString(w, line)
+	}
+
+	var currentDate string
+	for _, e := range entries {
+		ts := e.Timestamp.Local()
+		date := ts.Format("2006-01-02")
+		if date != currentDate {
+			if currentDate != "" {
+				printf("\n")
+			}
+			printf("%s\n", style.Bold.Render("─── "+date+" ───────────────────────────────────────────"))
+			currentDate = date
+		}
+
+		var refs string
+		if e.Bead != "" {
+			refs += " " + e.Bead
+		}
+		if e.MR != "" {
+			refs += " " + e.MR
+		}
+		if refs != "" {
+			refs = style.Dim.Render(" [" + refs[1:] + "]")
+		}
+
+		agent := e.Agent
+		if agent == "" {
+			agent = "-"
+		}
+		printf("%s %s %-24s %s%s\n",
+			style.Dim.Render(ts.Format("15:04:05")),
+			formatSource(e.Source),
+			agent,
+			e.Summary,
+			refs,
+		)
+	}
+}
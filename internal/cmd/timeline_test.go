@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/steveyegge/gastown/internal/timeline"
+)
+
+func TestRunTimeline_BadFormatKeepsOutputFile(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "timeline.html")
+	if err := os.WriteFile(out, []byte("previous export"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	oldFormat, oldOutput := timelineFormat, timelineOutput
+	defer func() { timelineFormat, timelineOutput = oldFormat, oldOutput }()
+	timelineFormat, timelineOutput = "htm", out
+
+	err := runTimeline(nil, nil)
+	if err == nil || !strings.Contains(err.Error(), `unknown format "htm"`) {
+		t.Fatalf("runTimeline() error = %v, want unknown format", err)
+	}
+	if data, _ := os.ReadFile(out); string(data) != "previous export" {
+		t.Errorf("output file = %q, want it untouched", data)
+	}
+}
+
+func TestPrintTimelineText_PlainForFiles(t *testing.T) {
+	// Style output as on a color terminal (0 is termenv.TrueColor)
+	oldProfile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(0)
+	defer lipgloss.SetColorProfile(oldProfile)
+
+	entries := []timeline.Entry{{
+		Timestamp: time.Date(2026, 3, 1, 12, 0, 0, 0, time.Local),
+		Source:    "mq",
+		Agent:     "gastown/refinery",
+		Bead:      "gt-abc",
+		Summary:   "merged",
+	}}
+
+	var styled, plain bytes.Buffer
+	printTimelineText(&styled, entries, false)
+	printTimelineText(&plain, entries, true)
+
+	if !strings.Contains(styled.String(), "\x1b[") {
+		t.Fatalf("styled output has no escape codes: %q", styled.String())
+	}
+	if strings.Contains(plain.String(), "\x1b") {
+		t.Errorf("plain output has escape codes: %q", plain.String())
+	}
+	if !strings.Contains(plain.String(), "12:00:00 [mq] gastown/refinery") || !strings.Contains(plain.String(), "merged [gt-abc]") {
+		t.Errorf("plain output = %q", plain.String())
+	}
+}
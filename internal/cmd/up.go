@@ -14,7 +14,9 @@ import (
 	"github.com/steveyegge/gastown/internal/constants"
 	"github.com/steveyegge/gastown/internal/daemon"
 	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/limits"
 	"github.com/steveyegge/gastown/internal/refinery"
+	"github.com/steveyegge/gastown/internal/sandbox"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tmux"
@@ -273,6 +275,7 @@ func ensureSession(t *tmux.Tmux, sessionName, workDir, role string) error {
 	} else {
 		claudeCmd = config.BuildAgentStartupCommand(role, role, "", "")
 	}
+	claudeCmd = limits.WrapCommand(workDir, "", role, sessionName, claudeCmd)
 
 	if err := t.SendKeysDelayed(sessionName, claudeCmd, 200); err != nil {
 		return err
@@ -329,7 +332,8 @@ func ensureWitness(t *tmux.Tmux, sessionName, rigPath, rigName string) error {
 
 	// Launch Claude using runtime config
 	// Export GT_ROLE and BD_ACTOR in the command since tmux SetEnvironment only affects new panes
-	claudeCmd := config.BuildAgentStartupCommand("witness", bdActor, rigPath, "")
+	claudeCmd := limits.WrapCommand(filepath.Dir(rigPath), rigName, "witness", sessionName,
+		config.BuildAgentStartupCommand("witness", bdActor, rigPath, ""))
 	if err := t.SendKeysDelayed(sessionName, claudeCmd, 200); err != nil {
 		return err
 	}
@@ -560,7 +564,8 @@ func ensureCrewSession(t *tmux.Tmux, sessionName, crewPath, rigName, crewName st
 	// Launch Claude using runtime config
 	// crewPath is like ~/gt/gastown/crew/max, so rig path is two dirs up
 	rigPath := filepath.Dir(filepath.Dir(crewPath))
-	claudeCmd := config.BuildCrewStartupCommand(rigName, crewName, rigPath, "")
+	claudeCmd := limits.WrapCommand(filepath.Dir(rigPath), rigName, "crew", sessionName,
+		config.BuildCrewStartupCommand(rigName, crewName, rigPath, ""))
 	if err := t.SendKeysDelayed(sessionName, claudeCmd, 200); err != nil {
 		return err
 	}
@@ -668,7 +673,11 @@ func ensurePolecatSession(t *tmux.Tmux, sessionName, polecatPath, rigName, polec
 	// Launch Claude using runtime config
 	// polecatPath is like ~/gt/gastown/polecats/toast, so rig path is two dirs up
 	rigPath := filepath.Dir(filepath.Dir(polecatPath))
-	claudeCmd := config.BuildPolecatStartupCommand(rigName, polecatName, rigPath, "")
+	claudeCmd, err := sandbox.WrapPolecat(filepath.Dir(rigPath), rigName, polecatName, "",
+		config.BuildPolecatStartupCommand(rigName, polecatName, rigPath, ""))
+	if err != nil {
+		return err
+	}
 	if err := t.SendKeysDelayed(sessionName, claudeCmd, 200); err != nil {
 		return err
 	}
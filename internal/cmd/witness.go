@@ -12,6 +12,7 @@ import (
 	"github.com/steveyegge/gastown/internal/claude"
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/limits"
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/style"
@@ -345,7 +346,9 @@ func ensureWitnessSession(rigName string, r *rig.Rig) (bool, error) {
 	// Restarts are handled by daemon via LIFECYCLE mail or deacon health-scan
 	// NOTE: No gt prime injection needed - SessionStart hook handles it automatically
 	// Export GT_ROLE and BD_ACTOR in the command since tmux SetEnvironment only affects new panes
-	if err := t.SendKeys(sessionName, config.BuildAgentStartupCommand("witness", bdActor, "", "")); err != nil {
+	startCmd := limits.WrapCommand(filepath.Dir(r.Path), rigName, "witness", sessionName,
+		config.BuildAgentStartupCommand("witness", bdActor, "", ""))
+	if err := t.SendKeys(sessionName, startCmd); err != nil {
 		return false, fmt.Errorf("sending command: %w", err)
 	}
 
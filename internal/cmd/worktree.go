@@ -1,14 +1,19 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/crew"
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/workspace"
@@ -16,7 +21,8 @@ import (
 
 // Worktree command flags
 var (
-	worktreeNoCD bool
+	worktreeNoCD   bool
+	worktreeBranch string
 )
 
 var worktreeCmd = &cobra.Command{
@@ -33,15 +39,22 @@ The worktree is created at: ~/gt/<target-rig>/crew/<source-rig>-<name>/
 
 For example, if you're gastown/crew/joe and run 'gt worktree beads':
 - Creates worktree at ~/gt/beads/crew/gastown-joe/
-- The worktree checks out main branch
+- The worktree checks out branch crew/gastown-joe, started from the beads
+  rig's default branch (override with --branch)
+- The worktree is registered in gastown/crew/joe's state
 - Your identity (BD_ACTOR, GT_ROLE) remains gastown/crew/joe
 
+Registered worktrees show their branch state in 'gt worktree list', can be
+rebased with 'gt worktree sync' and submitted to the other rig's merge queue
+with 'gt worktree submit'. 'gt doctor' flags stale and orphaned ones.
+
 Use --no-cd to just print the path without printing shell commands.
 
 Examples:
-  gt worktree beads         # Create worktree in beads rig
-  gt worktree gastown       # Create worktree in gastown rig (from another rig)
-  gt worktree beads --no-cd # Just print the path`,
+  gt worktree beads                      # Create worktree in beads rig
+  gt worktree gastown                    # Create worktree in gastown rig (from another rig)
+  gt worktree beads --branch fix/bd-a1b  # Use a specific branch
+  gt worktree beads --no-cd              # Just print the path`,
 	Args: cobra.ExactArgs(1),
 	RunE: runWorktree,
 }
@@ -51,15 +64,17 @@ var worktreeListCmd = &cobra.Command{
 	Short: "List all cross-rig worktrees owned by current crew member",
 	Long: `List all git worktrees created for cross-rig work.
 
-This command scans all rigs in the workspace and finds worktrees
-that belong to the current crew member. Each worktree is shown with
-its git status summary.
+This command shows the worktrees registered to the current crew member,
+plus any unregistered ones found by scanning the other rigs. Each worktree
+is shown with its branch, its position relative to the rig's target branch
+and its upstream, and how long since it was last synced or pushed. Counts
+use local refs; run 'gt worktree sync' to refresh them.
 
 Example output:
   Cross-rig worktrees for gastown/crew/joe:
 
-    beads     ~/gt/beads/crew/gastown-joe/     (clean)
-    mayor     ~/gt/mayor/crew/gastown-joe/     (2 uncommitted)`,
+    beads     crew/gastown-joe  2 ahead, pushed         synced 3h ago
+    mayor     crew/gastown-joe  2 uncommitted, 1 behind  synced 16d ago (stale)`,
 	RunE: runWorktreeList,
 }
 
@@ -68,6 +83,52 @@ var (
 	worktreeRemoveForce bool
 )
 
+var worktreeListJSON bool
+
+var worktreeSyncCmd = &cobra.Command{
+	Use:   "sync [rig]",
+	Short: "Rebase cross-rig worktrees on their rig's target branch",
+	Long: `Fetch and rebase cross-rig worktrees onto their rig's target branch.
+
+With a rig, syncs that worktree; without one, syncs every worktree registered
+to the current crew member (or, from inside a cross-rig worktree, that one).
+Worktrees with uncommitted changes are skipped. If a rebase conflicts it is
+aborted and the worktree is left as it was.
+
+Examples:
+  gt worktree sync          # Sync all your cross-rig worktrees
+  gt worktree sync beads    # Sync just the beads worktree`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runWorktreeSync,
+}
+
+// Worktree submit command flags
+var (
+	worktreeSubmitIssue    string
+	worktreeSubmitPriority int
+)
+
+var worktreeSubmitCmd = &cobra.Command{
+	Use:   "submit [rig]",
+	Short: "Submit a cross-rig worktree's branch to that rig's merge queue",
+	Long: `Push a cross-rig worktree's branch and submit it to the other rig's
+refinery queue.
+
+The branch is pushed with --force-with-lease (sync rebases it) and a
+merge-request bead is created in the target rig's beads, targeting the
+rig's default branch. The refinery of that rig merges it like any other MR.
+
+The source issue comes from --issue, or from the branch name when it
+contains one. Run from your crew workspace naming the rig, or from inside
+the worktree.
+
+Examples:
+  gt worktree submit beads --issue bd-a1b
+  gt worktree submit --issue bd-a1b --priority 1   # from inside the worktree`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runWorktreeSubmit,
+}
+
 var worktreeRemoveCmd = &cobra.Command{
 	Use:   "remove <rig>",
 	Short: "Remove a cross-rig worktree",
@@ -85,8 +146,17 @@ Examples:
 
 func init() {
 	worktreeCmd.Flags().BoolVar(&worktreeNoCD, "no-cd", false, "Just print path (don't print cd command)")
+	worktreeCmd.Flags().StringVar(&worktreeBranch, "branch", "", "Branch to work on (default: crew/<source-rig>-<name>)")
+
+	worktreeListCmd.Flags().BoolVar(&worktreeListJSON, "json", false, "Output as JSON")
 	worktreeCmd.AddCommand(worktreeListCmd)
 
+	worktreeCmd.AddCommand(worktreeSyncCmd)
+
+	worktreeSubmitCmd.Flags().StringVar(&worktreeSubmitIssue, "issue", "", "Source issue ID (default: parse from branch name)")
+	worktreeSubmitCmd.Flags().IntVarP(&worktreeSubmitPriority, "priority", "p", -1, "Override priority (0-4, default: inherit from issue)")
+	worktreeCmd.AddCommand(worktreeSubmitCmd)
+
 	worktreeRemoveCmd.Flags().BoolVarP(&worktreeRemoveForce, "force", "f", false, "Force remove even with uncommitted changes")
 	worktreeCmd.AddCommand(worktreeRemoveCmd)
 
@@ -96,14 +166,12 @@ func init() {
 func runWorktree(cmd *cobra.Command, args []string) error {
 	targetRig := args[0]
 
-	// Detect current crew identity from cwd
-	detected, err := detectCrewFromCwd()
+	owner, err := detectWorktreeOwner()
 	if err != nil {
-		return fmt.Errorf("must be in a crew workspace to use this command: %w", err)
+		return err
 	}
-
-	sourceRig := detected.rigName
-	crewName := detected.crewName
+	sourceRig := owner.rigName
+	crewName := owner.crewName
 
 	// Cannot create worktree in your own rig
 	if targetRig == sourceRig {
@@ -117,12 +185,25 @@ func runWorktree(cmd *cobra.Command, args []string) error {
 	}
 
 	// Compute worktree path: ~/gt/<target-rig>/crew/<source-rig>-<name>/
-	worktreeName := fmt.Sprintf("%s-%s", sourceRig, crewName)
+	worktreeName := crew.WorktreeName(sourceRig, crewName)
 	worktreePath := filepath.Join(constants.RigCrewPath(targetRigInfo.Path), worktreeName)
+	target := targetRigInfo.DefaultBranch()
 
 	// Check if worktree already exists
 	if _, err := os.Stat(worktreePath); err == nil {
-		// Worktree exists
+		// Adopt worktrees created before registration existed
+		if wt, _ := owner.mgr.Worktree(crewName, targetRig); wt == nil {
+			branch, _ := git.NewGit(worktreePath).CurrentBranch()
+			if err := owner.mgr.RegisterWorktree(crewName, crew.Worktree{
+				Rig:       targetRig,
+				Path:      worktreePath,
+				Branch:    branch,
+				Target:    target,
+				CreatedAt: time.Now(),
+			}); err != nil {
+				style.PrintWarning("could not register worktree: %v", err)
+			}
+		}
 		if worktreeNoCD {
 			fmt.Println(worktreePath)
 		} else {
@@ -132,9 +213,8 @@ func runWorktree(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	// Get the source rig's git repository (the bare repo for worktrees)
-	// For cross-rig work, we need to use the target rig's repository
-	// The target rig's mayor/rig is the main clone we create worktrees from
+	// For cross-rig work we use the target rig's repository: its mayor/rig
+	// is the main clone we create worktrees from
 	targetMayorRig := constants.RigMayorPath(targetRigInfo.Path)
 	g := git.NewGit(targetMayorRig)
 
@@ -150,33 +230,51 @@ func runWorktree(cmd *cobra.Command, args []string) error {
 		fmt.Printf("%s Warning: could not fetch from origin: %v\n", style.Warning.Render("⚠"), err)
 	}
 
-	// Create the worktree on main branch
-	// Use WorktreeAddExistingForce because main may already be checked out
-	// in other worktrees (e.g., mayor/rig). This is safe for cross-rig work.
-	if err := g.WorktreeAddExistingForce(worktreePath, "main"); err != nil {
+	// Work on a branch of our own so the worktree can be pushed and
+	// submitted; reuse it if a previous worktree left it behind
+	branch := worktreeBranch
+	if branch == "" {
+		branch = "crew/" + worktreeName
+	}
+	exists, err := g.BranchExists(branch)
+	if err != nil {
+		return fmt.Errorf("checking branch %s: %w", branch, err)
+	}
+	if exists {
+		err = g.WorktreeAddExisting(worktreePath, branch)
+	} else {
+		startPoint := "origin/" + target
+		if !g.RefExists(startPoint) {
+			startPoint = target
+		}
+		err = g.WorktreeAddFromRef(worktreePath, branch, startPoint)
+	}
+	if err != nil {
 		return fmt.Errorf("creating worktree: %w", err)
 	}
 
 	// Configure git author for identity preservation
-	worktreeGit := git.NewGit(worktreePath)
 	bdActor := fmt.Sprintf("%s/crew/%s", sourceRig, crewName)
-
-	// Set local git config for this worktree
 	if err := setGitConfig(worktreePath, "user.name", bdActor); err != nil {
 		fmt.Printf("%s Warning: could not set git author name: %v\n", style.Warning.Render("⚠"), err)
 	}
 
+	if err := owner.mgr.RegisterWorktree(crewName, crew.Worktree{
+		Rig:       targetRig,
+		Path:      worktreePath,
+		Branch:    branch,
+		Target:    target,
+		CreatedAt: time.Now(),
+	}); err != nil {
+		style.PrintWarning("could not register worktree with %s: %v", bdActor, err)
+	}
+
 	fmt.Printf("%s Created worktree for cross-rig work\n", style.Success.Render("✓"))
 	fmt.Printf("  Source: %s/crew/%s\n", sourceRig, crewName)
 	fmt.Printf("  Target: %s\n", worktreePath)
-	fmt.Printf("  Branch: main\n")
+	fmt.Printf("  Branch: %s (from %s)\n", branch, target)
 	fmt.Println()
 
-	// Pull latest main in the new worktree
-	if err := worktreeGit.Pull("origin", "main"); err != nil {
-		fmt.Printf("%s Warning: could not pull latest: %v\n", style.Warning.Render("⚠"), err)
-	}
-
 	if worktreeNoCD {
 		fmt.Println(worktreePath)
 	} else {
@@ -199,72 +297,162 @@ func setGitConfig(worktreePath, key, value string) error {
 	return cmd.Run()
 }
 
-func runWorktreeList(cmd *cobra.Command, args []string) error {
-	// Detect current crew identity from cwd
+// worktreeOwner is the crew worker whose cross-rig worktrees a command
+// manages.
+type worktreeOwner struct {
+	townRoot string
+	rigName  string
+	crewName string
+	mgr      *crew.Manager
+
+	// inRig is set when running from inside one of the owner's cross-rig
+	// worktrees: the rig that worktree is in.
+	inRig string
+}
+
+// detectWorktreeOwner finds the crew worker from cwd. Inside a cross-rig
+// worktree (<rig>/crew/<source-rig>-<name>) the owner is the source crew
+// worker, not a crew member of <rig>.
+func detectWorktreeOwner() (*worktreeOwner, error) {
 	detected, err := detectCrewFromCwd()
 	if err != nil {
-		return fmt.Errorf("must be in a crew workspace to use this command: %w", err)
+		return nil, fmt.Errorf("must be in a crew workspace to use this command: %w", err)
+	}
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return nil, fmt.Errorf("not in a Gas Town workspace: %w", err)
 	}
 
-	sourceRig := detected.rigName
-	crewName := detected.crewName
-	worktreeName := fmt.Sprintf("%s-%s", sourceRig, crewName)
+	owner := &worktreeOwner{townRoot: townRoot, rigName: detected.rigName, crewName: detected.crewName}
+	if src, name, ok := splitWorktreeName(townRoot, detected.rigName, detected.crewName); ok {
+		owner.rigName, owner.crewName, owner.inRig = src, name, detected.rigName
+	}
 
-	// Find town root
-	townRoot, err := workspace.FindFromCwdOrError()
+	mgr, _, err := getCrewManager(owner.rigName)
 	if err != nil {
-		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+		return nil, err
 	}
+	owner.mgr = mgr
+	return owner, nil
+}
 
-	// Load rigs config to list all rigs
-	rigsConfigPath := constants.MayorRigsPath(townRoot)
-	rigsConfig, err := config.LoadRigsConfig(rigsConfigPath)
+// splitWorktreeName reports whether dir in rigName's crew/ directory is a
+// cross-rig worktree, returning the owning crew worker.
+func splitWorktreeName(townRoot, rigName, dir string) (sourceRig, crewName string, ok bool) {
+	rigsConfig, err := config.LoadRigsConfig(constants.MayorRigsPath(townRoot))
 	if err != nil {
-		return fmt.Errorf("loading rigs config: %w", err)
+		return "", "", false
+	}
+	rigs := make([]string, 0, len(rigsConfig.Rigs))
+	for name := range rigsConfig.Rigs {
+		rigs = append(rigs, name)
 	}
+	return crew.WorktreeOwner(townRoot, rigName, dir, rigs)
+}
 
-	fmt.Printf("Cross-rig worktrees for %s/crew/%s:\n\n", sourceRig, crewName)
+// worktreeListEntry is one row of gt worktree list.
+type worktreeListEntry struct {
+	crew.Worktree
+	Registered bool                 `json:"registered"`
+	Stale      bool                 `json:"stale,omitempty"`
+	Status     *crew.WorktreeStatus `json:"status"`
+}
+
+func runWorktreeList(cmd *cobra.Command, args []string) error {
+	owner, err := detectWorktreeOwner()
+	if err != nil {
+		return err
+	}
+	sourceRig := owner.rigName
+	crewName := owner.crewName
+	worktreeName := crew.WorktreeName(sourceRig, crewName)
 
-	found := false
+	worker, err := owner.mgr.Get(crewName)
+	if err != nil {
+		return fmt.Errorf("loading crew state: %w", err)
+	}
+
+	var entries []worktreeListEntry
+	seen := make(map[string]bool)
+	for _, wt := range worker.Worktrees {
+		seen[wt.Rig] = true
+		entries = append(entries, worktreeListEntry{
+			Worktree:   wt,
+			Registered: true,
+			Stale:      time.Since(wt.LastActive()) > crew.StaleWorktreeAge,
+			Status:     wt.Status(),
+		})
+	}
+
+	// Also scan the other rigs for worktrees that were never registered
+	rigsConfigPath := constants.MayorRigsPath(owner.townRoot)
+	rigsConfig, err := config.LoadRigsConfig(rigsConfigPath)
+	if err != nil {
+		return fmt.Errorf("loading rigs config: %w", err)
+	}
 	for rigName := range rigsConfig.Rigs {
 		// Skip our own rig - worktrees are for cross-rig work
-		if rigName == sourceRig {
+		if rigName == sourceRig || seen[rigName] {
 			continue
 		}
-
-		// Rig path is simply townRoot/<rigName>
-		rigPath := filepath.Join(townRoot, rigName)
-
-		// Check if worktree exists: <rig>/crew/<source-rig>-<name>/
-		worktreePath := filepath.Join(constants.RigCrewPath(rigPath), worktreeName)
-
+		worktreePath := filepath.Join(constants.RigCrewPath(filepath.Join(owner.townRoot, rigName)), worktreeName)
 		if _, err := os.Stat(worktreePath); os.IsNotExist(err) {
 			continue
 		}
+		wt := crew.Worktree{Rig: rigName, Path: worktreePath}
+		status := wt.Status()
+		wt.Branch = status.Branch
+		entries = append(entries, worktreeListEntry{Worktree: wt, Status: status})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Rig < entries[j].Rig })
 
-		// Worktree exists - get git status
-		statusSummary := getGitStatusSummary(worktreePath)
-
-		// Format the path for display (use ~ for home directory)
-		displayPath := worktreePath
-		if home, err := os.UserHomeDir(); err == nil {
-			if rel, err := filepath.Rel(home, worktreePath); err == nil && !filepath.IsAbs(rel) {
-				displayPath = "~/" + rel
-			}
+	if worktreeListJSON {
+		if entries == nil {
+			entries = []worktreeListEntry{}
 		}
-
-		fmt.Printf("  %-10s %s     (%s)\n", rigName, displayPath, statusSummary)
-		found = true
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(entries)
 	}
 
-	if !found {
+	fmt.Printf("Cross-rig worktrees for %s/crew/%s:\n\n", sourceRig, crewName)
+	if len(entries) == 0 {
 		fmt.Printf("  (none)\n")
 		fmt.Printf("\nCreate a worktree with: gt worktree <rig>\n")
+		return nil
+	}
+
+	for _, e := range entries {
+		note := ""
+		switch {
+		case !e.Registered:
+			note = style.Warning.Render("(unregistered - run 'gt worktree " + e.Rig + "' to adopt)")
+		case e.Stale:
+			note = fmt.Sprintf("synced %s ago %s", formatWorktreeAge(time.Since(e.LastActive())), style.Warning.Render("(stale)"))
+		default:
+			note = style.Dim.Render(fmt.Sprintf("synced %s ago", formatWorktreeAge(time.Since(e.LastActive()))))
+		}
+		fmt.Printf("  %-10s %-24s %-26s %s\n", e.Rig, e.Branch, e.Status.Summary(), note)
+		if e.LastMR != "" {
+			fmt.Printf("  %-10s %s\n", "", style.Dim.Render("last MR: "+e.LastMR))
+		}
 	}
 
 	return nil
 }
 
+// formatWorktreeAge renders a duration as minutes, hours or days.
+func formatWorktreeAge(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
+
 // getGitStatusSummary returns a brief status summary for a git directory.
 func getGitStatusSummary(dir string) string {
 	g := git.NewGit(dir)
@@ -285,17 +473,175 @@ func getGitStatusSummary(dir string) string {
 	return fmt.Sprintf("%d uncommitted", uncommitted)
 }
 
+// ownerWorktrees returns the registered worktrees a sync or submit acts on:
+// the named rig's, the one cwd is in, or (if all) every registered one.
+func ownerWorktrees(owner *worktreeOwner, args []string, all bool) ([]crew.Worktree, error) {
+	rigName := owner.inRig
+	if len(args) > 0 {
+		rigName = args[0]
+	}
+
+	worker, err := owner.mgr.Get(owner.crewName)
+	if err != nil {
+		return nil, fmt.Errorf("loading crew state: %w", err)
+	}
+	if rigName == "" {
+		if !all {
+			return nil, fmt.Errorf("specify a rig, or run from inside the worktree")
+		}
+		if len(worker.Worktrees) == 0 {
+			return nil, fmt.Errorf("no cross-rig worktrees registered - create one with 'gt worktree <rig>'")
+		}
+		return worker.Worktrees, nil
+	}
+	for _, wt := range worker.Worktrees {
+		if wt.Rig == rigName {
+			return []crew.Worktree{wt}, nil
+		}
+	}
+	return nil, fmt.Errorf("no worktree registered in rig '%s' - run 'gt worktree %s' to create or adopt it", rigName, rigName)
+}
+
+func runWorktreeSync(cmd *cobra.Command, args []string) error {
+	owner, err := detectWorktreeOwner()
+	if err != nil {
+		return err
+	}
+	worktrees, err := ownerWorktrees(owner, args, true)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, wt := range worktrees {
+		if err := syncWorktree(wt); err != nil {
+			fmt.Printf("%s %s: %v\n", style.Warning.Render("⚠"), wt.Rig, err)
+			failed++
+			continue
+		}
+		if err := owner.mgr.UpdateWorktree(owner.crewName, wt.Rig, func(w *crew.Worktree) {
+			w.SyncedAt = time.Now()
+		}); err != nil {
+			style.PrintWarning("could not record sync for %s: %v", wt.Rig, err)
+		}
+		fmt.Printf("%s %s: rebased %s onto origin/%s\n", style.Success.Render("✓"), wt.Rig, wt.Branch, wt.Target)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d worktree(s) not synced", failed)
+	}
+	return nil
+}
+
+// syncWorktree fetches and rebases a worktree onto origin/<target>,
+// aborting the rebase if it conflicts.
+func syncWorktree(wt crew.Worktree) error {
+	status := wt.Status()
+	switch {
+	case !status.Exists:
+		return fmt.Errorf("worktree missing at %s", wt.Path)
+	case status.Error != "":
+		return fmt.Errorf("reading status: %s", status.Error)
+	case status.Uncommitted > 0:
+		return fmt.Errorf("%d uncommitted change(s) - commit or stash first", status.Uncommitted)
+	}
+
+	g := git.NewGit(wt.Path)
+	if err := g.Fetch("origin"); err != nil {
+		return fmt.Errorf("fetching: %w", err)
+	}
+	if err := g.Rebase("origin/" + wt.Target); err != nil {
+		_ = g.AbortRebase()
+		return fmt.Errorf("rebase onto origin/%s conflicts - rebase manually in %s", wt.Target, wt.Path)
+	}
+	return nil
+}
+
+func runWorktreeSubmit(cmd *cobra.Command, args []string) error {
+	owner, err := detectWorktreeOwner()
+	if err != nil {
+		return err
+	}
+	worktrees, err := ownerWorktrees(owner, args, false)
+	if err != nil {
+		return err
+	}
+	wt := worktrees[0]
+
+	_, targetRigInfo, err := getRig(wt.Rig)
+	if err != nil {
+		return fmt.Errorf("rig '%s' not found - run 'gt rigs' to see available rigs", wt.Rig)
+	}
+
+	status := wt.Status()
+	switch {
+	case !status.Exists:
+		return fmt.Errorf("worktree missing at %s", wt.Path)
+	case status.Error != "":
+		return fmt.Errorf("reading worktree status: %s", status.Error)
+	case status.Uncommitted > 0:
+		return fmt.Errorf("worktree has %d uncommitted change(s) - commit them first", status.Uncommitted)
+	case status.Ahead == 0:
+		return fmt.Errorf("%s has no commits beyond origin/%s - nothing to submit", status.Branch, wt.Target)
+	}
+	branch := status.Branch
+
+	// The default crew/<rig>-<name> branch names no issue, so only parse
+	// branches the crew member chose
+	issueID := worktreeSubmitIssue
+	if issueID == "" && branch != "crew/"+crew.WorktreeName(owner.rigName, owner.crewName) {
+		issueID = parseBranchName(branch).Issue
+	}
+	if issueID == "" {
+		return fmt.Errorf("cannot determine source issue from branch '%s'; use --issue to specify", branch)
+	}
+
+	// Sync rewrites the branch, so push with a lease rather than refusing
+	g := git.NewGit(wt.Path)
+	if err := g.PushUpstream("origin", branch, true); err != nil {
+		return fmt.Errorf("pushing %s: %w", branch, err)
+	}
+
+	// The MR goes into the target rig's beads, where its refinery looks
+	bd := beads.New(targetRigInfo.BeadsPath())
+	priority := worktreeSubmitPriority
+	if priority < 0 {
+		priority = 2
+		if sourceIssue, err := bd.Show(issueID); err == nil {
+			priority = sourceIssue.Priority
+		}
+	}
+	mrIssue, err := createMergeRequestBead(bd, wt.Rig, branch, wt.Target, issueID, "", "", priority)
+	if err != nil {
+		return err
+	}
+
+	if err := owner.mgr.UpdateWorktree(owner.crewName, wt.Rig, func(w *crew.Worktree) {
+		w.Branch = branch
+		w.PushedAt = time.Now()
+		w.LastMR = mrIssue.ID
+	}); err != nil {
+		style.PrintWarning("could not record submission: %v", err)
+	}
+
+	fmt.Printf("%s Submitted to %s merge queue\n", style.Bold.Render("✓"), wt.Rig)
+	fmt.Printf("  MR ID: %s\n", style.Bold.Render(mrIssue.ID))
+	fmt.Printf("  Source: %s\n", branch)
+	fmt.Printf("  Target: %s\n", wt.Target)
+	fmt.Printf("  Issue: %s\n", issueID)
+	fmt.Printf("  Priority: P%d\n", priority)
+	return nil
+}
+
 func runWorktreeRemove(cmd *cobra.Command, args []string) error {
 	targetRig := args[0]
 
-	// Detect current crew identity from cwd
-	detected, err := detectCrewFromCwd()
+	owner, err := detectWorktreeOwner()
 	if err != nil {
-		return fmt.Errorf("must be in a crew workspace to use this command: %w", err)
+		return err
 	}
-
-	sourceRig := detected.rigName
-	crewName := detected.crewName
+	sourceRig := owner.rigName
+	crewName := owner.crewName
 
 	// Cannot remove worktree in your own rig (doesn't make sense)
 	if targetRig == sourceRig {
@@ -309,11 +655,18 @@ func runWorktreeRemove(cmd *cobra.Command, args []string) error {
 	}
 
 	// Compute worktree path: ~/gt/<target-rig>/crew/<source-rig>-<name>/
-	worktreeName := fmt.Sprintf("%s-%s", sourceRig, crewName)
+	worktreeName := crew.WorktreeName(sourceRig, crewName)
 	worktreePath := filepath.Join(constants.RigCrewPath(targetRigInfo.Path), worktreeName)
 
-	// Check if worktree exists
+	// Check if worktree exists; a registration without one is just dropped
 	if _, err := os.Stat(worktreePath); os.IsNotExist(err) {
+		if wt, _ := owner.mgr.Worktree(crewName, targetRig); wt != nil {
+			if err := owner.mgr.UnregisterWorktree(crewName, targetRig); err != nil {
+				return fmt.Errorf("unregistering worktree: %w", err)
+			}
+			fmt.Printf("%s Unregistered missing worktree at %s\n", style.Success.Render("✓"), worktreePath)
+			return nil
+		}
 		return fmt.Errorf("worktree does not exist at %s", worktreePath)
 	}
 
@@ -333,6 +686,9 @@ func runWorktreeRemove(cmd *cobra.Command, args []string) error {
 	if err := g.WorktreeRemove(worktreePath, worktreeRemoveForce); err != nil {
 		return fmt.Errorf("removing worktree: %w", err)
 	}
+	if err := owner.mgr.UnregisterWorktree(crewName, targetRig); err != nil {
+		style.PrintWarning("could not unregister worktree: %v", err)
+	}
 
 	fmt.Printf("%s Removed worktree at %s\n", style.Success.Render("✓"), worktreePath)
 
@@ -237,6 +237,15 @@ func validateMergeQueueConfig(c *MergeQueueConfig) error {
 	if c.MaxConcurrent < 0 {
 		return fmt.Errorf("%w: max_concurrent must be non-negative", ErrMissingField)
 	}
+	if c.PostMergeWindow < 0 {
+		return fmt.Errorf("%w: post_merge_window must be non-negative", ErrMissingField)
+	}
+	if t := c.FlakyThreshold; t != nil && (*t <= 0 || *t > 1) {
+		return fmt.Errorf("invalid flaky_threshold %v: must be above 0 and at most 1", *t)
+	}
+	if c.TestResultsFormat != "" && c.TestResultsFormat != "go-json" && c.TestResultsFormat != "junit" {
+		return fmt.Errorf("invalid test_results_format %q: want 'go-json' or 'junit'", c.TestResultsFormat)
+	}
 
 	return nil
 }
@@ -432,6 +441,11 @@ func validateAccountsConfig(c *AccountsConfig) error {
 			return fmt.Errorf("%w: config_dir for account '%s'", ErrMissingField, handle)
 		}
 	}
+	for _, handle := range c.Pool {
+		if _, ok := c.Accounts[handle]; !ok {
+			return fmt.Errorf("%w: pool account '%s' not found in accounts", ErrMissingField, handle)
+		}
+	}
 	return nil
 }
 
@@ -504,6 +518,12 @@ func ResolveAccountConfigDir(accountsPath, accountFlag string) (configDir, handl
 	return "", "", nil
 }
 
+// ExpandAccountConfigDir returns an account's config_dir with ~ expanded,
+// as it is passed in CLAUDE_CONFIG_DIR.
+func ExpandAccountConfigDir(acct *Account) string {
+	return expandPath(acct.ConfigDir)
+}
+
 // expandPath expands ~ to home directory.
 func expandPath(path string) string {
 	if strings.HasPrefix(path, "~/") {
@@ -674,6 +694,55 @@ func RigSettingsPath(rigPath string) string {
 	return filepath.Join(rigPath, "settings", "config.json")
 }
 
+// ResolveResourceLimits returns the resource limits for an agent session.
+// The rig's limits for role win, falling back field by field to the town's;
+// rigName is empty for town-level agents (mayor, deacon).
+func ResolveResourceLimits(townRoot, rigName, role string) *ResourceLimits {
+	var town *LimitsConfig
+	if settings, err := LoadOrCreateTownSettings(TownSettingsPath(townRoot)); err == nil {
+		town = settings.Limits
+	}
+	limits := town.ForRole(role)
+	if rigName != "" {
+		if settings, err := LoadRigSettings(RigSettingsPath(filepath.Join(townRoot, rigName))); err == nil {
+			limits = settings.Limits.ForRole(role).Merge(limits)
+		}
+	}
+	return limits
+}
+
+// ResolveContextBudget returns the gt prime token budget for role from the
+// town settings, or 0 if the budget is disabled.
+func ResolveContextBudget(townRoot, role string) int {
+	var budget *ContextBudgetConfig
+	if settings, err := LoadOrCreateTownSettings(TownSettingsPath(townRoot)); err == nil {
+		budget = settings.ContextBudget
+	}
+	return budget.ForRole(role)
+}
+
+// RigCapabilities returns what a rig's agents can work on, for
+// capability-aware routing: the rig's declared capabilities plus
+// "runtime:<agent>" for the agent preset its sessions run (the rig's agent,
+// else the town's default_agent, else claude).
+func RigCapabilities(townRoot, rigName string) []string {
+	var caps []string
+	agentName := ""
+	if settings, err := LoadRigSettings(RigSettingsPath(filepath.Join(townRoot, rigName))); err == nil {
+		caps = append(caps, settings.Capabilities...)
+		agentName = settings.Agent
+	}
+	if agentName == "" {
+		if settings, err := LoadOrCreateTownSettings(TownSettingsPath(townRoot)); err == nil {
+			agentName = settings.DefaultAgent
+		}
+	}
+	if agentName == "" {
+		agentName = "claude"
+	}
+	return append(caps, "runtime:"+agentName)
+}
+
 // LoadOrCreateTownSettings loads town settings or creates defaults if missing.
 func LoadOrCreateTownSettings(path string) (*TownSettings, error) {
 	data, err := os.ReadFile(path) //nolint:gosec // G304: path is constructed internally
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -310,6 +311,50 @@ func TestRigSettingsValidation(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "invalid flaky_threshold",
+			settings: &RigSettings{
+				Type:    "rig-settings",
+				Version: 1,
+				MergeQueue: &MergeQueueConfig{
+					FlakyThreshold: floatPtr(1.5),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "zero flaky_threshold",
+			settings: &RigSettings{
+				Type:    "rig-settings",
+				Version: 1,
+				MergeQueue: &MergeQueueConfig{
+					FlakyThreshold: floatPtr(0),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative post_merge_window",
+			settings: &RigSettings{
+				Type:    "rig-settings",
+				Version: 1,
+				MergeQueue: &MergeQueueConfig{
+					PostMergeWindow: -1,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid test_results_format",
+			settings: &RigSettings{
+				Type:    "rig-settings",
+				Version: 1,
+				MergeQueue: &MergeQueueConfig{
+					TestResultsFormat: "tap",
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -495,6 +540,17 @@ func TestAccountsConfigValidation(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "pool refers to nonexistent account",
+			config: &AccountsConfig{
+				Version: 1,
+				Accounts: map[string]Account{
+					"test": {Email: "test@example.com", ConfigDir: "~/.claude-accounts/test"},
+				},
+				Pool: []string{"test", "nonexistent"},
+			},
+			wantErr: true,
+		},
 		{
 			name: "account missing config_dir",
 			config: &AccountsConfig{
@@ -970,3 +1026,159 @@ func TestLoadRuntimeConfigFallsBackToDefaults(t *testing.T) {
 		t.Errorf("Command = %q, want %q (default)", rc.Command, "claude")
 	}
 }
+
+func TestSparseConfigConePaths(t *testing.T) {
+	var disabled *SparseConfig
+	if got := disabled.ConePaths([]string{"svc/api"}); got != nil {
+		t.Errorf("nil config ConePaths = %v, want nil", got)
+	}
+
+	c := &SparseConfig{Cone: []string{"libs/", "./tools", "libs"}}
+	if got := c.ConePaths([]string{"svc/api"}); strings.Join(got, ",") != "libs,tools" {
+		t.Errorf("ConePaths without per_bead = %v, want [libs tools]", got)
+	}
+
+	c.PerBead = true
+	if got := c.ConePaths([]string{"svc/api/", "libs"}); strings.Join(got, ",") != "libs,tools,svc/api" {
+		t.Errorf("ConePaths with per_bead = %v, want [libs tools svc/api]", got)
+	}
+}
+
+func TestSparseConfigValidate(t *testing.T) {
+	valid := &SparseConfig{Cone: []string{"libs", "svc/api"}, Filter: "blob:limit=1m"}
+	if problems := valid.Validate(); len(problems) != 0 {
+		t.Errorf("Validate(valid) = %v", problems)
+	}
+
+	invalid := &SparseConfig{Cone: []string{"/abs", "src/*.go", "../up", " "}, Filter: "blobs:none"}
+	if problems := invalid.Validate(); len(problems) != 5 {
+		t.Errorf("Validate(invalid) = %d problems, want 5: %v", len(problems), problems)
+	}
+}
+
+func TestResourceLimitsMemoryBytes(t *testing.T) {
+	tests := []struct {
+		memory string
+		want   int64
+	}{
+		{"", 0},
+		{"1024", 1024},
+		{"512m", 512 << 20},
+		{"4G", 4 << 30},
+	}
+	for _, tt := range tests {
+		got, err := (&ResourceLimits{Memory: tt.memory}).MemoryBytes()
+		if err != nil || got != tt.want {
+			t.Errorf("MemoryBytes(%q) = %d, %v; want %d", tt.memory, got, err, tt.want)
+		}
+	}
+	if _, err := (&ResourceLimits{Memory: "4 gigs"}).MemoryBytes(); err == nil {
+		t.Error("MemoryBytes(\"4 gigs\") should fail")
+	}
+}
+
+func TestSandboxConfigValidate(t *testing.T) {
+	var disabled *SandboxConfig
+	if disabled.Enabled() || len(disabled.Validate()) != 0 {
+		t.Error("nil sandbox should be disabled and valid")
+	}
+
+	valid := &SandboxConfig{Runtime: SandboxPodman, Image: "gt-agent", Network: SandboxNetworkPrivate, ResourceLimits: ResourceLimits{Memory: "4g"}, Mounts: []string{"~/.claude:rw"}}
+	if problems := valid.Validate(); len(problems) != 0 {
+		t.Errorf("Validate(valid) = %v", problems)
+	}
+
+	invalid := &SandboxConfig{Runtime: SandboxBwrap, Network: SandboxNetworkPrivate, ResourceLimits: ResourceLimits{Memory: "lots", PIDs: -1}, Mounts: []string{"rel/dir"}}
+	if problems := invalid.Validate(); len(problems) != 4 {
+		t.Errorf("Validate(invalid) = %d problems, want 4: %v", len(problems), problems)
+	}
+
+	if problems := (&SandboxConfig{Runtime: "docker"}).Validate(); len(problems) != 1 {
+		t.Errorf("Validate(docker) = %v, want 1 problem", problems)
+	}
+	if problems := (&SandboxConfig{Runtime: SandboxPodman}).Validate(); len(problems) != 1 {
+		t.Errorf("Validate(podman without image) = %v, want 1 problem", problems)
+	}
+}
+
+func TestLimitsConfigForRole(t *testing.T) {
+	var unset *LimitsConfig
+	if !unset.ForRole("polecat").IsZero() {
+		t.Error("nil limits should be unlimited")
+	}
+
+	cfg := &LimitsConfig{
+		ResourceLimits: ResourceLimits{Memory: "2g", PIDs: 256},
+		Roles: map[string]*ResourceLimits{
+			"refinery": {Memory: "8g", CPUs: 4},
+		},
+	}
+	if got := cfg.ForRole("refinery"); *got != (ResourceLimits{Memory: "8g", CPUs: 4, PIDs: 256}) {
+		t.Errorf("ForRole(refinery) = %+v", got)
+	}
+	if got := cfg.ForRole("polecat"); *got != cfg.ResourceLimits {
+		t.Errorf("ForRole(polecat) = %+v, want defaults", got)
+	}
+
+	cfg.Roles["crew"] = &ResourceLimits{CPUs: -1}
+	if problems := cfg.Validate(); len(problems) != 1 || problems[0] != "crew: cpus must not be negative" {
+		t.Errorf("Validate = %v", problems)
+	}
+}
+
+func TestRigCapabilities(t *testing.T) {
+	townRoot := t.TempDir()
+	if got := RigCapabilities(townRoot, "gastown"); !reflect.DeepEqual(got, []string{"runtime:claude"}) {
+		t.Errorf("no settings = %v, want claude runtime only", got)
+	}
+
+	settings := NewRigSettings()
+	settings.Capabilities = []string{"go", "docker"}
+	settings.Agent = "gemini"
+	if err := SaveRigSettings(RigSettingsPath(filepath.Join(townRoot, "gastown")), settings); err != nil {
+		t.Fatalf("SaveRigSettings: %v", err)
+	}
+	want := []string{"go", "docker", "runtime:gemini"}
+	if got := RigCapabilities(townRoot, "gastown"); !reflect.DeepEqual(got, want) {
+		t.Errorf("RigCapabilities = %v, want %v", got, want)
+	}
+}
+
+func TestContextBudgetForRole(t *testing.T) {
+	var unset *ContextBudgetConfig
+	if got := unset.ForRole("polecat"); got != DefaultContextBudget {
+		t.Errorf("nil budget = %d, want %d", got, DefaultContextBudget)
+	}
+
+	cfg := &ContextBudgetConfig{
+		Default: 12000,
+		Roles:   map[string]int{"mayor": 40000, "crew": -1},
+	}
+	if got := cfg.ForRole("mayor"); got != 40000 {
+		t.Errorf("ForRole(mayor) = %d, want 40000", got)
+	}
+	if got := cfg.ForRole("polecat"); got != 12000 {
+		t.Errorf("ForRole(polecat) = %d, want 12000", got)
+	}
+	if got := cfg.ForRole("crew"); got != 0 {
+		t.Errorf("ForRole(crew) = %d, want 0 (unlimited)", got)
+	}
+
+	townRoot := t.TempDir()
+	if got := ResolveContextBudget(townRoot, "witness"); got != DefaultContextBudget {
+		t.Errorf("no settings = %d, want %d", got, DefaultContextBudget)
+	}
+	path := TownSettingsPath(townRoot)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	data := `{"type": "town-settings", "version": 1, "context_budget": {"default": 12000, "roles": {"mayor": 40000}}}`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := ResolveContextBudget(townRoot, "mayor"); got != 40000 {
+		t.Errorf("ResolveContextBudget(mayor) = %d, want 40000", got)
+	}
+}
+
+func floatPtr(f float64) *float64 { return &f }
@@ -2,7 +2,12 @@
 package config
 
 import (
+	"fmt"
 	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -48,6 +53,145 @@ type TownSettings struct {
 	// Values override or extend the built-in presets.
 	// Example: {"gemini": {"command": "/custom/path/to/gemini"}}
 	Agents map[string]*RuntimeConfig `json:"agents,omitempty"`
+
+	// Tracing configures span export for end-to-end work tracing.
+	// Nil means spans are only exported if OTEL_* environment variables are set.
+	Tracing *TracingConfig `json:"tracing,omitempty"`
+
+	// Notifications configures outbound notifications (webhooks, chat,
+	// email, desktop) for escalations and convoy events.
+	// Nil means notifications only reach agents via mail.
+	Notifications *NotificationsConfig `json:"notifications,omitempty"`
+
+	// Limits caps CPU, memory and processes for agent sessions, by role.
+	// Applies to town-level agents and is the default for every rig.
+	Limits *LimitsConfig `json:"limits,omitempty"`
+
+	// ContextBudget caps the tokens gt prime puts into a fresh session's
+	// context, by role. Nil uses DefaultContextBudget for every role.
+	ContextBudget *ContextBudgetConfig `json:"context_budget,omitempty"`
+}
+
+// DefaultContextBudget is the gt prime token budget for roles without one.
+const DefaultContextBudget = 20000
+
+// ContextBudgetConfig sets gt prime token budgets. Budgets are estimates
+// (about four characters per token); -1 disables the budget.
+type ContextBudgetConfig struct {
+	// Default applies to roles not listed in Roles (0 = DefaultContextBudget).
+	Default int `json:"default,omitempty"`
+
+	// Roles overrides the budget per role (mayor, deacon, witness,
+	// refinery, polecat, crew).
+	Roles map[string]int `json:"roles,omitempty"`
+}
+
+// ForRole returns the token budget for role, or 0 if unlimited.
+// Safe to call on a nil config.
+func (c *ContextBudgetConfig) ForRole(role string) int {
+	budget := DefaultContextBudget
+	if c != nil {
+		if c.Default != 0 {
+			budget = c.Default
+		}
+		if b, ok := c.Roles[role]; ok && b != 0 {
+			budget = b
+		}
+	}
+	if budget < 0 {
+		return 0
+	}
+	return budget
+}
+
+// TracingConfig configures where trace spans are exported.
+// Standard OTEL_EXPORTER_OTLP_* environment variables take precedence.
+type TracingConfig struct {
+	// OTLPEndpoint is an OTLP/HTTP collector base URL (e.g., "http://localhost:4318").
+	// Spans are posted to <endpoint>/v1/traces as OTLP JSON.
+	OTLPEndpoint string `json:"otlp_endpoint,omitempty"`
+
+	// Headers are sent with every OTLP export (e.g., collector auth tokens).
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// File is a local JSONL span log, relative to the town root
+	// (e.g., "logs/traces.jsonl").
+	File string `json:"file,omitempty"`
+}
+
+// NotificationsConfig configures outbound notification sinks and routing.
+type NotificationsConfig struct {
+	// Sinks are the available delivery targets, referenced by name from Routes.
+	Sinks []NotificationSinkConfig `json:"sinks,omitempty"`
+
+	// Routes select which sinks receive which notifications.
+	// With no routes, every notification goes to every sink.
+	Routes []NotificationRoute `json:"routes,omitempty"`
+
+	// RateLimitPerMinute caps deliveries per sink per minute (0 = unlimited).
+	// Critical notifications are never rate limited.
+	RateLimitPerMinute int `json:"rate_limit_per_minute,omitempty"`
+
+	// Retries is how many times a failed delivery is retried (default 2).
+	Retries *int `json:"retries,omitempty"`
+
+	// RetryBackoff is the delay before the first retry, doubled on each
+	// subsequent attempt (e.g., "500ms"; default "1s").
+	RetryBackoff string `json:"retry_backoff,omitempty"`
+}
+
+// Notification sink types.
+const (
+	NotifySinkWebhook = "webhook" // Generic JSON POST, optionally HMAC-signed
+	NotifySinkSlack   = "slack"   // Slack-compatible incoming webhook
+	NotifySinkEmail   = "email"   // SMTP
+	NotifySinkDesktop = "desktop" // notify-send
+)
+
+// NotificationSinkConfig describes one delivery target.
+type NotificationSinkConfig struct {
+	// Name identifies the sink in routes and delivery logs.
+	Name string `json:"name"`
+
+	// Type is one of webhook, slack, email, desktop.
+	Type string `json:"type"`
+
+	// URL is the endpoint for webhook and slack sinks.
+	URL string `json:"url,omitempty"`
+
+	// SecretEnv names an environment variable holding the HMAC-SHA256
+	// signing key for webhook sinks. Secrets are never stored in settings.
+	SecretEnv string `json:"secret_env,omitempty"`
+
+	// Headers are extra HTTP headers for webhook sinks.
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// SMTPAddr is the SMTP server "host:port" for email sinks.
+	SMTPAddr string `json:"smtp_addr,omitempty"`
+
+	// Username and PasswordEnv configure SMTP PLAIN auth (optional).
+	Username    string `json:"username,omitempty"`
+	PasswordEnv string `json:"password_env,omitempty"`
+
+	// From and To are the email envelope addresses.
+	From string   `json:"from,omitempty"`
+	To   []string `json:"to,omitempty"`
+}
+
+// NotificationRoute sends matching notifications to a set of sinks.
+// Empty match fields match everything.
+type NotificationRoute struct {
+	// Sinks lists sink names to deliver to.
+	Sinks []string `json:"sinks"`
+
+	// Events limits the route to these event types (e.g., "escalation", "convoy_landed").
+	Events []string `json:"events,omitempty"`
+
+	// MinSeverity is the lowest severity delivered: low, medium, high, critical.
+	MinSeverity string `json:"min_severity,omitempty"`
+
+	// Rigs limits the route to notifications from these rigs.
+	Rigs []string `json:"rigs,omitempty"`
 }
 
 // NewTownSettings creates a new TownSettings with defaults.
@@ -64,6 +208,21 @@ func NewTownSettings() *TownSettings {
 type DaemonConfig struct {
 	HeartbeatInterval string `json:"heartbeat_interval,omitempty"` // e.g., "30s"
 	PollInterval      string `json:"poll_interval,omitempty"`      // e.g., "10s"
+	MetricsAddr       string `json:"metrics_addr,omitempty"`       // e.g., "127.0.0.1:9464"; empty disables /metrics
+	ConvoyScheduler   bool   `json:"convoy_scheduler,omitempty"`   // dispatch ready convoy work each heartbeat
+
+	// Doctor runs doctor checks in the background. Nil disables it.
+	Doctor *DoctorMonitorConfig `json:"doctor,omitempty"`
+}
+
+// DoctorMonitorConfig configures the daemon's background doctor runs.
+// Checks that regress are mailed to the deacon (or escalated, for errors).
+type DoctorMonitorConfig struct {
+	Interval string   `json:"interval,omitempty"` // e.g., "30m" (default); rounded up to the heartbeat
+	Checks   []string `json:"checks,omitempty"`   // checks or categories to run (default: all)
+	Skip     []string `json:"skip,omitempty"`     // checks or categories to leave out
+	AutoFix  []string `json:"auto_fix,omitempty"` // checks or categories safe to fix unattended
+	Escalate bool     `json:"escalate,omitempty"` // escalate regressions to error instead of only mailing the deacon
 }
 
 // DeaconConfig represents deacon process settings.
@@ -130,8 +289,18 @@ type RigSettings struct {
 	Theme      *ThemeConfig      `json:"theme,omitempty"`       // tmux theme settings
 	Namepool   *NamepoolConfig   `json:"namepool,omitempty"`    // polecat name pool settings
 	Crew       *CrewConfig       `json:"crew,omitempty"`        // crew startup settings
+	Scheduler  *SchedulerConfig  `json:"scheduler,omitempty"`   // convoy scheduler settings
+	WarmPool   *WarmPoolConfig   `json:"warm_pool,omitempty"`   // pre-provisioned polecat worktrees
+	Sparse     *SparseConfig     `json:"sparse,omitempty"`      // sparse checkout / partial clone
+	Sandbox    *SandboxConfig    `json:"sandbox,omitempty"`     // polecat session isolation
+	Limits     *LimitsConfig     `json:"limits,omitempty"`      // agent session resource limits
 	Runtime    *RuntimeConfig    `json:"runtime,omitempty"`     // LLM runtime settings (deprecated: use Agent)
 
+	// Capabilities declares what the rig's agents can work on (languages,
+	// tooling, accounts: "go", "docker", "account:work"). gt sling --auto
+	// routes beads whose needs:<capability> labels they cover here.
+	Capabilities []string `json:"capabilities,omitempty"`
+
 	// Agent selects which agent preset to use for this rig.
 	// Can be a built-in preset ("claude", "gemini", "codex")
 	// or a custom agent defined in settings/agents.json.
@@ -140,6 +309,291 @@ type RigSettings struct {
 	Agent string `json:"agent,omitempty"`
 }
 
+// DefaultSchedulerMaxPolecats is the convoy scheduler's per-rig polecat
+// concurrency when a rig doesn't configure one.
+const DefaultSchedulerMaxPolecats = 3
+
+// SchedulerConfig represents convoy scheduler settings for a rig.
+type SchedulerConfig struct {
+	// MaxPolecats caps how many polecats may run in the rig at once.
+	// The scheduler only slings new work while fewer are active.
+	// Zero uses DefaultSchedulerMaxPolecats.
+	MaxPolecats int `json:"max_polecats,omitempty"`
+}
+
+// WarmPoolConfig represents warm polecat pool settings for a rig.
+type WarmPoolConfig struct {
+	// Size is how many idle, pre-provisioned polecat worktrees the daemon
+	// keeps ready for gt sling to claim. Zero disables the warm pool.
+	Size int `json:"size,omitempty"`
+}
+
+// SparseConfig represents sparse-checkout and partial-clone settings for a
+// rig. Large monorepo rigs use it so worktrees only check out what work needs.
+type SparseConfig struct {
+	// Cone lists directories for cone-mode sparse checkout, relative to the
+	// repo root. Top-level files are always checked out. Empty (with no
+	// per-bead paths) checks out the whole tree.
+	Cone []string `json:"cone,omitempty"`
+
+	// PerBead adds the directories a polecat's hooked bead names (a
+	// "paths:" description line or "path:<dir>" labels) to the cone.
+	PerBead bool `json:"per_bead,omitempty"`
+
+	// Filter is a partial-clone filter (e.g., "blob:none", "tree:0") so
+	// clones and fetches skip objects until a checkout needs them.
+	Filter string `json:"filter,omitempty"`
+}
+
+// ConePaths returns the cone directories for a worktree: the configured cone
+// plus beadPaths when PerBead is set, cleaned and deduplicated.
+// Returns nil when the worktree should check out the whole tree.
+func (c *SparseConfig) ConePaths(beadPaths []string) []string {
+	if c == nil {
+		return nil
+	}
+	paths := append([]string(nil), c.Cone...)
+	if c.PerBead {
+		paths = append(paths, beadPaths...)
+	}
+
+	seen := make(map[string]bool)
+	var cone []string
+	for _, p := range paths {
+		p = strings.Trim(filepath.ToSlash(filepath.Clean(p)), "/")
+		if p == "" || p == "." || seen[p] {
+			continue
+		}
+		seen[p] = true
+		cone = append(cone, p)
+	}
+	return cone
+}
+
+// partialCloneFilterRe matches the partial-clone filters git accepts that
+// make sense for a rig (combine: filters are left to git to validate).
+var partialCloneFilterRe = regexp.MustCompile(`^(blob:none|blob:limit=\d+[kmg]?|tree:\d+|object:type=(blob|tree|commit|tag)|sparse:oid=\S+|combine:\S+)$`)
+
+// Validate reports problems with the sparse settings: cone entries that
+// aren't plain relative directories, and unrecognized filters.
+func (c *SparseConfig) Validate() []string {
+	if c == nil {
+		return nil
+	}
+	var problems []string
+	for _, p := range c.Cone {
+		switch {
+		case strings.TrimSpace(p) == "":
+			problems = append(problems, "empty cone path")
+		case filepath.IsAbs(p) || strings.HasPrefix(p, "/"):
+			problems = append(problems, fmt.Sprintf("cone path %q must be relative to the repo root", p))
+		case strings.ContainsAny(p, "*?["):
+			problems = append(problems, fmt.Sprintf("cone path %q is a pattern; cone mode takes directories", p))
+		case strings.HasPrefix(filepath.Clean(p), ".."):
+			problems = append(problems, fmt.Sprintf("cone path %q escapes the repo", p))
+		}
+	}
+	if c.Filter != "" && !partialCloneFilterRe.MatchString(c.Filter) {
+		problems = append(problems, fmt.Sprintf("unrecognized partial clone filter %q (e.g., blob:none, blob:limit=1m, tree:0)", c.Filter))
+	}
+	return problems
+}
+
+// ResourceLimits caps an agent session's CPU, memory and process count.
+// Zero fields are unlimited.
+type ResourceLimits struct {
+	// Memory caps the session's memory (e.g., "4g", "512m").
+	Memory string `json:"memory,omitempty"`
+
+	// CPUs caps the session's CPU time in cores (e.g., 1.5).
+	CPUs float64 `json:"cpus,omitempty"`
+
+	// PIDs caps the number of processes in the session.
+	PIDs int `json:"pids,omitempty"`
+}
+
+// IsZero reports whether no limit is set.
+func (l *ResourceLimits) IsZero() bool {
+	return l == nil || (l.Memory == "" && l.CPUs == 0 && l.PIDs == 0)
+}
+
+// memoryRe matches memory sizes: a number with an optional b/k/m/g suffix.
+var memoryRe = regexp.MustCompile(`(?i)^(\d+)([bkmg]?)$`)
+
+// MemoryBytes returns the memory limit in bytes (0 = unlimited).
+func (l *ResourceLimits) MemoryBytes() (int64, error) {
+	if l == nil || l.Memory == "" {
+		return 0, nil
+	}
+	m := memoryRe.FindStringSubmatch(strings.TrimSpace(l.Memory))
+	if m == nil {
+		return 0, fmt.Errorf("invalid memory limit %q (e.g., 4g, 512m)", l.Memory)
+	}
+	n, err := strconv.ParseInt(m[1], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid memory limit %q: %w", l.Memory, err)
+	}
+	switch strings.ToLower(m[2]) {
+	case "k":
+		n <<= 10
+	case "m":
+		n <<= 20
+	case "g":
+		n <<= 30
+	}
+	return n, nil
+}
+
+// Validate reports problems with the limits.
+func (l *ResourceLimits) Validate() []string {
+	if l == nil {
+		return nil
+	}
+	var problems []string
+	if _, err := l.MemoryBytes(); err != nil {
+		problems = append(problems, err.Error())
+	}
+	if l.CPUs < 0 {
+		problems = append(problems, "cpus must not be negative")
+	}
+	if l.PIDs < 0 {
+		problems = append(problems, "pids must not be negative")
+	}
+	return problems
+}
+
+// Merge returns l with its unset fields filled in from base.
+func (l *ResourceLimits) Merge(base *ResourceLimits) *ResourceLimits {
+	var merged ResourceLimits
+	if l != nil {
+		merged = *l
+	}
+	if base == nil {
+		return &merged
+	}
+	if merged.Memory == "" {
+		merged.Memory = base.Memory
+	}
+	if merged.CPUs == 0 {
+		merged.CPUs = base.CPUs
+	}
+	if merged.PIDs == 0 {
+		merged.PIDs = base.PIDs
+	}
+	return &merged
+}
+
+// LimitsConfig represents agent session resource limits: defaults for
+// every role, with per-role overrides (e.g., a bigger refinery).
+type LimitsConfig struct {
+	ResourceLimits
+
+	// Roles overrides the defaults for a role ("polecat", "crew",
+	// "witness", "refinery", "mayor", "deacon").
+	Roles map[string]*ResourceLimits `json:"roles,omitempty"`
+}
+
+// ForRole returns the limits for role: its override merged over the defaults.
+func (c *LimitsConfig) ForRole(role string) *ResourceLimits {
+	if c == nil {
+		return &ResourceLimits{}
+	}
+	return c.Roles[role].Merge(&c.ResourceLimits)
+}
+
+// Validate reports problems with the defaults and every role override.
+func (c *LimitsConfig) Validate() []string {
+	if c == nil {
+		return nil
+	}
+	problems := c.ResourceLimits.Validate()
+	roles := make([]string, 0, len(c.Roles))
+	for role := range c.Roles {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+	for _, role := range roles {
+		for _, problem := range c.Roles[role].Validate() {
+			problems = append(problems, role+": "+problem)
+		}
+	}
+	return problems
+}
+
+// Sandbox runtimes.
+const (
+	SandboxPodman = "podman" // rootless container
+	SandboxBwrap  = "bwrap"  // bubblewrap user namespace
+)
+
+// Sandbox network policies.
+const (
+	SandboxNetworkHost    = "host"    // share the host network (default)
+	SandboxNetworkPrivate = "private" // own network namespace with outbound NAT (podman only)
+	SandboxNetworkNone    = "none"    // loopback only
+)
+
+// SandboxConfig represents polecat session isolation settings for a rig.
+// When a runtime is set, polecat sessions run inside a rootless container or
+// user namespace that only sees the polecat's worktree and the town's beads.
+type SandboxConfig struct {
+	// Runtime is "podman" or "bwrap". Empty runs polecats on the host.
+	Runtime string `json:"runtime,omitempty"`
+
+	// Image is the container image for podman. It must provide the agent
+	// CLI, gt and bd at the same paths the rig's startup command uses.
+	Image string `json:"image,omitempty"`
+
+	// Network is "host" (default), "private" or "none".
+	Network string `json:"network,omitempty"`
+
+	// ResourceLimits are enforced by the sandbox runtime. Fields left unset
+	// fall back to the rig's polecat limits.
+	ResourceLimits
+
+	// Mounts lists extra host paths to bind into the sandbox, read-only
+	// unless suffixed with ":rw" (e.g., "~/.claude:rw", "/opt/tools").
+	Mounts []string `json:"mounts,omitempty"`
+}
+
+// Enabled reports whether polecat sessions should be sandboxed.
+func (c *SandboxConfig) Enabled() bool {
+	return c != nil && c.Runtime != ""
+}
+
+// Validate reports problems with the sandbox settings.
+func (c *SandboxConfig) Validate() []string {
+	if !c.Enabled() {
+		return nil
+	}
+	var problems []string
+	switch c.Runtime {
+	case SandboxPodman:
+		if c.Image == "" {
+			problems = append(problems, "podman sandbox requires an image")
+		}
+	case SandboxBwrap:
+		if c.Network == SandboxNetworkPrivate {
+			problems = append(problems, "private network requires the podman runtime (use host or none)")
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("unknown sandbox runtime %q (want podman or bwrap)", c.Runtime))
+	}
+	switch c.Network {
+	case "", SandboxNetworkHost, SandboxNetworkPrivate, SandboxNetworkNone:
+	default:
+		problems = append(problems, fmt.Sprintf("unknown network policy %q (want host, private or none)", c.Network))
+	}
+	problems = append(problems, c.ResourceLimits.Validate()...)
+	for _, m := range c.Mounts {
+		src := strings.TrimSuffix(strings.TrimSuffix(m, ":rw"), ":ro")
+		if src == "" || !(filepath.IsAbs(src) || strings.HasPrefix(src, "~/")) {
+			problems = append(problems, fmt.Sprintf("mount %q must be an absolute or ~/ path", m))
+		}
+	}
+	return problems
+}
+
 // CrewConfig represents crew workspace settings for a rig.
 type CrewConfig struct {
 	// Startup is a natural language instruction for which crew to start on boot.
@@ -296,6 +750,33 @@ type MergeQueueConfig struct {
 	// RetryFlakyTests is the number of times to retry flaky tests.
 	RetryFlakyTests int `json:"retry_flaky_tests"`
 
+	// TestResultsFormat is how to parse test results: "go-json" (go test -json
+	// output), "junit" (JUnit XML), or empty to detect.
+	TestResultsFormat string `json:"test_results_format,omitempty"`
+
+	// TestResultsPath is a JUnit XML report written by the test command,
+	// relative to the rig.
+	TestResultsPath string `json:"test_results_path,omitempty"`
+
+	// RerunCommand reruns only the failing tests; {tests} and {run} expand
+	// to the failing test names. Go results default to go test -run.
+	RerunCommand string `json:"rerun_command,omitempty"`
+
+	// FlakyThreshold is the recent flip rate at which a test is quarantined
+	// as flaky, above 0 and at most 1. Nil uses the refinery's default.
+	FlakyThreshold *float64 `json:"flaky_threshold,omitempty"`
+
+	// AllowQuarantinedFailures lets an MR merge when its only failing
+	// tests are quarantined.
+	AllowQuarantinedFailures bool `json:"allow_quarantined_failures,omitempty"`
+
+	// PostMergeCheck tests the target after each merge, bisecting recent
+	// merges and reverting the one that broke it on failure.
+	PostMergeCheck bool `json:"post_merge_check,omitempty"`
+
+	// PostMergeWindow is how many recent merges the post-merge bisect searches.
+	PostMergeWindow int `json:"post_merge_window,omitempty"`
+
 	// PollInterval is how often to poll for new merge requests (e.g., "30s").
 	PollInterval string `json:"poll_interval"`
 
@@ -354,6 +835,12 @@ type AccountsConfig struct {
 	Version  int                `json:"version"`  // schema version
 	Accounts map[string]Account `json:"accounts"` // handle -> account details
 	Default  string             `json:"default"`  // default account handle
+
+	// Pool lists the accounts new sessions are balanced across when no
+	// account is requested, and that the daemon rotates sessions onto when
+	// their account hits a usage or rate limit. Empty disables the pool:
+	// sessions use the default account and are never rotated.
+	Pool []string `json:"pool,omitempty"`
 }
 
 // Account represents a single Claude Code account.
@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/util"
@@ -35,6 +36,16 @@ func NewManager(r *rig.Rig, g *git.Git) *Manager {
 	}
 }
 
+// sparseConfig returns the rig's sparse checkout / partial clone settings,
+// or nil if the rig checks out everything.
+func (m *Manager) sparseConfig() *config.SparseConfig {
+	settings, err := config.LoadRigSettings(filepath.Join(m.rig.Path, "settings", "config.json"))
+	if err != nil {
+		return nil
+	}
+	return settings.Sparse
+}
+
 // crewDir returns the directory for a crew worker.
 func (m *Manager) crewDir(name string) string {
 	return filepath.Join(m.rig.Path, "crew", name)
@@ -71,7 +82,14 @@ func (m *Manager) Add(name string, createBranch bool) (*CrewWorker, error) {
 	}
 
 	// Clone the rig repo
-	if m.rig.LocalRepo != "" {
+	if sparse := m.sparseConfig(); sparse != nil && (sparse.Filter != "" || len(sparse.Cone) > 0) {
+		// Monorepo rigs: partial clone and/or sparse checkout of the rig's cone
+		opts := git.CloneOptions{Reference: m.rig.LocalRepo, Filter: sparse.Filter, Sparse: sparse.ConePaths(nil)}
+		if err := m.git.CloneWithOptions(m.rig.GitURL, crewPath, opts); err != nil {
+			_ = os.RemoveAll(crewPath) // best-effort cleanup
+			return nil, fmt.Errorf("cloning rig: %w", err)
+		}
+	} else if m.rig.LocalRepo != "" {
 		if err := m.git.CloneWithReference(m.rig.GitURL, crewPath, m.rig.LocalRepo); err != nil {
 			fmt.Printf("Warning: could not clone with local repo reference: %v\n", err)
 			if err := m.git.Clone(m.rig.GitURL, crewPath); err != nil {
@@ -22,6 +22,49 @@ type CrewWorker struct {
 
 	// UpdatedAt is when the crew worker was last updated.
 	UpdatedAt time.Time `json:"updated_at"`
+
+	// Worktrees are the worktrees this crew worker keeps in other rigs.
+	Worktrees []Worktree `json:"worktrees,omitempty"`
+}
+
+// Worktree is a worktree a crew worker keeps in another rig for cross-rig
+// work (gt worktree <rig>). It lives in the other rig's crew/ directory but
+// is registered in the owning crew worker's state.
+type Worktree struct {
+	// Rig is the rig the worktree is in.
+	Rig string `json:"rig"`
+
+	// Path is the worktree directory.
+	Path string `json:"path"`
+
+	// Branch is the worktree's working branch.
+	Branch string `json:"branch"`
+
+	// Target is the rig's branch the worktree syncs with and merges into.
+	Target string `json:"target"`
+
+	// CreatedAt is when the worktree was created.
+	CreatedAt time.Time `json:"created_at"`
+
+	// SyncedAt is when the worktree was last rebased onto Target.
+	SyncedAt time.Time `json:"synced_at,omitempty"`
+
+	// PushedAt is when the branch was last pushed.
+	PushedAt time.Time `json:"pushed_at,omitempty"`
+
+	// LastMR is the merge request most recently submitted from the worktree.
+	LastMR string `json:"last_mr,omitempty"`
+}
+
+// LastActive returns when the worktree was last created, synced or pushed.
+func (w *Worktree) LastActive() time.Time {
+	last := w.CreatedAt
+	for _, t := range []time.Time{w.SyncedAt, w.PushedAt} {
+		if t.After(last) {
+			last = t
+		}
+	}
+	return last
 }
 
 // Summary provides a concise view of crew worker status.
@@ -0,0 +1,188 @@
+package crew
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/git"
+)
+
+// StaleWorktreeAge is how long a cross-rig worktree can go without being
+// synced or pushed before gt doctor flags it.
+const StaleWorktreeAge = 14 * 24 * time.Hour
+
+// WorktreeName returns the directory name of a crew worker's worktree in
+// another rig: <source-rig>-<name>.
+func WorktreeName(sourceRig, crewName string) string {
+	return fmt.Sprintf("%s-%s", sourceRig, crewName)
+}
+
+// WorktreeOwner reports whether dir in rigName's crew/ directory is another
+// rig's cross-rig worktree, returning the owning crew worker. A directory
+// with its own crew state is a crew member of rigName, even if its name has
+// a dash.
+func WorktreeOwner(townRoot, rigName, dir string, rigs []string) (sourceRig, crewName string, ok bool) {
+	if _, err := os.Stat(filepath.Join(townRoot, rigName, "crew", dir, "state.json")); err == nil {
+		return "", "", false
+	}
+	for _, name := range rigs {
+		rest, found := strings.CutPrefix(dir, name+"-")
+		if !found || name == rigName || rest == "" {
+			continue
+		}
+		if info, err := os.Stat(filepath.Join(townRoot, name, "crew", rest)); err == nil && info.IsDir() {
+			return name, rest, true
+		}
+	}
+	return "", "", false
+}
+
+// RegisterWorktree records a cross-rig worktree in a crew worker's state,
+// replacing any existing registration for the same rig.
+func (m *Manager) RegisterWorktree(name string, wt Worktree) error {
+	return m.updateWorktrees(name, func(worker *CrewWorker) {
+		for i := range worker.Worktrees {
+			if worker.Worktrees[i].Rig == wt.Rig {
+				worker.Worktrees[i] = wt
+				return
+			}
+		}
+		worker.Worktrees = append(worker.Worktrees, wt)
+	})
+}
+
+// UpdateWorktree applies fn to a crew worker's registered worktree in rig.
+func (m *Manager) UpdateWorktree(name, rig string, fn func(*Worktree)) error {
+	found := false
+	err := m.updateWorktrees(name, func(worker *CrewWorker) {
+		for i := range worker.Worktrees {
+			if worker.Worktrees[i].Rig == rig {
+				fn(&worker.Worktrees[i])
+				found = true
+			}
+		}
+	})
+	if err == nil && !found {
+		return fmt.Errorf("no worktree registered in rig %s", rig)
+	}
+	return err
+}
+
+// UnregisterWorktree removes a crew worker's worktree registration for rig.
+// It is not an error if none exists.
+func (m *Manager) UnregisterWorktree(name, rig string) error {
+	return m.updateWorktrees(name, func(worker *CrewWorker) {
+		kept := worker.Worktrees[:0]
+		for _, wt := range worker.Worktrees {
+			if wt.Rig != rig {
+				kept = append(kept, wt)
+			}
+		}
+		worker.Worktrees = kept
+	})
+}
+
+// Worktree returns a crew worker's registered worktree in rig, or nil.
+func (m *Manager) Worktree(name, rig string) (*Worktree, error) {
+	worker, err := m.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	for i := range worker.Worktrees {
+		if worker.Worktrees[i].Rig == rig {
+			return &worker.Worktrees[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *Manager) updateWorktrees(name string, fn func(*CrewWorker)) error {
+	worker, err := m.Get(name)
+	if err != nil {
+		return err
+	}
+	fn(worker)
+	worker.UpdatedAt = time.Now()
+	return m.saveState(worker)
+}
+
+// WorktreeStatus is the git state of a cross-rig worktree, read from local
+// refs only (run a fetch first for up-to-date remote counts).
+type WorktreeStatus struct {
+	Exists      bool   `json:"exists"`
+	Branch      string `json:"branch,omitempty"` // Checked-out branch (may differ from registered)
+	Uncommitted int    `json:"uncommitted"`      // Modified, added, deleted and untracked files
+	Upstream    bool   `json:"upstream"`         // origin/<branch> exists
+	Unpushed    int    `json:"unpushed"`         // Commits not on origin/<branch> (or the target if never pushed)
+	Ahead       int    `json:"ahead"`            // Commits not on origin/<target>
+	Behind      int    `json:"behind"`           // Commits on origin/<target> not in the worktree
+	Error       string `json:"error,omitempty"`  // Why the status couldn't be read
+}
+
+// Status reads the worktree's git state.
+func (w *Worktree) Status() *WorktreeStatus {
+	s := &WorktreeStatus{}
+	if _, err := os.Stat(filepath.Join(w.Path, ".git")); err != nil {
+		return s
+	}
+	s.Exists = true
+
+	g := git.NewGit(w.Path)
+	branch, err := g.CurrentBranch()
+	if err != nil {
+		s.Error = err.Error()
+		return s
+	}
+	s.Branch = branch
+
+	status, err := g.Status()
+	if err != nil {
+		s.Error = err.Error()
+		return s
+	}
+	s.Uncommitted = len(status.Modified) + len(status.Added) + len(status.Deleted) + len(status.Untracked)
+
+	target := "origin/" + w.Target
+	if g.RefExists(target) {
+		s.Ahead, s.Behind, _ = g.AheadBehind(target, "HEAD")
+	}
+	if g.RefExists("origin/" + branch) {
+		s.Upstream = true
+		s.Unpushed, _ = g.CommitsAhead("origin/"+branch, "HEAD")
+	} else {
+		s.Unpushed = s.Ahead
+	}
+	return s
+}
+
+// Summary describes the status in a few words for listings.
+func (s *WorktreeStatus) Summary() string {
+	switch {
+	case !s.Exists:
+		return "missing"
+	case s.Error != "":
+		return "error"
+	}
+	parts := []string{}
+	if s.Uncommitted > 0 {
+		parts = append(parts, fmt.Sprintf("%d uncommitted", s.Uncommitted))
+	}
+	if s.Ahead > 0 {
+		parts = append(parts, fmt.Sprintf("%d ahead", s.Ahead))
+	}
+	if s.Behind > 0 {
+		parts = append(parts, fmt.Sprintf("%d behind", s.Behind))
+	}
+	if s.Unpushed > 0 {
+		parts = append(parts, fmt.Sprintf("%d unpushed", s.Unpushed))
+	} else if s.Ahead > 0 {
+		parts = append(parts, "pushed")
+	}
+	if len(parts) == 0 {
+		return "clean"
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,127 @@
+package crew
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+func TestWorktreeRegistration(t *testing.T) {
+	rigPath := filepath.Join(t.TempDir(), "gastown")
+	if err := os.MkdirAll(filepath.Join(rigPath, "crew", "joe"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	mgr := NewManager(&rig.Rig{Name: "gastown", Path: rigPath}, git.NewGit(rigPath))
+
+	created := time.Now().Add(-time.Hour)
+	for _, rigName := range []string{"beads", "mayor"} {
+		if err := mgr.RegisterWorktree("joe", Worktree{Rig: rigName, Branch: "crew/gastown-joe", Target: "main", CreatedAt: created}); err != nil {
+			t.Fatalf("RegisterWorktree(%s): %v", rigName, err)
+		}
+	}
+	// Re-registering replaces rather than duplicates
+	if err := mgr.RegisterWorktree("joe", Worktree{Rig: "beads", Branch: "fix/bd-a1b", Target: "main", CreatedAt: created}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := mgr.UpdateWorktree("joe", "beads", func(w *Worktree) { w.LastMR = "bd-mr1" }); err != nil {
+		t.Fatalf("UpdateWorktree: %v", err)
+	}
+	if err := mgr.UpdateWorktree("joe", "nope", func(w *Worktree) {}); err == nil {
+		t.Error("UpdateWorktree on an unregistered rig should fail")
+	}
+
+	wt, err := mgr.Worktree("joe", "beads")
+	if err != nil || wt == nil {
+		t.Fatalf("Worktree(beads) = %v, %v", wt, err)
+	}
+	if wt.Branch != "fix/bd-a1b" || wt.LastMR != "bd-mr1" {
+		t.Errorf("beads worktree = %+v", wt)
+	}
+
+	if err := mgr.UnregisterWorktree("joe", "beads"); err != nil {
+		t.Fatalf("UnregisterWorktree: %v", err)
+	}
+	worker, err := mgr.Get("joe")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(worker.Worktrees) != 1 || worker.Worktrees[0].Rig != "mayor" {
+		t.Errorf("Worktrees = %+v, want only mayor", worker.Worktrees)
+	}
+}
+
+func TestWorktreeLastActive(t *testing.T) {
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	wt := Worktree{CreatedAt: created}
+	if !wt.LastActive().Equal(created) {
+		t.Errorf("LastActive = %v, want created", wt.LastActive())
+	}
+	wt.PushedAt = created.Add(48 * time.Hour)
+	wt.SyncedAt = created.Add(24 * time.Hour)
+	if !wt.LastActive().Equal(wt.PushedAt) {
+		t.Errorf("LastActive = %v, want pushed", wt.LastActive())
+	}
+}
+
+func TestWorktreeOwner(t *testing.T) {
+	town := t.TempDir()
+	for _, dir := range []string{"gastown/crew/joe", "beads/crew/gastown-joe", "beads/crew/gastown-fan"} {
+		if err := os.MkdirAll(filepath.Join(town, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(town, "beads/crew/gastown-fan/state.json"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	rigs := []string{"gastown", "beads"}
+
+	if src, name, ok := WorktreeOwner(town, "beads", "gastown-joe", rigs); !ok || src != "gastown" || name != "joe" {
+		t.Errorf("WorktreeOwner(gastown-joe) = %q, %q, %v", src, name, ok)
+	}
+	if _, _, ok := WorktreeOwner(town, "beads", "gastown-fan", rigs); ok {
+		t.Error("crew member with state should not be a worktree")
+	}
+	if _, _, ok := WorktreeOwner(town, "beads", "gastown-nobody", rigs); ok {
+		t.Error("unknown owner should not be a worktree")
+	}
+}
+
+func TestWorktreeStatus(t *testing.T) {
+	tmp := t.TempDir()
+	origin := filepath.Join(tmp, "origin.git")
+	clone := filepath.Join(tmp, "clone")
+	for _, args := range [][]string{
+		{"init", "--bare", "-b", "main", origin},
+		{"clone", origin, clone},
+		{"-C", clone, "-c", "user.name=t", "-c", "user.email=t@t", "commit", "--allow-empty", "-m", "base"},
+		{"-C", clone, "push", "origin", "main"},
+		{"-C", clone, "checkout", "-b", "crew/gastown-joe"},
+		{"-C", clone, "-c", "user.name=t", "-c", "user.email=t@t", "commit", "--allow-empty", "-m", "work"},
+	} {
+		if err := runCmd("git", args...); err != nil {
+			t.Fatalf("git %v: %v", args, err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(clone, "scratch.txt"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	wt := Worktree{Rig: "beads", Path: clone, Branch: "crew/gastown-joe", Target: "main"}
+	s := wt.Status()
+	if !s.Exists || s.Branch != "crew/gastown-joe" || s.Uncommitted != 1 || s.Ahead != 1 || s.Behind != 0 || s.Upstream || s.Unpushed != 1 {
+		t.Errorf("Status = %+v", s)
+	}
+	if got, want := s.Summary(), "1 uncommitted, 1 ahead, 1 unpushed"; got != want {
+		t.Errorf("Summary = %q, want %q", got, want)
+	}
+
+	missing := Worktree{Path: filepath.Join(tmp, "gone")}
+	if got := missing.Status().Summary(); got != "missing" {
+		t.Errorf("missing Summary = %q", got)
+	}
+}
@@ -0,0 +1,295 @@
+package daemon
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/account"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/session"
+)
+
+// accountLimitScanLines is how much of each pane is searched for limit
+// messages. Claude prints them at the bottom, so a screenful is enough.
+const accountLimitScanLines = 30
+
+// accountRotation moves a session off an account that hit a limit.
+type accountRotation struct {
+	Session string
+	From    string
+	To      string
+	Reason  string
+}
+
+// accountLimitScan remembers each session's pane between heartbeats, so a
+// limit message only counts when it is new - not stale scrollback or text
+// already on screen when the daemon started - and only once the next scan
+// still shows it, so a message scrolling past in test output is not enough.
+type accountLimitScan struct {
+	panes   map[string]string // Last capture per session
+	pending map[string]string // Limit line seen once, awaiting confirmation
+}
+
+func newAccountLimitScan() *accountLimitScan {
+	return &accountLimitScan{
+		panes:   make(map[string]string),
+		pending: make(map[string]string),
+	}
+}
+
+// observe records this heartbeat's captures and returns the limits that
+// are confirmed: first seen as new on the previous scan and still shown.
+func (a *accountLimitScan) observe(outputs map[string]string, now time.Time) map[string]*account.Limit {
+	confirmed := make(map[string]*account.Limit)
+	for sess, out := range outputs {
+		limit := account.DetectLimit(out, now)
+		prev, scanned := a.panes[sess]
+		switch {
+		case limit == nil:
+			delete(a.pending, sess)
+		case a.pending[sess] != "" && strings.Contains(out, a.pending[sess]):
+			confirmed[sess] = limit
+			delete(a.pending, sess)
+		case scanned && isNewLimit(prev, out, now):
+			a.pending[sess] = limitLine(out, now)
+		default:
+			// Already on screen last time (or nothing to compare with yet)
+			delete(a.pending, sess)
+		}
+	}
+	for sess := range a.pending {
+		if _, ok := outputs[sess]; !ok {
+			delete(a.pending, sess)
+		}
+	}
+	a.panes = outputs
+	return confirmed
+}
+
+// isNewLimit reports whether out shows a limit message that prev did not.
+func isNewLimit(prev, out string, now time.Time) bool {
+	if account.DetectLimit(prev, now) == nil {
+		return true
+	}
+	line := limitLine(out, now)
+	return line != "" && !strings.Contains(prev, line)
+}
+
+// limitLine returns the last pane line holding a limit message, or the whole
+// output when the message is wrapped across lines.
+func limitLine(out string, now time.Time) string {
+	lines := strings.Split(out, "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		if line := strings.TrimSpace(lines[i]); line != "" && account.DetectLimit(line, now) != nil {
+			return line
+		}
+	}
+	return strings.TrimSpace(out)
+}
+
+// checkAccountLimits looks for usage and rate limit messages in agent
+// panes. The account behind a limited session is marked as cooling down
+// until its reset time, and - if the town has an account pool - the session
+// is restarted on the least-loaded pool account that is still available.
+// Work survives the restart on the agent's hook.
+func (d *Daemon) checkAccountLimits() {
+	cfg, err := config.LoadAccountsConfig(constants.MayorAccountsPath(d.config.TownRoot))
+	if err != nil || len(cfg.Accounts) == 0 {
+		return // No accounts configured
+	}
+
+	// Talk to tmux before taking the state lock, so spawns waiting to
+	// record their account aren't held up by a slow capture.
+	snapshot, err := account.Load(d.config.TownRoot)
+	if err != nil {
+		d.logger.Printf("Error checking account limits: %v", err)
+		return
+	}
+	live, err := d.tmux.ListSessions()
+	if err != nil {
+		return
+	}
+	alive := make(map[string]bool, len(live))
+	for _, sess := range live {
+		alive[sess] = true
+	}
+	discovered := d.discoverAccountSessions(cfg, snapshot, live)
+	outputs := make(map[string]string)
+	for _, sess := range live {
+		if snapshot.Sessions[sess] == "" && discovered[sess] == "" {
+			continue
+		}
+		if out, err := d.tmux.CapturePane(sess, accountLimitScanLines); err == nil {
+			outputs[sess] = out
+		}
+	}
+	if d.accountScan == nil {
+		d.accountScan = newAccountLimitScan()
+	}
+	limits := d.accountScan.observe(outputs, time.Now())
+
+	var rotations []accountRotation
+	err = account.Update(d.config.TownRoot, func(s *account.State) error {
+		s.Prune(func(sess string) bool { return alive[sess] })
+		for sess, handle := range discovered {
+			if s.Sessions[sess] == "" {
+				s.Assign(sess, handle)
+			}
+		}
+		rotations = planAccountRotations(cfg, s, limits, time.Now())
+		return nil
+	})
+	if err != nil {
+		d.logger.Printf("Error checking account limits: %v", err)
+		return
+	}
+
+	for _, r := range rotations {
+		if r.To == "" {
+			d.logger.Printf("Account %s hit a limit (%s) in %s; no pool account available to rotate to",
+				r.From, r.Reason, r.Session)
+			continue
+		}
+		d.logger.Printf("Account %s hit a limit (%s); rotating %s to %s", r.From, r.Reason, r.Session, r.To)
+		if err := d.restartOnAccount(r.Session); err != nil {
+			d.logger.Printf("Error rotating %s to account %s: %v", r.Session, r.To, err)
+			d.undoAccountRotation(r)
+		}
+	}
+}
+
+// discoverAccountSessions finds Gas Town sessions started outside the
+// spawn paths that record accounts (e.g. by hand), matching their
+// CLAUDE_CONFIG_DIR to an account. Returns session -> account handle.
+func (d *Daemon) discoverAccountSessions(cfg *config.AccountsConfig, s *account.State, live []string) map[string]string {
+	discovered := make(map[string]string)
+	for _, sess := range live {
+		if !strings.HasPrefix(sess, session.Prefix) || s.Sessions[sess] != "" {
+			continue
+		}
+		dir, err := d.tmux.GetEnvironment(sess, account.EnvConfigDir)
+		if err != nil || dir == "" {
+			continue
+		}
+		if handle := account.HandleForConfigDir(cfg, dir); handle != "" {
+			discovered[sess] = handle
+		}
+	}
+	return discovered
+}
+
+// planAccountRotations marks the accounts of sessions with a confirmed
+// limit as cooling down, and picks a new pool account for each of those
+// sessions. A rotation with an empty To means the account just started
+// cooling down but no other account can take the session (no pool, or
+// every pool account is cooling down too).
+func planAccountRotations(cfg *config.AccountsConfig, s *account.State, limits map[string]*account.Limit, now time.Time) []accountRotation {
+	sessions := make([]string, 0, len(limits))
+	for sess := range limits {
+		sessions = append(sessions, sess)
+	}
+	sort.Strings(sessions)
+
+	var rotations []accountRotation
+	for _, sess := range sessions {
+		handle := s.Sessions[sess]
+		limit := limits[sess]
+		if handle == "" || limit == nil {
+			continue
+		}
+		newlyLimited := !s.CoolingDown(handle, now)
+		if newlyLimited {
+			s.MarkLimited(handle, limit, now)
+		}
+
+		r := accountRotation{Session: sess, From: handle, Reason: limit.Reason}
+		if next, err := account.Select(cfg.Pool, s, cfg.Default, now); err == nil && next != handle {
+			r.To = next
+			// Count the move now so later sessions spread across the pool
+			s.Assign(sess, next)
+		} else if !newlyLimited {
+			continue // Already reported; keep waiting for an account
+		}
+		rotations = append(rotations, r)
+	}
+	return rotations
+}
+
+// accountConfigDir returns the CLAUDE_CONFIG_DIR recorded for a session in
+// the account pool state, or "" if the session has no account.
+func (d *Daemon) accountConfigDir(sessionName string) string {
+	s, err := account.Load(d.config.TownRoot)
+	if err != nil || s.Sessions[sessionName] == "" {
+		return ""
+	}
+	cfg, err := config.LoadAccountsConfig(constants.MayorAccountsPath(d.config.TownRoot))
+	if err != nil {
+		return ""
+	}
+	acct := cfg.GetAccount(s.Sessions[sessionName])
+	if acct == nil {
+		return ""
+	}
+	return config.ExpandAccountConfigDir(acct)
+}
+
+// restartOnAccount kills a session and starts it again; the restart paths
+// pick up the session's newly assigned account. The session is only killed
+// once its startup command has been resolved, so a restart that can't work
+// (missing worktree, broken sandbox) leaves the old session running.
+func (d *Daemon) restartOnAccount(sessionName string) error {
+	identity, err := session.ParseSessionName(sessionName)
+	if err != nil {
+		return err
+	}
+
+	if identity.Role == session.RolePolecat {
+		if _, err := d.polecatStartCommand(identity.Rig, identity.Name, d.accountConfigDir(sessionName)); err != nil {
+			return err
+		}
+		if err := d.tmux.KillSession(sessionName); err != nil {
+			return fmt.Errorf("killing session: %w", err)
+		}
+		return d.restartPolecatSession(identity.Rig, identity.Name, sessionName)
+	}
+
+	var roleIdentity string
+	switch identity.Role {
+	case session.RoleMayor, session.RoleDeacon:
+		roleIdentity = string(identity.Role)
+	case session.RoleWitness, session.RoleRefinery:
+		roleIdentity = identity.Rig + "-" + string(identity.Role)
+	case session.RoleCrew:
+		roleIdentity = identity.Rig + "-crew-" + identity.Name
+	default:
+		return fmt.Errorf("unknown role for session %s", sessionName)
+	}
+	config, parsed, err := d.getRoleConfigForIdentity(roleIdentity)
+	if err != nil {
+		return fmt.Errorf("parsing identity: %w", err)
+	}
+	if d.getWorkDir(config, parsed) == "" {
+		return fmt.Errorf("cannot determine working directory for %s", roleIdentity)
+	}
+	if err := d.tmux.KillSession(sessionName); err != nil {
+		return fmt.Errorf("killing session: %w", err)
+	}
+	return d.restartSession(sessionName, roleIdentity)
+}
+
+// undoAccountRotation moves a session whose restart failed back to the
+// account it was on, unless something else has reassigned it since.
+func (d *Daemon) undoAccountRotation(r accountRotation) {
+	err := account.Update(d.config.TownRoot, func(s *account.State) error {
+		if s.Sessions[r.Session] == r.To {
+			s.Assign(r.Session, r.From)
+		}
+		return nil
+	})
+	if err != nil {
+		d.logger.Printf("Error restoring %s to account %s: %v", r.Session, r.From, err)
+	}
+}
@@ -0,0 +1,166 @@
+package daemon
+
+import (
+	"io"
+	"log"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/account"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+// detectLimits runs limit detection over pane outputs, as a confirmed scan would.
+func detectLimits(outputs map[string]string, now time.Time) map[string]*account.Limit {
+	limits := make(map[string]*account.Limit)
+	for sess, out := range outputs {
+		if limit := account.DetectLimit(out, now); limit != nil {
+			limits[sess] = limit
+		}
+	}
+	return limits
+}
+
+func TestPlanAccountRotations(t *testing.T) {
+	now := time.Now()
+	cfg := config.NewAccountsConfig()
+	for _, h := range []string{"work", "spare", "extra"} {
+		cfg.Accounts[h] = config.Account{ConfigDir: "/accts/" + h}
+	}
+	cfg.Default = "work"
+	cfg.Pool = []string{"work", "spare", "extra"}
+
+	s, _ := account.Load(t.TempDir())
+	s.Assign("gt-gastown-toast", "work")
+	s.Assign("gt-gastown-nux", "work")
+	s.Assign("gt-beads-ace", "spare")
+	outputs := map[string]string{
+		"gt-gastown-toast": "Claude usage limit reached. Your limit will reset at 5pm",
+		"gt-gastown-nux":   "5-hour limit reached ∙ resets 5pm",
+		"gt-beads-ace":     "> working on bd-a1b",
+	}
+
+	rotations := planAccountRotations(cfg, s, detectLimits(outputs, now), now)
+	if len(rotations) != 2 {
+		t.Fatalf("rotations = %+v, want both work sessions", rotations)
+	}
+	// extra is idle and gets the first session; then extra and spare are
+	// tied at one session each and extra wins on handle order
+	want := map[string]string{"gt-gastown-nux": "extra", "gt-gastown-toast": "extra"}
+	for _, r := range rotations {
+		if r.From != "work" || r.To != want[r.Session] {
+			t.Errorf("rotation %+v, want %s -> %s", r, r.Session, want[r.Session])
+		}
+	}
+	if !s.CoolingDown("work", now) || s.Accounts["work"].LimitHits != 1 {
+		t.Errorf("work status = %+v, want one limit and cooling down", s.Accounts["work"])
+	}
+}
+
+func TestPlanAccountRotations_NoPool(t *testing.T) {
+	now := time.Now()
+	cfg := config.NewAccountsConfig()
+	cfg.Accounts["work"] = config.Account{ConfigDir: "/accts/work"}
+	cfg.Default = "work"
+
+	s, _ := account.Load(t.TempDir())
+	s.Assign("gt-mayor", "work")
+	limits := detectLimits(map[string]string{"gt-mayor": "API Error: 429 rate_limit_error"}, now)
+
+	rotations := planAccountRotations(cfg, s, limits, now)
+	if len(rotations) != 1 || rotations[0].To != "" {
+		t.Fatalf("rotations = %+v, want one report with nowhere to go", rotations)
+	}
+
+	// Still limited on the next heartbeat: already reported, so stay quiet
+	if rotations := planAccountRotations(cfg, s, limits, now.Add(time.Minute)); len(rotations) != 0 {
+		t.Errorf("repeat rotations = %+v, want none", rotations)
+	}
+}
+
+func TestAccountLimitScan(t *testing.T) {
+	now := time.Now()
+	const limited = "> running tests\nClaude usage limit reached. Your limit will reset at 5pm"
+	scan := newAccountLimitScan()
+
+	// First scan: the message may be stale scrollback, so it is only a baseline
+	if got := scan.observe(map[string]string{"gt-mayor": limited, "gt-gastown-nux": "> idle"}, now); len(got) != 0 {
+		t.Fatalf("baseline scan confirmed %v, want none", got)
+	}
+	if got := scan.observe(map[string]string{"gt-mayor": limited, "gt-gastown-nux": "> idle"}, now); len(got) != 0 {
+		t.Errorf("unchanged stale message confirmed %v, want none", got)
+	}
+
+	// A new message in nux's pane is pending until the next scan shows it again
+	if got := scan.observe(map[string]string{"gt-gastown-nux": limited}, now); len(got) != 0 {
+		t.Errorf("single match confirmed %v, want none", got)
+	}
+	got := scan.observe(map[string]string{"gt-gastown-nux": limited}, now)
+	if len(got) != 1 || got["gt-gastown-nux"] == nil {
+		t.Errorf("second match = %v, want nux confirmed", got)
+	}
+	if got := scan.observe(map[string]string{"gt-gastown-nux": limited}, now); len(got) != 0 {
+		t.Errorf("already acted on: confirmed %v, want none", got)
+	}
+
+	// Test output that scrolls away before the next scan is ignored
+	scan.observe(map[string]string{"gt-gastown-nux": "> idle"}, now)
+	scan.observe(map[string]string{"gt-gastown-nux": "--- FAIL: TestRetry\n    API Error: 429 rate_limit_error"}, now)
+	if got := scan.observe(map[string]string{"gt-gastown-nux": "ok  \tpkg\t0.1s"}, now); len(got) != 0 {
+		t.Errorf("scrolled-away match confirmed %v, want none", got)
+	}
+}
+
+func TestRestartOnAccount_KeepsSessionThatCantRestart(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+	d := &Daemon{
+		config: &Config{TownRoot: t.TempDir()},
+		tmux:   tmux.NewTmux(),
+		logger: log.New(io.Discard, "", 0),
+	}
+	sessionName := "gt-gtrotatetest-toast"
+	_ = d.tmux.KillSession(sessionName)
+	if err := d.tmux.NewSession(sessionName, ""); err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+	defer func() { _ = d.tmux.KillSession(sessionName) }()
+
+	// The polecat has no worktree, so a restart can't work
+	err := d.restartOnAccount(sessionName)
+	if err == nil || !strings.Contains(err.Error(), "worktree does not exist") {
+		t.Fatalf("restartOnAccount() error = %v, want missing worktree", err)
+	}
+	if has, _ := d.tmux.HasSession(sessionName); !has {
+		t.Error("session was killed although it couldn't be restarted")
+	}
+}
+
+func TestUndoAccountRotation(t *testing.T) {
+	d := &Daemon{config: &Config{TownRoot: t.TempDir()}, logger: log.New(io.Discard, "", 0)}
+	if err := account.Assign(d.config.TownRoot, "gt-gastown-toast", "spare"); err != nil {
+		t.Fatal(err)
+	}
+	if err := account.Assign(d.config.TownRoot, "gt-gastown-nux", "extra"); err != nil {
+		t.Fatal(err)
+	}
+
+	d.undoAccountRotation(accountRotation{Session: "gt-gastown-toast", From: "work", To: "spare"})
+	// nux has moved on since its rotation was planned; leave it alone
+	d.undoAccountRotation(accountRotation{Session: "gt-gastown-nux", From: "work", To: "spare"})
+
+	s, err := account.Load(d.config.TownRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Sessions["gt-gastown-toast"]; got != "work" {
+		t.Errorf("toast account = %q, want work", got)
+	}
+	if got := s.Sessions["gt-gastown-nux"]; got != "extra" {
+		t.Errorf("nux account = %q, want extra", got)
+	}
+}
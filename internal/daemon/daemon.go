@@ -10,16 +10,22 @@ import (
 	"os/signal"
 	"path/filepath"
 	"strconv"
+	"strings"
+	"sync/atomic"
 	"syscall"
 	"time"
 
+	"github.com/steveyegge/gastown/internal/account"
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/boot"
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
 	"github.com/steveyegge/gastown/internal/deacon"
+	"github.com/steveyegge/gastown/internal/escalation"
 	"github.com/steveyegge/gastown/internal/feed"
+	"github.com/steveyegge/gastown/internal/limits"
 	"github.com/steveyegge/gastown/internal/polecat"
+	"github.com/steveyegge/gastown/internal/sandbox"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/tmux"
 )
@@ -35,6 +41,10 @@ type Daemon struct {
 	ctx     context.Context
 	cancel  context.CancelFunc
 	curator *feed.Curator
+	metrics *daemonMetrics
+
+	accountScan *accountLimitScan // Pane state between account limit scans
+	doctorBusy  atomic.Bool       // A background doctor run is in flight
 }
 
 // New creates a new daemon instance.
@@ -55,11 +65,12 @@ func New(config *Config) (*Daemon, error) {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	return &Daemon{
-		config: config,
-		tmux:   tmux.NewTmux(),
-		logger: logger,
-		ctx:    ctx,
-		cancel: cancel,
+		config:  config,
+		tmux:    tmux.NewTmux(),
+		logger:  logger,
+		ctx:     ctx,
+		cancel:  cancel,
+		metrics: newDaemonMetrics(config.TownRoot),
 	}, nil
 }
 
@@ -91,9 +102,10 @@ func (d *Daemon) Run() error {
 
 	// Update state
 	state := &State{
-		Running:   true,
-		PID:       os.Getpid(),
-		StartedAt: time.Now(),
+		Running:     true,
+		PID:         os.Getpid(),
+		StartedAt:   time.Now(),
+		MetricsAddr: d.config.MetricsAddr,
 	}
 	if err := SaveState(d.config.TownRoot, state); err != nil {
 		d.logger.Printf("Warning: failed to save state: %v", err)
@@ -118,6 +130,13 @@ func (d *Daemon) Run() error {
 		d.logger.Println("Feed curator started")
 	}
 
+	// Start metrics endpoint if configured
+	if d.config.MetricsAddr != "" {
+		srv := d.startMetricsServer(d.config.MetricsAddr)
+		defer stopMetricsServer(srv)
+		d.logger.Printf("Metrics endpoint listening on %s/metrics", d.config.MetricsAddr)
+	}
+
 	// Initial heartbeat
 	d.heartbeat(state)
 
@@ -160,6 +179,7 @@ const recoveryHeartbeatInterval = 3 * time.Minute
 // - Agents with work-on-hook not progressing (GUPP violation)
 // - Orphaned work (assigned to dead agents)
 func (d *Daemon) heartbeat(state *State) {
+	started := time.Now()
 	d.logger.Println("Heartbeat starting (recovery-focused)")
 
 	// 1. Poke Boot (the Deacon's watchdog) instead of Deacon directly
@@ -198,6 +218,27 @@ func (d *Daemon) heartbeat(state *State) {
 	// This validates tmux sessions are still alive for polecats with work-on-hook
 	d.checkPolecatSessionHealth()
 
+	// 9. Forward timed-out escalations up the chain (or apply their fallback)
+	d.checkEscalationTimeouts()
+
+	// 10. Dispatch ready convoy work to free polecat capacity (opt-in)
+	if d.config.ConvoyScheduler {
+		d.runConvoyScheduler()
+	}
+
+	// 11. Refresh and refill warm polecat pools (rigs with warm_pool.size set)
+	d.fillWarmPools()
+
+	// 12. Rotate sessions off accounts that hit a usage or rate limit
+	d.checkAccountLimits()
+
+	// 13. Run background doctor checks and report regressions (opt-in)
+	d.startDoctor()
+
+	// Refresh metrics that need tmux or bd (kept out of the scrape path)
+	d.refreshMetrics()
+	d.metrics.observeHeartbeat(started)
+
 	// Update state
 	state.LastHeartbeat = time.Now()
 	state.HeartbeatCount++
@@ -345,7 +386,8 @@ func (d *Daemon) ensureDeaconRunning() {
 	// Launch Claude directly (no shell respawn loop)
 	// The daemon will detect if Claude exits and restart it on next heartbeat
 	// Export GT_ROLE and BD_ACTOR so Claude inherits them (tmux SetEnvironment doesn't export to processes)
-	if err := d.tmux.SendKeys(sessionName, config.BuildAgentStartupCommand("deacon", "deacon", "", "")); err != nil {
+	startCmd := limits.WrapCommand(d.config.TownRoot, "", "deacon", sessionName, config.BuildAgentStartupCommand("deacon", "deacon", "", ""))
+	if err := d.tmux.SendKeys(sessionName, startCmd); err != nil {
 		d.logger.Printf("Error launching Claude in Deacon session: %v", err)
 		return
 	}
@@ -482,7 +524,8 @@ func (d *Daemon) ensureWitnessRunning(rigName string) {
 		"BD_ACTOR":        bdActor,
 		"GIT_AUTHOR_NAME": bdActor,
 	}
-	if err := d.tmux.SendKeys(sessionName, config.BuildStartupCommand(envVars, "", "")); err != nil {
+	startCmd := limits.WrapCommand(d.config.TownRoot, rigName, "witness", sessionName, config.BuildStartupCommand(envVars, "", ""))
+	if err := d.tmux.SendKeys(sessionName, startCmd); err != nil {
 		d.logger.Printf("Error launching Claude in witness session for %s: %v", rigName, err)
 		return
 	}
@@ -585,7 +628,8 @@ func (d *Daemon) ensureRefineryRunning(rigName string) {
 		"BD_ACTOR":        bdActor,
 		"GIT_AUTHOR_NAME": bdActor,
 	}
-	if err := d.tmux.SendKeys(sessionName, config.BuildStartupCommand(envVars, "", "")); err != nil {
+	startCmd := limits.WrapCommand(d.config.TownRoot, rigName, "refinery", sessionName, config.BuildStartupCommand(envVars, "", ""))
+	if err := d.tmux.SendKeys(sessionName, startCmd); err != nil {
 		d.logger.Printf("Error launching Claude in refinery session for %s: %v", rigName, err)
 		return
 	}
@@ -670,6 +714,15 @@ func (d *Daemon) triggerPendingSpawns() {
 	}
 }
 
+// refreshMetrics updates metrics that require tmux or bd calls.
+// Runs once per heartbeat so /metrics scrapes stay cheap.
+func (d *Daemon) refreshMetrics() {
+	rigs := d.getKnownRigs()
+	d.metrics.setRigs(rigs)
+	d.metrics.refreshPolecats(rigs, d.tmux.HasSession)
+	d.metrics.refreshUnreadMail(rigs)
+}
+
 // processLifecycleRequests checks for and processes lifecycle requests.
 func (d *Daemon) processLifecycleRequests() {
 	d.ProcessLifecycleRequests()
@@ -852,14 +905,25 @@ func (d *Daemon) checkPolecatHealth(rigName, polecatName string) {
 	}
 }
 
+// polecatStartCommand returns the command that launches a polecat: Claude
+// with its environment exported inline, on accountDir's account, inside the
+// rig's sandbox if it has one. Fails if the worktree is gone.
+func (d *Daemon) polecatStartCommand(rigName, polecatName, accountDir string) (string, error) {
+	workDir := filepath.Join(d.config.TownRoot, rigName, "polecats", polecatName)
+	if _, err := os.Stat(workDir); os.IsNotExist(err) {
+		return "", fmt.Errorf("polecat worktree does not exist: %s", workDir)
+	}
+	return sandbox.WrapPolecat(d.config.TownRoot, rigName, polecatName, accountDir,
+		account.ExportCommand(accountDir, config.BuildPolecatStartupCommand(rigName, polecatName, "", "")))
+}
+
 // restartPolecatSession restarts a crashed polecat session.
 func (d *Daemon) restartPolecatSession(rigName, polecatName, sessionName string) error {
-	// Determine working directory
 	workDir := filepath.Join(d.config.TownRoot, rigName, "polecats", polecatName)
-
-	// Verify the worktree exists
-	if _, err := os.Stat(workDir); os.IsNotExist(err) {
-		return fmt.Errorf("polecat worktree does not exist: %s", workDir)
+	accountDir := d.accountConfigDir(sessionName)
+	startCmd, err := d.polecatStartCommand(rigName, polecatName, accountDir)
+	if err != nil {
+		return err
 	}
 
 	// Pre-sync workspace (ensure beads are current)
@@ -883,6 +947,9 @@ func (d *Daemon) restartPolecatSession(rigName, polecatName, sessionName string)
 	_ = d.tmux.SetEnvironment(sessionName, "BEADS_DIR", beadsDir)
 	_ = d.tmux.SetEnvironment(sessionName, "BEADS_NO_DAEMON", "1")
 	_ = d.tmux.SetEnvironment(sessionName, "BEADS_AGENT_NAME", fmt.Sprintf("%s/%s", rigName, polecatName))
+	if accountDir != "" {
+		_ = d.tmux.SetEnvironment(sessionName, account.EnvConfigDir, accountDir)
+	}
 
 	// Apply theme
 	theme := tmux.AssignTheme(rigName)
@@ -892,8 +959,6 @@ func (d *Daemon) restartPolecatSession(rigName, polecatName, sessionName string)
 	agentID := fmt.Sprintf("%s/%s", rigName, polecatName)
 	_ = d.tmux.SetPaneDiedHook(sessionName, agentID)
 
-	// Launch Claude with environment exported inline
-	startCmd := config.BuildPolecatStartupCommand(rigName, polecatName, "", "")
 	if err := d.tmux.SendKeys(sessionName, startCmd); err != nil {
 		return fmt.Errorf("sending startup command: %w", err)
 	}
@@ -926,3 +991,100 @@ Manual intervention may be required.`,
 		d.logger.Printf("Warning: failed to notify witness of crashed polecat: %v", err)
 	}
 }
+
+// checkEscalationTimeouts forwards escalations whose current tier didn't
+// answer within the escalation's timeout, and applies the fallback option
+// once the last tier times out. Escalations without a timeout wait forever.
+func (d *Daemon) checkEscalationTimeouts() {
+	results, err := escalation.NewManager(d.config.TownRoot).ProcessTimeouts(time.Now())
+	if err != nil {
+		d.logger.Printf("Error checking escalation timeouts: %v", err)
+		return
+	}
+
+	for _, r := range results {
+		switch {
+		case r.Err != nil:
+			d.logger.Printf("Escalation %s timeout handling failed: %v", r.ID, r.Err)
+		case r.Action == escalation.ActionForward:
+			d.logger.Printf("Escalation %s timed out, forwarded to %s", r.ID, r.Tier)
+		case r.Action == escalation.ActionFallback:
+			d.logger.Printf("Escalation %s timed out at last tier, applied fallback %q", r.ID, r.Answer)
+		}
+	}
+}
+
+// runConvoyScheduler slings ready convoy issues to rigs with free polecat
+// capacity. The scheduling logic lives in gt convoy schedule so the daemon
+// and manual runs make identical decisions.
+func (d *Daemon) runConvoyScheduler() {
+	cmd := exec.Command("gt", "convoy", "schedule", "--json")
+	cmd.Dir = d.config.TownRoot
+	out, err := cmd.Output()
+	if err != nil {
+		d.logger.Printf("Convoy scheduler failed: %v", err)
+		return
+	}
+
+	var result struct {
+		Decisions []struct {
+			IssueID string `json:"issue_id"`
+			Rig     string `json:"rig"`
+			Action  string `json:"action"`
+			Error   string `json:"error"`
+		} `json:"decisions"`
+	}
+	if err := json.Unmarshal(out, &result); err != nil {
+		d.logger.Printf("Convoy scheduler: parsing output: %v", err)
+		return
+	}
+
+	for _, dec := range result.Decisions {
+		if dec.Action != "sling" {
+			continue
+		}
+		if dec.Error != "" {
+			d.logger.Printf("Convoy scheduler: sling %s to %s failed: %s", dec.IssueID, dec.Rig, dec.Error)
+		} else {
+			d.logger.Printf("Convoy scheduler: slung %s to %s", dec.IssueID, dec.Rig)
+		}
+	}
+}
+
+// fillWarmPools keeps each rig's warm polecat worktrees at origin's default
+// branch and tops pools up to their configured size, so gt sling can claim a
+// ready worktree instead of creating one. Rigs without a pool are skipped,
+// and gt isn't run at all when no rig has one.
+func (d *Daemon) fillWarmPools() {
+	if !d.hasWarmPools() {
+		return
+	}
+	cmd := exec.Command("gt", "polecat", "pool", "fill", "--all")
+	cmd.Dir = d.config.TownRoot
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		d.logger.Printf("Warm pool fill failed: %v: %s", err, strings.TrimSpace(string(out)))
+		return
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if line != "" && !strings.Contains(line, "(+0, -0)") {
+			d.logger.Printf("Warm pool: %s", line)
+		}
+	}
+}
+
+// hasWarmPools reports whether any rig configures a warm pool or still has
+// warm worktrees left over from one (which a fill removes).
+func (d *Daemon) hasWarmPools() bool {
+	for _, rigName := range d.getKnownRigs() {
+		rigPath := filepath.Join(d.config.TownRoot, rigName)
+		settings, err := config.LoadRigSettings(config.RigSettingsPath(rigPath))
+		if err == nil && settings.WarmPool != nil && settings.WarmPool.Size > 0 {
+			return true
+		}
+		if entries, err := os.ReadDir(filepath.Join(rigPath, ".warm")); err == nil && len(entries) > 0 {
+			return true
+		}
+	}
+	return false
+}
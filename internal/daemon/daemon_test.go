@@ -246,3 +246,40 @@ func TestLifecycleRequest_Serialization(t *testing.T) {
 		t.Errorf("Action mismatch: got %q, want %q", loaded.Action, request.Action)
 	}
 }
+
+func TestHasWarmPools(t *testing.T) {
+	townRoot := t.TempDir()
+	d := &Daemon{config: &Config{TownRoot: townRoot}}
+	write := func(rel, content string) {
+		t.Helper()
+		path := filepath.Join(townRoot, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("mayor/rigs.json", `{"rigs":{"gastown":{},"beads":{}}}`)
+	write("gastown/settings/config.json", `{"type":"rig-settings","version":1,"warm_pool":{"size":0}}`)
+
+	if d.hasWarmPools() {
+		t.Fatal("hasWarmPools() = true with no pool configured")
+	}
+
+	// A disabled pool with worktrees left over still needs a fill to drain it
+	if err := os.MkdirAll(filepath.Join(townRoot, "beads", ".warm", "warm-1"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if !d.hasWarmPools() {
+		t.Error("hasWarmPools() = false with leftover warm worktrees")
+	}
+	if err := os.RemoveAll(filepath.Join(townRoot, "beads", ".warm")); err != nil {
+		t.Fatal(err)
+	}
+
+	write("gastown/settings/config.json", `{"type":"rig-settings","version":1,"warm_pool":{"size":2}}`)
+	if !d.hasWarmPools() {
+		t.Error("hasWarmPools() = false with a pool configured")
+	}
+}
@@ -0,0 +1,362 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// DefaultDoctorInterval is how often background doctor runs happen when the
+// config doesn't say.
+const DefaultDoctorInterval = 30 * time.Minute
+
+// doctorHistoryLimit caps the transitions kept in the doctor state file.
+const doctorHistoryLimit = 500
+
+// doctorRunTimeout bounds each gt doctor run, so a hung check can't stall
+// the heartbeat. A variable so tests can shorten it.
+var doctorRunTimeout = 5 * time.Minute
+
+// Doctor check statuses as reported by gt doctor --json.
+const (
+	doctorStatusOK      = "ok"
+	doctorStatusWarning = "warning"
+	doctorStatusError   = "error"
+	doctorStatusSkipped = "skipped"
+)
+
+// DoctorTransition records a check changing status between background runs.
+type DoctorTransition struct {
+	Time     time.Time `json:"time"`
+	Check    string    `json:"check"`
+	Category string    `json:"category,omitempty"`
+	From     string    `json:"from,omitempty"` // Empty the first time a check is seen
+	To       string    `json:"to"`
+	Message  string    `json:"message,omitempty"`
+	Fixed    bool      `json:"fixed,omitempty"` // An auto-fix brought the check back to ok
+}
+
+// Regression returns true if the check got worse.
+func (t DoctorTransition) Regression() bool {
+	return doctorSeverity(t.To) > doctorSeverity(t.From)
+}
+
+// DoctorState is the daemon's record of background doctor runs.
+type DoctorState struct {
+	LastRun  time.Time          `json:"last_run"`
+	Statuses map[string]string  `json:"statuses"` // Check name -> last status
+	History  []DoctorTransition `json:"history"`  // Oldest first
+}
+
+// DoctorStateFile returns the path to the background doctor state file.
+func DoctorStateFile(townRoot string) string {
+	return filepath.Join(townRoot, "daemon", "doctor.json")
+}
+
+// LoadDoctorState loads background doctor state from disk.
+func LoadDoctorState(townRoot string) (*DoctorState, error) {
+	data, err := os.ReadFile(DoctorStateFile(townRoot))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &DoctorState{Statuses: make(map[string]string)}, nil
+		}
+		return nil, err
+	}
+
+	var state DoctorState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+	if state.Statuses == nil {
+		state.Statuses = make(map[string]string)
+	}
+	return &state, nil
+}
+
+// SaveDoctorState saves background doctor state using atomic write.
+func SaveDoctorState(townRoot string, state *DoctorState) error {
+	path := DoctorStateFile(townRoot)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return util.AtomicWriteJSON(path, state)
+}
+
+// doctorSeverity orders statuses so regressions can be detected. Skipped
+// checks rank with unknown ones: they say nothing about health.
+func doctorSeverity(status string) int {
+	switch status {
+	case doctorStatusOK:
+		return 1
+	case doctorStatusWarning:
+		return 2
+	case doctorStatusError:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// doctorResult is the subset of a gt doctor --json check the daemon uses.
+type doctorResult struct {
+	Name     string   `json:"name"`
+	Category string   `json:"category"`
+	Status   string   `json:"status"`
+	Message  string   `json:"message"`
+	Details  []string `json:"details"`
+}
+
+// runDoctor runs gt doctor --json with the given extra args. Doctor exits 1
+// when a check errors, which still produces a report.
+func (d *Daemon) runDoctor(args ...string) ([]doctorResult, error) {
+	parent := d.ctx
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(parent, doctorRunTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "gt", append([]string{"doctor", "--json"}, args...)...) //nolint:gosec // G204: args are constructed internally
+	cmd.Dir = d.config.TownRoot
+	cmd.WaitDelay = 5 * time.Second // Don't wait on check subprocesses holding stdout
+	out, err := cmd.Output()
+	if ctx.Err() == context.DeadlineExceeded {
+		return nil, fmt.Errorf("timed out after %s", doctorRunTimeout)
+	}
+	var exitErr *exec.ExitError
+	if err != nil && !(errors.As(err, &exitErr) && exitErr.ExitCode() == 1) {
+		return nil, err
+	}
+
+	var report struct {
+		Checks []doctorResult `json:"checks"`
+	}
+	if err := json.Unmarshal(out, &report); err != nil {
+		return nil, fmt.Errorf("parsing doctor output: %w", err)
+	}
+	return report.Checks, nil
+}
+
+// startDoctor runs checkDoctor in the background, since a doctor run and
+// its fixes can take minutes. A heartbeat that finds a run in flight skips
+// it.
+func (d *Daemon) startDoctor() {
+	if d.config.Doctor == nil || !d.doctorBusy.CompareAndSwap(false, true) {
+		return
+	}
+	go func() {
+		defer d.doctorBusy.Store(false)
+		d.checkDoctor()
+	}()
+}
+
+// checkDoctor runs the configured doctor checks once the interval has
+// elapsed, applies safe fixes, records status transitions and reports
+// regressions to the deacon.
+func (d *Daemon) checkDoctor() {
+	cfg := d.config.Doctor
+	if cfg == nil {
+		return
+	}
+
+	interval := DefaultDoctorInterval
+	if cfg.Interval != "" {
+		parsed, err := time.ParseDuration(cfg.Interval)
+		if err != nil {
+			d.logger.Printf("Doctor: invalid interval %q, using %s", cfg.Interval, interval)
+		} else {
+			interval = parsed
+		}
+	}
+
+	state, err := LoadDoctorState(d.config.TownRoot)
+	if err != nil {
+		d.logger.Printf("Doctor: loading state: %v", err)
+		return
+	}
+	if time.Since(state.LastRun) < interval {
+		return
+	}
+
+	var args []string
+	if len(cfg.Checks) > 0 {
+		args = append(args, "--only", strings.Join(cfg.Checks, ","))
+	}
+	if len(cfg.Skip) > 0 {
+		args = append(args, "--skip", strings.Join(cfg.Skip, ","))
+	}
+	results, err := d.runDoctor(args...)
+	if err != nil {
+		// Count the attempt, so a bad selector (exit 2) or a hung check is
+		// retried next interval rather than every heartbeat.
+		d.logger.Printf("Doctor: run failed: %v", err)
+		state.LastRun = time.Now()
+		if err := SaveDoctorState(d.config.TownRoot, state); err != nil {
+			d.logger.Printf("Doctor: saving state: %v", err)
+		}
+		return
+	}
+
+	// Fix failing checks that are marked safe, then take the post-fix results.
+	fixed := make(map[string]bool)
+	if toFix := doctorFixable(results, cfg.AutoFix); len(toFix) > 0 {
+		fixResults, err := d.runDoctor("--fix", "--only", strings.Join(toFix, ","))
+		if err != nil {
+			d.logger.Printf("Doctor: auto-fix failed: %v", err)
+		} else {
+			byName := make(map[string]doctorResult, len(fixResults))
+			for _, r := range fixResults {
+				byName[r.Name] = r
+			}
+			for i, r := range results {
+				if after, ok := byName[r.Name]; ok {
+					if after.Status == doctorStatusOK {
+						fixed[r.Name] = true
+						d.logger.Printf("Doctor: auto-fixed %s", r.Name)
+					}
+					results[i] = after
+				}
+			}
+		}
+	}
+
+	now := time.Now()
+	transitions := diffDoctorResults(state.Statuses, results, fixed, now)
+	for _, r := range results {
+		if r.Status != doctorStatusSkipped {
+			state.Statuses[r.Name] = r.Status
+		}
+	}
+	state.LastRun = now
+	state.History = append(state.History, transitions...)
+	if len(state.History) > doctorHistoryLimit {
+		state.History = state.History[len(state.History)-doctorHistoryLimit:]
+	}
+	if err := SaveDoctorState(d.config.TownRoot, state); err != nil {
+		d.logger.Printf("Doctor: saving state: %v", err)
+	}
+
+	var regressions []DoctorTransition
+	for _, t := range transitions {
+		d.logger.Printf("Doctor: %s %s -> %s: %s", t.Check, orUnknown(t.From), t.To, t.Message)
+		if t.Regression() {
+			regressions = append(regressions, t)
+		}
+	}
+	if len(regressions) > 0 {
+		d.reportDoctorRegressions(regressions, results, cfg.Escalate)
+	}
+}
+
+// doctorFixable returns the failing checks that auto-fix selectors
+// (names or categories) allow fixing unattended.
+func doctorFixable(results []doctorResult, autoFix []string) []string {
+	if len(autoFix) == 0 {
+		return nil
+	}
+	allowed := make(map[string]bool, len(autoFix))
+	for _, sel := range autoFix {
+		allowed[sel] = true
+	}
+	var names []string
+	for _, r := range results {
+		failing := r.Status == doctorStatusWarning || r.Status == doctorStatusError
+		if failing && (allowed[r.Name] || allowed[r.Category]) {
+			names = append(names, r.Name)
+		}
+	}
+	return names
+}
+
+// diffDoctorResults returns the transitions from prev to results, in check
+// name order. Skipped checks keep their previous status. A check seen for
+// the first time only produces a transition if it isn't ok, so enabling
+// background runs doesn't flood the history.
+func diffDoctorResults(prev map[string]string, results []doctorResult, fixed map[string]bool, now time.Time) []DoctorTransition {
+	var transitions []DoctorTransition
+	for _, r := range results {
+		if r.Status == doctorStatusSkipped {
+			continue
+		}
+		from, seen := prev[r.Name]
+		if from == r.Status || (!seen && r.Status == doctorStatusOK && !fixed[r.Name]) {
+			continue
+		}
+		transitions = append(transitions, DoctorTransition{
+			Time:     now,
+			Check:    r.Name,
+			Category: r.Category,
+			From:     from,
+			To:       r.Status,
+			Message:  r.Message,
+			Fixed:    fixed[r.Name],
+		})
+	}
+	sort.Slice(transitions, func(i, j int) bool { return transitions[i].Check < transitions[j].Check })
+	return transitions
+}
+
+// reportDoctorRegressions mails the deacon about checks that got worse.
+// With escalate set, regressions to error are escalated instead, so they
+// follow the escalation chain if nobody picks them up.
+func (d *Daemon) reportDoctorRegressions(regressions []DoctorTransition, results []doctorResult, escalate bool) {
+	details := make(map[string][]string, len(results))
+	for _, r := range results {
+		details[r.Name] = r.Details
+	}
+	describe := func(ts []DoctorTransition) string {
+		var b strings.Builder
+		for _, t := range ts {
+			fmt.Fprintf(&b, "%s: %s -> %s: %s\n", t.Check, orUnknown(t.From), t.To, t.Message)
+			for _, detail := range details[t.Check] {
+				fmt.Fprintf(&b, "    %s\n", detail)
+			}
+		}
+		b.WriteString("\nRun 'gt doctor' for the full report, 'gt doctor history' for recent transitions.")
+		return b.String()
+	}
+
+	var errs, rest []DoctorTransition
+	for _, t := range regressions {
+		if escalate && t.To == doctorStatusError {
+			errs = append(errs, t)
+		} else {
+			rest = append(rest, t)
+		}
+	}
+
+	if len(errs) > 0 {
+		topic := fmt.Sprintf("Doctor: %d check(s) regressed to error", len(errs))
+		cmd := exec.Command("gt", "escalate", topic, "--type", "failed", "-s", "HIGH", "-m", describe(errs)) //nolint:gosec // G204: args are constructed internally
+		cmd.Dir = d.config.TownRoot
+		if err := cmd.Run(); err != nil {
+			d.logger.Printf("Doctor: escalating regressions failed: %v", err)
+			rest = append(rest, errs...) // Fall back to mailing the deacon
+		}
+	}
+
+	if len(rest) > 0 {
+		subject := fmt.Sprintf("DOCTOR: %d check(s) regressed", len(rest))
+		cmd := exec.Command("gt", "mail", "send", "deacon/", "-s", subject, "-m", describe(rest)) //nolint:gosec // G204: args are constructed internally
+		cmd.Dir = d.config.TownRoot
+		if err := cmd.Run(); err != nil {
+			d.logger.Printf("Doctor: notifying deacon failed: %v", err)
+		}
+	}
+}
+
+func orUnknown(status string) string {
+	if status == "" {
+		return "unknown"
+	}
+	return status
+}
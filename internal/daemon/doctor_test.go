@@ -0,0 +1,172 @@
+package daemon
+
+import (
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func TestDiffDoctorResults(t *testing.T) {
+	now := time.Now()
+	prev := map[string]string{
+		"daemon":   "ok",
+		"wisp-gc":  "warning",
+		"town-git": "warning",
+		"routes":   "error",
+	}
+	results := []doctorResult{
+		{Name: "daemon", Status: "error", Message: "not running"},        // regression
+		{Name: "wisp-gc", Status: "ok", Message: "clean"},                // recovered (auto-fixed)
+		{Name: "town-git", Status: "warning"},                            // unchanged
+		{Name: "routes", Status: "skipped"},                              // skipped: keeps old status
+		{Name: "themes", Status: "ok"},                                   // new and healthy: not recorded
+		{Name: "clone-divergence", Status: "warning", Message: "behind"}, // new and unhealthy
+	}
+
+	got := diffDoctorResults(prev, results, map[string]bool{"wisp-gc": true}, now)
+
+	want := []DoctorTransition{
+		{Check: "clone-divergence", From: "", To: "warning", Message: "behind"},
+		{Check: "daemon", From: "ok", To: "error", Message: "not running"},
+		{Check: "wisp-gc", From: "warning", To: "ok", Message: "clean", Fixed: true},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d transitions, want %d: %+v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		g := got[i]
+		if g.Check != w.Check || g.From != w.From || g.To != w.To || g.Message != w.Message || g.Fixed != w.Fixed {
+			t.Errorf("transition %d = %+v, want %+v", i, g, w)
+		}
+		if !g.Time.Equal(now) {
+			t.Errorf("transition %d time = %v, want %v", i, g.Time, now)
+		}
+	}
+
+	if !got[0].Regression() || !got[1].Regression() || got[2].Regression() {
+		t.Errorf("Regression() = %v %v %v, want true true false",
+			got[0].Regression(), got[1].Regression(), got[2].Regression())
+	}
+}
+
+func TestDoctorFixable(t *testing.T) {
+	results := []doctorResult{
+		{Name: "orphan-sessions", Category: "cleanup", Status: "warning"},
+		{Name: "wisp-gc", Category: "cleanup", Status: "ok"},
+		{Name: "daemon", Category: "infrastructure", Status: "error"},
+		{Name: "routes-config", Category: "beads", Status: "error"},
+	}
+
+	if got := doctorFixable(results, nil); got != nil {
+		t.Errorf("no auto_fix: got %v", got)
+	}
+
+	got := doctorFixable(results, []string{"cleanup", "daemon"})
+	if len(got) != 2 || got[0] != "orphan-sessions" || got[1] != "daemon" {
+		t.Errorf("doctorFixable() = %v, want [orphan-sessions daemon]", got)
+	}
+}
+
+func TestDoctorStateRoundTrip(t *testing.T) {
+	townRoot := t.TempDir()
+
+	state, err := LoadDoctorState(townRoot)
+	if err != nil {
+		t.Fatalf("LoadDoctorState() on empty town: %v", err)
+	}
+	if !state.LastRun.IsZero() || state.Statuses == nil {
+		t.Fatalf("empty state = %+v", state)
+	}
+
+	state.LastRun = time.Now().Truncate(time.Second)
+	state.Statuses["daemon"] = "ok"
+	state.History = append(state.History, DoctorTransition{Check: "daemon", To: "ok"})
+	if err := SaveDoctorState(townRoot, state); err != nil {
+		t.Fatalf("SaveDoctorState() error = %v", err)
+	}
+
+	loaded, err := LoadDoctorState(townRoot)
+	if err != nil {
+		t.Fatalf("LoadDoctorState() error = %v", err)
+	}
+	if !loaded.LastRun.Equal(state.LastRun) || loaded.Statuses["daemon"] != "ok" || len(loaded.History) != 1 {
+		t.Errorf("loaded = %+v, want %+v", loaded, state)
+	}
+}
+
+// fakeGt puts a gt on PATH that runs script.
+func fakeGt(t *testing.T, script string) {
+	t.Helper()
+	bin := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bin, "gt"), []byte("#!/bin/sh\n"+script+"\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestCheckDoctorFailedRunCountsAsRun(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+	}{
+		{"bad selector", "echo 'unknown check: nope' >&2; exit 2"},
+		{"hung check", "exec sleep 30"},
+	}
+	old := doctorRunTimeout
+	doctorRunTimeout = 200 * time.Millisecond
+	defer func() { doctorRunTimeout = old }()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGt(t, tt.script)
+			townRoot := t.TempDir()
+			d := &Daemon{
+				config: &Config{TownRoot: townRoot, Doctor: &config.DoctorMonitorConfig{Checks: []string{"nope"}}},
+				logger: log.New(io.Discard, "", 0),
+			}
+
+			start := time.Now()
+			d.checkDoctor()
+			if elapsed := time.Since(start); elapsed > 10*time.Second {
+				t.Errorf("checkDoctor took %s, want it bounded by the timeout", elapsed)
+			}
+			state, err := LoadDoctorState(townRoot)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if state.LastRun.Before(start) {
+				t.Errorf("LastRun = %v, want the failed run recorded", state.LastRun)
+			}
+		})
+	}
+}
+
+func TestStartDoctorRunsOneAtATime(t *testing.T) {
+	runs := filepath.Join(t.TempDir(), "runs")
+	fakeGt(t, "echo run >> "+runs+"; sleep 1; echo '{\"checks\":[]}'")
+	d := &Daemon{
+		config: &Config{TownRoot: t.TempDir(), Doctor: &config.DoctorMonitorConfig{}},
+		logger: log.New(io.Discard, "", 0),
+	}
+
+	start := time.Now()
+	d.startDoctor()
+	d.startDoctor()
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("startDoctor took %s, want it to return without waiting for the run", elapsed)
+	}
+	for deadline := time.Now().Add(10 * time.Second); d.doctorBusy.Load(); {
+		if time.Now().After(deadline) {
+			t.Fatal("doctor run did not finish")
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if data, _ := os.ReadFile(runs); string(data) != "run\n" {
+		t.Errorf("doctor ran %q, want once", data)
+	}
+}
@@ -8,10 +8,13 @@ import (
 	"strings"
 	"time"
 
+	"github.com/steveyegge/gastown/internal/account"
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/limits"
 	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/sandbox"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/tmux"
 )
@@ -340,6 +343,19 @@ func (d *Daemon) restartSession(sessionName, identity string) error {
 		d.syncWorkspace(workDir)
 	}
 
+	// Get startup command; polecats run inside their rig's sandbox, if any,
+	// and every role runs under its resource limits
+	accountDir := d.accountConfigDir(sessionName)
+	startCmd := account.ExportCommand(accountDir, d.getStartCommand(config, parsed))
+	if parsed.RoleType == "polecat" {
+		startCmd, err = sandbox.WrapPolecat(d.config.TownRoot, parsed.RigName, parsed.AgentName, accountDir, startCmd)
+		if err != nil {
+			return err
+		}
+	} else {
+		startCmd = limits.WrapCommand(d.config.TownRoot, parsed.RigName, parsed.RoleType, sessionName, startCmd)
+	}
+
 	// Create session
 	// Use EnsureSessionFresh to handle zombie sessions that exist but have dead Claude
 	if err := d.tmux.EnsureSessionFresh(sessionName, workDir); err != nil {
@@ -352,8 +368,7 @@ func (d *Daemon) restartSession(sessionName, identity string) error {
 	// Apply theme (non-fatal: theming failure doesn't affect operation)
 	d.applySessionTheme(sessionName, parsed)
 
-	// Get and send startup command
-	startCmd := d.getStartCommand(config, parsed)
+	// Send startup command
 	if err := d.tmux.SendKeys(sessionName, startCmd); err != nil {
 		return fmt.Errorf("sending startup command: %w", err)
 	}
@@ -817,6 +832,8 @@ func (d *Daemon) checkRigGUPPViolations(rigName string) {
 				d.logger.Printf("GUPP violation: agent %s has hook_bead=%s but hasn't updated in %v (timeout: %v)",
 					agent.ID, agent.HookBead, age.Round(time.Minute), GUPPViolationTimeout)
 
+				d.metrics.recordGUPPViolation(rigName)
+
 				// Notify the witness for this rig
 				d.notifyWitnessOfGUPP(rigName, agent.ID, agent.HookBead, age)
 			}
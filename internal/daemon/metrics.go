@@ -0,0 +1,314 @@
+package daemon
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/deacon"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/metrics"
+	"github.com/steveyegge/gastown/internal/mrqueue"
+)
+
+// daemonMetrics holds the Prometheus series exposed on the daemon's
+// /metrics endpoint.
+//
+// Values come from three places:
+//   - Heartbeat-time refresh for anything that shells out (tmux, bd mail),
+//     so a scrape never blocks on external commands.
+//   - Scrape-time collection for cheap file reads (MQ files, event logs,
+//     deacon health state).
+//   - Direct increments from daemon checks (GUPP violations).
+type daemonMetrics struct {
+	townRoot string
+	registry *metrics.Registry
+
+	polecatsActive *metrics.Vec
+	mqDepth        *metrics.Vec
+	mqOldestAge    *metrics.Vec
+	mrOutcomes     *metrics.Vec
+	verifications  *metrics.Vec
+	forceKills     *metrics.Vec
+	gupp           *metrics.Vec
+	unreadMail     *metrics.Vec
+	heartbeatDur   *metrics.Vec
+	heartbeats     *metrics.Vec
+	lastHeartbeat  *metrics.Vec
+
+	// rigs is the rig list captured at the last heartbeat, used by
+	// scrape-time collectors so they don't re-read rigs.json.
+	mu      sync.Mutex
+	rigs    []string
+	tailers map[string]*jsonlTailer
+}
+
+// newDaemonMetrics registers all daemon metrics.
+func newDaemonMetrics(townRoot string) *daemonMetrics {
+	r := metrics.NewRegistry()
+	m := &daemonMetrics{
+		townRoot: townRoot,
+		registry: r,
+
+		polecatsActive: r.NewGauge("gastown_polecats_active",
+			"Polecats with a live tmux session.", "rig"),
+		mqDepth: r.NewGauge("gastown_merge_queue_depth",
+			"Merge requests waiting in the queue.", "rig"),
+		mqOldestAge: r.NewGauge("gastown_merge_queue_oldest_age_seconds",
+			"Age of the oldest merge request in the queue.", "rig"),
+		mrOutcomes: r.NewCounter("gastown_mr_outcomes_total",
+			"Merge request processing outcomes by failure type.", "rig", "outcome", "failure_type"),
+		verifications: r.NewCounter("gastown_verification_verdicts_total",
+			"Verification gate verdicts.", "status"),
+		forceKills: r.NewGauge("gastown_force_kills",
+			"Times the deacon health check has force-killed each agent, as recorded in its state.", "agent"),
+		gupp: r.NewCounter("gastown_gupp_violations_total",
+			"Agents with hooked work that stopped progressing.", "rig"),
+		unreadMail: r.NewGauge("gastown_unread_mail",
+			"Unread messages per patrol agent mailbox.", "agent"),
+		heartbeatDur: r.NewGauge("gastown_daemon_heartbeat_duration_seconds",
+			"Duration of the most recent daemon heartbeat."),
+		heartbeats: r.NewCounter("gastown_daemon_heartbeats_total",
+			"Completed daemon heartbeats."),
+		lastHeartbeat: r.NewGauge("gastown_daemon_last_heartbeat_timestamp_seconds",
+			"Unix time of the most recent completed heartbeat."),
+
+		tailers: make(map[string]*jsonlTailer),
+	}
+
+	r.OnCollect(m.collectQueues)
+	r.OnCollect(m.collectEvents)
+	r.OnCollect(m.collectForceKills)
+	return m
+}
+
+// Handler returns the /metrics HTTP handler.
+func (m *daemonMetrics) Handler() http.Handler {
+	return m.registry.Handler()
+}
+
+// setRigs records the rig list for scrape-time collectors.
+func (m *daemonMetrics) setRigs(rigs []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rigs = append([]string(nil), rigs...)
+}
+
+func (m *daemonMetrics) knownRigs() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.rigs...)
+}
+
+// observeHeartbeat records a completed heartbeat.
+func (m *daemonMetrics) observeHeartbeat(started time.Time) {
+	now := time.Now()
+	m.heartbeatDur.With().Set(now.Sub(started).Seconds())
+	m.heartbeats.With().Inc()
+	m.lastHeartbeat.With().Set(float64(now.Unix()))
+}
+
+// recordGUPPViolation counts a GUPP violation detected in rig.
+func (m *daemonMetrics) recordGUPPViolation(rig string) {
+	m.gupp.With(rig).Inc()
+}
+
+// refreshPolecats counts polecats whose tmux session is alive.
+// hasSession is injected so tests don't need tmux.
+func (m *daemonMetrics) refreshPolecats(rigs []string, hasSession func(string) (bool, error)) {
+	m.polecatsActive.Reset()
+	for _, rig := range rigs {
+		entries, err := os.ReadDir(filepath.Join(m.townRoot, rig, "polecats"))
+		if err != nil {
+			m.polecatsActive.With(rig).Set(0)
+			continue
+		}
+		active := 0
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+			if alive, err := hasSession("gt-" + rig + "-" + entry.Name()); err == nil && alive {
+				active++
+			}
+		}
+		m.polecatsActive.With(rig).Set(float64(active))
+	}
+}
+
+// refreshUnreadMail counts unread mail for the town and rig patrol agents.
+func (m *daemonMetrics) refreshUnreadMail(rigs []string) {
+	addrs := []string{"mayor/", "deacon/"}
+	for _, rig := range rigs {
+		addrs = append(addrs, rig+"/witness", rig+"/refinery")
+	}
+
+	m.unreadMail.Reset()
+	for _, addr := range addrs {
+		msgs, err := mail.NewMailboxFromAddress(addr, m.townRoot).ListUnread()
+		if err != nil {
+			continue
+		}
+		m.unreadMail.With(strings.TrimSuffix(addr, "/")).Set(float64(len(msgs)))
+	}
+}
+
+// collectQueues sets MQ depth and oldest-MR age from the queue files.
+func (m *daemonMetrics) collectQueues() {
+	m.mqDepth.Reset()
+	m.mqOldestAge.Reset()
+	now := time.Now()
+	for _, rig := range m.knownRigs() {
+		mrs, err := mrqueue.New(filepath.Join(m.townRoot, rig)).List()
+		if err != nil {
+			continue
+		}
+		m.mqDepth.With(rig).Set(float64(len(mrs)))
+
+		var oldest time.Time
+		for _, mr := range mrs {
+			if oldest.IsZero() || mr.CreatedAt.Before(oldest) {
+				oldest = mr.CreatedAt
+			}
+		}
+		age := 0.0
+		if !oldest.IsZero() {
+			age = now.Sub(oldest).Seconds()
+		}
+		m.mqOldestAge.With(rig).Set(age)
+	}
+}
+
+// collectEvents consumes new lines from each rig's MQ event log and the
+// town event log, counting MR outcomes and verification verdicts.
+func (m *daemonMetrics) collectEvents() {
+	for _, rig := range m.knownRigs() {
+		path := mrqueue.NewEventLoggerFromRig(filepath.Join(m.townRoot, rig)).LogPath()
+		m.tailer(path).each(func(line []byte) {
+			var ev mrqueue.Event
+			if json.Unmarshal(line, &ev) != nil {
+				return
+			}
+			switch ev.Type {
+			case mrqueue.EventMerged, mrqueue.EventMergeFailed, mrqueue.EventMergeSkipped:
+				m.mrOutcomes.With(rig, string(ev.Type), ev.FailureType).Inc()
+			}
+		})
+	}
+
+	m.tailer(filepath.Join(m.townRoot, events.EventsFile)).each(func(line []byte) {
+		var ev events.Event
+		if json.Unmarshal(line, &ev) != nil || ev.Type != events.TypeVerification {
+			return
+		}
+		status, _ := ev.Payload["status"].(string)
+		if status != "" {
+			m.verifications.With(status).Inc()
+		}
+	})
+}
+
+// collectForceKills mirrors the deacon's persisted force-kill totals. They are
+// exported as a gauge since they go down whenever that state file is reset.
+func (m *daemonMetrics) collectForceKills() {
+	state, err := deacon.LoadHealthCheckState(m.townRoot)
+	if err != nil {
+		return
+	}
+	m.forceKills.Reset()
+	for id, agent := range state.Agents {
+		if agent.ForceKillCount > 0 {
+			m.forceKills.With(id).Set(float64(agent.ForceKillCount))
+		}
+	}
+}
+
+func (m *daemonMetrics) tailer(path string) *jsonlTailer {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	t, ok := m.tailers[path]
+	if !ok {
+		t = &jsonlTailer{path: path}
+		m.tailers[path] = t
+	}
+	return t
+}
+
+// jsonlTailer reads lines appended to a JSONL file since the last call.
+// Counters derived from the file therefore cover the daemon's lifetime
+// plus whatever history existed at startup, which is what Prometheus
+// rate() expects from a counter that resets on restart.
+type jsonlTailer struct {
+	mu     sync.Mutex
+	path   string
+	offset int64
+}
+
+// each calls fn for every complete line written since the previous call.
+// A file that shrank (rotated or truncated) is re-read from the start.
+func (t *jsonlTailer) each(fn func(line []byte)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	f, err := os.Open(t.path)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	if info, err := f.Stat(); err == nil && info.Size() < t.offset {
+		t.offset = 0
+	}
+	if _, err := f.Seek(t.offset, io.SeekStart); err != nil {
+		return
+	}
+
+	r := bufio.NewReader(f)
+	for {
+		line, err := r.ReadBytes('\n')
+		if err != nil {
+			// Partial trailing line: leave it for the next scrape
+			return
+		}
+		t.offset += int64(len(line))
+		if trimmed := strings.TrimSpace(string(line)); trimmed != "" {
+			fn([]byte(trimmed))
+		}
+	}
+}
+
+// startMetricsServer serves /metrics on addr in the background.
+// Listen errors are logged rather than fatal: metrics are optional.
+func (d *Daemon) startMetricsServer(addr string) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", d.metrics.Handler())
+
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			d.logger.Printf("Warning: metrics server on %s stopped: %v", addr, err)
+		}
+	}()
+	return srv
+}
+
+// stopMetricsServer shuts the metrics server down, waiting briefly for
+// in-flight scrapes.
+func stopMetricsServer(srv *http.Server) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	_ = srv.Shutdown(ctx)
+}
@@ -0,0 +1,166 @@
+package daemon
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/deacon"
+	"github.com/steveyegge/gastown/internal/mrqueue"
+)
+
+func TestDaemonMetrics_QueueDepthAndAge(t *testing.T) {
+	townRoot := t.TempDir()
+	q := mrqueue.New(filepath.Join(townRoot, "gastown"))
+	old := time.Now().Add(-10 * time.Minute)
+	if err := q.Submit(&mrqueue.MR{Branch: "polecat/a", CreatedAt: old}); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	if err := q.Submit(&mrqueue.MR{Branch: "polecat/b"}); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+
+	m := newDaemonMetrics(townRoot)
+	m.setRigs([]string{"gastown", "empty"})
+	m.collectQueues()
+
+	if got := m.mqDepth.Value("gastown"); got != 2 {
+		t.Errorf("depth(gastown) = %v, want 2", got)
+	}
+	if got := m.mqDepth.Value("empty"); got != 0 {
+		t.Errorf("depth(empty) = %v, want 0", got)
+	}
+	if age := m.mqOldestAge.Value("gastown"); age < 600 || age > 660 {
+		t.Errorf("oldest age = %v, want ~600s", age)
+	}
+}
+
+func TestDaemonMetrics_OutcomesTailIncrementally(t *testing.T) {
+	townRoot := t.TempDir()
+	rigPath := filepath.Join(townRoot, "gastown")
+	logger := mrqueue.NewEventLoggerFromRig(rigPath)
+	mr := &mrqueue.MR{ID: "mr-1", Branch: "polecat/a", Target: "main"}
+
+	m := newDaemonMetrics(townRoot)
+	m.setRigs([]string{"gastown"})
+
+	_ = logger.LogMergeStarted(mr)
+	_ = logger.LogMerged(mr, "abc123")
+	_ = logger.LogMergeFailed(mr, "conflict", "conflict in a.go")
+	m.collectEvents()
+
+	if got := m.mrOutcomes.Value("gastown", "merged", ""); got != 1 {
+		t.Errorf("merged = %v, want 1", got)
+	}
+	if got := m.mrOutcomes.Value("gastown", "merge_failed", "conflict"); got != 1 {
+		t.Errorf("merge_failed/conflict = %v, want 1", got)
+	}
+
+	// Only new lines are counted on the next scrape
+	_ = logger.LogMergeFailed(mr, "conflict", "again")
+	m.collectEvents()
+	m.collectEvents()
+	if got := m.mrOutcomes.Value("gastown", "merge_failed", "conflict"); got != 2 {
+		t.Errorf("merge_failed/conflict after append = %v, want 2", got)
+	}
+}
+
+func TestDaemonMetrics_VerificationVerdicts(t *testing.T) {
+	townRoot := t.TempDir()
+	lines := []string{
+		`{"ts":"2026-01-01T00:00:00Z","type":"verification","actor":"refinery","payload":{"status":"verified"}}`,
+		`{"ts":"2026-01-01T00:00:01Z","type":"verification","actor":"refinery","payload":{"status":"rejected"}}`,
+		`{"ts":"2026-01-01T00:00:02Z","type":"sling","actor":"mayor","payload":{"bead":"gt-1"}}`,
+		`{"ts":"2026-01-01T00:00:03Z","type":"verification","actor":"refinery","payload":{"status":"verified"}}`,
+	}
+	path := filepath.Join(townRoot, ".events.jsonl")
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	m := newDaemonMetrics(townRoot)
+	m.collectEvents()
+
+	if got := m.verifications.Value("verified"); got != 2 {
+		t.Errorf("verified = %v, want 2", got)
+	}
+	if got := m.verifications.Value("rejected"); got != 1 {
+		t.Errorf("rejected = %v, want 1", got)
+	}
+}
+
+func TestJSONLTailer_TruncationAndPartialLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log.jsonl")
+	tl := &jsonlTailer{path: path}
+
+	count := func() int {
+		n := 0
+		tl.each(func([]byte) { n++ })
+		return n
+	}
+
+	if n := count(); n != 0 {
+		t.Errorf("missing file: got %d lines", n)
+	}
+
+	_ = os.WriteFile(path, []byte("{\"a\":1}\n{\"a\":2}"), 0644)
+	if n := count(); n != 1 {
+		t.Errorf("partial trailing line should wait: got %d lines, want 1", n)
+	}
+
+	f, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	_, _ = f.WriteString("\n")
+	f.Close()
+	if n := count(); n != 1 {
+		t.Errorf("completed line: got %d lines, want 1", n)
+	}
+
+	_ = os.WriteFile(path, []byte("{\"b\":1}\n"), 0644)
+	if n := count(); n != 1 {
+		t.Errorf("after truncation: got %d lines, want 1", n)
+	}
+}
+
+func TestDaemonMetrics_PolecatsAndForceKills(t *testing.T) {
+	townRoot := t.TempDir()
+	for _, name := range []string{"toast", "nux", "slit"} {
+		if err := os.MkdirAll(filepath.Join(townRoot, "gastown", "polecats", name), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	live := map[string]bool{"gt-gastown-toast": true, "gt-gastown-nux": true}
+
+	m := newDaemonMetrics(townRoot)
+	m.refreshPolecats([]string{"gastown"}, func(s string) (bool, error) { return live[s], nil })
+	if got := m.polecatsActive.Value("gastown"); got != 2 {
+		t.Errorf("active polecats = %v, want 2", got)
+	}
+
+	state := &deacon.HealthCheckState{Agents: map[string]*deacon.AgentHealthState{
+		"gastown/witness": {AgentID: "gastown/witness", ForceKillCount: 3},
+		"deacon":          {AgentID: "deacon"},
+	}}
+	if err := deacon.SaveHealthCheckState(townRoot, state); err != nil {
+		t.Fatal(err)
+	}
+	m.collectForceKills()
+	if got := m.forceKills.Value("gastown/witness"); got != 3 {
+		t.Errorf("force kills = %v, want 3", got)
+	}
+
+	var sb strings.Builder
+	if err := m.registry.WriteText(&sb); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(sb.String(), `agent="deacon"`) {
+		t.Errorf("agents with no force-kills should be omitted:\n%s", sb.String())
+	}
+	if !strings.Contains(sb.String(), "# TYPE gastown_force_kills gauge") {
+		t.Errorf("force kills should be a gauge:\n%s", sb.String())
+	}
+	if !strings.Contains(sb.String(), `gastown_polecats_active{rig="gastown"} 2`) {
+		t.Errorf("missing polecat series:\n%s", sb.String())
+	}
+}
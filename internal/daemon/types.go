@@ -14,6 +14,7 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/util"
 )
 
@@ -30,6 +31,18 @@ type Config struct {
 
 	// PidFile is the path to the PID file.
 	PidFile string `json:"pid_file"`
+
+	// MetricsAddr is the listen address for the Prometheus /metrics
+	// endpoint (e.g., "127.0.0.1:9464"). Empty disables the endpoint.
+	MetricsAddr string `json:"metrics_addr,omitempty"`
+
+	// ConvoyScheduler enables dispatching ready convoy work to rigs with
+	// free polecat capacity on each heartbeat (gt convoy schedule).
+	ConvoyScheduler bool `json:"convoy_scheduler,omitempty"`
+
+	// Doctor runs a subset of doctor checks on a schedule and reports
+	// regressions. Nil disables background doctor runs.
+	Doctor *config.DoctorMonitorConfig `json:"doctor,omitempty"`
 }
 
 // DefaultConfig returns the default daemon configuration.
@@ -59,6 +72,9 @@ type State struct {
 
 	// HeartbeatCount is how many heartbeats have completed.
 	HeartbeatCount int64 `json:"heartbeat_count"`
+
+	// MetricsAddr is where /metrics is served, if enabled.
+	MetricsAddr string `json:"metrics_addr,omitempty"`
 }
 
 // StateFile returns the path to the state file.
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 )
 
@@ -52,6 +53,20 @@ type AgentHealthState struct {
 
 	// ForceKillCount is total number of force-kills for this agent
 	ForceKillCount int `json:"force_kill_count"`
+
+	// LimitBreaches counts health checks that found the agent at or over
+	// its resource limits
+	LimitBreaches int `json:"limit_breaches,omitempty"`
+
+	// LastBreach describes the most recent limit breach
+	LastBreach string `json:"last_breach,omitempty"`
+
+	// LastBreachTime is when the most recent limit breach was seen
+	LastBreachTime time.Time `json:"last_breach_time,omitempty"`
+
+	// OOMKills is the session's OOM kill count as of the last check, so
+	// only new kills count as a breach
+	OOMKills int `json:"oom_kills,omitempty"`
 }
 
 // HealthCheckState holds health check state for all monitored agents.
@@ -164,11 +179,21 @@ func (s *AgentHealthState) RecordFailure() {
 	s.ConsecutiveFailures++
 }
 
+// RecordBreach records that an agent was found at or over its resource
+// limits. It does not count as a failed health check; whether the agent
+// responds decides that.
+func (s *AgentHealthState) RecordBreach(reasons []string) {
+	s.LimitBreaches++
+	s.LastBreach = strings.Join(reasons, "; ")
+	s.LastBreachTime = time.Now().UTC()
+}
+
 // RecordForceKill records that an agent was force-killed.
 func (s *AgentHealthState) RecordForceKill() {
 	s.LastForceKillTime = time.Now().UTC()
 	s.ForceKillCount++
 	s.ConsecutiveFailures = 0 // Reset after kill
+	s.OOMKills = 0            // The restarted session gets a fresh scope
 }
 
 // IsInCooldown returns true if the agent was recently force-killed.
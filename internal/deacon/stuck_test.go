@@ -148,6 +148,27 @@ func TestAgentHealthState_RecordFailure(t *testing.T) {
 	}
 }
 
+func TestAgentHealthState_RecordBreach(t *testing.T) {
+	agent := &AgentHealthState{
+		ConsecutiveFailures: 1,
+	}
+
+	agent.RecordBreach([]string{"memory at 95% of limit (3.8G/4.0G)", "processes at 500/512"})
+
+	if agent.ConsecutiveFailures != 1 {
+		t.Errorf("ConsecutiveFailures = %d, want 1 (a breach is not a failed check)", agent.ConsecutiveFailures)
+	}
+	if agent.LimitBreaches != 1 {
+		t.Errorf("LimitBreaches = %d, want 1", agent.LimitBreaches)
+	}
+	if agent.LastBreach != "memory at 95% of limit (3.8G/4.0G); processes at 500/512" {
+		t.Errorf("LastBreach = %q", agent.LastBreach)
+	}
+	if agent.LastBreachTime.IsZero() {
+		t.Error("LastBreachTime should be set")
+	}
+}
+
 func TestAgentHealthState_RecordForceKill(t *testing.T) {
 	agent := &AgentHealthState{
 		ConsecutiveFailures: 5,
@@ -28,6 +28,9 @@ func NewAgentBeadsCheck() *AgentBeadsCheck {
 			BaseCheck: BaseCheck{
 				CheckName:        "agent-beads-exist",
 				CheckDescription: "Verify agent beads exist for all agents",
+				CheckCategory:    "beads",
+				CheckDependsOn:   []string{"beads-database"},
+				CheckCost:        CostExpensive,
 			},
 		},
 	}
@@ -20,6 +20,8 @@ func NewBdDaemonCheck() *BdDaemonCheck {
 			BaseCheck: BaseCheck{
 				CheckName:        "bd-daemon",
 				CheckDescription: "Check if bd (beads) daemon is running",
+				CheckCategory:    "infrastructure",
+				CheckCost:        CostModerate,
 			},
 		},
 	}
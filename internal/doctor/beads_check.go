@@ -25,6 +25,8 @@ func NewBeadsDatabaseCheck() *BeadsDatabaseCheck {
 			BaseCheck: BaseCheck{
 				CheckName:        "beads-database",
 				CheckDescription: "Verify beads database is properly initialized",
+				CheckCategory:    "beads",
+				CheckCost:        CostModerate,
 			},
 		},
 	}
@@ -175,6 +177,9 @@ func NewPrefixConflictCheck() *PrefixConflictCheck {
 		BaseCheck: BaseCheck{
 			CheckName:        "prefix-conflict",
 			CheckDescription: "Check for duplicate beads prefixes across rigs",
+			CheckCategory:    "beads",
+			CheckDependsOn:   []string{"beads-database"},
+			CheckCost:        CostModerate,
 		},
 	}
 }
@@ -23,6 +23,9 @@ func NewBranchCheck() *BranchCheck {
 			BaseCheck: BaseCheck{
 				CheckName:        "persistent-role-branches",
 				CheckDescription: "Detect persistent roles not on main branch",
+				CheckCategory:    "git",
+				CheckDependsOn:   []string{"rigs-registry-valid"},
+				CheckCost:        CostExpensive,
 			},
 		},
 	}
@@ -115,7 +118,7 @@ func (c *BranchCheck) Fix(ctx *CheckContext) error {
 }
 
 // findPersistentRoleDirs finds all directories that should be on main:
-// - <rig>/crew/*
+// - <rig>/crew/* (except cross-rig worktrees, which work on their own branch)
 // - <rig>/witness/rig (if exists)
 // - <rig>/refinery/rig (if exists)
 func (c *BranchCheck) findPersistentRoleDirs(townRoot string) []string {
@@ -126,6 +129,7 @@ func (c *BranchCheck) findPersistentRoleDirs(townRoot string) []string {
 	if err != nil {
 		return dirs
 	}
+	rigNames, _ := discoverRigs(townRoot)
 
 	for _, entry := range entries {
 		if !entry.IsDir() {
@@ -148,7 +152,8 @@ func (c *BranchCheck) findPersistentRoleDirs(townRoot string) []string {
 		crewPath := filepath.Join(rigPath, "crew")
 		if crewEntries, err := os.ReadDir(crewPath); err == nil {
 			for _, crew := range crewEntries {
-				if crew.IsDir() && !strings.HasPrefix(crew.Name(), ".") {
+				if crew.IsDir() && !strings.HasPrefix(crew.Name(), ".") &&
+					crossRigWorktreeOwner(townRoot, name, crew.Name(), rigNames) == "" {
 					dirs = append(dirs, filepath.Join(crewPath, crew.Name()))
 				}
 			}
@@ -213,6 +218,9 @@ func NewBeadsSyncOrphanCheck() *BeadsSyncOrphanCheck {
 		BaseCheck: BaseCheck{
 			CheckName:        "beads-sync-orphans",
 			CheckDescription: "Detect orphaned code on beads-sync branch",
+			CheckCategory:    "git",
+			CheckDependsOn:   []string{"rigs-registry-valid"},
+			CheckCost:        CostExpensive,
 		},
 	}
 }
@@ -338,6 +346,9 @@ func NewCloneDivergenceCheck() *CloneDivergenceCheck {
 		BaseCheck: BaseCheck{
 			CheckName:        "clone-divergence",
 			CheckDescription: "Detect emergency divergence between git clones",
+			CheckCategory:    "git",
+			CheckDependsOn:   []string{"rigs-registry-valid"},
+			CheckCost:        CostExpensive,
 		},
 	}
 }
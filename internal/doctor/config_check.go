@@ -23,6 +23,7 @@ func NewSettingsCheck() *SettingsCheck {
 			BaseCheck: BaseCheck{
 				CheckName:        "rig-settings",
 				CheckDescription: "Check that rigs have settings/ directory",
+				CheckCategory:    "config",
 			},
 		},
 	}
@@ -104,6 +105,7 @@ func NewRuntimeGitignoreCheck() *RuntimeGitignoreCheck {
 		BaseCheck: BaseCheck{
 			CheckName:        "runtime-gitignore",
 			CheckDescription: "Check that .runtime/ directories are gitignored",
+			CheckCategory:    "config",
 		},
 	}
 }
@@ -193,6 +195,7 @@ func NewLegacyGastownCheck() *LegacyGastownCheck {
 			BaseCheck: BaseCheck{
 				CheckName:        "legacy-gastown",
 				CheckDescription: "Check for old .gastown/ directories that should be migrated",
+				CheckCategory:    "config",
 			},
 		},
 	}
@@ -279,6 +282,7 @@ func NewSessionHookCheck() *SessionHookCheck {
 		BaseCheck: BaseCheck{
 			CheckName:        "session-hooks",
 			CheckDescription: "Check that settings.json hooks use session-start.sh",
+			CheckCategory:    "config",
 		},
 	}
 }
@@ -31,6 +31,7 @@ func NewCrewStateCheck() *CrewStateCheck {
 			BaseCheck: BaseCheck{
 				CheckName:        "crew-state",
 				CheckDescription: "Validate crew worker state.json files",
+				CheckCategory:    "crew",
 			},
 		},
 	}
@@ -0,0 +1,629 @@
+package doctor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// CustomChecksDir is where town-specific checks live, relative to the town root.
+//
+// Two kinds of checks are loaded from it:
+//   - *.toml files describing a shell command and its expected result
+//   - executable files implementing the plugin protocol (see pluginCheck)
+const CustomChecksDir = ".gt/checks"
+
+// CustomCategory is the default category of user-defined checks.
+const CustomCategory = "custom"
+
+// Custom check scopes.
+const (
+	ScopeTown    = "town"    // Run once in the town root
+	ScopeRig     = "rig"     // Run in every rig (or just --rig)
+	ScopePolecat = "polecat" // Run in every polecat worktree
+)
+
+// customCommandTimeout bounds a single command when the spec sets no timeout.
+const customCommandTimeout = 30 * time.Second
+
+// CustomCheckSpec is the TOML form of a declarative check.
+//
+//	name = "pre-commit-hook"
+//	description = "Rigs have the pre-commit hook installed"
+//	scope = "rig"
+//	command = "test -x .git/hooks/pre-commit"
+//	fix = "cp $GT_TOWN_ROOT/hooks/pre-commit .git/hooks/"
+type CustomCheckSpec struct {
+	Name         string   `toml:"name"`          // Defaults to the file name
+	Description  string   `toml:"description"`   // Shown in --list and SARIF
+	Category     string   `toml:"category"`      // Defaults to "custom"
+	Scope        string   `toml:"scope"`         // town (default), rig or polecat
+	Command      string   `toml:"command"`       // Run with sh -c in each target
+	ExpectExit   int      `toml:"expect_exit"`   // Expected exit code (default 0)
+	ExpectOutput string   `toml:"expect_output"` // Regexp the combined output must match
+	Severity     string   `toml:"severity"`      // error (default) or warning
+	Fix          string   `toml:"fix"`           // Optional command run in failing targets
+	FixHint      string   `toml:"fix_hint"`      // Shown when there is no fix command
+	Timeout      string   `toml:"timeout"`       // Per-command timeout (default 30s), per target
+	DependsOn    []string `toml:"depends_on"`    // Checks that must pass first
+	Cost         string   `toml:"cost"`          // cheap (default), moderate or expensive
+}
+
+// LoadCustomChecks loads user-defined checks from the town's CustomChecksDir.
+// Files that fail to parse, and checks whose name is already taken by a
+// registered check, are returned as checks that report the problem, so a
+// broken definition shows up in the report instead of silently vanishing.
+func LoadCustomChecks(townRoot string, registered []Check) []Check {
+	dir := filepath.Join(townRoot, CustomChecksDir)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return []Check{newBrokenCheck("custom-checks", fmt.Errorf("reading %s: %w", CustomChecksDir, err))}
+	}
+
+	taken := make(map[string]bool, len(registered))
+	for _, check := range registered {
+		taken[check.Name()] = true
+	}
+
+	var checks []Check
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".") {
+			continue
+		}
+		path := filepath.Join(dir, name)
+
+		var check Check
+		if strings.HasSuffix(name, ".toml") {
+			spec, err := ParseCustomCheck(path)
+			if err != nil {
+				check = newBrokenCheck(strings.TrimSuffix(name, ".toml"), err)
+			} else {
+				check = newCommandCheck(spec)
+			}
+		} else {
+			info, err := entry.Info()
+			if err != nil || info.Mode()&0111 == 0 {
+				continue // Not executable: README, notes, etc.
+			}
+			check = newPluginCheck(path)
+		}
+
+		if taken[check.Name()] {
+			check = newBrokenCheck(check.Name(), fmt.Errorf("%s: check name %q is already in use", name, check.Name()))
+		}
+		taken[check.Name()] = true
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// ParseCustomCheck reads and validates a declarative check file.
+func ParseCustomCheck(path string) (*CustomCheckSpec, error) {
+	var spec CustomCheckSpec
+	if _, err := toml.DecodeFile(path, &spec); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", filepath.Base(path), err)
+	}
+	if spec.Name == "" {
+		spec.Name = strings.TrimSuffix(filepath.Base(path), ".toml")
+	}
+	if spec.Scope == "" {
+		spec.Scope = ScopeTown
+	}
+	if spec.Severity == "" {
+		spec.Severity = "error"
+	}
+
+	if spec.Command == "" {
+		return nil, fmt.Errorf("%s: command is required", filepath.Base(path))
+	}
+	switch spec.Scope {
+	case ScopeTown, ScopeRig, ScopePolecat:
+	default:
+		return nil, fmt.Errorf("%s: invalid scope %q (must be town, rig or polecat)", filepath.Base(path), spec.Scope)
+	}
+	if spec.Severity != "error" && spec.Severity != "warning" {
+		return nil, fmt.Errorf("%s: invalid severity %q (must be error or warning)", filepath.Base(path), spec.Severity)
+	}
+	if spec.ExpectOutput != "" {
+		if _, err := regexp.Compile(spec.ExpectOutput); err != nil {
+			return nil, fmt.Errorf("%s: invalid expect_output: %w", filepath.Base(path), err)
+		}
+	}
+	if spec.Timeout != "" {
+		if _, err := time.ParseDuration(spec.Timeout); err != nil {
+			return nil, fmt.Errorf("%s: invalid timeout: %w", filepath.Base(path), err)
+		}
+	}
+	if _, err := parseCost(spec.Cost); err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	return &spec, nil
+}
+
+func parseCost(s string) (CheckCost, error) {
+	switch s {
+	case "", "cheap":
+		return CostCheap, nil
+	case "moderate":
+		return CostModerate, nil
+	case "expensive":
+		return CostExpensive, nil
+	default:
+		return CostCheap, fmt.Errorf("invalid cost %q (must be cheap, moderate or expensive)", s)
+	}
+}
+
+// checkTarget is a directory a custom check runs in.
+type checkTarget struct {
+	Label   string // e.g. "gastown" or "gastown/Toast"; empty for the town
+	Dir     string
+	Rig     string
+	Polecat string
+}
+
+// env returns the environment passed to custom check commands.
+func (t checkTarget) env(ctx *CheckContext) []string {
+	return append(os.Environ(),
+		"GT_TOWN_ROOT="+ctx.TownRoot,
+		"GT_CHECK_DIR="+t.Dir,
+		"GT_RIG="+t.Rig,
+		"GT_POLECAT="+t.Polecat,
+	)
+}
+
+// customCheckTargets resolves the directories a check with scope runs in.
+// Rig and polecat scopes are narrowed to ctx.RigName when it is set.
+func customCheckTargets(ctx *CheckContext, scope string) ([]checkTarget, error) {
+	if scope == ScopeTown {
+		return []checkTarget{{Dir: ctx.TownRoot}}, nil
+	}
+
+	rigs := []string{ctx.RigName}
+	if ctx.RigName == "" {
+		var err error
+		if rigs, err = discoverRigs(ctx.TownRoot); err != nil {
+			return nil, fmt.Errorf("discovering rigs: %w", err)
+		}
+		sort.Strings(rigs)
+	}
+
+	var targets []checkTarget
+	for _, rig := range rigs {
+		rigDir := filepath.Join(ctx.TownRoot, rig)
+		if scope == ScopeRig {
+			targets = append(targets, checkTarget{Label: rig, Dir: rigDir, Rig: rig})
+			continue
+		}
+		entries, err := os.ReadDir(filepath.Join(rigDir, "polecats"))
+		if err != nil {
+			continue // No polecats in this rig
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+				continue
+			}
+			targets = append(targets, checkTarget{
+				Label:   rig + "/" + entry.Name(),
+				Dir:     filepath.Join(rigDir, "polecats", entry.Name()),
+				Rig:     rig,
+				Polecat: entry.Name(),
+			})
+		}
+	}
+	return targets, nil
+}
+
+// runShell runs command with sh -c in target, returning combined output and
+// the exit code (-1 if the command could not be run or timed out).
+func runShell(ctx *CheckContext, target checkTarget, command string, timeout time.Duration) (string, int, error) {
+	cctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(cctx, "sh", "-c", command) //nolint:gosec // G204: command comes from the town's own check definitions
+	cmd.Dir = target.Dir
+	cmd.Env = target.env(ctx)
+	// Kill the whole process group on timeout, so children the check
+	// spawned don't outlive it or keep the output pipe open.
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+	cmd.WaitDelay = 5 * time.Second
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+
+	err := cmd.Run()
+	if cctx.Err() == context.DeadlineExceeded {
+		return out.String(), -1, fmt.Errorf("timed out after %s", timeout)
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return out.String(), exitErr.ExitCode(), nil
+	}
+	if err != nil {
+		return out.String(), -1, err
+	}
+	return out.String(), 0, nil
+}
+
+// firstLine returns the first non-empty line of s, for compact details.
+func firstLine(s string) string {
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
+
+// commandCheck runs a declarative check from a TOML spec.
+type commandCheck struct {
+	BaseCheck
+	spec    *CustomCheckSpec
+	expect  *regexp.Regexp
+	timeout time.Duration
+	failing []checkTarget // Cached during Run for use in Fix
+}
+
+func newCommandCheck(spec *CustomCheckSpec) *commandCheck {
+	category := spec.Category
+	if category == "" {
+		category = CustomCategory
+	}
+	description := spec.Description
+	if description == "" {
+		description = "Custom check: " + spec.Command
+	}
+	cost, _ := parseCost(spec.Cost) // Validated in ParseCustomCheck
+	timeout := customCommandTimeout
+	if spec.Timeout != "" {
+		timeout, _ = time.ParseDuration(spec.Timeout)
+	}
+
+	c := &commandCheck{
+		BaseCheck: BaseCheck{
+			CheckName:        spec.Name,
+			CheckDescription: description,
+			CheckCategory:    category,
+			CheckDependsOn:   spec.DependsOn,
+			CheckCost:        cost,
+		},
+		spec:    spec,
+		timeout: timeout,
+	}
+	if spec.ExpectOutput != "" {
+		c.expect = regexp.MustCompile(spec.ExpectOutput)
+	}
+	return c
+}
+
+// CanFix returns true if the spec has a fix command.
+func (c *commandCheck) CanFix() bool {
+	return c.spec.Fix != ""
+}
+
+// RunTimeout allows the per-command timeout for every target in scope, since
+// targets are checked one after another.
+func (c *commandCheck) RunTimeout(ctx *CheckContext) time.Duration {
+	targets, _ := customCheckTargets(ctx, c.spec.Scope)
+	return c.timeout * time.Duration(max(len(targets), 1))
+}
+
+// Run executes the command in every target in scope.
+func (c *commandCheck) Run(ctx *CheckContext) *CheckResult {
+	c.failing = nil
+
+	targets, err := customCheckTargets(ctx, c.spec.Scope)
+	if err != nil {
+		return &CheckResult{Name: c.Name(), Status: StatusError, Message: err.Error()}
+	}
+	if len(targets) == 0 {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusOK,
+			Message: fmt.Sprintf("No %ss to check", c.spec.Scope),
+		}
+	}
+
+	var details []string
+	for _, target := range targets {
+		if problem := c.check(ctx, target); problem != "" {
+			c.failing = append(c.failing, target)
+			if target.Label != "" {
+				problem = target.Label + ": " + problem
+			}
+			details = append(details, problem)
+		}
+	}
+
+	if len(c.failing) == 0 {
+		msg := "Passed"
+		if c.spec.Scope != ScopeTown {
+			msg = fmt.Sprintf("Passed in %d %s(s)", len(targets), c.spec.Scope)
+		}
+		return &CheckResult{Name: c.Name(), Status: StatusOK, Message: msg}
+	}
+
+	status := StatusError
+	if c.spec.Severity == "warning" {
+		status = StatusWarning
+	}
+	msg := "Failed"
+	if c.spec.Scope != ScopeTown {
+		msg = fmt.Sprintf("Failed in %d of %d %s(s)", len(c.failing), len(targets), c.spec.Scope)
+	}
+	hint := c.spec.FixHint
+	if hint == "" && c.CanFix() {
+		hint = "Run 'gt doctor --fix' to run: " + c.spec.Fix
+	}
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  status,
+		Message: msg,
+		Details: details,
+		FixHint: hint,
+	}
+}
+
+// check runs the command in one target and describes any mismatch.
+func (c *commandCheck) check(ctx *CheckContext, target checkTarget) string {
+	out, code, err := runShell(ctx, target, c.spec.Command, c.timeout)
+	if err != nil {
+		return err.Error()
+	}
+	if code != c.spec.ExpectExit {
+		problem := fmt.Sprintf("exit %d, want %d", code, c.spec.ExpectExit)
+		if line := firstLine(out); line != "" {
+			problem += ": " + line
+		}
+		return problem
+	}
+	if c.expect != nil && !c.expect.MatchString(out) {
+		return fmt.Sprintf("output does not match %q", c.spec.ExpectOutput)
+	}
+	return ""
+}
+
+// Fix runs the fix command in every target that failed.
+func (c *commandCheck) Fix(ctx *CheckContext) error {
+	if !c.CanFix() {
+		return ErrCannotFix
+	}
+	var errs []string
+	for _, target := range c.failing {
+		out, code, err := runShell(ctx, target, c.spec.Fix, c.timeout)
+		if err == nil && code != 0 {
+			err = fmt.Errorf("exit %d: %s", code, firstLine(out))
+		}
+		if err != nil {
+			label := target.Label
+			if label == "" {
+				label = "town"
+			}
+			errs = append(errs, fmt.Sprintf("%s: %v", label, err))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// pluginCheck runs an executable from CustomChecksDir.
+//
+// The plugin is named after its file and invoked with a single argument:
+//
+//	describe  print {"description", "category", "fixable", "depends_on",
+//	          "cost"} as JSON (optional; failures fall back to the custom
+//	          category with no fix support)
+//	run       print {"status": "ok|warning|error", "message", "details",
+//	          "fix_hint"} as JSON; if the output isn't JSON, exit 0 means ok,
+//	          1 warning, anything else error, and the first output line is
+//	          the message
+//	fix       attempt a fix; exit 0 on success
+//
+// GT_TOWN_ROOT, GT_RIG (with --rig) and GT_CHECK_DIR are set in its environment.
+//
+// describe runs the first time the metadata is needed rather than at load, so
+// plugins that --only/--skip filter out by name are never executed.
+type pluginCheck struct {
+	BaseCheck
+	path         string
+	fixable      bool
+	describeOnce sync.Once
+}
+
+type pluginDescription struct {
+	Description string   `json:"description"`
+	Category    string   `json:"category"`
+	Fixable     bool     `json:"fixable"`
+	DependsOn   []string `json:"depends_on"`
+	Cost        string   `json:"cost"`
+}
+
+type pluginResult struct {
+	Status  string   `json:"status"`
+	Message string   `json:"message"`
+	Details []string `json:"details"`
+	FixHint string   `json:"fix_hint"`
+}
+
+func newPluginCheck(path string) *pluginCheck {
+	return &pluginCheck{
+		BaseCheck: BaseCheck{
+			CheckName:        filepath.Base(path),
+			CheckDescription: "Custom check plugin " + filepath.Base(path),
+			CheckCategory:    CustomCategory,
+		},
+		path: path,
+	}
+}
+
+// describe fills in the plugin's metadata from its describe action, once.
+func (c *pluginCheck) describe() {
+	c.describeOnce.Do(func() {
+		// describe runs without a town context; plugins should answer statically.
+		out, code, err := runShell(&CheckContext{}, checkTarget{Dir: filepath.Dir(c.path)}, util.ShellQuote(c.path)+" describe", customCommandTimeout)
+		var desc pluginDescription
+		if err != nil || code != 0 || json.Unmarshal([]byte(out), &desc) != nil {
+			return
+		}
+		if desc.Description != "" {
+			c.CheckDescription = desc.Description
+		}
+		if desc.Category != "" {
+			c.CheckCategory = desc.Category
+		}
+		if cost, err := parseCost(desc.Cost); err == nil {
+			c.CheckCost = cost
+		}
+		c.CheckDependsOn = desc.DependsOn
+		c.fixable = desc.Fixable
+	})
+}
+
+// Description returns the plugin's self-description.
+func (c *pluginCheck) Description() string {
+	c.describe()
+	return c.BaseCheck.Description()
+}
+
+// Category returns the category the plugin described itself in.
+func (c *pluginCheck) Category() string {
+	c.describe()
+	return c.BaseCheck.Category()
+}
+
+// DependsOn returns the checks the plugin described itself as needing.
+func (c *pluginCheck) DependsOn() []string {
+	c.describe()
+	return c.BaseCheck.DependsOn()
+}
+
+// Cost returns the plugin's described cost.
+func (c *pluginCheck) Cost() CheckCost {
+	c.describe()
+	return c.BaseCheck.Cost()
+}
+
+// CanFix returns true if the plugin described itself as fixable.
+func (c *pluginCheck) CanFix() bool {
+	c.describe()
+	return c.fixable
+}
+
+func (c *pluginCheck) target(ctx *CheckContext) checkTarget {
+	t := checkTarget{Dir: ctx.TownRoot, Rig: ctx.RigName, Label: ctx.RigName}
+	if ctx.RigName != "" {
+		t.Dir = ctx.RigPath()
+	}
+	return t
+}
+
+// Run invokes the plugin's run action.
+func (c *pluginCheck) Run(ctx *CheckContext) *CheckResult {
+	timeout := costOf(c).Timeout()
+	out, code, err := runShell(ctx, c.target(ctx), util.ShellQuote(c.path)+" run", timeout)
+	if err != nil {
+		return &CheckResult{Name: c.Name(), Status: StatusError, Message: "plugin failed: " + err.Error()}
+	}
+
+	var res pluginResult
+	if json.Unmarshal([]byte(out), &res) == nil && res.Status != "" {
+		result := &CheckResult{
+			Name:    c.Name(),
+			Message: res.Message,
+			Details: res.Details,
+			FixHint: res.FixHint,
+		}
+		switch strings.ToLower(res.Status) {
+		case "ok":
+			result.Status = StatusOK
+		case "warning":
+			result.Status = StatusWarning
+		case "error":
+			result.Status = StatusError
+		default:
+			result.Status = StatusError
+			result.Message = fmt.Sprintf("plugin returned unknown status %q", res.Status)
+		}
+		return result
+	}
+
+	// Plain output: exit code carries the status.
+	result := &CheckResult{Name: c.Name(), Message: firstLine(out)}
+	switch code {
+	case 0:
+		result.Status = StatusOK
+	case 1:
+		result.Status = StatusWarning
+	default:
+		result.Status = StatusError
+	}
+	if result.Message == "" {
+		result.Message = fmt.Sprintf("exit %d", code)
+	}
+	return result
+}
+
+// Fix invokes the plugin's fix action.
+func (c *pluginCheck) Fix(ctx *CheckContext) error {
+	if !c.CanFix() {
+		return ErrCannotFix
+	}
+	out, code, err := runShell(ctx, c.target(ctx), util.ShellQuote(c.path)+" fix", costOf(c).Timeout())
+	if err != nil {
+		return err
+	}
+	if code != 0 {
+		return fmt.Errorf("exit %d: %s", code, firstLine(out))
+	}
+	return nil
+}
+
+// brokenCheck reports a custom check that could not be loaded.
+type brokenCheck struct {
+	BaseCheck
+	err error
+}
+
+func newBrokenCheck(name string, err error) *brokenCheck {
+	return &brokenCheck{
+		BaseCheck: BaseCheck{
+			CheckName:        name,
+			CheckDescription: "Invalid custom check definition",
+			CheckCategory:    CustomCategory,
+		},
+		err: err,
+	}
+}
+
+// Run reports the load error.
+func (c *brokenCheck) Run(ctx *CheckContext) *CheckResult {
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusError,
+		Message: "invalid custom check: " + c.err.Error(),
+		FixHint: "Fix the definition in " + CustomChecksDir,
+	}
+}
@@ -0,0 +1,255 @@
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeCustomCheck(t *testing.T, townRoot, name, content string, mode os.FileMode) {
+	t.Helper()
+	dir := filepath.Join(townRoot, CustomChecksDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), mode); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func findCheck(checks []Check, name string) Check {
+	for _, c := range checks {
+		if c.Name() == name {
+			return c
+		}
+	}
+	return nil
+}
+
+func TestLoadCustomChecks_None(t *testing.T) {
+	if checks := LoadCustomChecks(t.TempDir(), nil); len(checks) != 0 {
+		t.Errorf("LoadCustomChecks() = %d checks, want 0", len(checks))
+	}
+}
+
+func TestCustomCommandCheck_TownScope(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeCustomCheck(t, tmpDir, "marker.toml", `
+description = "Town has a marker file"
+command = "test -f marker"
+severity = "warning"
+fix = "touch marker"
+`, 0644)
+	writeCustomCheck(t, tmpDir, "version.toml", `
+command = "echo gt 1.2.3"
+expect_output = "^gt \\d+\\.\\d+"
+category = "tools"
+`, 0644)
+	writeCustomCheck(t, tmpDir, "README.md", "notes", 0644)
+
+	checks := LoadCustomChecks(tmpDir, nil)
+	if len(checks) != 2 {
+		t.Fatalf("LoadCustomChecks() = %d checks, want 2", len(checks))
+	}
+	ctx := &CheckContext{TownRoot: tmpDir}
+
+	version := findCheck(checks, "version")
+	if version == nil || CategoryOf(version) != "tools" {
+		t.Fatalf("version check missing or wrong category: %v", version)
+	}
+	if result := version.Run(ctx); result.Status != StatusOK {
+		t.Errorf("version: Status = %v, want OK: %s %v", result.Status, result.Message, result.Details)
+	}
+
+	marker := findCheck(checks, "marker")
+	if marker == nil || CategoryOf(marker) != CustomCategory || !marker.CanFix() {
+		t.Fatalf("marker check missing, wrong category or not fixable: %v", marker)
+	}
+	result := marker.Run(ctx)
+	if result.Status != StatusWarning {
+		t.Fatalf("marker: Status = %v, want Warning", result.Status)
+	}
+	if err := marker.Fix(ctx); err != nil {
+		t.Fatalf("Fix() error = %v", err)
+	}
+	if result := marker.Run(ctx); result.Status != StatusOK {
+		t.Errorf("marker after fix: Status = %v, want OK: %v", result.Status, result.Details)
+	}
+}
+
+func TestCustomCommandCheck_RigAndPolecatScope(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupRigConfig(t, tmpDir, []string{"gastown", "beads"})
+	for _, dir := range []string{
+		filepath.Join(tmpDir, "gastown", ".hooks-ok"),
+		filepath.Join(tmpDir, "gastown", "polecats", "toast"),
+		filepath.Join(tmpDir, "gastown", "polecats", "nux", ".clean"),
+	} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeCustomCheck(t, tmpDir, "hooks.toml", `
+scope = "rig"
+command = "test -d .hooks-ok && test \"$GT_RIG\" = \"$(basename \"$PWD\")\""
+`, 0644)
+	writeCustomCheck(t, tmpDir, "clean.toml", `
+scope = "polecat"
+command = "test -d .clean || { echo \"dirty $GT_POLECAT\"; exit 3; }"
+`, 0644)
+
+	checks := LoadCustomChecks(tmpDir, nil)
+	ctx := &CheckContext{TownRoot: tmpDir}
+
+	result := findCheck(checks, "hooks").Run(ctx)
+	if result.Status != StatusError || !strings.Contains(result.Message, "1 of 2 rig") {
+		t.Fatalf("hooks: got %v %q", result.Status, result.Message)
+	}
+	if len(result.Details) != 1 || !strings.HasPrefix(result.Details[0], "beads:") {
+		t.Errorf("hooks: details = %v", result.Details)
+	}
+
+	result = findCheck(checks, "clean").Run(ctx)
+	if result.Status != StatusError || !strings.Contains(result.Message, "1 of 2 polecat") {
+		t.Fatalf("clean: got %v %q", result.Status, result.Message)
+	}
+	if len(result.Details) != 1 || result.Details[0] != "gastown/toast: exit 3, want 0: dirty toast" {
+		t.Errorf("clean: details = %v", result.Details)
+	}
+
+	// --rig narrows the scope
+	result = findCheck(checks, "hooks").Run(&CheckContext{TownRoot: tmpDir, RigName: "gastown"})
+	if result.Status != StatusOK {
+		t.Errorf("hooks with --rig gastown: Status = %v: %v", result.Status, result.Details)
+	}
+}
+
+func TestCustomCommandCheck_Timeout(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupRigConfig(t, tmpDir, []string{"gastown", "beads", "wyvern"})
+	writeCustomCheck(t, tmpDir, "town.toml", `command = "true"`, 0644)
+	writeCustomCheck(t, tmpDir, "rigs.toml", `
+scope = "rig"
+command = "true"
+timeout = "20s"
+`, 0644)
+
+	checks := LoadCustomChecks(tmpDir, nil)
+	ctx := &CheckContext{TownRoot: tmpDir}
+
+	// Sequential targets each get the per-command timeout
+	if got, want := timeoutOf(ctx, findCheck(checks, "town")), 30*time.Second; got != want {
+		t.Errorf("town: timeout = %s, want %s", got, want)
+	}
+	if got, want := timeoutOf(ctx, findCheck(checks, "rigs")), time.Minute; got != want {
+		t.Errorf("rigs: timeout = %s, want %s", got, want)
+	}
+	ctx.RigName = "gastown"
+	if got, want := timeoutOf(ctx, findCheck(checks, "rigs")), 20*time.Second; got != want {
+		t.Errorf("rigs with --rig: timeout = %s, want %s", got, want)
+	}
+}
+
+func TestLoadCustomChecks_Invalid(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeCustomCheck(t, tmpDir, "nocommand.toml", `name = "nocommand"`, 0644)
+	writeCustomCheck(t, tmpDir, "badscope.toml", "command = \"true\"\nscope = \"galaxy\"", 0644)
+	writeCustomCheck(t, tmpDir, "daemon.toml", `command = "true"`, 0644)
+
+	builtin := []Check{newMockCheck("daemon", StatusOK)}
+	checks := LoadCustomChecks(tmpDir, builtin)
+	if len(checks) != 3 {
+		t.Fatalf("LoadCustomChecks() = %d checks, want 3", len(checks))
+	}
+	for _, c := range checks {
+		result := c.Run(&CheckContext{TownRoot: tmpDir})
+		if result.Status != StatusError || !strings.Contains(result.Message, "invalid custom check") {
+			t.Errorf("%s: got %v %q, want invalid custom check error", c.Name(), result.Status, result.Message)
+		}
+	}
+}
+
+func TestPluginCheck(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeCustomCheck(t, tmpDir, "disk-space", `#!/bin/sh
+case "$1" in
+describe) echo '{"description":"Enough disk per rig","category":"host","fixable":true}' ;;
+run) [ -f "$GT_TOWN_ROOT/freed" ] && echo '{"status":"ok","message":"plenty"}' || echo '{"status":"warning","message":"low","details":["/ 95%"]}' ;;
+fix) touch "$GT_TOWN_ROOT/freed" ;;
+esac
+`, 0755)
+	writeCustomCheck(t, tmpDir, "plain", "#!/bin/sh\necho 'something broke'\nexit 2\n", 0755)
+
+	checks := LoadCustomChecks(tmpDir, nil)
+	ctx := &CheckContext{TownRoot: tmpDir}
+
+	disk := findCheck(checks, "disk-space")
+	if disk == nil || CategoryOf(disk) != "host" || !disk.CanFix() {
+		t.Fatalf("disk-space plugin not described: %v", disk)
+	}
+	result := disk.Run(ctx)
+	if result.Status != StatusWarning || result.Message != "low" || len(result.Details) != 1 {
+		t.Fatalf("disk-space: got %+v", result)
+	}
+	if err := disk.Fix(ctx); err != nil {
+		t.Fatalf("Fix() error = %v", err)
+	}
+	if result := disk.Run(ctx); result.Status != StatusOK {
+		t.Errorf("disk-space after fix: Status = %v", result.Status)
+	}
+
+	plain := findCheck(checks, "plain")
+	if plain == nil || plain.CanFix() {
+		t.Fatalf("plain plugin missing or fixable: %v", plain)
+	}
+	result = plain.Run(ctx)
+	if result.Status != StatusError || result.Message != "something broke" {
+		t.Errorf("plain: got %v %q", result.Status, result.Message)
+	}
+}
+
+func TestRunShell_TimeoutKillsProcessGroup(t *testing.T) {
+	// The backgrounded sleep holds the output pipe open; without the group
+	// kill, runShell would wait for it to exit.
+	start := time.Now()
+	_, code, err := runShell(&CheckContext{}, checkTarget{Dir: t.TempDir()}, "sleep 30 & sleep 30", 200*time.Millisecond)
+	if err == nil || code != -1 {
+		t.Fatalf("runShell() = %d, %v; want timeout", code, err)
+	}
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Errorf("runShell() took %s after timeout", elapsed)
+	}
+}
+
+func TestPluginCheck_DescribesLazily(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeCustomCheck(t, tmpDir, "counted", `#!/bin/sh
+[ "$1" = describe ] && touch "$(dirname "$0")/described" && echo '{"category":"host"}'
+exit 0
+`, 0755)
+	described := filepath.Join(tmpDir, CustomChecksDir, "described")
+
+	d := NewDoctor()
+	d.Register(newMockCheck("daemon", StatusOK))
+	d.RegisterAll(LoadCustomChecks(tmpDir, d.Checks())...)
+	if err := d.Filter([]string{"daemon"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(described); err == nil {
+		t.Fatal("plugin described while loading or filtering by name")
+	}
+
+	d = NewDoctor()
+	d.RegisterAll(LoadCustomChecks(tmpDir, nil)...)
+	if err := d.Filter([]string{"host"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(d.Checks()) != 1 {
+		t.Fatalf("--only host kept %d checks, want 1", len(d.Checks()))
+	}
+	if _, err := os.Stat(described); err != nil {
+		t.Error("plugin not described for a category filter")
+	}
+}
@@ -1,8 +1,11 @@
 package doctor
 
+import "sync"
+
 // Doctor manages and executes health checks.
 type Doctor struct {
 	checks []Check
+	fixMu  sync.Mutex
 }
 
 // NewDoctor creates a new Doctor with no registered checks.
@@ -29,54 +32,47 @@ func (d *Doctor) Checks() []Check {
 
 // Run executes all registered checks and returns a report.
 func (d *Doctor) Run(ctx *CheckContext) *Report {
-	report := NewReport()
-
-	for _, check := range d.checks {
-		result := check.Run(ctx)
-		// Ensure check name is populated
-		if result.Name == "" {
-			result.Name = check.Name()
-		}
-		report.Add(result)
-	}
-
-	return report
+	return d.RunWithOptions(ctx, RunOptions{})
 }
 
 // Fix runs all checks with auto-fix enabled where possible.
 // It first runs the check, then if it fails and can be fixed, attempts the fix.
 func (d *Doctor) Fix(ctx *CheckContext) *Report {
-	report := NewReport()
-
-	for _, check := range d.checks {
-		result := check.Run(ctx)
-		if result.Name == "" {
-			result.Name = check.Name()
-		}
-
-		// Attempt fix if check failed and is fixable
-		if result.Status != StatusOK && check.CanFix() {
-			err := check.Fix(ctx)
-			if err == nil {
-				// Re-run check to verify fix worked
-				result = check.Run(ctx)
-				if result.Name == "" {
-					result.Name = check.Name()
-				}
-				// Update message to indicate fix was applied
-				if result.Status == StatusOK {
-					result.Message = result.Message + " (fixed)"
-				}
-			} else {
-				// Fix failed, add error to details
-				result.Details = append(result.Details, "Fix failed: "+err.Error())
-			}
-		}
-
-		report.Add(result)
+	return d.RunWithOptions(ctx, RunOptions{Fix: true})
+}
+
+// runOnce runs a check, filling in its name if the check left it empty.
+func runOnce(ctx *CheckContext, check Check) *CheckResult {
+	result := check.Run(ctx)
+	if result.Name == "" {
+		result.Name = check.Name()
+	}
+	return result
+}
+
+// fixCheck attempts to fix a failing check and re-runs it. Fixes are
+// serialized through fixMu since they mutate the workspace.
+func (d *Doctor) fixCheck(ctx *CheckContext, check Check, result *CheckResult) *CheckResult {
+	if result.Status == StatusOK || !check.CanFix() {
+		return result
+	}
+
+	d.fixMu.Lock()
+	err := check.Fix(ctx)
+	d.fixMu.Unlock()
+	if err != nil {
+		// Fix failed, add error to details
+		result.Details = append(result.Details, "Fix failed: "+err.Error())
+		return result
 	}
 
-	return report
+	// Re-run check to verify fix worked
+	result = runOnce(ctx, check)
+	// Update message to indicate fix was applied
+	if result.Status == StatusOK {
+		result.Message = result.Message + " (fixed)"
+	}
+	return result
 }
 
 // BaseCheck provides a base implementation for checks that don't support auto-fix.
@@ -84,6 +80,9 @@ func (d *Doctor) Fix(ctx *CheckContext) *Report {
 type BaseCheck struct {
 	CheckName        string
 	CheckDescription string
+	CheckCategory    string    // Selector group for --only/--skip (default "general")
+	CheckDependsOn   []string  // Checks that must not error before this one runs
+	CheckCost        CheckCost // Rough runtime cost; sets the default timeout
 }
 
 // Name returns the check name.
@@ -96,6 +95,24 @@ func (b *BaseCheck) Description() string {
 	return b.CheckDescription
 }
 
+// Category returns the check category.
+func (b *BaseCheck) Category() string {
+	if b.CheckCategory == "" {
+		return DefaultCategory
+	}
+	return b.CheckCategory
+}
+
+// DependsOn returns the names of checks this check depends on.
+func (b *BaseCheck) DependsOn() []string {
+	return b.CheckDependsOn
+}
+
+// Cost returns the check's rough runtime cost.
+func (b *BaseCheck) Cost() CheckCost {
+	return b.CheckCost
+}
+
 // CanFix returns false by default.
 func (b *BaseCheck) CanFix() bool {
 	return false
@@ -2,7 +2,10 @@ package doctor
 
 import (
 	"bytes"
+	"encoding/json"
+	"strings"
 	"testing"
+	"time"
 )
 
 // mockCheck is a test check that can be configured to return any status.
@@ -358,3 +361,233 @@ func TestFixableCheck(t *testing.T) {
 		t.Error("FixableCheck.CanFix() should return true")
 	}
 }
+
+// slowCheck is a mock check that sleeps before returning.
+type slowCheck struct {
+	mockCheck
+	delay time.Duration
+}
+
+func (s *slowCheck) Run(ctx *CheckContext) *CheckResult {
+	time.Sleep(s.delay)
+	return s.mockCheck.Run(ctx)
+}
+
+func TestDoctor_RunWithOptions_Dependencies(t *testing.T) {
+	d := NewDoctor()
+	base := newMockCheck("base", StatusError)
+	dependent := newMockCheck("dependent", StatusOK)
+	dependent.CheckDependsOn = []string{"base"}
+	transitive := newMockCheck("transitive", StatusOK)
+	transitive.CheckDependsOn = []string{"dependent"}
+	missingDep := newMockCheck("missing-dep", StatusOK)
+	missingDep.CheckDependsOn = []string{"not-registered"}
+	d.RegisterAll(transitive, dependent, base, missingDep)
+
+	report := d.RunWithOptions(&CheckContext{TownRoot: "/test"}, RunOptions{})
+
+	want := []CheckStatus{StatusSkipped, StatusSkipped, StatusError, StatusOK}
+	for i, w := range want {
+		if got := report.Checks[i].Status; got != w {
+			t.Errorf("check %s: status = %v, want %v", report.Checks[i].Name, got, w)
+		}
+	}
+	if report.Summary.Skipped != 2 {
+		t.Errorf("Summary.Skipped = %d, want 2", report.Summary.Skipped)
+	}
+}
+
+func TestDoctor_RunWithOptions_Cycle(t *testing.T) {
+	d := NewDoctor()
+	a := newMockCheck("a", StatusOK)
+	a.CheckDependsOn = []string{"b"}
+	b := newMockCheck("b", StatusOK)
+	b.CheckDependsOn = []string{"a"}
+	c := newMockCheck("c", StatusOK)
+	d.RegisterAll(a, b, c)
+
+	report := d.RunWithOptions(&CheckContext{TownRoot: "/test"}, RunOptions{})
+
+	if report.Checks[0].Status != StatusError || report.Checks[1].Status != StatusError {
+		t.Errorf("cyclic checks should error, got %v and %v", report.Checks[0].Status, report.Checks[1].Status)
+	}
+	if report.Checks[2].Status != StatusOK {
+		t.Errorf("independent check should still run, got %v", report.Checks[2].Status)
+	}
+}
+
+func TestDoctor_RunWithOptions_Parallel(t *testing.T) {
+	d := NewDoctor()
+	for _, name := range []string{"one", "two", "three", "four"} {
+		d.Register(&slowCheck{mockCheck: *newMockCheck(name, StatusOK), delay: 100 * time.Millisecond})
+	}
+
+	start := time.Now()
+	report := d.RunWithOptions(&CheckContext{TownRoot: "/test"}, RunOptions{Parallelism: 4})
+	elapsed := time.Since(start)
+
+	if elapsed > 300*time.Millisecond {
+		t.Errorf("4 parallel 100ms checks took %s", elapsed)
+	}
+	// Results stay in registration order
+	for i, name := range []string{"one", "two", "three", "four"} {
+		if report.Checks[i].Name != name {
+			t.Errorf("Checks[%d] = %s, want %s", i, report.Checks[i].Name, name)
+		}
+	}
+}
+
+func TestDoctor_RunWithOptions_Timeout(t *testing.T) {
+	d := NewDoctor()
+	d.Register(&slowCheck{mockCheck: *newMockCheck("slow", StatusOK), delay: time.Second})
+
+	report := d.RunWithOptions(&CheckContext{TownRoot: "/test"}, RunOptions{Timeout: 20 * time.Millisecond})
+
+	if report.Checks[0].Status != StatusError {
+		t.Errorf("timed out check status = %v, want Error", report.Checks[0].Status)
+	}
+	if !strings.Contains(report.Checks[0].Message, "timed out") {
+		t.Errorf("message = %q, want timeout", report.Checks[0].Message)
+	}
+}
+
+// slowFixCheck is a mock check whose fix takes a while.
+type slowFixCheck struct {
+	mockCheck
+	delay time.Duration
+}
+
+func (s *slowFixCheck) Fix(ctx *CheckContext) error {
+	time.Sleep(s.delay)
+	return s.mockCheck.Fix(ctx)
+}
+
+func TestDoctor_RunWithOptions_TimeoutSparesFix(t *testing.T) {
+	d := NewDoctor()
+	check := &slowFixCheck{mockCheck: *newMockCheck("slow-fix", StatusError), delay: 100 * time.Millisecond}
+	check.fixable = true
+	d.Register(check)
+
+	report := d.RunWithOptions(&CheckContext{TownRoot: "/test"}, RunOptions{Fix: true, Timeout: 20 * time.Millisecond})
+
+	if report.Checks[0].Status != StatusOK || !strings.Contains(report.Checks[0].Message, "(fixed)") {
+		t.Errorf("got %v %q, want fixed", report.Checks[0].Status, report.Checks[0].Message)
+	}
+	if check.fixCount != 1 {
+		t.Errorf("fixCount = %d, want 1", check.fixCount)
+	}
+}
+
+func TestDoctor_Filter(t *testing.T) {
+	newDoctor := func() *Doctor {
+		d := NewDoctor()
+		a := newMockCheck("a", StatusOK)
+		a.CheckCategory = "git"
+		b := newMockCheck("b", StatusOK)
+		b.CheckCategory = "git"
+		c := newMockCheck("c", StatusOK)
+		d.RegisterAll(a, b, c)
+		return d
+	}
+	names := func(d *Doctor) string {
+		var out []string
+		for _, c := range d.Checks() {
+			out = append(out, c.Name())
+		}
+		return strings.Join(out, ",")
+	}
+
+	tests := []struct {
+		name    string
+		only    []string
+		skip    []string
+		want    string
+		wantErr bool
+	}{
+		{"no selectors", nil, nil, "a,b,c", false},
+		{"only category", []string{"git"}, nil, "a,b", false},
+		{"only name", []string{"c"}, nil, "c", false},
+		{"skip name", nil, []string{"b"}, "a,c", false},
+		{"only category skip name", []string{"git"}, []string{"a"}, "b", false},
+		{"default category", []string{DefaultCategory}, nil, "c", false},
+		{"unknown selector", []string{"nope"}, nil, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newDoctor()
+			err := d.Filter(tt.only, tt.skip)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Filter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && names(d) != tt.want {
+				t.Errorf("Filter() kept %s, want %s", names(d), tt.want)
+			}
+		})
+	}
+}
+
+func TestReport_WriteJSON(t *testing.T) {
+	r := NewReport()
+	r.Add(&CheckResult{Name: "ok", Category: "git", Status: StatusOK, Message: "fine"})
+	r.Add(&CheckResult{Name: "bad", Status: StatusError, Message: "broken", FixHint: "fix it"})
+
+	var buf bytes.Buffer
+	if err := r.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+
+	var got struct {
+		Healthy bool `json:"healthy"`
+		Summary struct {
+			Errors int `json:"errors"`
+		} `json:"summary"`
+		Checks []struct {
+			Name     string `json:"name"`
+			Category string `json:"category"`
+			Status   string `json:"status"`
+			FixHint  string `json:"fix_hint"`
+		} `json:"checks"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if got.Healthy || got.Summary.Errors != 1 || len(got.Checks) != 2 {
+		t.Fatalf("unexpected report: %+v", got)
+	}
+	if got.Checks[0].Status != "ok" || got.Checks[0].Category != "git" {
+		t.Errorf("Checks[0] = %+v", got.Checks[0])
+	}
+	if got.Checks[1].Status != "error" || got.Checks[1].FixHint != "fix it" {
+		t.Errorf("Checks[1] = %+v", got.Checks[1])
+	}
+}
+
+func TestReport_WriteSARIF(t *testing.T) {
+	r := NewReport()
+	r.Add(&CheckResult{Name: "ok", Status: StatusOK, Message: "fine"})
+	r.Add(&CheckResult{Name: "warn", Status: StatusWarning, Message: "hmm", Details: []string{"detail"}})
+
+	var buf bytes.Buffer
+	checks := []Check{newMockCheck("ok", StatusOK), newMockCheck("warn", StatusWarning)}
+	if err := r.WriteSARIF(&buf, checks, "1.2.3"); err != nil {
+		t.Fatalf("WriteSARIF() error = %v", err)
+	}
+
+	var got sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid SARIF: %v", err)
+	}
+	if got.Version != "2.1.0" || len(got.Runs) != 1 {
+		t.Fatalf("unexpected SARIF log: %+v", got)
+	}
+	run := got.Runs[0]
+	if len(run.Tool.Driver.Rules) != 2 || run.Tool.Driver.Rules[1].ShortDescription.Text != "Test check: warn" {
+		t.Errorf("rules = %+v", run.Tool.Driver.Rules)
+	}
+	if run.Results[0].Kind != "pass" || run.Results[1].Level != "warning" {
+		t.Errorf("results = %+v", run.Results)
+	}
+	if run.Results[1].Message.Text != "hmm\ndetail" {
+		t.Errorf("message = %q", run.Results[1].Message.Text)
+	}
+}
@@ -32,6 +32,9 @@ func NewHookAttachmentValidCheck() *HookAttachmentValidCheck {
 			BaseCheck: BaseCheck{
 				CheckName:        "hook-attachment-valid",
 				CheckDescription: "Verify attached molecules exist and are not closed",
+				CheckCategory:    "hooks",
+				CheckDependsOn:   []string{"beads-database"},
+				CheckCost:        CostModerate,
 			},
 		},
 	}
@@ -207,6 +210,9 @@ func NewHookSingletonCheck() *HookSingletonCheck {
 			BaseCheck: BaseCheck{
 				CheckName:        "hook-singleton",
 				CheckDescription: "Ensure each agent has at most one handoff bead",
+				CheckCategory:    "hooks",
+				CheckDependsOn:   []string{"beads-database"},
+				CheckCost:        CostModerate,
 			},
 		},
 	}
@@ -346,6 +352,9 @@ func NewOrphanedAttachmentsCheck() *OrphanedAttachmentsCheck {
 		BaseCheck: BaseCheck{
 			CheckName:        "orphaned-attachments",
 			CheckDescription: "Detect handoff beads for non-existent agents",
+			CheckCategory:    "hooks",
+			CheckDependsOn:   []string{"beads-database"},
+			CheckCost:        CostModerate,
 		},
 	}
 }
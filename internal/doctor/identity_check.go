@@ -24,6 +24,14 @@ func (c *IdentityCollisionCheck) Description() string {
 	return "Check for agent identity collisions and stale locks"
 }
 
+func (c *IdentityCollisionCheck) Category() string {
+	return "sessions"
+}
+
+func (c *IdentityCollisionCheck) Cost() CheckCost {
+	return CostModerate
+}
+
 func (c *IdentityCollisionCheck) CanFix() bool {
 	return true // Can fix stale locks
 }
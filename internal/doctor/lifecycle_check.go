@@ -27,6 +27,8 @@ func NewLifecycleHygieneCheck() *LifecycleHygieneCheck {
 			BaseCheck: BaseCheck{
 				CheckName:        "lifecycle-hygiene",
 				CheckDescription: "Check for stale lifecycle messages",
+				CheckCategory:    "cleanup",
+				CheckCost:        CostModerate,
 			},
 		},
 	}
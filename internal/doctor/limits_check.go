@@ -0,0 +1,106 @@
+package doctor
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/limits"
+)
+
+// LimitsCheck validates the town and rig resource limits and verifies the
+// host can enforce them. Invalid or unenforceable limits don't stop agents
+// from starting - they just run unlimited - so this is where they surface.
+type LimitsCheck struct {
+	BaseCheck
+}
+
+// NewLimitsCheck creates a new resource limits check.
+func NewLimitsCheck() *LimitsCheck {
+	return &LimitsCheck{
+		BaseCheck: BaseCheck{
+			CheckName:        "resource-limits",
+			CheckDescription: "Validate agent session resource limits",
+			CheckCategory:    "sandbox",
+		},
+	}
+}
+
+// Run validates the town's limits and those of every rig (or just ctx.RigName).
+func (c *LimitsCheck) Run(ctx *CheckContext) *CheckResult {
+	rigs := []string{ctx.RigName}
+	if ctx.RigName == "" {
+		var err error
+		if rigs, err = discoverRigs(ctx.TownRoot); err != nil {
+			return &CheckResult{
+				Name:    c.Name(),
+				Status:  StatusError,
+				Message: fmt.Sprintf("Cannot read rigs.json: %v", err),
+			}
+		}
+	}
+	sort.Strings(rigs)
+
+	var problems []string
+	configured := 0
+	check := func(scope string, cfg *config.LimitsConfig) {
+		if cfg == nil {
+			return
+		}
+		configured++
+		for _, problem := range cfg.Validate() {
+			problems = append(problems, fmt.Sprintf("%s: %s", scope, problem))
+		}
+	}
+
+	if settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(ctx.TownRoot)); err == nil {
+		check("town", settings.Limits)
+	}
+	for _, rigName := range rigs {
+		settings, err := config.LoadRigSettings(config.RigSettingsPath(filepath.Join(ctx.TownRoot, rigName)))
+		if err == nil {
+			check(rigName, settings.Limits)
+		}
+	}
+
+	if configured == 0 {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusOK,
+			Message: "No resource limits configured",
+		}
+	}
+	if len(problems) > 0 {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusError,
+			Message: fmt.Sprintf("%d invalid limit(s); affected sessions run unlimited", len(problems)),
+			Details: problems,
+			FixHint: "Fix the \"limits\" section of settings/config.json or <rig>/settings/config.json",
+		}
+	}
+
+	switch limits.Method() {
+	case "":
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusWarning,
+			Message: "Resource limits configured but can't be enforced on this host",
+			FixHint: "Install systemd-run (with cgroups v2 and a user manager) or prlimit",
+		}
+	case limits.MethodPrlimit:
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusWarning,
+			Message: "Resource limits enforced with prlimit: only memory is capped (CPU and process limits need cgroups)",
+			FixHint: "Run with cgroups v2 and a systemd user manager for per-session limits",
+		}
+	}
+
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusOK,
+		Message: fmt.Sprintf("Resource limits configured in %d place(s), enforced with cgroups", configured),
+	}
+}
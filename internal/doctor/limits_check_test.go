@@ -0,0 +1,39 @@
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLimitsCheck_NoLimits(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupRigConfig(t, tmpDir, []string{"gastown"})
+	writeRigSettings(t, tmpDir, "gastown", `{"type":"rig-settings","version":1}`)
+
+	result := NewLimitsCheck().Run(&CheckContext{TownRoot: tmpDir})
+	if result.Status != StatusOK {
+		t.Errorf("Status = %v, want OK: %s", result.Status, result.Message)
+	}
+}
+
+func TestLimitsCheck_InvalidLimits(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupRigConfig(t, tmpDir, []string{"gastown"})
+	if err := os.MkdirAll(filepath.Join(tmpDir, "settings"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	town := `{"type":"town-settings","version":1,"limits":{"memory":"lots"}}`
+	if err := os.WriteFile(filepath.Join(tmpDir, "settings", "config.json"), []byte(town), 0644); err != nil {
+		t.Fatal(err)
+	}
+	writeRigSettings(t, tmpDir, "gastown", `{"type":"rig-settings","version":1,"limits":{"roles":{"polecat":{"pids":-1}}}}`)
+
+	result := NewLimitsCheck().Run(&CheckContext{TownRoot: tmpDir})
+	if result.Status != StatusError {
+		t.Fatalf("Status = %v, want Error: %s", result.Status, result.Message)
+	}
+	if len(result.Details) != 2 {
+		t.Errorf("Details = %v, want bad town memory and bad polecat pids", result.Details)
+	}
+}
@@ -26,6 +26,8 @@ func NewOrphanSessionCheck() *OrphanSessionCheck {
 			BaseCheck: BaseCheck{
 				CheckName:        "orphan-sessions",
 				CheckDescription: "Detect orphaned tmux sessions",
+				CheckCategory:    "cleanup",
+				CheckCost:        CostModerate,
 			},
 		},
 	}
@@ -239,6 +241,8 @@ func NewOrphanProcessCheck() *OrphanProcessCheck {
 			BaseCheck: BaseCheck{
 				CheckName:        "orphan-processes",
 				CheckDescription: "Detect orphaned Claude processes",
+				CheckCategory:    "cleanup",
+				CheckCost:        CostExpensive,
 			},
 		},
 	}
@@ -0,0 +1,187 @@
+package doctor
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+)
+
+// MarshalJSON encodes a status as its lowercase name.
+func (s CheckStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strings.ToLower(s.String()))
+}
+
+// jsonCheck is the JSON form of a CheckResult.
+type jsonCheck struct {
+	Name       string      `json:"name"`
+	Category   string      `json:"category"`
+	Status     CheckStatus `json:"status"`
+	Message    string      `json:"message"`
+	Details    []string    `json:"details,omitempty"`
+	FixHint    string      `json:"fix_hint,omitempty"`
+	DurationMS int64       `json:"duration_ms"`
+}
+
+// jsonReport is the JSON form of a Report.
+type jsonReport struct {
+	Timestamp  time.Time   `json:"timestamp"`
+	DurationMS int64       `json:"duration_ms"`
+	Healthy    bool        `json:"healthy"`
+	Summary    jsonSummary `json:"summary"`
+	Checks     []jsonCheck `json:"checks"`
+}
+
+type jsonSummary struct {
+	Total    int `json:"total"`
+	OK       int `json:"ok"`
+	Warnings int `json:"warnings"`
+	Errors   int `json:"errors"`
+	Skipped  int `json:"skipped"`
+}
+
+// WriteJSON writes the report as indented JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	out := jsonReport{
+		Timestamp:  r.Timestamp,
+		DurationMS: r.Duration.Milliseconds(),
+		Healthy:    r.IsHealthy(),
+		Summary: jsonSummary{
+			Total:    r.Summary.Total,
+			OK:       r.Summary.OK,
+			Warnings: r.Summary.Warnings,
+			Errors:   r.Summary.Errors,
+			Skipped:  r.Summary.Skipped,
+		},
+		Checks: make([]jsonCheck, 0, len(r.Checks)),
+	}
+	for _, c := range r.Checks {
+		out.Checks = append(out.Checks, jsonCheck{
+			Name:       c.Name,
+			Category:   c.Category,
+			Status:     c.Status,
+			Message:    c.Message,
+			Details:    c.Details,
+			FixHint:    c.FixHint,
+			DurationMS: c.Duration.Milliseconds(),
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// SARIF 2.1.0 subset used for doctor output, so results can be uploaded to
+// code scanning dashboards alongside linter output.
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name    string      `json:"name"`
+	Version string      `json:"version,omitempty"`
+	Rules   []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string            `json:"id"`
+	ShortDescription sarifMessage      `json:"shortDescription"`
+	Properties       map[string]string `json:"properties,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID     string            `json:"ruleId"`
+	RuleIndex  int               `json:"ruleIndex"`
+	Kind       string            `json:"kind"`
+	Level      string            `json:"level"`
+	Message    sarifMessage      `json:"message"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+// WriteSARIF writes the report as a SARIF 2.1.0 log. Each check becomes a
+// rule; passing checks are included with kind "pass" so dashboards can show
+// them as resolved.
+func (r *Report) WriteSARIF(w io.Writer, checks []Check, toolVersion string) error {
+	descriptions := make(map[string]string, len(checks))
+	for _, check := range checks {
+		descriptions[check.Name()] = check.Description()
+	}
+
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:    "gt doctor",
+			Version: toolVersion,
+			Rules:   make([]sarifRule, 0, len(r.Checks)),
+		}},
+		Results: make([]sarifResult, 0, len(r.Checks)),
+	}
+
+	for i, c := range r.Checks {
+		desc := descriptions[c.Name]
+		if desc == "" {
+			desc = c.Name
+		}
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+			ID:               c.Name,
+			ShortDescription: sarifMessage{Text: desc},
+			Properties:       map[string]string{"category": c.Category},
+		})
+
+		result := sarifResult{
+			RuleID:    c.Name,
+			RuleIndex: i,
+			Message:   sarifMessage{Text: sarifText(c)},
+		}
+		switch c.Status {
+		case StatusOK:
+			result.Kind, result.Level = "pass", "none"
+		case StatusWarning:
+			result.Kind, result.Level = "fail", "warning"
+		case StatusError:
+			result.Kind, result.Level = "fail", "error"
+		default:
+			result.Kind, result.Level = "notApplicable", "none"
+		}
+		if c.FixHint != "" {
+			result.Properties = map[string]string{"fixHint": c.FixHint}
+		}
+		run.Results = append(run.Results, result)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs:    []sarifRun{run},
+	})
+}
+
+// sarifText joins a result's message and details into one message.
+func sarifText(c *CheckResult) string {
+	if len(c.Details) == 0 {
+		return c.Message
+	}
+	return c.Message + "\n" + strings.Join(c.Details, "\n")
+}
@@ -28,6 +28,9 @@ func NewPatrolMoleculesExistCheck() *PatrolMoleculesExistCheck {
 			BaseCheck: BaseCheck{
 				CheckName:        "patrol-molecules-exist",
 				CheckDescription: "Check if patrol molecules exist for each rig",
+				CheckCategory:    "patrol",
+				CheckDependsOn:   []string{"beads-database"},
+				CheckCost:        CostModerate,
 			},
 		},
 	}
@@ -154,6 +157,7 @@ func NewPatrolHooksWiredCheck() *PatrolHooksWiredCheck {
 		BaseCheck: BaseCheck{
 			CheckName:        "patrol-hooks-wired",
 			CheckDescription: "Check if hooks trigger patrol execution",
+			CheckCategory:    "patrol",
 		},
 	}
 }
@@ -236,6 +240,9 @@ func NewPatrolNotStuckCheck() *PatrolNotStuckCheck {
 		BaseCheck: BaseCheck{
 			CheckName:        "patrol-not-stuck",
 			CheckDescription: "Check for stuck patrol wisps (>1h in_progress)",
+			CheckCategory:    "patrol",
+			CheckDependsOn:   []string{"beads-database"},
+			CheckCost:        CostModerate,
 		},
 		stuckThreshold: 1 * time.Hour,
 	}
@@ -340,6 +347,7 @@ func NewPatrolPluginsAccessibleCheck() *PatrolPluginsAccessibleCheck {
 			BaseCheck: BaseCheck{
 				CheckName:        "patrol-plugins-accessible",
 				CheckDescription: "Check if plugin directories exist and are readable",
+				CheckCategory:    "patrol",
 			},
 		},
 	}
@@ -409,6 +417,7 @@ func NewPatrolRolesHavePromptsCheck() *PatrolRolesHavePromptsCheck {
 			BaseCheck: BaseCheck{
 				CheckName:        "patrol-roles-have-prompts",
 				CheckDescription: "Check if internal/templates/roles/*.md.tmpl exist for each patrol role",
+				CheckCategory:    "patrol",
 			},
 		},
 	}
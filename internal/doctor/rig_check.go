@@ -21,6 +21,7 @@ func NewRigIsGitRepoCheck() *RigIsGitRepoCheck {
 		BaseCheck: BaseCheck{
 			CheckName:        "rig-is-git-repo",
 			CheckDescription: "Verify rig has a valid mayor/rig git clone",
+			CheckCategory:    "rig",
 		},
 	}
 }
@@ -95,6 +96,8 @@ func NewGitExcludeConfiguredCheck() *GitExcludeConfiguredCheck {
 			BaseCheck: BaseCheck{
 				CheckName:        "git-exclude-configured",
 				CheckDescription: "Check .git/info/exclude has Gas Town directories",
+				CheckCategory:    "rig",
+				CheckDependsOn:   []string{"rig-is-git-repo"},
 			},
 		},
 	}
@@ -247,6 +250,7 @@ func NewWitnessExistsCheck() *WitnessExistsCheck {
 			BaseCheck: BaseCheck{
 				CheckName:        "witness-exists",
 				CheckDescription: "Verify witness/ directory structure exists",
+				CheckCategory:    "rig",
 			},
 		},
 	}
@@ -353,6 +357,7 @@ func NewRefineryExistsCheck() *RefineryExistsCheck {
 			BaseCheck: BaseCheck{
 				CheckName:        "refinery-exists",
 				CheckDescription: "Verify refinery/ directory structure exists",
+				CheckCategory:    "rig",
 			},
 		},
 	}
@@ -458,6 +463,7 @@ func NewMayorCloneExistsCheck() *MayorCloneExistsCheck {
 			BaseCheck: BaseCheck{
 				CheckName:        "mayor-clone-exists",
 				CheckDescription: "Verify mayor/rig/ git clone exists",
+				CheckCategory:    "rig",
 			},
 		},
 	}
@@ -540,6 +546,9 @@ func NewPolecatClonesValidCheck() *PolecatClonesValidCheck {
 		BaseCheck: BaseCheck{
 			CheckName:        "polecat-clones-valid",
 			CheckDescription: "Verify polecat directories are valid git clones",
+			CheckCategory:    "rig",
+			CheckDependsOn:   []string{"rig-is-git-repo"},
+			CheckCost:        CostModerate,
 		},
 	}
 }
@@ -664,6 +673,7 @@ func NewBeadsConfigValidCheck() *BeadsConfigValidCheck {
 			BaseCheck: BaseCheck{
 				CheckName:        "beads-config-valid",
 				CheckDescription: "Verify beads configuration if .beads/ exists",
+				CheckCategory:    "rig",
 			},
 		},
 	}
@@ -0,0 +1,237 @@
+package doctor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultParallelism is the number of checks run concurrently by default.
+// Most checks wait on bd, git or tmux subprocesses rather than the CPU.
+const DefaultParallelism = 8
+
+// RunOptions controls how checks are executed.
+type RunOptions struct {
+	Fix         bool          // Attempt fixes for failing fixable checks
+	Parallelism int           // Max concurrent checks (0 = DefaultParallelism, 1 = sequential)
+	Timeout     time.Duration // Per-check timeout (0 = derived from each check's cost)
+}
+
+// RunWithOptions executes the registered checks and returns a report.
+//
+// Checks run concurrently once their dependencies have finished. A check
+// whose dependency reported an error (or was itself skipped) is reported as
+// skipped rather than run. Results are reported in registration order
+// regardless of completion order.
+//
+// A check that exceeds its timeout is reported as an error; its goroutine is
+// abandoned since checks have no way to be cancelled.
+func (d *Doctor) RunWithOptions(ctx *CheckContext, opts RunOptions) *Report {
+	report := NewReport()
+	start := time.Now()
+
+	parallelism := opts.Parallelism
+	if parallelism <= 0 {
+		parallelism = DefaultParallelism
+	}
+
+	n := len(d.checks)
+	results := make([]*CheckResult, n)
+	done := make([]chan struct{}, n)
+	index := make(map[string]int, n)
+	for i, check := range d.checks {
+		done[i] = make(chan struct{})
+		index[check.Name()] = i
+	}
+	cyclic := findDependencyCycles(d.checks, index)
+
+	sem := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+
+	// Start expensive checks first so they don't end up as the long tail.
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return costOf(d.checks[order[a]]) > costOf(d.checks[order[b]])
+	})
+
+	for _, i := range order {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer close(done[i])
+			check := d.checks[i]
+
+			if cyclic[i] {
+				results[i] = &CheckResult{
+					Name:    check.Name(),
+					Status:  StatusError,
+					Message: "dependency cycle: " + strings.Join(dependenciesOf(check), ", "),
+				}
+				return
+			}
+
+			// Wait for dependencies; unregistered dependencies (e.g. filtered
+			// out with --only) are treated as satisfied.
+			for _, dep := range dependenciesOf(check) {
+				j, ok := index[dep]
+				if !ok {
+					continue
+				}
+				<-done[j]
+				if status := results[j].Status; status == StatusError || status == StatusSkipped {
+					results[i] = &CheckResult{
+						Name:    check.Name(),
+						Status:  StatusSkipped,
+						Message: fmt.Sprintf("skipped: %s did not pass", dep),
+					}
+					return
+				}
+			}
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			timeout := opts.Timeout
+			if timeout <= 0 {
+				timeout = timeoutOf(ctx, check)
+			}
+			results[i] = d.runWithTimeout(ctx, check, opts.Fix, timeout)
+		}(i)
+	}
+	wg.Wait()
+
+	for i, result := range results {
+		result.Category = CategoryOf(d.checks[i])
+		report.Add(result)
+	}
+	report.Duration = time.Since(start)
+
+	return report
+}
+
+// runWithTimeout runs a check, giving up after timeout. Only the check itself
+// is bounded: once it reports, a fix runs to completion, since abandoning it
+// midway would leave the workspace half-repaired while still holding fixMu.
+func (d *Doctor) runWithTimeout(ctx *CheckContext, check Check, fix bool, timeout time.Duration) *CheckResult {
+	start := time.Now()
+	resultCh := make(chan *CheckResult, 1)
+	go func() {
+		resultCh <- runOnce(ctx, check)
+	}()
+
+	var result *CheckResult
+	select {
+	case result = <-resultCh:
+	case <-time.After(timeout):
+		return &CheckResult{
+			Name:     check.Name(),
+			Status:   StatusError,
+			Message:  fmt.Sprintf("timed out after %s", timeout),
+			FixHint:  "Re-run with --timeout to allow more time, or --skip " + check.Name(),
+			Duration: time.Since(start),
+		}
+	}
+
+	if fix {
+		result = d.fixCheck(ctx, check, result)
+	}
+	result.Duration = time.Since(start)
+	return result
+}
+
+// findDependencyCycles returns the indexes of checks that are part of, or
+// depend on, a dependency cycle. Such checks would otherwise wait forever.
+func findDependencyCycles(checks []Check, index map[string]int) map[int]bool {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(checks))
+	cyclic := make(map[int]bool)
+
+	var visit func(i int) bool
+	visit = func(i int) bool {
+		switch state[i] {
+		case visiting:
+			return true
+		case visited:
+			return cyclic[i]
+		}
+		state[i] = visiting
+		for _, dep := range dependenciesOf(checks[i]) {
+			if j, ok := index[dep]; ok && visit(j) {
+				cyclic[i] = true
+			}
+		}
+		state[i] = visited
+		return cyclic[i]
+	}
+
+	for i := range checks {
+		visit(i)
+	}
+	return cyclic
+}
+
+// Filter keeps only the checks matching only (if non-empty) and not matching
+// skip. Selectors match a check's name or its category. Unknown selectors
+// are reported as an error so typos don't silently select nothing.
+func (d *Doctor) Filter(only, skip []string) error {
+	// Categories are only looked up for selectors that aren't check names:
+	// a plugin check has to run its describe action to report one.
+	names := make(map[string]bool)
+	for _, check := range d.checks {
+		names[check.Name()] = true
+	}
+	var categories map[string]bool
+	var unknown []string
+	for _, sel := range append(append([]string{}, only...), skip...) {
+		if names[sel] {
+			continue
+		}
+		if categories == nil {
+			categories = make(map[string]bool)
+			for _, check := range d.checks {
+				categories[CategoryOf(check)] = true
+			}
+		}
+		if !categories[sel] {
+			unknown = append(unknown, sel)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown check or category: %s", strings.Join(unknown, ", "))
+	}
+
+	matches := func(check Check, sels []string) bool {
+		for _, sel := range sels {
+			if names[sel] {
+				if sel == check.Name() {
+					return true
+				}
+			} else if sel == CategoryOf(check) {
+				return true
+			}
+		}
+		return false
+	}
+
+	kept := d.checks[:0]
+	for _, check := range d.checks {
+		if len(only) > 0 && !matches(check, only) {
+			continue
+		}
+		if matches(check, skip) {
+			continue
+		}
+		kept = append(kept, check)
+	}
+	d.checks = kept
+	return nil
+}
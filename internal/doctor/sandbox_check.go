@@ -0,0 +1,86 @@
+package doctor
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/steveyegge/gastown/internal/sandbox"
+)
+
+// SandboxCheck validates each rig's polecat sandbox settings and verifies
+// the sandbox runtime is installed, since sandboxed rigs refuse to start
+// polecats on the host when it isn't.
+type SandboxCheck struct {
+	BaseCheck
+}
+
+// NewSandboxCheck creates a new polecat sandbox check.
+func NewSandboxCheck() *SandboxCheck {
+	return &SandboxCheck{
+		BaseCheck: BaseCheck{
+			CheckName:        "polecat-sandbox",
+			CheckDescription: "Validate polecat sandbox settings and runtime",
+			CheckCategory:    "sandbox",
+		},
+	}
+}
+
+// Run validates the sandbox settings of every rig (or just ctx.RigName).
+func (c *SandboxCheck) Run(ctx *CheckContext) *CheckResult {
+	rigs := []string{ctx.RigName}
+	if ctx.RigName == "" {
+		var err error
+		if rigs, err = discoverRigs(ctx.TownRoot); err != nil {
+			return &CheckResult{
+				Name:    c.Name(),
+				Status:  StatusError,
+				Message: fmt.Sprintf("Cannot read rigs.json: %v", err),
+			}
+		}
+	}
+	sort.Strings(rigs)
+
+	var problems []string
+	configured := 0
+	for _, rigName := range rigs {
+		cfg := sandbox.RigConfig(filepath.Join(ctx.TownRoot, rigName))
+		if !cfg.Enabled() {
+			continue
+		}
+		configured++
+
+		invalid := cfg.Validate()
+		for _, problem := range invalid {
+			problems = append(problems, fmt.Sprintf("%s: %s", rigName, problem))
+		}
+		if len(invalid) == 0 {
+			if err := sandbox.Available(cfg); err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %v", rigName, err))
+			}
+		}
+	}
+
+	if configured == 0 {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusOK,
+			Message: "No rigs sandbox polecats",
+		}
+	}
+	if len(problems) > 0 {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusError,
+			Message: fmt.Sprintf("%d sandbox problem(s); polecats in these rigs won't start", len(problems)),
+			Details: problems,
+			FixHint: "Fix the \"sandbox\" section of <rig>/settings/config.json or install the runtime",
+		}
+	}
+
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusOK,
+		Message: fmt.Sprintf("%d rig(s) sandbox polecats", configured),
+	}
+}
@@ -0,0 +1,45 @@
+package doctor
+
+import (
+	"os/exec"
+	"testing"
+)
+
+func TestSandboxCheck_NoSandboxedRigs(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupRigConfig(t, tmpDir, []string{"gastown"})
+	writeRigSettings(t, tmpDir, "gastown", `{"type":"rig-settings","version":1}`)
+
+	result := NewSandboxCheck().Run(&CheckContext{TownRoot: tmpDir})
+	if result.Status != StatusOK {
+		t.Errorf("Status = %v, want OK: %s", result.Status, result.Message)
+	}
+}
+
+func TestSandboxCheck_InvalidSettings(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupRigConfig(t, tmpDir, []string{"gastown"})
+	writeRigSettings(t, tmpDir, "gastown", `{"type":"rig-settings","version":1,"sandbox":{"runtime":"podman","network":"bridge"}}`)
+
+	result := NewSandboxCheck().Run(&CheckContext{TownRoot: tmpDir})
+	if result.Status != StatusError {
+		t.Fatalf("Status = %v, want Error: %s", result.Status, result.Message)
+	}
+	if len(result.Details) != 2 {
+		t.Errorf("Details = %v, want missing image and bad network", result.Details)
+	}
+}
+
+func TestSandboxCheck_MissingRuntime(t *testing.T) {
+	if _, err := exec.LookPath("podman"); err == nil {
+		t.Skip("podman installed")
+	}
+	tmpDir := t.TempDir()
+	setupRigConfig(t, tmpDir, []string{"gastown"})
+	writeRigSettings(t, tmpDir, "gastown", `{"type":"rig-settings","version":1,"sandbox":{"runtime":"podman","image":"gt-agent"}}`)
+
+	result := NewSandboxCheck().Run(&CheckContext{TownRoot: tmpDir, RigName: "gastown"})
+	if result.Status != StatusError || len(result.Details) != 1 {
+		t.Errorf("result = %v %v, want Error for missing podman", result.Status, result.Details)
+	}
+}
@@ -0,0 +1,145 @@
+package doctor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/git"
+)
+
+// SparseCheck validates each rig's sparse checkout / partial clone settings:
+// cone paths and filters must be well-formed, cone directories should exist
+// in the rig's repo, and polecat worktrees should match the configured profile.
+type SparseCheck struct {
+	BaseCheck
+}
+
+// NewSparseCheck creates a new sparse checkout check.
+func NewSparseCheck() *SparseCheck {
+	return &SparseCheck{
+		BaseCheck: BaseCheck{
+			CheckName:        "sparse-checkout",
+			CheckDescription: "Validate sparse checkout and partial clone settings",
+			CheckCategory:    "git",
+			CheckDependsOn:   []string{"rigs-registry-valid"},
+			CheckCost:        CostModerate,
+		},
+	}
+}
+
+// Run validates the sparse settings of every rig (or just ctx.RigName).
+func (c *SparseCheck) Run(ctx *CheckContext) *CheckResult {
+	rigs := []string{ctx.RigName}
+	if ctx.RigName == "" {
+		var err error
+		if rigs, err = discoverRigs(ctx.TownRoot); err != nil {
+			return &CheckResult{
+				Name:    c.Name(),
+				Status:  StatusError,
+				Message: fmt.Sprintf("Cannot read rigs.json: %v", err),
+			}
+		}
+	}
+	sort.Strings(rigs)
+
+	var invalid, warnings []string
+	configured := 0
+	for _, rigName := range rigs {
+		rigPath := filepath.Join(ctx.TownRoot, rigName)
+		settings, err := config.LoadRigSettings(filepath.Join(rigPath, "settings", "config.json"))
+		if err != nil || settings.Sparse == nil {
+			continue
+		}
+		configured++
+		sparse := settings.Sparse
+
+		for _, problem := range sparse.Validate() {
+			invalid = append(invalid, fmt.Sprintf("%s: %s", rigName, problem))
+		}
+
+		// Cone directories should exist on the rig's checkout of the default branch
+		mayorRig := filepath.Join(rigPath, "mayor", "rig")
+		for _, dir := range sparse.ConePaths(nil) {
+			if !treeHasPath(mayorRig, dir) {
+				warnings = append(warnings, fmt.Sprintf("%s: cone directory %q not found in mayor/rig", rigName, dir))
+			}
+		}
+
+		// Polecats created before the cone was configured still check out everything
+		if len(sparse.Cone) > 0 {
+			for _, name := range polecatNames(rigPath) {
+				cone, err := git.NewGit(filepath.Join(rigPath, "polecats", name)).SparseCheckoutList()
+				if err == nil && cone == nil {
+					warnings = append(warnings, fmt.Sprintf("%s/%s: full checkout (created before sparse settings)", rigName, name))
+				}
+			}
+		}
+	}
+
+	switch {
+	case configured == 0:
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusOK,
+			Message: "No rigs use sparse checkout",
+		}
+	case len(invalid) > 0:
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusError,
+			Message: fmt.Sprintf("%d invalid sparse setting(s)", len(invalid)),
+			Details: append(invalid, warnings...),
+			FixHint: "Fix the \"sparse\" section of <rig>/settings/config.json",
+		}
+	case len(warnings) > 0:
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusWarning,
+			Message: fmt.Sprintf("%d sparse checkout issue(s)", len(warnings)),
+			Details: warnings,
+			FixHint: "Check cone paths against the repo; recycle full-checkout polecats with 'gt polecat nuke'",
+		}
+	}
+
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusOK,
+		Message: fmt.Sprintf("%d rig(s) use sparse checkout", configured),
+	}
+}
+
+// treeHasPath reports whether HEAD of the repo at dir contains path.
+// Returns true when the repo can't be inspected, so only known-missing
+// paths are reported.
+func treeHasPath(dir, path string) bool {
+	if _, err := os.Stat(dir); err != nil {
+		return true
+	}
+	cmd := exec.Command("git", "rev-parse", "--verify", "--quiet", "HEAD:"+path)
+	cmd.Dir = dir
+	var exitErr *exec.ExitError
+	if err := cmd.Run(); errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		return false
+	}
+	return true
+}
+
+// polecatNames lists the polecat directories in a rig.
+func polecatNames(rigPath string) []string {
+	entries, err := os.ReadDir(filepath.Join(rigPath, "polecats"))
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() && e.Name()[0] != '.' {
+			names = append(names, e.Name())
+		}
+	}
+	return names
+}
@@ -0,0 +1,54 @@
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeRigSettings(t *testing.T, townRoot, rigName, content string) {
+	t.Helper()
+	dir := filepath.Join(townRoot, rigName, "settings")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("mkdir settings: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "config.json"), []byte(content), 0644); err != nil {
+		t.Fatalf("write settings: %v", err)
+	}
+}
+
+func TestSparseCheck_NoSparseRigs(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupRigConfig(t, tmpDir, []string{"gastown"})
+	writeRigSettings(t, tmpDir, "gastown", `{"type":"rig-settings","version":1}`)
+
+	result := NewSparseCheck().Run(&CheckContext{TownRoot: tmpDir})
+	if result.Status != StatusOK {
+		t.Errorf("Status = %v, want OK: %s", result.Status, result.Message)
+	}
+}
+
+func TestSparseCheck_InvalidSettings(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupRigConfig(t, tmpDir, []string{"monorepo", "gastown"})
+	writeRigSettings(t, tmpDir, "monorepo", `{"type":"rig-settings","version":1,"sparse":{"cone":["/abs","svc/*"],"filter":"bogus"}}`)
+
+	result := NewSparseCheck().Run(&CheckContext{TownRoot: tmpDir})
+	if result.Status != StatusError {
+		t.Fatalf("Status = %v, want Error: %s", result.Status, result.Message)
+	}
+	if len(result.Details) != 3 {
+		t.Errorf("Details = %v, want 3 problems", result.Details)
+	}
+}
+
+func TestSparseCheck_ValidSettings(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupRigConfig(t, tmpDir, []string{"monorepo"})
+	writeRigSettings(t, tmpDir, "monorepo", `{"type":"rig-settings","version":1,"sparse":{"cone":["svc/api"],"filter":"blob:none"}}`)
+
+	result := NewSparseCheck().Run(&CheckContext{TownRoot: tmpDir, RigName: "monorepo"})
+	if result.Status != StatusOK {
+		t.Errorf("Status = %v, want OK: %s %v", result.Status, result.Message, result.Details)
+	}
+}
@@ -20,6 +20,8 @@ func NewThemeCheck() *ThemeCheck {
 			BaseCheck: BaseCheck{
 				CheckName:        "themes",
 				CheckDescription: "Check tmux session theme configuration",
+				CheckCategory:    "sessions",
+				CheckCost:        CostModerate,
 			},
 		},
 	}
@@ -23,6 +23,8 @@ func NewLinkedPaneCheck() *LinkedPaneCheck {
 			BaseCheck: BaseCheck{
 				CheckName:        "linked-panes",
 				CheckDescription: "Detect tmux sessions sharing panes (causes crosstalk)",
+				CheckCategory:    "sessions",
+				CheckCost:        CostModerate,
 			},
 		},
 	}
@@ -20,6 +20,8 @@ const (
 	StatusWarning
 	// StatusError indicates a critical problem.
 	StatusError
+	// StatusSkipped indicates the check did not run because a dependency failed.
+	StatusSkipped
 )
 
 // String returns a human-readable status.
@@ -31,11 +33,40 @@ func (s CheckStatus) String() string {
 		return "Warning"
 	case StatusError:
 		return "Error"
+	case StatusSkipped:
+		return "Skipped"
 	default:
 		return "Unknown"
 	}
 }
 
+// CheckCost is a rough estimate of how long a check takes to run.
+type CheckCost int
+
+const (
+	// CostCheap checks read a few files or run a single fast command.
+	CostCheap CheckCost = iota
+	// CostModerate checks run a handful of bd, git or tmux commands.
+	CostModerate
+	// CostExpensive checks walk every rig or clone, or scan processes.
+	CostExpensive
+)
+
+// Timeout returns the default per-check timeout for a cost.
+func (c CheckCost) Timeout() time.Duration {
+	switch c {
+	case CostModerate:
+		return time.Minute
+	case CostExpensive:
+		return 3 * time.Minute
+	default:
+		return 15 * time.Second
+	}
+}
+
+// DefaultCategory is the category of checks that don't declare one.
+const DefaultCategory = "general"
+
 // CheckContext provides context for running checks.
 type CheckContext struct {
 	TownRoot string // Root directory of the Gas Town workspace
@@ -54,11 +85,13 @@ func (ctx *CheckContext) RigPath() string {
 
 // CheckResult represents the outcome of a health check.
 type CheckResult struct {
-	Name    string      // Check name
-	Status  CheckStatus // Result status
-	Message string      // Primary result message
-	Details []string    // Additional information
-	FixHint string      // Suggestion if not auto-fixable
+	Name     string        // Check name
+	Status   CheckStatus   // Result status
+	Message  string        // Primary result message
+	Details  []string      // Additional information
+	FixHint  string        // Suggestion if not auto-fixable
+	Category string        // Check category (filled in by the runner)
+	Duration time.Duration // Time spent running (and fixing) the check
 }
 
 // Check defines the interface for a health check.
@@ -80,17 +113,76 @@ type Check interface {
 	CanFix() bool
 }
 
+// CategorizedCheck is implemented by checks that belong to a category.
+// Categories can be used in place of check names with --only and --skip.
+type CategorizedCheck interface {
+	Category() string
+}
+
+// DependentCheck is implemented by checks that only make sense once other
+// checks have passed. A check is skipped if any dependency reports an error.
+type DependentCheck interface {
+	DependsOn() []string
+}
+
+// CostedCheck is implemented by checks that declare their runtime cost.
+type CostedCheck interface {
+	Cost() CheckCost
+}
+
+// TimedCheck is implemented by checks whose run time depends on the
+// workspace, such as one command per rig. The runner allows the longer of
+// RunTimeout and the cost's default timeout.
+type TimedCheck interface {
+	RunTimeout(ctx *CheckContext) time.Duration
+}
+
+// CategoryOf returns the category of a check.
+func CategoryOf(check Check) string {
+	if c, ok := check.(CategorizedCheck); ok && c.Category() != "" {
+		return c.Category()
+	}
+	return DefaultCategory
+}
+
+func dependenciesOf(check Check) []string {
+	if c, ok := check.(DependentCheck); ok {
+		return c.DependsOn()
+	}
+	return nil
+}
+
+func costOf(check Check) CheckCost {
+	if c, ok := check.(CostedCheck); ok {
+		return c.Cost()
+	}
+	return CostCheap
+}
+
+// timeoutOf returns how long the runner waits for a check by default.
+func timeoutOf(ctx *CheckContext, check Check) time.Duration {
+	timeout := costOf(check).Timeout()
+	if c, ok := check.(TimedCheck); ok {
+		if t := c.RunTimeout(ctx); t > timeout {
+			timeout = t
+		}
+	}
+	return timeout
+}
+
 // ReportSummary summarizes the results of all checks.
 type ReportSummary struct {
 	Total    int
 	OK       int
 	Warnings int
 	Errors   int
+	Skipped  int
 }
 
 // Report contains all check results and a summary.
 type Report struct {
 	Timestamp time.Time
+	Duration  time.Duration
 	Checks    []*CheckResult
 	Summary   ReportSummary
 }
@@ -115,6 +207,8 @@ func (r *Report) Add(result *CheckResult) {
 		r.Summary.Warnings++
 	case StatusError:
 		r.Summary.Errors++
+	case StatusSkipped:
+		r.Summary.Skipped++
 	}
 }
 
@@ -155,9 +249,15 @@ func (r *Report) printCheck(w io.Writer, check *CheckResult, verbose bool) {
 		prefix = style.WarningPrefix
 	case StatusError:
 		prefix = style.ErrorPrefix
+	case StatusSkipped:
+		prefix = style.Dim.Render("○")
 	}
 
-	_, _ = fmt.Fprintf(w, "%s %s: %s\n", prefix, check.Name, check.Message)
+	_, _ = fmt.Fprintf(w, "%s %s: %s", prefix, check.Name, check.Message)
+	if verbose && check.Duration > 0 {
+		_, _ = fmt.Fprintf(w, " %s", style.Dim.Render(fmt.Sprintf("(%s)", check.Duration.Round(time.Millisecond))))
+	}
+	_, _ = fmt.Fprintln(w)
 
 	// Print details in verbose mode or for non-OK results
 	if len(check.Details) > 0 && (verbose || check.Status != StatusOK) {
@@ -167,7 +267,7 @@ func (r *Report) printCheck(w io.Writer, check *CheckResult, verbose bool) {
 	}
 
 	// Print fix hint for errors/warnings
-	if check.FixHint != "" && check.Status != StatusOK {
+	if check.FixHint != "" && (check.Status == StatusWarning || check.Status == StatusError) {
 		_, _ = fmt.Fprintf(w, "    %s %s\n", style.ArrowPrefix, check.FixHint)
 	}
 }
@@ -187,6 +287,12 @@ func (r *Report) printSummary(w io.Writer) {
 	if r.Summary.Errors > 0 {
 		parts = append(parts, style.Error.Render(fmt.Sprintf("%d errors", r.Summary.Errors)))
 	}
+	if r.Summary.Skipped > 0 {
+		parts = append(parts, style.Dim.Render(fmt.Sprintf("%d skipped", r.Summary.Skipped)))
+	}
+	if r.Duration >= 100*time.Millisecond {
+		parts = append(parts, style.Dim.Render(fmt.Sprintf("in %s", r.Duration.Round(100*time.Millisecond))))
+	}
 
 	_, _ = fmt.Fprintln(w, strings.Join(parts, ", "))
 }
@@ -28,6 +28,9 @@ func NewWispGCCheck() *WispGCCheck {
 			BaseCheck: BaseCheck{
 				CheckName:        "wisp-gc",
 				CheckDescription: "Detect and clean orphaned wisps (>1h old)",
+				CheckCategory:    "cleanup",
+				CheckDependsOn:   []string{"beads-database"},
+				CheckCost:        CostModerate,
 			},
 		},
 		threshold:     1 * time.Hour,
@@ -18,6 +18,7 @@ func NewTownConfigExistsCheck() *TownConfigExistsCheck {
 		BaseCheck: BaseCheck{
 			CheckName:        "town-config-exists",
 			CheckDescription: "Check that mayor/town.json exists",
+			CheckCategory:    "workspace",
 		},
 	}
 }
@@ -53,6 +54,8 @@ func NewTownConfigValidCheck() *TownConfigValidCheck {
 		BaseCheck: BaseCheck{
 			CheckName:        "town-config-valid",
 			CheckDescription: "Check that mayor/town.json is valid with required fields",
+			CheckCategory:    "workspace",
+			CheckDependsOn:   []string{"town-config-exists"},
 		},
 	}
 }
@@ -130,6 +133,7 @@ func NewRigsRegistryExistsCheck() *RigsRegistryExistsCheck {
 			BaseCheck: BaseCheck{
 				CheckName:        "rigs-registry-exists",
 				CheckDescription: "Check that mayor/rigs.json exists",
+				CheckCategory:    "workspace",
 			},
 		},
 	}
@@ -188,6 +192,8 @@ func NewRigsRegistryValidCheck() *RigsRegistryValidCheck {
 			BaseCheck: BaseCheck{
 				CheckName:        "rigs-registry-valid",
 				CheckDescription: "Check that registered rigs exist on disk",
+				CheckCategory:    "workspace",
+				CheckDependsOn:   []string{"rigs-registry-exists"},
 			},
 		},
 	}
@@ -320,6 +326,7 @@ func NewMayorExistsCheck() *MayorExistsCheck {
 		BaseCheck: BaseCheck{
 			CheckName:        "mayor-exists",
 			CheckDescription: "Check that mayor/ directory exists with required files",
+			CheckCategory:    "workspace",
 		},
 	}
 }
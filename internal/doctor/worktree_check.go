@@ -0,0 +1,137 @@
+package doctor
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/crew"
+)
+
+// CrossRigWorktreeCheck finds cross-rig worktrees (gt worktree <rig>) that
+// have drifted from their owner's registration: worktrees nobody registered,
+// registrations whose worktree is gone, and worktrees left unsynced and
+// unpushed long enough that their work is likely forgotten.
+type CrossRigWorktreeCheck struct {
+	BaseCheck
+}
+
+// NewCrossRigWorktreeCheck creates a new cross-rig worktree check.
+func NewCrossRigWorktreeCheck() *CrossRigWorktreeCheck {
+	return &CrossRigWorktreeCheck{
+		BaseCheck: BaseCheck{
+			CheckName:        "cross-rig-worktrees",
+			CheckDescription: "Detect orphaned and stale cross-rig worktrees",
+			CheckCategory:    "git",
+			CheckDependsOn:   []string{"rigs-registry-valid"},
+			CheckCost:        CostExpensive,
+		},
+	}
+}
+
+// Run compares each rig's crew/ directory with the worktrees registered in
+// crew state across the town.
+func (c *CrossRigWorktreeCheck) Run(ctx *CheckContext) *CheckResult {
+	rigs, err := discoverRigs(ctx.TownRoot)
+	if err != nil {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusError,
+			Message: fmt.Sprintf("Cannot read rigs.json: %v", err),
+		}
+	}
+	sort.Strings(rigs)
+
+	// Registered worktrees, by path, with their owner
+	registered := make(map[string]string)
+	var details []string
+	total := 0
+	for _, rigName := range rigs {
+		for _, worker := range loadCrewStates(ctx.TownRoot, rigName) {
+			owner := fmt.Sprintf("%s/crew/%s", rigName, worker.Name)
+			for _, wt := range worker.Worktrees {
+				total++
+				registered[filepath.Clean(wt.Path)] = owner
+				if _, err := os.Stat(wt.Path); os.IsNotExist(err) {
+					details = append(details, fmt.Sprintf("%s: registered worktree in %s is missing (%s)", owner, wt.Rig, wt.Path))
+					continue
+				}
+				if idle := time.Since(wt.LastActive()); idle > crew.StaleWorktreeAge {
+					details = append(details, fmt.Sprintf("%s: worktree in %s idle %dd (%s)",
+						owner, wt.Rig, int(idle.Hours()/24), wt.Status().Summary()))
+				}
+			}
+		}
+	}
+
+	// Cross-rig worktree directories that no crew worker registered
+	for _, rigName := range rigs {
+		crewPath := filepath.Join(ctx.TownRoot, rigName, "crew")
+		entries, err := os.ReadDir(crewPath)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			path := filepath.Join(crewPath, e.Name())
+			if !e.IsDir() || registered[path] != "" {
+				continue
+			}
+			if owner := crossRigWorktreeOwner(ctx.TownRoot, rigName, e.Name(), rigs); owner != "" {
+				total++
+				details = append(details, fmt.Sprintf("%s/crew/%s: unregistered worktree of %s", rigName, e.Name(), owner))
+			}
+		}
+	}
+
+	if len(details) == 0 {
+		msg := "No cross-rig worktrees"
+		if total > 0 {
+			msg = fmt.Sprintf("%d cross-rig worktree(s) registered and active", total)
+		}
+		return &CheckResult{Name: c.Name(), Status: StatusOK, Message: msg}
+	}
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusWarning,
+		Message: fmt.Sprintf("%d cross-rig worktree problem(s)", len(details)),
+		Details: details,
+		FixHint: "From the owning crew workspace: 'gt worktree <rig>' adopts, 'gt worktree sync' refreshes, 'gt worktree remove <rig>' cleans up",
+	}
+}
+
+// loadCrewStates reads the state of every crew worker in a rig.
+func loadCrewStates(townRoot, rigName string) []crew.CrewWorker {
+	crewPath := filepath.Join(townRoot, rigName, "crew")
+	entries, err := os.ReadDir(crewPath)
+	if err != nil {
+		return nil
+	}
+	var workers []crew.CrewWorker
+	for _, e := range entries {
+		data, err := os.ReadFile(filepath.Join(crewPath, e.Name(), "state.json"))
+		if err != nil {
+			continue
+		}
+		var worker crew.CrewWorker
+		if json.Unmarshal(data, &worker) != nil {
+			continue
+		}
+		if worker.Name == "" {
+			worker.Name = e.Name()
+		}
+		workers = append(workers, worker)
+	}
+	return workers
+}
+
+// crossRigWorktreeOwner returns the crew address that owns dir in rigName's
+// crew/ if it is a cross-rig worktree, or "".
+func crossRigWorktreeOwner(townRoot, rigName, dir string, rigs []string) string {
+	if sourceRig, name, ok := crew.WorktreeOwner(townRoot, rigName, dir, rigs); ok {
+		return fmt.Sprintf("%s/crew/%s", sourceRig, name)
+	}
+	return ""
+}
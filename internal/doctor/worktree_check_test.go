@@ -0,0 +1,94 @@
+package doctor
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/crew"
+)
+
+func writeCrewState(t *testing.T, townRoot, rigName string, worker crew.CrewWorker) {
+	t.Helper()
+	dir := filepath.Join(townRoot, rigName, "crew", worker.Name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(worker)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "state.json"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCrossRigWorktreeCheck_None(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupRigConfig(t, tmpDir, []string{"gastown", "beads"})
+	writeCrewState(t, tmpDir, "gastown", crew.CrewWorker{Name: "joe"})
+
+	result := NewCrossRigWorktreeCheck().Run(&CheckContext{TownRoot: tmpDir})
+	if result.Status != StatusOK {
+		t.Errorf("Status = %v, want OK: %v", result.Status, result.Details)
+	}
+}
+
+func TestCrossRigWorktreeCheck_Problems(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupRigConfig(t, tmpDir, []string{"gastown", "beads", "mayor-tools"})
+
+	active := filepath.Join(tmpDir, "beads", "crew", "gastown-joe")
+	stale := filepath.Join(tmpDir, "mayor-tools", "crew", "gastown-joe")
+	for _, dir := range []string{active, stale} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	old := time.Now().Add(-2 * crew.StaleWorktreeAge)
+	writeCrewState(t, tmpDir, "gastown", crew.CrewWorker{Name: "joe", Worktrees: []crew.Worktree{
+		{Rig: "beads", Path: active, Branch: "crew/gastown-joe", Target: "main", CreatedAt: old, SyncedAt: time.Now()},
+		{Rig: "mayor-tools", Path: stale, Branch: "crew/gastown-joe", Target: "main", CreatedAt: old},
+	}})
+
+	// A registration whose worktree was deleted by hand
+	writeCrewState(t, tmpDir, "beads", crew.CrewWorker{Name: "max", Worktrees: []crew.Worktree{
+		{Rig: "gastown", Path: filepath.Join(tmpDir, "gastown", "crew", "beads-max"), Target: "main", CreatedAt: time.Now()},
+	}})
+
+	// An unregistered worktree, and a dashed crew member that isn't one
+	if err := os.MkdirAll(filepath.Join(tmpDir, "gastown", "crew", "beads-sam"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(tmpDir, "beads", "crew", "sam"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeCrewState(t, tmpDir, "beads", crew.CrewWorker{Name: "gastown-fan"})
+
+	result := NewCrossRigWorktreeCheck().Run(&CheckContext{TownRoot: tmpDir})
+	if result.Status != StatusWarning {
+		t.Fatalf("Status = %v, want Warning: %s", result.Status, result.Message)
+	}
+	want := []string{
+		"gastown/crew/joe: worktree in mayor-tools idle",
+		"beads/crew/max: registered worktree in gastown is missing",
+		"gastown/crew/beads-sam: unregistered worktree of beads/crew/sam",
+	}
+	if len(result.Details) != len(want) {
+		t.Fatalf("Details = %v, want %d problems", result.Details, len(want))
+	}
+	for _, w := range want {
+		found := false
+		for _, d := range result.Details {
+			if strings.HasPrefix(d, w) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Details = %v, missing %q", result.Details, w)
+		}
+	}
+}
@@ -137,6 +137,20 @@ func (m *Manager) createRigWorktree(dogPath, dogName, rigName string) (string, e
 	// Unique branch per dog-rig combination
 	branchName := fmt.Sprintf("dog/%s-%s-%d", dogName, rigName, time.Now().UnixMilli())
 
+	// Monorepo rigs: partial clone filter on the repo base, sparse worktree
+	settings, _ := config.LoadRigSettings(filepath.Join(rigPath, "settings", "config.json"))
+	if settings != nil && settings.Sparse != nil {
+		if err := repoGit.SetPartialCloneFilter("origin", settings.Sparse.Filter); err != nil {
+			fmt.Printf("Warning: could not apply partial clone filter %s: %v\n", settings.Sparse.Filter, err)
+		}
+		if cone := settings.Sparse.ConePaths(nil); len(cone) > 0 {
+			if err := repoGit.WorktreeAddSparse(worktreePath, branchName, "", cone); err != nil {
+				return "", fmt.Errorf("creating sparse worktree: %w", err)
+			}
+			return worktreePath, nil
+		}
+	}
+
 	// Create worktree with new branch
 	if err := repoGit.WorktreeAdd(worktreePath, branchName); err != nil {
 		return "", fmt.Errorf("creating worktree: %w", err)
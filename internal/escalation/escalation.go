@@ -0,0 +1,267 @@
+// Package escalation implements structured escalations: categories, tiered
+// routing (Witness → Deacon → Mayor → Overseer), decision options with a
+// recommended default, and per-tier timeouts that forward unanswered
+// escalations up the chain or apply a fallback at the top.
+//
+// Escalations are stored as town beads tagged "escalation" whose structured
+// fields live in the description (see beads.EscalationFields).
+package escalation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+// Label is the tag carried by every escalation bead.
+const Label = "escalation"
+
+// Escalation categories (see docs/escalation.md).
+const (
+	CategoryDecision    = "decision"
+	CategoryHelp        = "help"
+	CategoryBlocked     = "blocked"
+	CategoryFailed      = "failed"
+	CategoryEmergency   = "emergency"
+	CategoryGateTimeout = "gate_timeout"
+	CategoryLifecycle   = "lifecycle"
+)
+
+// Categories lists the valid categories in display order.
+var Categories = []string{
+	CategoryDecision,
+	CategoryHelp,
+	CategoryBlocked,
+	CategoryFailed,
+	CategoryEmergency,
+	CategoryGateTimeout,
+	CategoryLifecycle,
+}
+
+// Routing tiers, lowest to highest.
+const (
+	TierWitness  = "witness"
+	TierDeacon   = "deacon"
+	TierMayor    = "mayor"
+	TierOverseer = "overseer"
+)
+
+// chains maps each category to its default routing chain.
+// Uncategorized (legacy) escalations go straight to the overseer.
+var chains = map[string][]string{
+	CategoryDecision:    {TierDeacon, TierMayor, TierOverseer},
+	CategoryHelp:        {TierDeacon, TierMayor, TierOverseer},
+	CategoryBlocked:     {TierMayor, TierOverseer},
+	CategoryFailed:      {TierDeacon, TierMayor, TierOverseer},
+	CategoryEmergency:   {TierOverseer},
+	CategoryGateTimeout: {TierDeacon, TierMayor, TierOverseer},
+	CategoryLifecycle:   {TierWitness, TierMayor, TierOverseer},
+}
+
+// ValidCategory reports whether category is known. Empty is valid (legacy).
+func ValidCategory(category string) bool {
+	if category == "" {
+		return true
+	}
+	_, ok := chains[category]
+	return ok
+}
+
+// Chain returns the routing chain for a category.
+func Chain(category string) []string {
+	if chain, ok := chains[category]; ok {
+		return chain
+	}
+	return []string{TierOverseer}
+}
+
+// StartTier returns the tier an escalation is first routed to. When to is
+// set it must be a tier in the category's chain (escalating past lower tiers);
+// otherwise the chain's first tier is used.
+func StartTier(category, to string) (string, error) {
+	chain := Chain(category)
+	if to == "" {
+		return chain[0], nil
+	}
+	to = strings.TrimSuffix(strings.ToLower(to), "/")
+	for _, tier := range chain {
+		if tier == to {
+			return tier, nil
+		}
+	}
+	return "", fmt.Errorf("tier %q is not in the %s chain (%s)", to, categoryName(category), strings.Join(chain, " → "))
+}
+
+// NextTier returns the tier after current in the category's chain.
+// Returns false when current is the last tier.
+func NextTier(category, current string) (string, bool) {
+	chain := Chain(category)
+	for i, tier := range chain {
+		if tier == current && i+1 < len(chain) {
+			return chain[i+1], true
+		}
+	}
+	return "", false
+}
+
+// Address returns the mail address for a tier. The witness tier is per-rig
+// and falls back to the mayor when the rig is unknown.
+func Address(tier, rig string) string {
+	switch tier {
+	case TierWitness:
+		if rig == "" {
+			return "mayor/"
+		}
+		return rig + "/witness"
+	case TierDeacon:
+		return "deacon/"
+	case TierMayor:
+		return "mayor/"
+	default:
+		return "overseer"
+	}
+}
+
+func categoryName(category string) string {
+	if category == "" {
+		return "default"
+	}
+	return category
+}
+
+// OptionLabel returns the letter label for the i'th option (A, B, C, ...).
+func OptionLabel(i int) string {
+	if i < 26 {
+		return string(rune('A' + i))
+	}
+	return strconv.Itoa(i + 1)
+}
+
+// ParseOptions splits a comma-separated option list.
+func ParseOptions(s string) ([]string, error) {
+	var options []string
+	for _, opt := range strings.Split(s, ",") {
+		opt = strings.TrimSpace(opt)
+		if opt == "" {
+			continue
+		}
+		if strings.Contains(opt, "|") {
+			return nil, fmt.Errorf("option %q must not contain '|'", opt)
+		}
+		options = append(options, opt)
+	}
+	return options, nil
+}
+
+// ResolveOption maps a choice to one of options. A choice may be the option's
+// letter (A), its 1-based number (1), or its text (case-insensitive).
+// Escalations without options accept any non-empty answer.
+func ResolveOption(options []string, choice string) (string, error) {
+	choice = strings.TrimSpace(choice)
+	if choice == "" {
+		return "", fmt.Errorf("answer is empty")
+	}
+	if len(options) == 0 {
+		return choice, nil
+	}
+
+	for i, opt := range options {
+		if strings.EqualFold(choice, opt) || strings.EqualFold(choice, OptionLabel(i)) {
+			return opt, nil
+		}
+	}
+	if n, err := strconv.Atoi(choice); err == nil && n >= 1 && n <= len(options) {
+		return options[n-1], nil
+	}
+
+	var labels []string
+	for i, opt := range options {
+		labels = append(labels, fmt.Sprintf("%s) %s", OptionLabel(i), opt))
+	}
+	return "", fmt.Errorf("%q is not an option: %s", choice, strings.Join(labels, ", "))
+}
+
+// Action is what a timeout check decides for an open escalation.
+type Action int
+
+const (
+	// ActionWait leaves the escalation with its current tier.
+	ActionWait Action = iota
+	// ActionForward routes the escalation to the next tier.
+	ActionForward
+	// ActionFallback answers the escalation with its fallback option.
+	ActionFallback
+)
+
+// CheckTimeout decides whether an open escalation's current tier has timed
+// out. Escalations without a timeout, or whose last tier timed out with no
+// fallback, wait for a human. Returns the next tier for ActionForward.
+func CheckTimeout(fields *beads.EscalationFields, now time.Time) (Action, string) {
+	if fields == nil || fields.Timeout == "" || fields.Answer != "" {
+		return ActionWait, ""
+	}
+	timeout, err := time.ParseDuration(fields.Timeout)
+	if err != nil || timeout <= 0 {
+		return ActionWait, ""
+	}
+	since, err := time.Parse(time.RFC3339, fields.TierSince)
+	if err != nil || now.Sub(since) < timeout {
+		return ActionWait, ""
+	}
+
+	if next, ok := NextTier(fields.Category, fields.Tier); ok {
+		return ActionForward, next
+	}
+	if fields.Fallback != "" {
+		return ActionFallback, ""
+	}
+	return ActionWait, ""
+}
+
+// Format renders an escalation for mail and CLI display: the question,
+// lettered options, and how to answer.
+func Format(id, question string, fields *beads.EscalationFields, details string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Escalation: %s\n", id)
+	if fields.Category != "" {
+		fmt.Fprintf(&sb, "Category: %s\n", fields.Category)
+	}
+	fmt.Fprintf(&sb, "Severity: %s\n", fields.Severity)
+	fmt.Fprintf(&sb, "From: %s\n", fields.From)
+	if fields.Issue != "" {
+		fmt.Fprintf(&sb, "Issue: %s\n", fields.Issue)
+	}
+
+	fmt.Fprintf(&sb, "\n%s\n", question)
+	if details != "" {
+		fmt.Fprintf(&sb, "\n%s\n", details)
+	}
+
+	if len(fields.Options) > 0 {
+		sb.WriteString("\nOptions:\n")
+		for i, opt := range fields.Options {
+			marker := ""
+			if opt == fields.Recommended {
+				marker = " (recommended)"
+			}
+			fmt.Fprintf(&sb, "  %s) %s%s\n", OptionLabel(i), opt, marker)
+		}
+	}
+	if fields.Timeout != "" {
+		fmt.Fprintf(&sb, "\nTimeout: %s per tier", fields.Timeout)
+		if fields.Fallback != "" {
+			fmt.Fprintf(&sb, ", then fallback: %s", fields.Fallback)
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(fields.Options) > 0 {
+		fmt.Fprintf(&sb, "\nAnswer with: gt escalate answer %s <letter|option>\n", id)
+	} else {
+		fmt.Fprintf(&sb, "\nResolve with: gt escalate close %s --reason \"...\"\n", id)
+	}
+	return sb.String()
+}
@@ -0,0 +1,181 @@
+package escalation
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+func TestStartTier(t *testing.T) {
+	tests := []struct {
+		category, to, want string
+		wantErr            bool
+	}{
+		{"", "", TierOverseer, false},
+		{CategoryDecision, "", TierDeacon, false},
+		{CategoryBlocked, "", TierMayor, false},
+		{CategoryEmergency, "", TierOverseer, false},
+		{CategoryLifecycle, "", TierWitness, false},
+		{CategoryDecision, "mayor/", TierMayor, false},
+		{CategoryDecision, "Overseer", TierOverseer, false},
+		{CategoryBlocked, "deacon", "", true},
+		{CategoryEmergency, "mayor", "", true},
+	}
+	for _, tt := range tests {
+		got, err := StartTier(tt.category, tt.to)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("StartTier(%q, %q) error = %v, wantErr %v", tt.category, tt.to, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("StartTier(%q, %q) = %q, want %q", tt.category, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestNextTierAndAddress(t *testing.T) {
+	if next, ok := NextTier(CategoryLifecycle, TierWitness); !ok || next != TierMayor {
+		t.Errorf("NextTier(lifecycle, witness) = %q, %v", next, ok)
+	}
+	if _, ok := NextTier(CategoryDecision, TierOverseer); ok {
+		t.Error("overseer should be the last tier")
+	}
+
+	if got := Address(TierWitness, "gastown"); got != "gastown/witness" {
+		t.Errorf("Address(witness, gastown) = %q", got)
+	}
+	if got := Address(TierWitness, ""); got != "mayor/" {
+		t.Errorf("Address(witness, \"\") = %q, want mayor/", got)
+	}
+	if got := Address(TierDeacon, ""); got != "deacon/" {
+		t.Errorf("Address(deacon) = %q", got)
+	}
+}
+
+func TestResolveOption(t *testing.T) {
+	options := []string{"JWT tokens", "Session cookies", "OAuth2"}
+	tests := []struct {
+		choice, want string
+		wantErr      bool
+	}{
+		{"A", "JWT tokens", false},
+		{"b", "Session cookies", false},
+		{"3", "OAuth2", false},
+		{"oauth2", "OAuth2", false},
+		{"D", "", true},
+		{"0", "", true},
+		{"Kerberos", "", true},
+		{"  ", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ResolveOption(options, tt.choice)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ResolveOption(%q) error = %v, wantErr %v", tt.choice, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ResolveOption(%q) = %q, want %q", tt.choice, got, tt.want)
+		}
+	}
+
+	if got, err := ResolveOption(nil, "ship it"); err != nil || got != "ship it" {
+		t.Errorf("free-form answer = %q, %v", got, err)
+	}
+}
+
+func TestParseOptions(t *testing.T) {
+	got, err := ParseOptions(" Redis, In-memory ,,SQLite ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(got, "|") != "Redis|In-memory|SQLite" {
+		t.Errorf("ParseOptions = %v", got)
+	}
+	if _, err := ParseOptions("a|b,c"); err == nil {
+		t.Error("expected error for option containing '|'")
+	}
+}
+
+func TestCheckTimeout(t *testing.T) {
+	now := time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC)
+	since := func(d time.Duration) string { return now.Add(-d).Format(time.RFC3339) }
+
+	tests := []struct {
+		name     string
+		fields   *beads.EscalationFields
+		want     Action
+		wantTier string
+	}{
+		{
+			name:   "no timeout waits",
+			fields: &beads.EscalationFields{Category: CategoryDecision, Tier: TierDeacon, TierSince: since(time.Hour)},
+			want:   ActionWait,
+		},
+		{
+			name:   "within timeout waits",
+			fields: &beads.EscalationFields{Category: CategoryDecision, Tier: TierDeacon, Timeout: "2h", TierSince: since(time.Hour)},
+			want:   ActionWait,
+		},
+		{
+			name:     "elapsed forwards to next tier",
+			fields:   &beads.EscalationFields{Category: CategoryDecision, Tier: TierDeacon, Timeout: "2h", TierSince: since(3 * time.Hour)},
+			want:     ActionForward,
+			wantTier: TierMayor,
+		},
+		{
+			name:   "last tier with fallback applies it",
+			fields: &beads.EscalationFields{Category: CategoryDecision, Tier: TierOverseer, Timeout: "2h", TierSince: since(3 * time.Hour), Fallback: "A"},
+			want:   ActionFallback,
+		},
+		{
+			name:   "last tier without fallback waits",
+			fields: &beads.EscalationFields{Category: CategoryDecision, Tier: TierOverseer, Timeout: "2h", TierSince: since(3 * time.Hour)},
+			want:   ActionWait,
+		},
+		{
+			name:   "answered waits",
+			fields: &beads.EscalationFields{Category: CategoryDecision, Tier: TierDeacon, Timeout: "2h", TierSince: since(3 * time.Hour), Answer: "A"},
+			want:   ActionWait,
+		},
+		{
+			name:   "bad tier_since waits",
+			fields: &beads.EscalationFields{Category: CategoryDecision, Tier: TierDeacon, Timeout: "2h", TierSince: "yesterday"},
+			want:   ActionWait,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, tier := CheckTimeout(tt.fields, now)
+			if got != tt.want || tier != tt.wantTier {
+				t.Errorf("CheckTimeout = (%v, %q), want (%v, %q)", got, tier, tt.want, tt.wantTier)
+			}
+		})
+	}
+}
+
+func TestFormatMarksRecommended(t *testing.T) {
+	fields := &beads.EscalationFields{
+		Category:    CategoryDecision,
+		Severity:    "MEDIUM",
+		From:        "gastown/polecats/toast",
+		Options:     []string{"Redis", "SQLite"},
+		Recommended: "SQLite",
+		Timeout:     "2h",
+		Fallback:    "SQLite",
+	}
+	out := Format("hq-abc", "Which cache?", fields, "Responses are slow")
+	for _, want := range []string{
+		"Which cache?",
+		"Responses are slow",
+		"A) Redis\n",
+		"B) SQLite (recommended)",
+		"then fallback: SQLite",
+		"gt escalate answer hq-abc",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Format output missing %q:\n%s", want, out)
+		}
+	}
+}
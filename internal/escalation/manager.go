@@ -0,0 +1,340 @@
+package escalation
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/mail"
+)
+
+// TitlePrefix marks escalation bead titles.
+const TitlePrefix = "[ESCALATION] "
+
+// AnsweredByTimeout is recorded as the answerer when a fallback is applied.
+const AnsweredByTimeout = "timeout"
+
+// Escalation is an escalation bead with its parsed fields.
+type Escalation struct {
+	ID        string                  `json:"id"`
+	Question  string                  `json:"question"`
+	Status    string                  `json:"status"`
+	CreatedAt string                  `json:"created_at"`
+	Fields    *beads.EscalationFields `json:"fields"`
+	Details   string                  `json:"details,omitempty"`
+}
+
+// fromIssue converts an escalation bead.
+func fromIssue(issue *beads.Issue) *Escalation {
+	fields := beads.ParseEscalationFields(issue)
+	if fields == nil {
+		fields = &beads.EscalationFields{}
+	}
+	return &Escalation{
+		ID:        issue.ID,
+		Question:  strings.TrimPrefix(issue.Title, TitlePrefix),
+		Status:    issue.Status,
+		CreatedAt: issue.CreatedAt,
+		Fields:    fields,
+		Details:   beads.SetEscalationFields(issue, nil),
+	}
+}
+
+// description renders the bead description for e.
+func (e *Escalation) description() string {
+	desc := beads.FormatEscalationFields(e.Fields)
+	if e.Details != "" {
+		desc += "\n\n" + e.Details
+	}
+	return desc
+}
+
+// Resolution reports the side effects of answering or closing an escalation.
+// The bead is closed even if mailing the originator or releasing the gate fails.
+type Resolution struct {
+	Escalation *Escalation
+	MailErr    error
+	GateErr    error
+}
+
+// Manager reads and updates escalations in town beads.
+type Manager struct {
+	townRoot string
+	beads    *beads.Beads
+	router   *mail.Router
+}
+
+// NewManager creates a manager for the town at townRoot.
+func NewManager(townRoot string) *Manager {
+	return &Manager{
+		townRoot: townRoot,
+		beads:    beads.New(townRoot),
+		router:   mail.NewRouter(townRoot),
+	}
+}
+
+// List returns open escalations, or all escalations when all is set.
+// category filters by category when non-empty.
+func (m *Manager) List(all bool, category string) ([]*Escalation, error) {
+	status := "open"
+	if all {
+		status = "all"
+	}
+	issues, err := m.beads.List(beads.ListOptions{Status: status, Tag: Label, Priority: -1})
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*Escalation
+	for _, issue := range issues {
+		e := fromIssue(issue)
+		if category != "" && e.Fields.Category != category {
+			continue
+		}
+		result = append(result, e)
+	}
+	return result, nil
+}
+
+// Get loads a single escalation.
+func (m *Manager) Get(id string) (*Escalation, error) {
+	issue, err := m.beads.Show(id)
+	if err != nil {
+		return nil, err
+	}
+	if !hasLabel(issue, Label) && !strings.HasPrefix(issue.Title, TitlePrefix) {
+		return nil, fmt.Errorf("%s is not an escalation", id)
+	}
+	return fromIssue(issue), nil
+}
+
+func hasLabel(issue *beads.Issue, label string) bool {
+	for _, l := range issue.Labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
+
+// Answer records choice as the answer, closes the escalation, mails the
+// originating agent, and releases the gate it parked on.
+func (m *Manager) Answer(id, choice, by, note string) (*Resolution, error) {
+	e, err := m.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if e.Status == "closed" {
+		return nil, fmt.Errorf("escalation %s is already closed", id)
+	}
+	answer, err := ResolveOption(e.Fields.Options, choice)
+	if err != nil {
+		return nil, err
+	}
+	return m.resolve(e, answer, by, note, time.Now())
+}
+
+// Close resolves an escalation without choosing an option.
+func (m *Manager) Close(id, by, reason string) (*Resolution, error) {
+	e, err := m.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if e.Status == "closed" {
+		return nil, fmt.Errorf("escalation %s is already closed", id)
+	}
+	if reason == "" {
+		reason = "resolved"
+	}
+
+	res := &Resolution{Escalation: e}
+	if err := m.beads.CloseWithReason(reason, e.ID); err != nil {
+		return nil, err
+	}
+	e.Status = "closed"
+
+	subject := fmt.Sprintf("✅ ESCALATION CLOSED: %s", e.Question)
+	body := fmt.Sprintf("Escalation %s was closed by %s.\n\nReason: %s", e.ID, by, reason)
+	res.MailErr = m.notifyOriginator(e, by, subject, body)
+	res.GateErr = m.releaseGate(e, fmt.Sprintf("Escalation %s closed: %s", e.ID, reason))
+
+	payload := map[string]interface{}{"bead": e.ID, "reason": reason}
+	_ = events.LogFeed(events.TypeEscalationClosed, by, payload)
+	return res, nil
+}
+
+// resolve records an answer and performs the follow-up side effects.
+func (m *Manager) resolve(e *Escalation, answer, by, note string, now time.Time) (*Resolution, error) {
+	e.Fields.Answer = answer
+	e.Fields.AnsweredBy = by
+	e.Fields.AnsweredAt = now.UTC().Format(time.RFC3339)
+
+	desc := e.description()
+	if err := m.beads.Update(e.ID, beads.UpdateOptions{Description: &desc}); err != nil {
+		return nil, fmt.Errorf("recording answer: %w", err)
+	}
+	if err := m.beads.CloseWithReason("answered: "+answer, e.ID); err != nil {
+		return nil, err
+	}
+	e.Status = "closed"
+
+	res := &Resolution{Escalation: e}
+	subject := fmt.Sprintf("✅ DECISION: %s → %s", e.Question, answer)
+	var body strings.Builder
+	fmt.Fprintf(&body, "Escalation %s was answered by %s.\n\nAnswer: %s\n", e.ID, by, answer)
+	if by == AnsweredByTimeout {
+		body.WriteString("\nNo tier answered before the timeout; the fallback was applied.\n")
+	}
+	if note != "" {
+		fmt.Fprintf(&body, "\n%s\n", note)
+	}
+	if e.Fields.Gate != "" {
+		fmt.Fprintf(&body, "\nGate %s has been released. Run 'gt resume' to continue.\n", e.Fields.Gate)
+	}
+	res.MailErr = m.notifyOriginator(e, by, subject, body.String())
+	res.GateErr = m.releaseGate(e, fmt.Sprintf("Escalation %s answered: %s", e.ID, answer))
+
+	payload := map[string]interface{}{
+		"bead":   e.ID,
+		"answer": answer,
+		"to":     e.Fields.From,
+	}
+	_ = events.LogFeed(events.TypeEscalationAnswered, by, payload)
+	return res, nil
+}
+
+// notifyOriginator mails the agent that raised the escalation.
+func (m *Manager) notifyOriginator(e *Escalation, by, subject, body string) error {
+	if e.Fields.From == "" || e.Fields.From == "unknown" {
+		return nil
+	}
+	from := by
+	if from == AnsweredByTimeout {
+		from = "deacon/"
+	}
+	return m.router.Send(&mail.Message{
+		From:     from,
+		To:       e.Fields.From,
+		Subject:  subject,
+		Body:     body,
+		Type:     mail.TypeReply,
+		Priority: mail.PriorityHigh,
+	})
+}
+
+// releaseGate closes the gate the originator parked on and wakes its waiters.
+func (m *Manager) releaseGate(e *Escalation, reason string) error {
+	if e.Fields.Gate == "" {
+		return nil
+	}
+
+	closeCmd := exec.Command("bd", "gate", "close", e.Fields.Gate, "--reason", reason) //nolint:gosec // G204: gate ID comes from the escalation bead
+	closeCmd.Dir = m.townRoot
+	if out, err := closeCmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("closing gate %s: %s", e.Fields.Gate, strings.TrimSpace(string(out)))
+	}
+
+	wakeCmd := exec.Command("gt", "gate", "wake", e.Fields.Gate) //nolint:gosec // G204: gate ID comes from the escalation bead
+	wakeCmd.Dir = m.townRoot
+	if out, err := wakeCmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("waking gate %s: %s", e.Fields.Gate, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// TimeoutResult describes one escalation acted on by ProcessTimeouts.
+type TimeoutResult struct {
+	ID     string
+	Action Action
+	Tier   string // New tier for forwards
+	Answer string // Fallback applied
+	Err    error
+}
+
+// ProcessTimeouts forwards escalations whose current tier has timed out and
+// applies fallbacks for those that timed out at the last tier.
+func (m *Manager) ProcessTimeouts(now time.Time) ([]TimeoutResult, error) {
+	open, err := m.List(false, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var results []TimeoutResult
+	for _, e := range open {
+		action, next := CheckTimeout(e.Fields, now)
+		switch action {
+		case ActionForward:
+			reason := e.Fields.Tier + " timed out"
+			results = append(results, TimeoutResult{
+				ID: e.ID, Action: action, Tier: next,
+				Err: m.Forward(e, next, "deacon/", reason, now),
+			})
+		case ActionFallback:
+			res := TimeoutResult{ID: e.ID, Action: action, Answer: e.Fields.Fallback}
+			if _, err := m.resolve(e, e.Fields.Fallback, AnsweredByTimeout, "", now); err != nil {
+				res.Err = err
+			}
+			results = append(results, res)
+		}
+	}
+	return results, nil
+}
+
+// ForwardNext routes an open escalation to the next tier in its chain.
+// Returns the new tier.
+func (m *Manager) ForwardNext(id, by, reason string) (string, error) {
+	e, err := m.Get(id)
+	if err != nil {
+		return "", err
+	}
+	if e.Status == "closed" {
+		return "", fmt.Errorf("escalation %s is already closed", id)
+	}
+	next, ok := NextTier(e.Fields.Category, e.Fields.Tier)
+	if !ok {
+		return "", fmt.Errorf("escalation %s is already at the last tier (%s)", id, e.Fields.Tier)
+	}
+	return next, m.Forward(e, next, by, reason, time.Now())
+}
+
+// Forward routes an escalation to tier and mails that tier.
+func (m *Manager) Forward(e *Escalation, tier, by, reason string, now time.Time) error {
+	e.Fields.Tier = tier
+	e.Fields.TierSince = now.UTC().Format(time.RFC3339)
+
+	desc := e.description()
+	if err := m.beads.Update(e.ID, beads.UpdateOptions{Description: &desc}); err != nil {
+		return fmt.Errorf("updating tier: %w", err)
+	}
+
+	to := Address(tier, e.Fields.Rig)
+	msg := &mail.Message{
+		From:     by,
+		To:       to,
+		Subject:  fmt.Sprintf("[%s] %s (forwarded: %s)", e.Fields.Severity, e.Question, reason),
+		Body:     Format(e.ID, e.Question, e.Fields, e.Details),
+		Priority: Priority(e.Fields.Severity),
+	}
+	if err := m.router.Send(msg); err != nil {
+		return fmt.Errorf("mailing %s: %w", to, err)
+	}
+
+	payload := events.EscalationPayload(e.Fields.Rig, e.ID, to, reason)
+	_ = events.LogFeed(events.TypeEscalationForwarded, by, payload)
+	return nil
+}
+
+// Priority maps escalation severity to mail priority.
+func Priority(severity string) mail.Priority {
+	switch strings.ToUpper(severity) {
+	case "CRITICAL":
+		return mail.PriorityUrgent
+	case "HIGH":
+		return mail.PriorityHigh
+	default:
+		return mail.PriorityNormal
+	}
+}
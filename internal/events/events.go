@@ -5,6 +5,7 @@
 package events
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -62,6 +63,19 @@ const (
 	TypeMerged       = "merged"
 	TypeMergeFailed  = "merge_failed"
 	TypeMergeSkipped = "merge_skipped"
+
+	// Verification verdicts (emitted by refinery verification gate)
+	TypeVerification = "verification"
+
+	// Convoy lifecycle controls (gt convoy pause/resume/cancel)
+	TypeConvoyPaused    = "convoy_paused"
+	TypeConvoyResumed   = "convoy_resumed"
+	TypeConvoyCancelled = "convoy_cancelled"
+
+	// Structured escalation lifecycle (gt escalate answer/close, daemon timeouts)
+	TypeEscalationAnswered  = "escalation_answered"
+	TypeEscalationForwarded = "escalation_forwarded"
+	TypeEscalationClosed    = "escalation_closed"
 )
 
 // EventsFile is the name of the raw events log.
@@ -207,6 +221,21 @@ func MergePayload(mrID, worker, branch, reason string) map[string]interface{} {
 	return p
 }
 
+// VerificationPayload creates a payload for verification verdict events.
+// status: verification status (verified, rejected, needs_review)
+// reviewedBy: runtime/model that performed the review
+func VerificationPayload(mrID, beadID, status, reviewedBy string) map[string]interface{} {
+	p := map[string]interface{}{
+		"mr":     mrID,
+		"bead":   beadID,
+		"status": status,
+	}
+	if reviewedBy != "" {
+		p["reviewed_by"] = reviewedBy
+	}
+	return p
+}
+
 // PatrolPayload creates a payload for patrol start/complete events.
 func PatrolPayload(rig string, polecatCount int, message string) map[string]interface{} {
 	p := map[string]interface{}{
@@ -293,3 +322,41 @@ func SessionPayload(sessionID, role, topic, cwd string) map[string]interface{} {
 	}
 	return p
 }
+
+// ReadEvents reads all events from the town's raw events log.
+// Malformed lines are skipped. Returns nil if the log doesn't exist yet.
+func ReadEvents(townRoot string) ([]Event, error) {
+	eventsPath := filepath.Join(townRoot, EventsFile)
+	f, err := os.Open(eventsPath) //nolint:gosec // G304: path is constructed from trusted townRoot
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("opening events file: %w", err)
+	}
+	defer f.Close()
+
+	var result []Event
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue // Skip malformed lines
+		}
+		result = append(result, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return result, fmt.Errorf("reading events file: %w", err)
+	}
+	return result, nil
+}
+
+// Time parses the event timestamp. Returns the zero time if unparseable.
+func (e Event) Time() time.Time {
+	ts, err := time.Parse(time.RFC3339, e.Timestamp)
+	if err != nil {
+		return time.Time{}
+	}
+	return ts
+}
@@ -0,0 +1,218 @@
+// Package forecast derives throughput and cycle-time statistics from Gas
+// Town's recorded history and uses them to estimate when convoys will land.
+//
+// A sample is one finished issue: it starts when the issue was slung (or
+// created, if never slung) and ends when its work merged (or the worker ran
+// gt done, or the issue closed). Samples come from the normalized timeline
+// stream (internal/timeline), so every source it understands contributes.
+//
+// ETAs use Little's law: a rig's historical concurrency is its throughput
+// times its median cycle time. Remaining issues are worked in waves of that
+// size, each taking between the P50 and P90 cycle time.
+package forecast
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultWindow is how much history statistics consider.
+const DefaultWindow = 14 * 24 * time.Hour
+
+// MinSamples is the fewest samples a rig needs before its own statistics are
+// used for estimates; below that the town-wide statistics stand in.
+const MinSamples = 3
+
+// Sample is one finished issue.
+type Sample struct {
+	IssueID string    `json:"issue_id"`
+	Rig     string    `json:"rig,omitempty"`
+	Role    string    `json:"role,omitempty"`
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+}
+
+// CycleTime returns how long the issue took.
+func (s Sample) CycleTime() time.Duration {
+	return s.End.Sub(s.Start)
+}
+
+// Stats summarizes a set of samples.
+type Stats struct {
+	Count      int           `json:"count"`
+	Throughput float64       `json:"throughput_per_day"`
+	P50        time.Duration `json:"p50"`
+	P90        time.Duration `json:"p90"`
+	P95        time.Duration `json:"p95"`
+}
+
+// Summarize computes statistics for samples finished within window.
+func Summarize(samples []Sample, window time.Duration) Stats {
+	durations := make([]time.Duration, 0, len(samples))
+	for _, s := range samples {
+		durations = append(durations, s.CycleTime())
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+
+	stats := Stats{
+		Count: len(durations),
+		P50:   Percentile(durations, 50),
+		P90:   Percentile(durations, 90),
+		P95:   Percentile(durations, 95),
+	}
+	if days := window.Hours() / 24; days > 0 {
+		stats.Throughput = float64(len(durations)) / days
+	}
+	return stats
+}
+
+// Percentile returns the nearest-rank percentile of sorted durations.
+func Percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return sorted[rank-1]
+}
+
+// GroupBy splits samples by key, skipping samples whose key is empty.
+func GroupBy(samples []Sample, key func(Sample) string) map[string][]Sample {
+	groups := make(map[string][]Sample)
+	for _, s := range samples {
+		if k := key(s); k != "" {
+			groups[k] = append(groups[k], s)
+		}
+	}
+	return groups
+}
+
+// History holds the samples of a window and their statistics.
+type History struct {
+	Samples []Sample
+	Window  time.Duration
+	Overall Stats
+	ByRig   map[string]Stats
+	ByRole  map[string]Stats
+}
+
+// NewHistory summarizes samples finished within window before now.
+func NewHistory(samples []Sample, window time.Duration, now time.Time) *History {
+	cutoff := now.Add(-window)
+	var recent []Sample
+	for _, s := range samples {
+		if !s.End.Before(cutoff) && !s.End.After(now) {
+			recent = append(recent, s)
+		}
+	}
+
+	h := &History{
+		Samples: recent,
+		Window:  window,
+		Overall: Summarize(recent, window),
+		ByRig:   make(map[string]Stats),
+		ByRole:  make(map[string]Stats),
+	}
+	for rig, group := range GroupBy(recent, func(s Sample) string { return s.Rig }) {
+		h.ByRig[rig] = Summarize(group, window)
+	}
+	for role, group := range GroupBy(recent, func(s Sample) string { return s.Role }) {
+		h.ByRole[role] = Summarize(group, window)
+	}
+	return h
+}
+
+// ForRig returns the statistics used to forecast a rig's work: its own when
+// it has at least MinSamples, otherwise the town-wide statistics.
+func (h *History) ForRig(rig string) Stats {
+	if s, ok := h.ByRig[rig]; ok && s.Count >= MinSamples {
+		return s
+	}
+	return h.Overall
+}
+
+// ETA is a forecast range for finishing remaining work.
+type ETA struct {
+	Remaining int           `json:"remaining"`
+	Known     bool          `json:"known"`
+	Low       time.Duration `json:"low,omitempty"`
+	High      time.Duration `json:"high,omitempty"`
+}
+
+// Estimate forecasts how long the remaining issues (counted per rig) will
+// take. Rigs work in parallel, so the convoy finishes with its slowest rig.
+func (h *History) Estimate(remainingByRig map[string]int) ETA {
+	eta := ETA{Known: true}
+	for rig, n := range remainingByRig {
+		if n <= 0 {
+			continue
+		}
+		eta.Remaining += n
+
+		stats := h.ForRig(rig)
+		if stats.Count == 0 || stats.P50 <= 0 {
+			eta.Known = false
+			continue
+		}
+
+		// Little's law: average work in progress = arrival rate × time in system
+		concurrency := int(math.Round(stats.Throughput * stats.P50.Hours() / 24))
+		if concurrency < 1 {
+			concurrency = 1
+		}
+		waves := time.Duration((n + concurrency - 1) / concurrency)
+
+		if low := waves * stats.P50; low > eta.Low {
+			eta.Low = low
+		}
+		if high := waves * stats.P90; high > eta.High {
+			eta.High = high
+		}
+	}
+	if eta.Remaining == 0 {
+		eta.Known = true
+		eta.Low, eta.High = 0, 0
+	} else if !eta.Known {
+		eta.Low, eta.High = 0, 0
+	}
+	return eta
+}
+
+// String renders the ETA as a compact range, e.g. "2h–5h".
+func (e ETA) String() string {
+	switch {
+	case e.Remaining == 0:
+		return "done"
+	case !e.Known:
+		return "unknown"
+	}
+	low, high := FormatDuration(e.Low), FormatDuration(e.High)
+	if low == high {
+		return "~" + low
+	}
+	return low + "–" + high
+}
+
+// FormatDuration renders a duration at a forecast's precision:
+// minutes under an hour, hours under two days, days beyond.
+func FormatDuration(d time.Duration) string {
+	switch {
+	case d <= 0:
+		return "0m"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(math.Ceil(d.Minutes())))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(math.Round(d.Hours())))
+	default:
+		days := d.Hours() / 24
+		return strings.TrimSuffix(fmt.Sprintf("%.1f", days), ".0") + "d"
+	}
+}
@@ -0,0 +1,143 @@
+package forecast
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/timeline"
+)
+
+func TestPercentile(t *testing.T) {
+	sorted := []time.Duration{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	tests := []struct {
+		p    float64
+		want time.Duration
+	}{
+		{50, 5},
+		{90, 9},
+		{95, 10},
+		{0, 1},
+		{100, 10},
+	}
+	for _, tt := range tests {
+		if got := Percentile(sorted, tt.p); got != tt.want {
+			t.Errorf("Percentile(p%v) = %v, want %v", tt.p, got, tt.want)
+		}
+	}
+	if got := Percentile(nil, 50); got != 0 {
+		t.Errorf("Percentile(nil) = %v, want 0", got)
+	}
+}
+
+func TestFromTimeline(t *testing.T) {
+	t0 := time.Date(2026, 1, 15, 9, 0, 0, 0, time.UTC)
+	entries := []timeline.Entry{
+		// gt-a: slung, done, merged → sling to merge
+		{Timestamp: t0.Add(-time.Hour), Type: timeline.TypeBeadCreated, Bead: "gt-a", Rig: "gastown"},
+		{Timestamp: t0, Type: events.TypeSling, Bead: "gt-a", Agent: "mayor"},
+		{Timestamp: t0.Add(2 * time.Hour), Type: events.TypeDone, Bead: "gt-a", Agent: "gastown/polecats/nux", Rig: "gastown"},
+		{Timestamp: t0.Add(3 * time.Hour), Type: events.TypeMerged, Bead: "gt-a", Rig: "gastown"},
+		// gt-b: never slung, closed by crew → create to close
+		{Timestamp: t0, Type: timeline.TypeBeadCreated, Bead: "gt-b", Rig: "gastown"},
+		{Timestamp: t0.Add(5 * time.Hour), Type: timeline.TypeBeadClosed, Bead: "gt-b", Agent: "gastown/crew/max", Rig: "gastown"},
+		// gt-c: still in progress
+		{Timestamp: t0, Type: events.TypeSling, Bead: "gt-c"},
+		// convoys are bookkeeping, not work
+		{Timestamp: t0, Type: timeline.TypeBeadCreated, Bead: "hq-cv-x", Convoy: "hq-cv-x"},
+		{Timestamp: t0.Add(time.Hour), Type: timeline.TypeBeadClosed, Bead: "hq-cv-x", Convoy: "hq-cv-x"},
+	}
+
+	samples := FromTimeline(entries)
+	if len(samples) != 2 {
+		t.Fatalf("got %d samples, want 2: %+v", len(samples), samples)
+	}
+
+	a := samples[0]
+	if a.IssueID != "gt-a" || a.CycleTime() != 3*time.Hour || a.Role != "polecat" || a.Rig != "gastown" {
+		t.Errorf("gt-a sample = %+v (cycle %v)", a, a.CycleTime())
+	}
+	b := samples[1]
+	if b.IssueID != "gt-b" || b.CycleTime() != 5*time.Hour || b.Role != "crew" {
+		t.Errorf("gt-b sample = %+v (cycle %v)", b, b.CycleTime())
+	}
+}
+
+func TestRoleFromAgent(t *testing.T) {
+	tests := map[string]string{
+		"gastown/polecats/nux": "polecat",
+		"gastown/polecat/nux":  "polecat",
+		"gastown/nux":          "polecat",
+		"gastown/crew/max":     "crew",
+		"gastown/refinery":     "refinery",
+		"mayor/":               "mayor",
+		"deacon/dogs/rex":      "deacon",
+		"":                     "",
+	}
+	for agent, want := range tests {
+		if got := RoleFromAgent(agent); got != want {
+			t.Errorf("RoleFromAgent(%q) = %q, want %q", agent, got, want)
+		}
+	}
+}
+
+func TestHistoryEstimate(t *testing.T) {
+	now := time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC)
+	window := 7 * 24 * time.Hour
+
+	// 14 gastown issues in a week, each taking 12h: throughput 2/day,
+	// so Little's law puts concurrency at 2 × 0.5d = 1.
+	var samples []Sample
+	for i := 0; i < 14; i++ {
+		end := now.Add(-time.Duration(i) * 12 * time.Hour)
+		samples = append(samples, Sample{IssueID: "gt-x", Rig: "gastown", Role: "polecat", Start: end.Add(-12 * time.Hour), End: end})
+	}
+	// Outside the window: ignored
+	samples = append(samples, Sample{Rig: "gastown", Start: now.Add(-30 * 24 * time.Hour), End: now.Add(-29 * 24 * time.Hour)})
+
+	h := NewHistory(samples, window, now)
+	if h.Overall.Count != 14 {
+		t.Fatalf("Overall.Count = %d, want 14", h.Overall.Count)
+	}
+	if h.ByRig["gastown"].Throughput != 2 {
+		t.Errorf("gastown throughput = %v, want 2/day", h.ByRig["gastown"].Throughput)
+	}
+	if h.ByRole["polecat"].P50 != 12*time.Hour {
+		t.Errorf("polecat P50 = %v, want 12h", h.ByRole["polecat"].P50)
+	}
+
+	eta := h.Estimate(map[string]int{"gastown": 3, "beads": 0})
+	if !eta.Known || eta.Remaining != 3 || eta.Low != 36*time.Hour || eta.High != 36*time.Hour {
+		t.Errorf("Estimate = %+v, want 36h for 3 waves", eta)
+	}
+	if got := eta.String(); got != "~36h" {
+		t.Errorf("ETA.String() = %q", got)
+	}
+
+	// A rig without its own history borrows the town-wide statistics
+	if eta := h.Estimate(map[string]int{"beads": 1}); !eta.Known || eta.Low != 12*time.Hour {
+		t.Errorf("fallback Estimate = %+v", eta)
+	}
+
+	if eta := NewHistory(nil, window, now).Estimate(map[string]int{"gastown": 2}); eta.Known || eta.String() != "unknown" {
+		t.Errorf("no-history Estimate = %+v", eta)
+	}
+	if eta := h.Estimate(nil); eta.String() != "done" {
+		t.Errorf("empty Estimate = %q, want done", eta.String())
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := map[time.Duration]string{
+		0:                            "0m",
+		90 * time.Second:             "2m",
+		3*time.Hour + 20*time.Minute: "3h",
+		60 * time.Hour:               "2.5d",
+		72 * time.Hour:               "3d",
+	}
+	for d, want := range tests {
+		if got := FormatDuration(d); got != want {
+			t.Errorf("FormatDuration(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
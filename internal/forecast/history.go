@@ -0,0 +1,166 @@
+package forecast
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/mrqueue"
+	"github.com/steveyegge/gastown/internal/timeline"
+)
+
+// workTypes are the issue types that count as units of work. Convoys,
+// molecules, agents, messages and the like are bookkeeping.
+var workTypes = map[string]bool{
+	"task":    true,
+	"bug":     true,
+	"feature": true,
+	"chore":   true,
+}
+
+// Load reads the town's history and summarizes the samples finished within
+// window before now. Sources are best-effort: one that fails to load just
+// contributes no samples.
+func Load(townRoot string, window time.Duration, now time.Time) *History {
+	var sources [][]timeline.Entry
+
+	if evts, err := events.ReadEvents(townRoot); err == nil {
+		sources = append(sources, timeline.FromEvents(evts))
+	}
+
+	if rigsConfig, err := config.LoadRigsConfig(filepath.Join(townRoot, "mayor", "rigs.json")); err == nil {
+		for rigName := range rigsConfig.Rigs {
+			rigPath := filepath.Join(townRoot, rigName)
+			if mqEvents, err := mrqueue.NewEventLoggerFromRig(rigPath).ReadEvents(); err == nil {
+				sources = append(sources, timeline.FromMQEvents(rigName, mqEvents))
+			}
+			if issues, err := beads.New(rigPath).List(beads.ListOptions{Status: "closed", Priority: -1}); err == nil {
+				sources = append(sources, timeline.FromIssues(rigName, workIssues(issues)))
+			}
+		}
+	}
+
+	return NewHistory(FromTimeline(timeline.Merge(sources...)), window, now)
+}
+
+// workIssues keeps the issues that represent units of work.
+func workIssues(issues []*beads.Issue) []*beads.Issue {
+	var work []*beads.Issue
+	for _, issue := range issues {
+		if workTypes[issue.Type] {
+			work = append(work, issue)
+		}
+	}
+	return work
+}
+
+// beadSpan collects the lifecycle entries of one bead.
+type beadSpan struct {
+	slung, created       *timeline.Entry
+	merged, done, closed *timeline.Entry
+}
+
+// FromTimeline builds a sample for every bead the timeline shows finishing.
+// Start is the first sling (else creation); end is the last merge (else
+// gt done, else close). Beads with no start or finishing entry are skipped.
+func FromTimeline(entries []timeline.Entry) []Sample {
+	spans := make(map[string]*beadSpan)
+	var order []string
+	for i := range entries {
+		e := &entries[i]
+		if e.Bead == "" || e.Convoy == e.Bead {
+			continue
+		}
+		span, ok := spans[e.Bead]
+		if !ok {
+			span = &beadSpan{}
+			spans[e.Bead] = span
+			order = append(order, e.Bead)
+		}
+
+		switch e.Type {
+		case events.TypeSling:
+			if span.slung == nil || e.Timestamp.Before(span.slung.Timestamp) {
+				span.slung = e
+			}
+		case timeline.TypeBeadCreated:
+			span.created = e
+		case events.TypeMerged:
+			if span.merged == nil || e.Timestamp.After(span.merged.Timestamp) {
+				span.merged = e
+			}
+		case events.TypeDone:
+			if span.done == nil || e.Timestamp.After(span.done.Timestamp) {
+				span.done = e
+			}
+		case timeline.TypeBeadClosed:
+			span.closed = e
+		}
+	}
+
+	var samples []Sample
+	for _, id := range order {
+		span := spans[id]
+		start := firstEntry(span.slung, span.created)
+		end := firstEntry(span.merged, span.done, span.closed)
+		if start == nil || end == nil || !end.Timestamp.After(start.Timestamp) {
+			continue
+		}
+
+		// The worker is whoever ran gt done; merges and closes carry it too
+		worker := firstEntry(span.done, span.merged, span.closed)
+		samples = append(samples, Sample{
+			IssueID: id,
+			Rig:     firstRig(end, span.done, span.merged, span.closed, start),
+			Role:    RoleFromAgent(worker.Agent),
+			Start:   start.Timestamp,
+			End:     end.Timestamp,
+		})
+	}
+	return samples
+}
+
+// RoleFromAgent returns the role of an agent address:
+// "gastown/polecats/nux" → "polecat", "gastown/crew/max" → "crew",
+// "gastown/refinery" → "refinery", "mayor/" → "mayor".
+// A bare "rig/name" is a polecat.
+func RoleFromAgent(agent string) string {
+	parts := strings.Split(strings.Trim(agent, "/"), "/")
+	switch {
+	case parts[0] == "":
+		return ""
+	case len(parts) == 1:
+		return parts[0]
+	}
+	switch parts[1] {
+	case "polecats", "polecat":
+		return "polecat"
+	case "crew", "witness", "refinery":
+		return parts[1]
+	}
+	if parts[0] == "deacon" {
+		return "deacon"
+	}
+	return "polecat"
+}
+
+func firstEntry(entries ...*timeline.Entry) *timeline.Entry {
+	for _, e := range entries {
+		if e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+func firstRig(entries ...*timeline.Entry) string {
+	for _, e := range entries {
+		if e != nil && e.Rig != "" {
+			return e.Rig
+		}
+	}
+	return ""
+}
@@ -46,6 +46,12 @@ func (g *Git) IsRepo() bool {
 	return err == nil
 }
 
+// CommonDir returns the absolute path of the repository's common git
+// directory (the main .git dir shared by all of its worktrees).
+func (g *Git) CommonDir() (string, error) {
+	return g.run("rev-parse", "--path-format=absolute", "--git-common-dir")
+}
+
 // run executes a git command and returns stdout.
 func (g *Git) run(args ...string) (string, error) {
 	// If gitDir is set (bare repo), prepend --git-dir flag
@@ -117,6 +123,42 @@ func (g *Git) CloneWithReference(url, dest, reference string) error {
 	return nil
 }
 
+// CloneOptions configures CloneWithOptions.
+type CloneOptions struct {
+	Reference string   // Local repo to borrow objects from (--reference-if-able)
+	Filter    string   // Partial clone filter (e.g., "blob:none")
+	Sparse    []string // Cone directories to check out; empty checks out everything
+}
+
+// CloneWithOptions clones a repository as a partial clone and/or with a
+// cone-mode sparse checkout, so large repos only fetch and check out what's needed.
+func (g *Git) CloneWithOptions(url, dest string, opts CloneOptions) error {
+	args := []string{"clone"}
+	if opts.Reference != "" {
+		args = append(args, "--reference-if-able", opts.Reference)
+	}
+	if opts.Filter != "" {
+		args = append(args, "--filter="+opts.Filter)
+	}
+	if len(opts.Sparse) > 0 {
+		// --sparse checks out only top-level files until the cone is set
+		args = append(args, "--sparse")
+	}
+	args = append(args, url, dest)
+
+	cmd := exec.Command("git", args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return g.wrapError(err, stderr.String(), args)
+	}
+
+	if len(opts.Sparse) > 0 {
+		return NewGit(dest).SparseCheckoutSet(opts.Sparse)
+	}
+	return nil
+}
+
 // CloneBare clones a repository as a bare repo (no working directory).
 // This is used for the shared repo architecture where all worktrees share a single git database.
 func (g *Git) CloneBare(url, dest string) error {
@@ -335,12 +377,41 @@ func (g *Git) Rebase(onto string) error {
 	return err
 }
 
+// RebaseOnto replays the commits of branch that are not in upstream onto
+// newBase, leaving branch checked out.
+func (g *Git) RebaseOnto(newBase, upstream, branch string) error {
+	_, err := g.run("rebase", "--onto", newBase, upstream, branch)
+	return err
+}
+
 // AbortMerge aborts a merge in progress.
 func (g *Git) AbortMerge() error {
 	_, err := g.run("merge", "--abort")
 	return err
 }
 
+// RevertMerge commits a revert of a merge commit, undoing the changes it
+// brought in relative to its first parent.
+func (g *Git) RevertMerge(commit, message string) error {
+	if _, err := g.run("revert", "--no-commit", "-m", "1", commit); err != nil {
+		return err
+	}
+	_, err := g.run("commit", "-m", message)
+	return err
+}
+
+// AbortRevert aborts a revert in progress.
+func (g *Git) AbortRevert() error {
+	_, err := g.run("revert", "--abort")
+	return err
+}
+
+// ResetHard resets the current branch, index and worktree to ref.
+func (g *Git) ResetHard(ref string) error {
+	_, err := g.run("reset", "--hard", ref)
+	return err
+}
+
 // CheckConflicts performs a test merge to check if source can be merged into target
 // without conflicts. Returns a list of conflicting files, or empty slice if clean.
 // The merge is always aborted after checking - no actual changes are made.
@@ -571,6 +642,40 @@ func (g *Git) WorktreeRemove(path string, force bool) error {
 	return err
 }
 
+// WorktreeAddSparse creates a worktree that only checks out the cone
+// directories (plus top-level files). With a branch, it is created at
+// startPoint like WorktreeAddFromRef; without one, HEAD is detached at
+// startPoint like WorktreeAddDetached. An empty startPoint means HEAD.
+func (g *Git) WorktreeAddSparse(path, branch, startPoint string, cone []string) error {
+	args := []string{"worktree", "add", "--no-checkout"}
+	if branch != "" {
+		args = append(args, "-b", branch)
+	} else {
+		args = append(args, "--detach")
+	}
+	args = append(args, path)
+	if startPoint != "" {
+		args = append(args, startPoint)
+	}
+	if _, err := g.run(args...); err != nil {
+		return err
+	}
+
+	worktreeGit := NewGit(path)
+	if err := worktreeGit.SparseCheckoutSet(cone); err != nil {
+		return err
+	}
+	// Populate the working tree; only the cone is checked out
+	_, err := worktreeGit.run("reset", "--hard", "--quiet")
+	return err
+}
+
+// WorktreeMove moves a worktree to a new path.
+func (g *Git) WorktreeMove(path, newPath string) error {
+	_, err := g.run("worktree", "move", path, newPath)
+	return err
+}
+
 // WorktreePrune removes worktree entries for deleted paths.
 func (g *Git) WorktreePrune() error {
 	_, err := g.run("worktree", "prune")
@@ -676,6 +781,63 @@ func (g *Git) CommitsAhead(base, branch string) (int, error) {
 	return count, nil
 }
 
+// AheadBehind returns how many commits ref has that base doesn't (ahead)
+// and how many base has that ref doesn't (behind).
+func (g *Git) AheadBehind(base, ref string) (ahead, behind int, err error) {
+	out, err := g.run("rev-list", "--left-right", "--count", base+"..."+ref)
+	if err != nil {
+		return 0, 0, err
+	}
+	if _, err := fmt.Sscanf(out, "%d %d", &behind, &ahead); err != nil {
+		return 0, 0, fmt.Errorf("parsing commit counts: %w", err)
+	}
+	return ahead, behind, nil
+}
+
+// ChangedFiles returns the files HEAD and the working tree change relative
+// to where HEAD diverged from base: committed, uncommitted and untracked.
+func (g *Git) ChangedFiles(base string) ([]string, error) {
+	mergeBase, err := g.run("merge-base", base, "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	changed, err := g.run("diff", "--name-only", mergeBase)
+	if err != nil {
+		return nil, err
+	}
+	untracked, err := g.run("ls-files", "--others", "--exclude-standard")
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, line := range strings.Split(changed+"\n"+untracked, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
+
+// RefExists reports whether ref resolves to a commit, without touching the
+// network (e.g., "origin/main" only exists locally after a fetch).
+func (g *Git) RefExists(ref string) bool {
+	_, err := g.run("rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	return err == nil
+}
+
+// PushUpstream pushes branch to remote and sets it as the upstream. With
+// forceWithLease, rewritten history (e.g., after a rebase) replaces the
+// remote branch only if nobody else pushed to it since the last fetch.
+func (g *Git) PushUpstream(remote, branch string, forceWithLease bool) error {
+	args := []string{"push", "-u", remote, branch}
+	if forceWithLease {
+		args = append(args, "--force-with-lease")
+	}
+	_, err := g.run(args...)
+	return err
+}
+
 // StashCount returns the number of stashes in the repository.
 func (g *Git) StashCount() (int, error) {
 	out, err := g.run("stash", "list")
@@ -387,3 +387,168 @@ func TestCheckConflicts_WithConflict(t *testing.T) {
 		t.Error("expected clean working directory after CheckConflicts")
 	}
 }
+
+func TestAheadBehindAndPushUpstream(t *testing.T) {
+	remoteDir := t.TempDir()
+	cmd := exec.Command("git", "init", "--bare")
+	cmd.Dir = remoteDir
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("git init --bare: %v", err)
+	}
+
+	localDir := initTestRepo(t)
+	g := NewGit(localDir)
+	mainBranch, _ := g.CurrentBranch()
+	cmd = exec.Command("git", "remote", "add", "origin", remoteDir)
+	cmd.Dir = localDir
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("git remote add: %v", err)
+	}
+	if err := g.PushUpstream("origin", mainBranch, false); err != nil {
+		t.Fatalf("PushUpstream: %v", err)
+	}
+	if !g.RefExists("origin/" + mainBranch) {
+		t.Fatalf("origin/%s should exist after push", mainBranch)
+	}
+	if g.RefExists("origin/nope") {
+		t.Error("origin/nope should not exist")
+	}
+
+	// Two local commits on a branch, one new commit on main
+	if err := g.CreateBranch("feature"); err != nil {
+		t.Fatalf("CreateBranch: %v", err)
+	}
+	if err := g.Checkout("feature"); err != nil {
+		t.Fatalf("Checkout: %v", err)
+	}
+	for _, name := range []string{"a.txt", "b.txt"} {
+		if err := os.WriteFile(filepath.Join(localDir, name), []byte(name), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := g.Add(name); err != nil {
+			t.Fatal(err)
+		}
+		if err := g.Commit("add " + name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := g.Checkout(mainBranch); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(localDir, "c.txt"), []byte("c"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Add("c.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Commit("add c.txt"); err != nil {
+		t.Fatal(err)
+	}
+
+	ahead, behind, err := g.AheadBehind(mainBranch, "feature")
+	if err != nil {
+		t.Fatalf("AheadBehind: %v", err)
+	}
+	if ahead != 2 || behind != 1 {
+		t.Errorf("AheadBehind = %d ahead, %d behind; want 2, 1", ahead, behind)
+	}
+}
+
+func TestChangedFiles(t *testing.T) {
+	dir := initTestRepo(t)
+	g := NewGit(dir)
+	mainBranch, _ := g.CurrentBranch()
+
+	if err := g.CreateBranch("feature"); err != nil {
+		t.Fatalf("CreateBranch: %v", err)
+	}
+	if err := g.Checkout("feature"); err != nil {
+		t.Fatalf("Checkout: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "pkg"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "pkg", "a.go"), []byte("package pkg\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Add("pkg/a.go"); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Commit("add a.go"); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("# Changed\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("new"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := g.ChangedFiles(mainBranch)
+	if err != nil {
+		t.Fatalf("ChangedFiles: %v", err)
+	}
+	want := map[string]bool{"pkg/a.go": true, "README.md": true, "notes.txt": true}
+	if len(files) != len(want) {
+		t.Fatalf("ChangedFiles = %v, want %d files", files, len(want))
+	}
+	for _, f := range files {
+		if !want[f] {
+			t.Errorf("unexpected changed file %q", f)
+		}
+	}
+}
+
+func TestRebaseOnto(t *testing.T) {
+	dir := initTestRepo(t)
+	g := NewGit(dir)
+	mainBranch, _ := g.CurrentBranch()
+
+	commit := func(name string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(name+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := g.Add(name); err != nil {
+			t.Fatal(err)
+		}
+		if err := g.Commit("add " + name); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// child is stacked on parent; parent is then squashed into main, so
+	// the child must drop parent's original commit.
+	if err := g.CreateBranch("parent"); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Checkout("parent"); err != nil {
+		t.Fatal(err)
+	}
+	commit("parent.txt")
+	if err := g.CreateBranch("child"); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Checkout("child"); err != nil {
+		t.Fatal(err)
+	}
+	commit("child.txt")
+	if err := g.Checkout(mainBranch); err != nil {
+		t.Fatal(err)
+	}
+	commit("parent.txt")
+
+	if err := g.RebaseOnto(mainBranch, "parent", "child"); err != nil {
+		t.Fatalf("RebaseOnto: %v", err)
+	}
+	if branch, _ := g.CurrentBranch(); branch != "child" {
+		t.Errorf("branch = %q, want child", branch)
+	}
+	ahead, err := g.CommitsAhead(mainBranch, "child")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ahead != 1 {
+		t.Errorf("child is %d commits ahead of %s, want 1 (only its own)", ahead, mainBranch)
+	}
+}
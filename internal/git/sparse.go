@@ -0,0 +1,53 @@
+package git
+
+import (
+	"strings"
+)
+
+// SparseCheckoutSet switches the working tree to a cone-mode sparse checkout
+// of the given directories (top-level files are always included).
+func (g *Git) SparseCheckoutSet(cone []string) error {
+	args := append([]string{"sparse-checkout", "set", "--cone", "--"}, cone...)
+	_, err := g.run(args...)
+	return err
+}
+
+// SparseCheckoutList returns the cone directories of a sparse checkout,
+// or nil if the working tree checks out everything.
+func (g *Git) SparseCheckoutList() ([]string, error) {
+	enabled, err := g.run("config", "--bool", "core.sparseCheckout")
+	if err != nil || enabled != "true" {
+		// Unset config exits non-zero: not sparse
+		return nil, nil
+	}
+
+	out, err := g.run("sparse-checkout", "list")
+	if err != nil {
+		return nil, err
+	}
+	if out == "" {
+		return []string{}, nil
+	}
+	return strings.Split(out, "\n"), nil
+}
+
+// PartialCloneFilter returns the partial clone filter configured for a
+// remote, or "" if the repo is a full clone of it.
+func (g *Git) PartialCloneFilter(remote string) string {
+	filter, err := g.run("config", "--get", "remote."+remote+".partialclonefilter")
+	if err != nil {
+		return ""
+	}
+	return filter
+}
+
+// SetPartialCloneFilter makes later fetches from remote skip objects that
+// filter excludes, converting a full clone into a partial one. A no-op when
+// the filter is already configured; otherwise fetches once with the filter.
+func (g *Git) SetPartialCloneFilter(remote, filter string) error {
+	if filter == "" || g.PartialCloneFilter(remote) == filter {
+		return nil
+	}
+	_, err := g.run("fetch", "--filter="+filter, remote)
+	return err
+}
@@ -0,0 +1,98 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// initMonorepo creates a repo with top-level dirs a/, b/ and c/ plus a README.
+func initMonorepo(t *testing.T) string {
+	t.Helper()
+	dir := initTestRepo(t)
+	for _, d := range []string{"a", "b", "c"} {
+		if err := os.MkdirAll(filepath.Join(dir, d), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, d, "f.txt"), []byte(d), 0644); err != nil {
+			t.Fatalf("write file: %v", err)
+		}
+	}
+	g := NewGit(dir)
+	if err := g.Add("."); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if err := g.Commit("dirs"); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+	return dir
+}
+
+func TestWorktreeAddSparse(t *testing.T) {
+	repo := initMonorepo(t)
+	g := NewGit(repo)
+	wt := filepath.Join(t.TempDir(), "wt")
+
+	if err := g.WorktreeAddSparse(wt, "feature", "", []string{"a", "b"}); err != nil {
+		t.Fatalf("WorktreeAddSparse: %v", err)
+	}
+
+	for path, want := range map[string]bool{"README.md": true, "a/f.txt": true, "b/f.txt": true, "c/f.txt": false} {
+		_, err := os.Stat(filepath.Join(wt, path))
+		if got := err == nil; got != want {
+			t.Errorf("%s checked out = %v, want %v", path, got, want)
+		}
+	}
+
+	wtGit := NewGit(wt)
+	if branch, _ := wtGit.CurrentBranch(); branch != "feature" {
+		t.Errorf("branch = %q, want feature", branch)
+	}
+	if status, err := wtGit.Status(); err != nil || !status.Clean {
+		t.Errorf("sparse worktree not clean: %+v, %v", status, err)
+	}
+
+	cone, err := wtGit.SparseCheckoutList()
+	if err != nil || !reflect.DeepEqual(cone, []string{"a", "b"}) {
+		t.Errorf("SparseCheckoutList = %v, %v; want [a b]", cone, err)
+	}
+
+	// Other worktrees of the same repo stay full checkouts
+	if cone, err := g.SparseCheckoutList(); err != nil || cone != nil {
+		t.Errorf("main worktree SparseCheckoutList = %v, %v; want nil", cone, err)
+	}
+}
+
+func TestCloneWithOptionsSparse(t *testing.T) {
+	repo := initMonorepo(t)
+	dest := filepath.Join(t.TempDir(), "clone")
+
+	if err := NewGit("").CloneWithOptions(repo, dest, CloneOptions{Sparse: []string{"c"}}); err != nil {
+		t.Fatalf("CloneWithOptions: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dest, "c", "f.txt")); err != nil {
+		t.Errorf("cone dir not checked out: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dest, "a")); !os.IsNotExist(err) {
+		t.Errorf("a/ checked out outside the cone")
+	}
+}
+
+func TestSetPartialCloneFilter(t *testing.T) {
+	origin := initMonorepo(t)
+	dest := filepath.Join(t.TempDir(), "clone")
+	if err := NewGit("").Clone(origin, dest); err != nil {
+		t.Fatalf("clone: %v", err)
+	}
+	g := NewGit(dest)
+	if f := g.PartialCloneFilter("origin"); f != "" {
+		t.Fatalf("PartialCloneFilter on full clone = %q", f)
+	}
+	if err := g.SetPartialCloneFilter("origin", "blob:none"); err != nil {
+		t.Fatalf("SetPartialCloneFilter: %v", err)
+	}
+	if f := g.PartialCloneFilter("origin"); f != "blob:none" {
+		t.Errorf("PartialCloneFilter = %q, want blob:none", f)
+	}
+}
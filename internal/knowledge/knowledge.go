@@ -0,0 +1,379 @@
+// Package knowledge keeps a rig's durable notes: facts agents learn while
+// working (flaky tests, build quirks, where things live) that should outlive
+// the polecat that learned them. Agents add notes with gt learn, the witness
+// and refinery curate them, and gt prime injects the ones relevant to a
+// polecat's hooked bead.
+//
+// Notes live in knowledge.json at the rig root, shared by every worktree.
+// The file is rewritten in place under a lock rather than replaced, so a
+// polecat sandbox can bind-mount it (see sandbox.PolecatSpec).
+package knowledge
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// FileName is the knowledge store's file name at the rig root.
+const FileName = "knowledge.json"
+
+// DuplicateThreshold is the word overlap (Jaccard similarity) at which two
+// notes are reported as likely duplicates.
+const DuplicateThreshold = 0.7
+
+// Note is one learned fact.
+type Note struct {
+	ID   string   `json:"id"`
+	Fact string   `json:"fact"`
+	Tags []string `json:"tags,omitempty"`
+
+	// Paths are the directories or files the fact is about.
+	Paths []string `json:"paths,omitempty"`
+
+	// Pinned notes are injected for every polecat regardless of relevance.
+	Pinned bool `json:"pinned,omitempty"`
+
+	Author  string    `json:"author,omitempty"`
+	Bead    string    `json:"bead,omitempty"` // Bead being worked when learned
+	Created time.Time `json:"created"`
+	Updated time.Time `json:"updated"`
+
+	// Confirmations counts the times the fact was learned again (or merged
+	// from a duplicate) after it was first recorded.
+	Confirmations int `json:"confirmations,omitempty"`
+}
+
+// Store is a rig's knowledge.
+type Store struct {
+	NextID int     `json:"next_id"`
+	Notes  []*Note `json:"notes"`
+}
+
+// Path returns the knowledge store path for a rig.
+func Path(rigPath string) string {
+	return filepath.Join(rigPath, FileName)
+}
+
+// LockPath returns the lock file guarding a rig's knowledge store.
+func LockPath(rigPath string) string {
+	return Path(rigPath) + ".lock"
+}
+
+// Load reads a rig's knowledge, returning an empty store if there is none.
+func Load(rigPath string) (*Store, error) {
+	if _, err := os.Stat(Path(rigPath)); os.IsNotExist(err) {
+		return &Store{NextID: 1}, nil
+	}
+	var s *Store
+	err := util.WithFileLock(Path(rigPath), func() error {
+		var err error
+		s, err = load(rigPath)
+		return err
+	})
+	return s, err
+}
+
+// load reads a rig's knowledge; the caller holds the lock, since a write
+// in progress leaves the file partly written.
+func load(rigPath string) (*Store, error) {
+	s := &Store{NextID: 1}
+	data, err := os.ReadFile(Path(rigPath))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, fmt.Errorf("reading knowledge: %w", err)
+	}
+	if len(data) == 0 {
+		return s, nil // Created empty for a sandbox mount
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("parsing knowledge: %w", err)
+	}
+	if s.NextID < 1 {
+		s.NextID = 1
+	}
+	return s, nil
+}
+
+// Update applies fn to a rig's knowledge under an exclusive lock and saves
+// it, so concurrent gt learn calls don't lose each other's notes. Nothing is
+// saved if fn returns an error.
+func Update(rigPath string, fn func(*Store) error) error {
+	path := Path(rigPath)
+	return util.WithFileLock(path, func() error {
+		s, err := load(rigPath)
+		if err != nil {
+			return err
+		}
+		if err := fn(s); err != nil {
+			return err
+		}
+		data, err := json.MarshalIndent(s, "", "  ")
+		if err != nil {
+			return fmt.Errorf("encoding knowledge: %w", err)
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return fmt.Errorf("writing knowledge: %w", err)
+		}
+		return nil
+	})
+}
+
+// Get returns the note with id, or nil.
+func (s *Store) Get(id string) *Note {
+	for _, n := range s.Notes {
+		if n.ID == id {
+			return n
+		}
+	}
+	return nil
+}
+
+// Add records a note. If the store already has the same fact (ignoring case,
+// spacing and punctuation), that note absorbs the new tags and paths and
+// counts a confirmation instead; the returned bool is true in that case.
+func (s *Store) Add(note Note, now time.Time) (*Note, bool) {
+	key := normalize(note.Fact)
+	for _, existing := range s.Notes {
+		if normalize(existing.Fact) == key {
+			absorb(existing, &note)
+			existing.Confirmations++
+			existing.Updated = now
+			return existing, true
+		}
+	}
+
+	n := note
+	n.ID = fmt.Sprintf("kn-%d", s.NextID)
+	s.NextID++
+	n.Tags = mergeStrings(nil, note.Tags)
+	n.Paths = mergeStrings(nil, note.Paths)
+	n.Created = now
+	n.Updated = now
+	s.Notes = append(s.Notes, &n)
+	return &n, false
+}
+
+// Remove deletes the note with id, reporting whether it existed.
+func (s *Store) Remove(id string) bool {
+	for i, n := range s.Notes {
+		if n.ID == id {
+			s.Notes = append(s.Notes[:i], s.Notes[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Merge folds the notes in dropIDs into keepID: their tags, paths and
+// confirmations move to the kept note and they are removed.
+func (s *Store) Merge(keepID string, dropIDs []string, now time.Time) error {
+	keep := s.Get(keepID)
+	if keep == nil {
+		return fmt.Errorf("note %s not found", keepID)
+	}
+	for _, id := range dropIDs {
+		if id == keepID {
+			continue
+		}
+		drop := s.Get(id)
+		if drop == nil {
+			return fmt.Errorf("note %s not found", id)
+		}
+		absorb(keep, drop)
+		keep.Confirmations += drop.Confirmations + 1
+		keep.Pinned = keep.Pinned || drop.Pinned
+		s.Remove(id)
+	}
+	keep.Updated = now
+	return nil
+}
+
+// Duplicates groups notes whose facts overlap by at least threshold. Each
+// group lists the oldest note first, the natural one to keep.
+func (s *Store) Duplicates(threshold float64) [][]*Note {
+	sets := make([]map[string]bool, len(s.Notes))
+	for i, n := range s.Notes {
+		sets[i] = wordSet(n.Fact)
+	}
+
+	grouped := make([]bool, len(s.Notes))
+	var groups [][]*Note
+	for i := range s.Notes {
+		if grouped[i] {
+			continue
+		}
+		group := []*Note{s.Notes[i]}
+		for j := i + 1; j < len(s.Notes); j++ {
+			if !grouped[j] && jaccard(sets[i], sets[j]) >= threshold {
+				group = append(group, s.Notes[j])
+				grouped[j] = true
+			}
+		}
+		if len(group) > 1 {
+			sort.SliceStable(group, func(a, b int) bool { return group[a].Created.Before(group[b].Created) })
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+// Relevant returns up to limit notes that apply to work carrying labels and
+// touching paths, most relevant first. Pinned notes always apply; a path
+// matches when one contains the other; notes with neither tags nor paths
+// are general rig knowledge and rank last. limit <= 0 means no limit.
+func (s *Store) Relevant(labels, paths []string, limit int) []*Note {
+	labelSet := make(map[string]bool)
+	for _, l := range labels {
+		l = strings.ToLower(l)
+		labelSet[l] = true
+		if i := strings.Index(l, ":"); i >= 0 {
+			labelSet[l[i+1:]] = true
+		}
+	}
+
+	type scored struct {
+		note  *Note
+		score int
+	}
+	var matches []scored
+	for _, n := range s.Notes {
+		score := 0
+		if n.Pinned {
+			score += 100
+		}
+		for _, t := range n.Tags {
+			if labelSet[strings.ToLower(t)] {
+				score += 2
+			}
+		}
+		for _, p := range n.Paths {
+			for _, touched := range paths {
+				if pathOverlaps(p, touched) {
+					score += 3
+					break
+				}
+			}
+		}
+		if score == 0 && len(n.Tags) == 0 && len(n.Paths) == 0 {
+			score = 1
+		}
+		if score > 0 {
+			matches = append(matches, scored{n, score})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		if matches[i].note.Confirmations != matches[j].note.Confirmations {
+			return matches[i].note.Confirmations > matches[j].note.Confirmations
+		}
+		return matches[i].note.Updated.After(matches[j].note.Updated)
+	})
+
+	var notes []*Note
+	for _, m := range matches {
+		if limit > 0 && len(notes) == limit {
+			break
+		}
+		notes = append(notes, m.note)
+	}
+	return notes
+}
+
+// HasTag reports whether the note carries tag (case-insensitive).
+func (n *Note) HasTag(tag string) bool {
+	for _, t := range n.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// absorb adds from's tags and paths to into.
+func absorb(into, from *Note) {
+	into.Tags = mergeStrings(into.Tags, from.Tags)
+	into.Paths = mergeStrings(into.Paths, from.Paths)
+}
+
+// mergeStrings appends the values in add missing from base, trimmed and
+// without empties.
+func mergeStrings(base, add []string) []string {
+	seen := make(map[string]bool, len(base))
+	for _, v := range base {
+		seen[v] = true
+	}
+	for _, v := range add {
+		v = strings.TrimSpace(v)
+		if v != "" && !seen[v] {
+			seen[v] = true
+			base = append(base, v)
+		}
+	}
+	return base
+}
+
+// pathOverlaps reports whether a and b name the same file or directory, or
+// one lies inside the other.
+func pathOverlaps(a, b string) bool {
+	a = strings.Trim(filepath.ToSlash(filepath.Clean(a)), "/")
+	b = strings.Trim(filepath.ToSlash(filepath.Clean(b)), "/")
+	if a == "" || b == "" || a == "." || b == "." {
+		return false
+	}
+	return a == b || strings.HasPrefix(b, a+"/") || strings.HasPrefix(a, b+"/")
+}
+
+// normalize reduces a fact to lowercase words for exact-duplicate detection.
+func normalize(fact string) string {
+	return strings.Join(words(fact), " ")
+}
+
+func wordSet(fact string) map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range words(fact) {
+		set[w] = true
+	}
+	return set
+}
+
+// words splits a fact into lowercase words, keeping paths and identifiers
+// (internal/api, TestFoo_Bar, go-1.22) whole.
+func words(fact string) []string {
+	var out []string
+	for _, w := range strings.FieldsFunc(strings.ToLower(fact), isSeparator) {
+		if w = strings.Trim(w, ".-/"); w != "" {
+			out = append(out, w)
+		}
+	}
+	return out
+}
+
+func isSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '-' && r != '/' && r != '.'
+}
+
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for w := range a {
+		if b[w] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
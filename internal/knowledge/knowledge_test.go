@@ -0,0 +1,140 @@
+package knowledge
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestAddDedupesSameFact(t *testing.T) {
+	s := &Store{NextID: 1}
+	now := time.Now()
+
+	first, dup := s.Add(Note{Fact: "TestSync is flaky under -race", Tags: []string{"flaky"}}, now)
+	if dup || first.ID != "kn-1" {
+		t.Fatalf("first Add = %s dup=%v, want kn-1 new", first.ID, dup)
+	}
+
+	again, dup := s.Add(Note{Fact: "  testsync is FLAKY under -race. ", Tags: []string{"flaky", "tests"}, Paths: []string{"internal/sync"}}, now.Add(time.Hour))
+	if !dup || again != first {
+		t.Fatalf("second Add should confirm kn-1, got %s dup=%v", again.ID, dup)
+	}
+	if first.Confirmations != 1 || len(first.Tags) != 2 || len(first.Paths) != 1 {
+		t.Errorf("confirmed note = %+v", first)
+	}
+	if !first.Updated.After(first.Created) {
+		t.Error("confirmation should bump Updated")
+	}
+
+	other, dup := s.Add(Note{Fact: "make build needs protoc"}, now)
+	if dup || other.ID != "kn-2" || len(s.Notes) != 2 {
+		t.Errorf("distinct fact: %s dup=%v, %d notes", other.ID, dup, len(s.Notes))
+	}
+}
+
+func TestDuplicatesAndMerge(t *testing.T) {
+	s := &Store{NextID: 1}
+	now := time.Now()
+	s.Add(Note{Fact: "run go generate before building the api package", Tags: []string{"build"}}, now)
+	s.Add(Note{Fact: "integration tests need docker running"}, now.Add(time.Minute))
+	s.Add(Note{Fact: "run go generate before building the api package again", Paths: []string{"api"}}, now.Add(2*time.Minute))
+
+	groups := s.Duplicates(DuplicateThreshold)
+	if len(groups) != 1 || len(groups[0]) != 2 || groups[0][0].ID != "kn-1" || groups[0][1].ID != "kn-3" {
+		t.Fatalf("Duplicates() = %v", groups)
+	}
+
+	if err := s.Merge("kn-1", []string{"kn-3"}, now); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	kept := s.Get("kn-1")
+	if s.Get("kn-3") != nil || kept.Confirmations != 1 || len(kept.Paths) != 1 || !kept.HasTag("BUILD") {
+		t.Errorf("after merge: kept = %+v, notes = %d", kept, len(s.Notes))
+	}
+	if err := s.Merge("kn-1", []string{"kn-9"}, now); err == nil {
+		t.Error("Merge() with unknown note should fail")
+	}
+}
+
+func TestRelevant(t *testing.T) {
+	s := &Store{NextID: 1}
+	now := time.Now()
+	for _, n := range []Note{
+		{Fact: "auth tests need a redis", Tags: []string{"auth"}},
+		{Fact: "api handlers are generated", Paths: []string{"internal/api"}},
+		{Fact: "the ui uses pnpm", Tags: []string{"ui"}, Paths: []string{"web"}},
+		{Fact: "main is protected; always go through the refinery"},
+		{Fact: "never touch vendor/", Pinned: true, Paths: []string{"vendor"}},
+		{Fact: "auth tokens live in internal/api/auth", Tags: []string{"auth"}, Paths: []string{"internal/api/auth"}},
+	} {
+		s.Add(n, now)
+	}
+
+	// kn-3 (ui) doesn't apply; pinned first, then path+tag, path, tag, general.
+	got := s.Relevant([]string{"area:auth"}, []string{"internal/api/auth/token.go"}, 0)
+	want := []string{"kn-5", "kn-6", "kn-2", "kn-1", "kn-4"}
+	if len(got) != len(want) {
+		t.Fatalf("Relevant() = %d notes, want %d", len(got), len(want))
+	}
+	for i, id := range want {
+		if got[i].ID != id {
+			t.Errorf("Relevant()[%d] = %s, want %s", i, got[i].ID, id)
+		}
+	}
+
+	if got := s.Relevant(nil, nil, 1); len(got) != 1 || got[0].ID != "kn-5" {
+		t.Errorf("Relevant(limit 1) = %v, want pinned note only", got)
+	}
+}
+
+func TestUpdateRoundTrip(t *testing.T) {
+	rigPath := t.TempDir()
+
+	err := Update(rigPath, func(s *Store) error {
+		s.Add(Note{Fact: "use make test, not go test", Author: "gastown/polecats/toast"}, time.Now())
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	s, err := Load(rigPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(s.Notes) != 1 || s.NextID != 2 || s.Notes[0].Author != "gastown/polecats/toast" {
+		t.Errorf("loaded store = %+v", s)
+	}
+}
+
+func TestUpdateKeepsFileInPlace(t *testing.T) {
+	rigPath := t.TempDir()
+	// An empty store file, as a sandbox mount creates it
+	if err := os.WriteFile(Path(rigPath), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	before, err := os.Stat(Path(rigPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = Update(rigPath, func(s *Store) error {
+		s.Add(Note{Fact: "the e2e suite needs docker"}, time.Now())
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Update() on empty file error = %v", err)
+	}
+
+	// A bind mount pins the inode, so the file must not be replaced
+	after, err := os.Stat(Path(rigPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !os.SameFile(before, after) {
+		t.Error("Update() replaced the store file instead of rewriting it")
+	}
+	if s, err := Load(rigPath); err != nil || len(s.Notes) != 1 {
+		t.Errorf("Load() = %+v, %v; want one note", s, err)
+	}
+}
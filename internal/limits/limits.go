@@ -0,0 +1,152 @@
+// Package limits applies CPU, memory and process-count limits to agent
+// sessions and reports what they use.
+//
+// Limits are applied by wrapping a session's startup command. Where cgroups v2
+// and a systemd user manager are available, the agent runs in a transient
+// systemd scope with MemoryMax, CPUQuota and TasksMax; otherwise prlimit caps
+// memory only. Rlimits can't cap a CPU rate, and RLIMIT_NPROC counts all of
+// the user's processes, so a session limit would starve every other agent.
+package limits
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// How limits are enforced.
+const (
+	MethodCgroup  = "cgroup"  // transient systemd user scope (cgroups v2)
+	MethodPrlimit = "prlimit" // rlimits on the agent process
+)
+
+// UnitPrefix starts the name of every systemd scope created for a session,
+// so usage sampling can tell a session's own cgroup from tmux's.
+const UnitPrefix = "gastown-"
+
+// Host probes, overridable in tests.
+var (
+	cgroupRoot = "/sys/fs/cgroup"
+	procRoot   = "/proc"
+	lookPath   = exec.LookPath
+
+	// probeScope checks that systemd-run can reach a user manager, since a
+	// failing wrapper would stop the agent from starting at all.
+	probeScope = func() bool {
+		return exec.Command("systemd-run", "--user", "--scope", "--quiet", "--", "true").Run() == nil
+	}
+
+	methodOnce   sync.Once
+	cachedMethod string
+)
+
+// Method returns how limits can be enforced on this host, or "" if they can't.
+func Method() string {
+	methodOnce.Do(func() {
+		cachedMethod = detectMethod()
+	})
+	return cachedMethod
+}
+
+func detectMethod() string {
+	if _, err := os.Stat(cgroupRoot + "/cgroup.controllers"); err == nil {
+		if _, err := lookPath("systemd-run"); err == nil && probeScope() {
+			return MethodCgroup
+		}
+	}
+	if _, err := lookPath("prlimit"); err == nil {
+		return MethodPrlimit
+	}
+	return ""
+}
+
+// SystemdProperties returns the systemd resource-control properties for l
+// ("MemoryMax=...", "CPUQuota=...", "TasksMax=...").
+func SystemdProperties(l *config.ResourceLimits) []string {
+	if l.IsZero() {
+		return nil
+	}
+	var props []string
+	if memory, _ := l.MemoryBytes(); memory > 0 {
+		props = append(props, fmt.Sprintf("MemoryMax=%d", memory))
+	}
+	if l.CPUs > 0 {
+		props = append(props, fmt.Sprintf("CPUQuota=%d%%", int(l.CPUs*100)))
+	}
+	if l.PIDs > 0 {
+		props = append(props, fmt.Sprintf("TasksMax=%d", l.PIDs))
+	}
+	return props
+}
+
+// Args returns the argv prefix that runs a command under l with method, or
+// nil when there is nothing to apply. unit names the systemd scope.
+func Args(l *config.ResourceLimits, method, unit string) []string {
+	if l.IsZero() || len(l.Validate()) > 0 {
+		return nil
+	}
+
+	switch method {
+	case MethodCgroup:
+		args := []string{"systemd-run", "--user", "--scope", "--quiet", "--collect", "--unit=" + unit}
+		for _, prop := range SystemdProperties(l) {
+			args = append(args, "-p", prop)
+		}
+		return append(args, "--")
+
+	case MethodPrlimit:
+		var args []string
+		// RLIMIT_DATA covers heap and anonymous mappings without tripping
+		// over runtimes that reserve large virtual address ranges.
+		if memory, _ := l.MemoryBytes(); memory > 0 {
+			args = append(args, fmt.Sprintf("--data=%d", memory))
+		}
+		if len(args) == 0 {
+			return nil // CPU rate and process count need cgroups
+		}
+		return append(append([]string{"prlimit"}, args...), "--")
+	}
+
+	return nil
+}
+
+// Wrap returns a shell command line that execs command under l, or command
+// unchanged when l sets no limits or they can't be enforced here.
+// name identifies the session in its systemd scope name. The wrapper shell
+// stays the pane's command; tmux.IsClaudeRunning looks beneath it.
+func Wrap(l *config.ResourceLimits, name, command string) string {
+	prefix := Args(l, Method(), UnitName(name))
+	if prefix == nil {
+		return command
+	}
+	return "exec " + util.ShellJoin(append(prefix, "sh", "-c", command))
+}
+
+// WrapCommand resolves the limits for a session's role (see
+// config.ResolveResourceLimits) and wraps its startup command in them.
+// rigName is empty for town-level agents.
+func WrapCommand(townRoot, rigName, role, name, command string) string {
+	return Wrap(config.ResolveResourceLimits(townRoot, rigName, role), name, command)
+}
+
+// UnitName returns a unique systemd scope name for a session. The timestamp
+// keeps a restart from colliding with a scope that hasn't been collected yet.
+func UnitName(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if r < 128 && (r == '-' || r == '_' || r == '.' || r == ':' ||
+			(r >= '0' && r <= '9') || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')) {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('_')
+		}
+	}
+	return UnitPrefix + b.String() + "-" + strconv.FormatInt(time.Now().Unix(), 36)
+}
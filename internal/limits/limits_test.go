@@ -0,0 +1,220 @@
+package limits
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// fakeHost points the package at a temp /proc and /sys/fs/cgroup and resets
+// the cached method, restoring everything when the test ends.
+func fakeHost(t *testing.T, cgroupV2 bool, bins ...string) (proc, cgroup string) {
+	t.Helper()
+	root := t.TempDir()
+	proc, cgroup = filepath.Join(root, "proc"), filepath.Join(root, "cgroup")
+	for _, dir := range []string{proc, cgroup} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if cgroupV2 {
+		writeFile(t, filepath.Join(cgroup, "cgroup.controllers"), "cpu memory pids")
+	}
+
+	oldProc, oldCgroup, oldLook, oldProbe := procRoot, cgroupRoot, lookPath, probeScope
+	procRoot, cgroupRoot = proc, cgroup
+	lookPath = func(bin string) (string, error) {
+		for _, b := range bins {
+			if b == bin {
+				return "/usr/bin/" + bin, nil
+			}
+		}
+		return "", exec.ErrNotFound
+	}
+	probeScope = func() bool { return true }
+	methodOnce = sync.Once{}
+	t.Cleanup(func() {
+		procRoot, cgroupRoot, lookPath, probeScope = oldProc, oldCgroup, oldLook, oldProbe
+		methodOnce = sync.Once{}
+	})
+	return proc, cgroup
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMethod(t *testing.T) {
+	tests := []struct {
+		name     string
+		cgroupV2 bool
+		bins     []string
+		want     string
+	}{
+		{"cgroup v2 with systemd", true, []string{"systemd-run", "prlimit"}, MethodCgroup},
+		{"cgroup v1 falls back", false, []string{"systemd-run", "prlimit"}, MethodPrlimit},
+		{"no systemd falls back", true, []string{"prlimit"}, MethodPrlimit},
+		{"nothing available", true, nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeHost(t, tt.cgroupV2, tt.bins...)
+			if got := Method(); got != tt.want {
+				t.Errorf("Method() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestArgs(t *testing.T) {
+	l := &config.ResourceLimits{Memory: "2g", CPUs: 1.5, PIDs: 256}
+
+	got := Args(l, MethodCgroup, "gastown-x")
+	want := []string{"systemd-run", "--user", "--scope", "--quiet", "--collect", "--unit=gastown-x",
+		"-p", "MemoryMax=2147483648", "-p", "CPUQuota=150%", "-p", "TasksMax=256", "--"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("cgroup args = %v\nwant %v", got, want)
+	}
+
+	got = Args(l, MethodPrlimit, "gastown-x")
+	want = []string{"prlimit", "--data=2147483648", "--"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("prlimit args = %v\nwant %v", got, want)
+	}
+
+	if got := Args(&config.ResourceLimits{CPUs: 2, PIDs: 64}, MethodPrlimit, "x"); got != nil {
+		t.Errorf("CPU and process prlimit args = %v, want nil", got)
+	}
+	if got := Args(&config.ResourceLimits{}, MethodCgroup, "x"); got != nil {
+		t.Errorf("no limits = %v, want nil", got)
+	}
+	if got := Args(&config.ResourceLimits{Memory: "lots"}, MethodCgroup, "x"); got != nil {
+		t.Errorf("invalid limits = %v, want nil", got)
+	}
+}
+
+func TestWrap(t *testing.T) {
+	fakeHost(t, true, "systemd-run")
+
+	got := Wrap(&config.ResourceLimits{PIDs: 64}, "gt-gastown-Toast", "export A=1 && exec claude")
+	if !strings.HasPrefix(got, "exec systemd-run --user --scope --quiet --collect --unit=gastown-gt-gastown-Toast-") {
+		t.Errorf("Wrap = %q", got)
+	}
+	if !strings.HasSuffix(got, "-p TasksMax=64 -- sh -c 'export A=1 && exec claude'") {
+		t.Errorf("Wrap = %q", got)
+	}
+
+	if got := Wrap(nil, "x", "exec claude"); got != "exec claude" {
+		t.Errorf("Wrap without limits = %q, want unchanged", got)
+	}
+}
+
+func TestWrapCommandResolvesRoleLimits(t *testing.T) {
+	fakeHost(t, false, "prlimit")
+	town := t.TempDir()
+	writeFile(t, filepath.Join(town, "settings", "config.json"),
+		`{"type":"town-settings","version":1,"limits":{"memory":"1g","roles":{"mayor":{"memory":"2g","pids":50}}}}`)
+	writeFile(t, filepath.Join(town, "gastown", "settings", "config.json"),
+		`{"type":"rig-settings","version":1,"limits":{"roles":{"polecat":{"memory":"512m"}}}}`)
+
+	if got := WrapCommand(town, "", "mayor", "hq-mayor", "exec claude"); !strings.Contains(got, "prlimit --data=2147483648 --") {
+		t.Errorf("mayor = %q", got)
+	}
+	if got := WrapCommand(town, "gastown", "polecat", "gastown-Toast", "exec claude"); !strings.Contains(got, "prlimit --data=536870912 --") {
+		t.Errorf("polecat = %q", got)
+	}
+	if got := WrapCommand(town, "gastown", "witness", "gastown-witness", "exec claude"); !strings.Contains(got, "prlimit --data=1073741824 --") {
+		t.Errorf("witness should inherit the town default: %q", got)
+	}
+}
+
+func TestForPIDCgroup(t *testing.T) {
+	proc, cgroup := fakeHost(t, true)
+	scope := "/user.slice/user@1000.service/app.slice/gastown-gt-gastown-Toast-abc.scope"
+	writeFile(t, filepath.Join(proc, "42", "cgroup"), "0::"+scope+"\n")
+	dir := filepath.Join(cgroup, scope)
+	writeFile(t, filepath.Join(dir, "memory.current"), "1073741824\n") // includes page cache
+	writeFile(t, filepath.Join(dir, "memory.stat"), "anon 943718400\nfile 130023424\n")
+	writeFile(t, filepath.Join(dir, "memory.max"), "1073741824\n")
+	writeFile(t, filepath.Join(dir, "pids.current"), "12\n")
+	writeFile(t, filepath.Join(dir, "pids.max"), "max\n")
+	writeFile(t, filepath.Join(dir, "cpu.stat"), "usage_usec 2500000\nuser_usec 2000000\n")
+	writeFile(t, filepath.Join(dir, "memory.events"), "low 0\nhigh 0\nmax 7\noom 1\noom_kill 1\n")
+
+	u, err := ForPID(42, nil)
+	if err != nil {
+		t.Fatalf("ForPID: %v", err)
+	}
+	want := Usage{Source: MethodCgroup, MemoryBytes: 943718400, MemoryMax: 1073741824, PIDs: 12, CPUSeconds: 2.5, OOMKills: 1}
+	if *u != want {
+		t.Errorf("usage = %+v\nwant %+v", *u, want)
+	}
+
+	// 900M of 1G is under the 90% threshold, so only the OOM kill counts
+	if breaches := u.Breaches(0); len(breaches) != 1 || !strings.Contains(breaches[0], "1 process(es) OOM-killed") {
+		t.Errorf("Breaches(0) = %v", breaches)
+	}
+	if got := u.Breaches(1); len(got) != 0 {
+		t.Errorf("Breaches(1) = %v, want none once the OOM kill was reported", got)
+	}
+	u.MemoryBytes = u.MemoryMax
+	if got := u.Breaches(1); len(got) != 1 || !strings.HasPrefix(got[0], "memory at 100% of limit") {
+		t.Errorf("Breaches at the limit = %v", got)
+	}
+}
+
+func TestForPIDProcTree(t *testing.T) {
+	proc, _ := fakeHost(t, true)
+	// 42 (shell) -> 43 (claude) -> 44 (test runner); 50 is unrelated
+	for pid, stat := range map[string]string{
+		"42": "42 (bash) S 1 42 42 0 -1 0 0 0 0 0 100 50 0 0",
+		"43": "43 (claude code) S 42 42 42 0 -1 0 0 0 0 0 300 100 0 0",
+		"44": "44 (go) R 43 42 42 0 -1 0 0 0 0 0 50 0 0 0",
+		"50": "50 (sshd) S 1 50 50 0 -1 0 0 0 0 0 999 999 0 0",
+	} {
+		writeFile(t, filepath.Join(proc, pid, "stat"), stat)
+		writeFile(t, filepath.Join(proc, pid, "statm"), "1000 300 44 0 0 0 0")
+		writeFile(t, filepath.Join(proc, pid, "cgroup"), "0::/user.slice/session-1.scope\n")
+	}
+
+	u, err := ForPID(42, &config.ResourceLimits{Memory: "4m", PIDs: 3})
+	if err != nil {
+		t.Fatalf("ForPID: %v", err)
+	}
+	// prlimit doesn't cap processes, so PIDs isn't held against the session
+	want := Usage{Source: "proc", MemoryBytes: 3 * 256 * pageSize, MemoryMax: 4 << 20, PIDs: 3, CPUSeconds: 6}
+	if *u != want {
+		t.Errorf("usage = %+v\nwant %+v", *u, want)
+	}
+	if breaches := u.Breaches(0); len(breaches) != 0 {
+		t.Errorf("Breaches = %v, want none", breaches)
+	}
+	u, _ = ForPID(42, &config.ResourceLimits{Memory: "3m"})
+	if breaches := u.Breaches(0); len(breaches) != 1 || breaches[0] != "memory at 100% of limit (3.0M/3.0M)" {
+		t.Errorf("Breaches = %v", breaches)
+	}
+
+	if _, err := ForPID(99, nil); err == nil {
+		t.Error("ForPID of a missing process should fail")
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	for n, want := range map[int64]string{512: "512B", 1536: "1.5K", 4 << 30: "4.0G"} {
+		if got := FormatBytes(n); got != want {
+			t.Errorf("FormatBytes(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
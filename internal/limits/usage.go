@@ -0,0 +1,266 @@
+package limits
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+// Breach thresholds: a session this close to a limit is about to be
+// throttled, OOM-killed, or unable to fork.
+const (
+	memoryBreachRatio = 0.9
+	pidsBreachRatio   = 0.9
+)
+
+// Linux reports per-process CPU time in clock ticks and RSS in pages; both
+// are fixed on the platforms gt runs on.
+const (
+	clockTicks = 100
+	pageSize   = 4096
+)
+
+// Usage is a session's current resource consumption.
+type Usage struct {
+	// Source is "cgroup" when read from the session's own scope, or "proc"
+	// when summed over the session's process tree.
+	Source string `json:"source"`
+
+	// MemoryBytes is anonymous memory (heap, stacks): page cache is left
+	// out, since the kernel reclaims it before the limit bites.
+	MemoryBytes int64   `json:"memory_bytes"`
+	MemoryMax   int64   `json:"memory_max,omitempty"`
+	PIDs        int     `json:"pids"`
+	PIDsMax     int     `json:"pids_max,omitempty"`
+	CPUSeconds  float64 `json:"cpu_seconds"`
+
+	// OOMKills counts processes the kernel killed at the memory limit
+	// (cgroup only; cumulative for the session).
+	OOMKills int `json:"oom_kills,omitempty"`
+}
+
+// ForPID returns the resource usage of the session whose pane process is pid.
+// When the session runs in its own scope the kernel's cgroup accounting is
+// used; otherwise the process tree under pid is summed and compared against
+// the configured memory limit in l (which may be nil). Process limits are
+// only reported for scopes, since nothing enforces them without cgroups.
+func ForPID(pid int, l *config.ResourceLimits) (*Usage, error) {
+	if dir := sessionCgroup(pid); dir != "" {
+		return cgroupUsage(dir)
+	}
+	return procUsage(pid, l)
+}
+
+// ForSession returns the resource usage of a tmux session (see ForPID).
+func ForSession(t *tmux.Tmux, session string, l *config.ResourceLimits) (*Usage, error) {
+	pid, err := t.GetPanePID(session)
+	if err != nil {
+		return nil, fmt.Errorf("finding pane process: %w", err)
+	}
+	return ForPID(pid, l)
+}
+
+// sessionCgroup returns the cgroup v2 directory of pid when it is a session
+// scope created by Wrap, or "" otherwise.
+func sessionCgroup(pid int) string {
+	data, err := os.ReadFile(filepath.Join(procRoot, strconv.Itoa(pid), "cgroup"))
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		path, ok := strings.CutPrefix(line, "0::")
+		if !ok || !strings.HasPrefix(filepath.Base(path), UnitPrefix) {
+			continue
+		}
+		dir := filepath.Join(cgroupRoot, path)
+		if _, err := os.Stat(dir); err == nil {
+			return dir
+		}
+	}
+	return ""
+}
+
+func cgroupUsage(dir string) (*Usage, error) {
+	// memory.current includes page cache; memory.stat's anon is what
+	// actually pushes a session toward an OOM kill.
+	memory, ok := readKeyedValue(filepath.Join(dir, "memory.stat"), "anon")
+	if !ok {
+		var err error
+		if memory, err = readInt(filepath.Join(dir, "memory.current")); err != nil {
+			return nil, fmt.Errorf("reading memory usage: %w", err)
+		}
+	}
+	u := &Usage{Source: MethodCgroup, MemoryBytes: memory}
+	u.MemoryMax, _ = readInt(filepath.Join(dir, "memory.max"))
+	pids, _ := readInt(filepath.Join(dir, "pids.current"))
+	pidsMax, _ := readInt(filepath.Join(dir, "pids.max"))
+	u.PIDs, u.PIDsMax = int(pids), int(pidsMax)
+
+	stat := readKeyed(filepath.Join(dir, "cpu.stat"))
+	u.CPUSeconds = float64(stat["usage_usec"]) / 1e6
+	u.OOMKills = int(readKeyed(filepath.Join(dir, "memory.events"))["oom_kill"])
+	return u, nil
+}
+
+func procUsage(pid int, l *config.ResourceLimits) (*Usage, error) {
+	if _, err := os.Stat(filepath.Join(procRoot, strconv.Itoa(pid))); err != nil {
+		return nil, fmt.Errorf("process %d not found", pid)
+	}
+
+	// Map every process to its parent, then walk down from pid
+	children := make(map[int][]int)
+	entries, err := os.ReadDir(procRoot)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", procRoot, err)
+	}
+	stats := make(map[int][]string)
+	for _, e := range entries {
+		p, err := strconv.Atoi(e.Name())
+		if err != nil {
+			continue
+		}
+		fields := statFields(p)
+		if len(fields) < 13 {
+			continue
+		}
+		stats[p] = fields
+		if ppid, err := strconv.Atoi(fields[1]); err == nil {
+			children[ppid] = append(children[ppid], p)
+		}
+	}
+
+	u := &Usage{Source: "proc"}
+	if l != nil {
+		u.MemoryMax, _ = l.MemoryBytes()
+	}
+	queue := []int{pid}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		queue = append(queue, children[p]...)
+
+		u.PIDs++
+		if fields := stats[p]; fields != nil {
+			// utime and stime are fields 14 and 15 of /proc/<pid>/stat
+			utime, _ := strconv.ParseFloat(fields[11], 64)
+			stime, _ := strconv.ParseFloat(fields[12], 64)
+			u.CPUSeconds += (utime + stime) / clockTicks
+		}
+		if statm, err := os.ReadFile(filepath.Join(procRoot, strconv.Itoa(p), "statm")); err == nil {
+			// resident minus shared (file-backed) pages approximates anon RSS
+			if f := strings.Fields(string(statm)); len(f) > 2 {
+				rss, _ := strconv.ParseInt(f[1], 10, 64)
+				shared, _ := strconv.ParseInt(f[2], 10, 64)
+				u.MemoryBytes += (rss - shared) * pageSize
+			}
+		}
+	}
+	return u, nil
+}
+
+// statFields returns the fields of /proc/<pid>/stat after the command name
+// (which may contain spaces), so fields[0] is the state and fields[1] the ppid.
+func statFields(pid int) []string {
+	data, err := os.ReadFile(filepath.Join(procRoot, strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return nil
+	}
+	s := string(data)
+	i := strings.LastIndexByte(s, ')')
+	if i < 0 {
+		return nil
+	}
+	return strings.Fields(s[i+1:])
+}
+
+// Breaches describes the limits the session is at or over. prevOOMKills is
+// the OOM kill count already reported, so only new kills are flagged.
+func (u *Usage) Breaches(prevOOMKills int) []string {
+	var breaches []string
+	if u.OOMKills > prevOOMKills {
+		breaches = append(breaches, fmt.Sprintf("%d process(es) OOM-killed at the memory limit", u.OOMKills-prevOOMKills))
+	}
+	if u.MemoryMax > 0 && float64(u.MemoryBytes) >= memoryBreachRatio*float64(u.MemoryMax) {
+		breaches = append(breaches, fmt.Sprintf("memory at %d%% of limit (%s/%s)",
+			u.MemoryBytes*100/u.MemoryMax, FormatBytes(u.MemoryBytes), FormatBytes(u.MemoryMax)))
+	}
+	if u.PIDsMax > 0 && float64(u.PIDs) >= pidsBreachRatio*float64(u.PIDsMax) {
+		breaches = append(breaches, fmt.Sprintf("processes at %d/%d", u.PIDs, u.PIDsMax))
+	}
+	return breaches
+}
+
+// String summarizes usage on one line: "1.2G/4.0G mem, 37/512 procs, 1m2s cpu".
+func (u *Usage) String() string {
+	mem := FormatBytes(u.MemoryBytes)
+	if u.MemoryMax > 0 {
+		mem += "/" + FormatBytes(u.MemoryMax)
+	}
+	procs := strconv.Itoa(u.PIDs)
+	if u.PIDsMax > 0 {
+		procs += "/" + strconv.Itoa(u.PIDsMax)
+	}
+	s := fmt.Sprintf("%s mem, %s procs, %.0fs cpu", mem, procs, u.CPUSeconds)
+	if u.OOMKills > 0 {
+		s += fmt.Sprintf(", %d OOM kill(s)", u.OOMKills)
+	}
+	return s
+}
+
+// FormatBytes formats a byte count with a binary unit (512K, 1.5G).
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%c", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// readInt reads a single-integer cgroup file; "max" reads as 0 (unlimited).
+func readInt(path string) (int64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	s := strings.TrimSpace(string(data))
+	if s == "max" {
+		return 0, nil
+	}
+	return strconv.ParseInt(s, 10, 64)
+}
+
+// readKeyedValue reads one key from a keyed cgroup file, reporting whether
+// it was present.
+func readKeyedValue(path, key string) (int64, bool) {
+	v, ok := readKeyed(path)[key]
+	return v, ok
+}
+
+// readKeyed reads a "key value" per line cgroup file (cpu.stat, memory.events).
+func readKeyed(path string) map[string]int64 {
+	values := make(map[string]int64)
+	f, err := os.Open(path)
+	if err != nil {
+		return values
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 {
+			values[fields[0]], _ = strconv.ParseInt(fields[1], 10, 64)
+		}
+	}
+	return values
+}
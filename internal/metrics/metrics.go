@@ -0,0 +1,228 @@
+// Package metrics provides a minimal Prometheus-compatible metrics registry.
+//
+// Only counters and gauges with string labels are supported, rendered in the
+// Prometheus text exposition format (version 0.0.4). This avoids pulling in
+// the full client library for the handful of series the daemon exposes.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ContentType is the Content-Type for the text exposition format.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Metric types.
+const (
+	TypeCounter = "counter"
+	TypeGauge   = "gauge"
+)
+
+// Registry holds metric families and renders them.
+type Registry struct {
+	mu       sync.Mutex
+	families []*Vec
+
+	// collectors run before each render to refresh scrape-time values.
+	collectors []func()
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// NewCounter registers a counter family with the given label names.
+func (r *Registry) NewCounter(name, help string, labels ...string) *Vec {
+	return r.register(name, help, TypeCounter, labels)
+}
+
+// NewGauge registers a gauge family with the given label names.
+func (r *Registry) NewGauge(name, help string, labels ...string) *Vec {
+	return r.register(name, help, TypeGauge, labels)
+}
+
+func (r *Registry) register(name, help, typ string, labels []string) *Vec {
+	v := &Vec{
+		name:   name,
+		help:   help,
+		typ:    typ,
+		labels: labels,
+		series: make(map[string]*series),
+	}
+	r.mu.Lock()
+	r.families = append(r.families, v)
+	r.mu.Unlock()
+	return v
+}
+
+// OnCollect registers a function run before every render, for values that
+// are cheap to compute at scrape time.
+func (r *Registry) OnCollect(fn func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.collectors = append(r.collectors, fn)
+}
+
+// WriteText runs collectors and writes all families in text format.
+func (r *Registry) WriteText(w io.Writer) error {
+	r.mu.Lock()
+	collectors := append([]func(){}, r.collectors...)
+	families := append([]*Vec{}, r.families...)
+	r.mu.Unlock()
+
+	for _, fn := range collectors {
+		fn()
+	}
+
+	var sb strings.Builder
+	for _, v := range families {
+		v.writeText(&sb)
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// Handler returns an http.Handler serving the registry.
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", ContentType)
+		_ = r.WriteText(w)
+	})
+}
+
+// Vec is a metric family: one metric name with any number of labeled series.
+type Vec struct {
+	name   string
+	help   string
+	typ    string
+	labels []string
+
+	mu     sync.Mutex
+	series map[string]*series
+}
+
+type series struct {
+	labelValues []string
+	value       float64
+}
+
+// With returns the series for the given label values (in label-name order),
+// creating it at zero if needed. Missing values are treated as "".
+func (v *Vec) With(labelValues ...string) *Series {
+	values := make([]string, len(v.labels))
+	copy(values, labelValues)
+	return &Series{vec: v, key: strings.Join(values, "\xff"), values: values}
+}
+
+// Reset removes all series. Use for gauges that are fully recomputed on
+// each collection so stale label sets disappear.
+func (v *Vec) Reset() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.series = make(map[string]*series)
+}
+
+// Value returns the current value for the given label values (0 if unset).
+func (v *Vec) Value(labelValues ...string) float64 {
+	s := v.With(labelValues...)
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if existing, ok := v.series[s.key]; ok {
+		return existing.value
+	}
+	return 0
+}
+
+func (v *Vec) update(s *Series, fn func(float64) float64) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	existing, ok := v.series[s.key]
+	if !ok {
+		existing = &series{labelValues: s.values}
+		v.series[s.key] = existing
+	}
+	existing.value = fn(existing.value)
+}
+
+func (v *Vec) writeText(sb *strings.Builder) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	fmt.Fprintf(sb, "# HELP %s %s\n", v.name, escapeHelp(v.help))
+	fmt.Fprintf(sb, "# TYPE %s %s\n", v.name, v.typ)
+
+	keys := make([]string, 0, len(v.series))
+	for k := range v.series {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		s := v.series[k]
+		sb.WriteString(v.name)
+		if len(v.labels) > 0 {
+			sb.WriteByte('{')
+			for i, name := range v.labels {
+				if i > 0 {
+					sb.WriteByte(',')
+				}
+				fmt.Fprintf(sb, "%s=\"%s\"", name, escapeLabel(s.labelValues[i]))
+			}
+			sb.WriteByte('}')
+		}
+		sb.WriteByte(' ')
+		sb.WriteString(formatValue(s.value))
+		sb.WriteByte('\n')
+	}
+}
+
+// Series is a handle to one labeled series within a Vec.
+type Series struct {
+	vec    *Vec
+	key    string
+	values []string
+}
+
+// Set sets a gauge value.
+func (s *Series) Set(value float64) {
+	s.vec.update(s, func(float64) float64 { return value })
+}
+
+// Add adds delta to the value. Counters should only be given non-negative deltas.
+func (s *Series) Add(delta float64) {
+	s.vec.update(s, func(old float64) float64 { return old + delta })
+}
+
+// Inc adds one.
+func (s *Series) Inc() {
+	s.Add(1)
+}
+
+// formatValue renders a float the way Prometheus expects.
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+var (
+	labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+)
+
+func escapeLabel(s string) string { return labelEscaper.Replace(s) }
+func escapeHelp(s string) string  { return helpEscaper.Replace(s) }
@@ -0,0 +1,97 @@
+package metrics
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWriteText(t *testing.T) {
+	r := NewRegistry()
+	depth := r.NewGauge("gastown_merge_queue_depth", "MRs waiting in the merge queue.", "rig")
+	outcomes := r.NewCounter("gastown_mr_outcomes_total", "MR processing outcomes.", "rig", "outcome")
+	loops := r.NewCounter("gastown_daemon_heartbeats_total", "Completed heartbeats.")
+
+	depth.With("gastown").Set(3)
+	depth.With("beads").Set(0)
+	outcomes.With("gastown", "merged").Inc()
+	outcomes.With("gastown", "merged").Add(2)
+	loops.With().Inc()
+
+	var sb strings.Builder
+	if err := r.WriteText(&sb); err != nil {
+		t.Fatalf("WriteText: %v", err)
+	}
+
+	want := `# HELP gastown_merge_queue_depth MRs waiting in the merge queue.
+# TYPE gastown_merge_queue_depth gauge
+gastown_merge_queue_depth{rig="beads"} 0
+gastown_merge_queue_depth{rig="gastown"} 3
+# HELP gastown_mr_outcomes_total MR processing outcomes.
+# TYPE gastown_mr_outcomes_total counter
+gastown_mr_outcomes_total{rig="gastown",outcome="merged"} 3
+# HELP gastown_daemon_heartbeats_total Completed heartbeats.
+# TYPE gastown_daemon_heartbeats_total counter
+gastown_daemon_heartbeats_total 1
+`
+	if sb.String() != want {
+		t.Errorf("WriteText mismatch\ngot:\n%s\nwant:\n%s", sb.String(), want)
+	}
+}
+
+func TestLabelEscaping(t *testing.T) {
+	r := NewRegistry()
+	g := r.NewGauge("x", "help with \\ backslash", "agent")
+	g.With("a\"b\\c\nd").Set(1)
+
+	var sb strings.Builder
+	_ = r.WriteText(&sb)
+	if !strings.Contains(sb.String(), `x{agent="a\"b\\c\nd"} 1`) {
+		t.Errorf("label not escaped:\n%s", sb.String())
+	}
+	if !strings.Contains(sb.String(), `# HELP x help with \\ backslash`) {
+		t.Errorf("help not escaped:\n%s", sb.String())
+	}
+}
+
+func TestResetAndCollectors(t *testing.T) {
+	r := NewRegistry()
+	g := r.NewGauge("active", "Active things.", "rig")
+	g.With("old").Set(5)
+
+	calls := 0
+	r.OnCollect(func() {
+		calls++
+		g.Reset()
+		g.With("new").Set(1)
+	})
+
+	var sb strings.Builder
+	_ = r.WriteText(&sb)
+	if calls != 1 {
+		t.Errorf("collector ran %d times, want 1", calls)
+	}
+	if strings.Contains(sb.String(), `rig="old"`) {
+		t.Errorf("stale series survived Reset:\n%s", sb.String())
+	}
+	if g.Value("new") != 1 {
+		t.Errorf("Value(new) = %v, want 1", g.Value("new"))
+	}
+}
+
+func TestHandler(t *testing.T) {
+	r := NewRegistry()
+	r.NewGauge("up", "Daemon is up.").With().Set(1)
+
+	rec := httptest.NewRecorder()
+	r.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+	if ct := rec.Header().Get("Content-Type"); ct != ContentType {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	body, _ := io.ReadAll(rec.Body)
+	if !strings.Contains(string(body), "up 1\n") {
+		t.Errorf("body missing sample:\n%s", body)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 )
@@ -22,6 +23,12 @@ const (
 	EventMergeFailed EventType = "merge_failed"
 	// EventMergeSkipped indicates an MR was skipped (already merged, etc.).
 	EventMergeSkipped EventType = "merge_skipped"
+	// EventRestacked indicates a stacked MR was moved onto its parent's
+	// target after the parent merged.
+	EventRestacked EventType = "restacked"
+	// EventReverted indicates a merged MR was reverted because the target
+	// failed its post-merge check.
+	EventReverted EventType = "reverted"
 )
 
 // Event represents a single MQ lifecycle event.
@@ -34,8 +41,9 @@ type Event struct {
 	Worker      string    `json:"worker,omitempty"`
 	SourceIssue string    `json:"source_issue,omitempty"`
 	Rig         string    `json:"rig,omitempty"`
-	MergeCommit string    `json:"merge_commit,omitempty"` // For merged events
+	MergeCommit string    `json:"merge_commit,omitempty"` // For merged events (the revert commit for reverted events)
 	Reason      string    `json:"reason,omitempty"`       // For failed/skipped events
+	FailureType string    `json:"failure_type,omitempty"` // For failed events (conflict, tests, push, ...)
 }
 
 // EventLogger handles writing MQ events to the event log.
@@ -119,7 +127,8 @@ func (l *EventLogger) LogMerged(mr *MR, mergeCommit string) error {
 }
 
 // LogMergeFailed logs a merge_failed event.
-func (l *EventLogger) LogMergeFailed(mr *MR, reason string) error {
+// failureType categorizes the failure (e.g., "conflict", "tests", "push").
+func (l *EventLogger) LogMergeFailed(mr *MR, failureType, reason string) error {
 	return l.LogEvent(Event{
 		Type:        EventMergeFailed,
 		MRID:        mr.ID,
@@ -129,6 +138,7 @@ func (l *EventLogger) LogMergeFailed(mr *MR, reason string) error {
 		SourceIssue: mr.SourceIssue,
 		Rig:         mr.Rig,
 		Reason:      reason,
+		FailureType: failureType,
 	})
 }
 
@@ -146,7 +156,65 @@ func (l *EventLogger) LogMergeSkipped(mr *MR, reason string) error {
 	})
 }
 
+// LogRestacked logs a restacked event for a child of the merged parent.
+func (l *EventLogger) LogRestacked(mr *MR, parentID string) error {
+	return l.LogEvent(Event{
+		Type:        EventRestacked,
+		MRID:        mr.ID,
+		Branch:      mr.Branch,
+		Target:      mr.Target,
+		Worker:      mr.Worker,
+		SourceIssue: mr.SourceIssue,
+		Rig:         mr.Rig,
+		Reason:      fmt.Sprintf("parent %s merged", parentID),
+	})
+}
+
+// LogReverted logs a reverted event for a merge that broke its target.
+// merged is the MR's merged event; revertCommit is the revert's SHA.
+func (l *EventLogger) LogReverted(merged Event, revertCommit, reason string) error {
+	return l.LogEvent(Event{
+		Type:        EventReverted,
+		MRID:        merged.MRID,
+		Branch:      merged.Branch,
+		Target:      merged.Target,
+		Worker:      merged.Worker,
+		SourceIssue: merged.SourceIssue,
+		Rig:         merged.Rig,
+		MergeCommit: revertCommit,
+		Reason:      reason,
+	})
+}
+
 // LogPath returns the path to the event log file.
 func (l *EventLogger) LogPath() string {
 	return l.logPath
 }
+
+// ReadEvents reads all events from the MQ event log.
+// Malformed lines are skipped. Returns nil if the log doesn't exist yet.
+func (l *EventLogger) ReadEvents() ([]Event, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	data, err := os.ReadFile(l.logPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading event log: %w", err)
+	}
+
+	var events []Event
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		var e Event
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			continue // Skip malformed lines
+		}
+		events = append(events, e)
+	}
+	return events, nil
+}
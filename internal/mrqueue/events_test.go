@@ -44,7 +44,7 @@ func TestEventLogger(t *testing.T) {
 	}
 
 	// Log merge_failed
-	if err := logger.LogMergeFailed(mr, "conflict in file.go"); err != nil {
+	if err := logger.LogMergeFailed(mr, "conflict", "conflict in file.go"); err != nil {
 		t.Errorf("LogMergeFailed failed: %v", err)
 	}
 
@@ -89,6 +89,10 @@ func TestEventLogger(t *testing.T) {
 			t.Errorf("Event %d: expected branch %s, got %s", i, mr.Branch, event.Branch)
 		}
 
+		if event.Type == EventMergeFailed && event.FailureType != "conflict" {
+			t.Errorf("Event %d: expected failure type conflict, got %q", i, event.FailureType)
+		}
+
 		// Check timestamp is recent
 		if time.Since(event.Timestamp) > time.Minute {
 			t.Errorf("Event %d: timestamp too old: %v", i, event.Timestamp)
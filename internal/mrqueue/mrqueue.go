@@ -39,6 +39,14 @@ type MR struct {
 
 	// Blocking fields for non-blocking delegation
 	BlockedBy string `json:"blocked_by,omitempty"` // Task ID that blocks this MR (e.g., conflict resolution task)
+
+	// ParentMR is the MR this one is stacked on: its branch builds on the
+	// parent's unmerged branch, so it merges only after the parent does.
+	ParentMR string `json:"parent_mr,omitempty"`
+
+	// TraceParent is the W3C traceparent of the submitting span, so the
+	// refinery can continue the sling-to-merge trace.
+	TraceParent string `json:"trace_parent,omitempty"`
 }
 
 // Queue manages the MR storage.
@@ -410,6 +418,7 @@ type BeadStatusChecker func(beadID string) (isOpen bool, err error)
 // ListReady returns MRs that are ready for processing:
 // - Not claimed by another worker (or claim is stale)
 // - Not blocked by an open task
+// - Not stacked on an MR that is still queued
 // Sorted by priority score (highest first).
 // The checkStatus function is used to check if blocking tasks are still open.
 func (q *Queue) ListReady(checkStatus BeadStatusChecker) ([]*MR, error) {
@@ -438,13 +447,19 @@ func (q *Queue) ListReady(checkStatus BeadStatusChecker) ([]*MR, error) {
 			// If error or task closed, proceed (fail open)
 		}
 
+		// Skip if stacked on an MR that hasn't merged yet
+		if q.IsStackedOnQueued(mr) {
+			continue
+		}
+
 		ready = append(ready, mr)
 	}
 
 	return ready, nil
 }
 
-// ListBlocked returns MRs that are blocked by open tasks.
+// ListBlocked returns MRs that are blocked by open tasks or stacked on
+// MRs that are still queued.
 // Useful for reporting/monitoring.
 func (q *Queue) ListBlocked(checkStatus BeadStatusChecker) ([]*MR, error) {
 	all, err := q.List()
@@ -454,6 +469,10 @@ func (q *Queue) ListBlocked(checkStatus BeadStatusChecker) ([]*MR, error) {
 
 	var blocked []*MR
 	for _, mr := range all {
+		if q.IsStackedOnQueued(mr) {
+			blocked = append(blocked, mr)
+			continue
+		}
 		if mr.BlockedBy == "" {
 			continue
 		}
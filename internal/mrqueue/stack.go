@@ -0,0 +1,237 @@
+package mrqueue
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// Stacked merge requests.
+//
+// A polecat that builds on another polecat's unmerged branch submits its MR
+// with ParentMR set. The stack merges in order: a child is not ready while
+// its parent is still queued, and once the parent merges the refinery
+// retargets the children at the parent's target and rebases them. Removing
+// an MR without merging it (rejection) takes its whole stack with it, since
+// the children carry the parent's commits.
+
+// ErrStackCycle is returned when stacking an MR would make it its own ancestor.
+var ErrStackCycle = errors.New("merge request stack would contain a cycle")
+
+// StackNode is an MR and the MRs stacked on it.
+type StackNode struct {
+	MR       *MR
+	Children []*StackNode
+}
+
+// BuildStacks arranges mrs into stacks. MRs whose parent is not in mrs are
+// roots. Roots keep the order of mrs; children are ordered by creation time.
+func BuildStacks(mrs []*MR) []*StackNode {
+	roots, _ := buildStacks(mrs)
+	return roots
+}
+
+// buildStacks returns the stack roots and the node of every MR.
+func buildStacks(mrs []*MR) ([]*StackNode, map[string]*StackNode) {
+	nodes := make(map[string]*StackNode, len(mrs))
+	for _, mr := range mrs {
+		nodes[mr.ID] = &StackNode{MR: mr}
+	}
+
+	var roots []*StackNode
+	for _, mr := range mrs {
+		node := nodes[mr.ID]
+		parent, ok := nodes[mr.ParentMR]
+		if !ok || mr.ParentMR == mr.ID {
+			roots = append(roots, node)
+			continue
+		}
+		parent.Children = append(parent.Children, node)
+	}
+
+	for _, node := range nodes {
+		sort.SliceStable(node.Children, func(i, j int) bool {
+			return node.Children[i].MR.CreatedAt.Before(node.Children[j].MR.CreatedAt)
+		})
+	}
+
+	// A corrupt queue may hold a parent cycle, which no root reaches.
+	// Break it so every MR is still listed.
+	reached := make(map[string]bool, len(mrs))
+	for _, root := range roots {
+		root.Walk(func(mr *MR, _ int) { reached[mr.ID] = true })
+	}
+	for _, mr := range mrs {
+		if reached[mr.ID] {
+			continue
+		}
+		node := nodes[mr.ID]
+		parent := nodes[mr.ParentMR]
+		for i, c := range parent.Children {
+			if c == node {
+				parent.Children = append(parent.Children[:i], parent.Children[i+1:]...)
+				break
+			}
+		}
+		roots = append(roots, node)
+		node.Walk(func(mr *MR, _ int) { reached[mr.ID] = true })
+	}
+	return roots, nodes
+}
+
+// Walk visits the node and its descendants parent-first, with the depth
+// below the node.
+func (n *StackNode) Walk(fn func(mr *MR, depth int)) {
+	n.walk(fn, 0)
+}
+
+func (n *StackNode) walk(fn func(mr *MR, depth int), depth int) {
+	fn(n.MR, depth)
+	for _, child := range n.Children {
+		child.walk(fn, depth+1)
+	}
+}
+
+// SetParent stacks an MR on parentID. An empty parentID unstacks it.
+// The parent must be queued and must not be stacked on the MR itself.
+func (q *Queue) SetParent(mrID, parentID string) error {
+	mr, err := q.Get(mrID)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("loading MR: %w", err)
+	}
+
+	if parentID != "" {
+		all, err := q.List()
+		if err != nil {
+			return err
+		}
+		byID := make(map[string]*MR, len(all))
+		for _, m := range all {
+			byID[m.ID] = m
+		}
+		if _, ok := byID[parentID]; !ok {
+			return fmt.Errorf("parent %s: %w", parentID, ErrNotFound)
+		}
+		seen := make(map[string]bool)
+		for id := parentID; id != "" && !seen[id]; id = byID[id].ParentMR {
+			if id == mrID {
+				return ErrStackCycle
+			}
+			if _, ok := byID[id]; !ok {
+				break
+			}
+			seen[id] = true
+		}
+	}
+
+	mr.ParentMR = parentID
+	return q.save(mr)
+}
+
+// Children returns the MRs stacked directly on id, oldest first.
+func (q *Queue) Children(id string) ([]*MR, error) {
+	all, err := q.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var children []*MR
+	for _, mr := range all {
+		if mr.ParentMR == id && mr.ID != id {
+			children = append(children, mr)
+		}
+	}
+	sort.SliceStable(children, func(i, j int) bool {
+		return children[i].CreatedAt.Before(children[j].CreatedAt)
+	})
+	return children, nil
+}
+
+// Stack returns the MR and everything stacked on it, parent-first.
+func (q *Queue) Stack(id string) ([]*MR, error) {
+	all, err := q.List()
+	if err != nil {
+		return nil, err
+	}
+
+	_, nodes := buildStacks(all)
+	node, ok := nodes[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	var stack []*MR
+	node.Walk(func(mr *MR, _ int) { stack = append(stack, mr) })
+	return stack, nil
+}
+
+// IsStackedOnQueued reports whether the MR's parent is still in the queue,
+// which means the MR must wait for it to merge.
+func (q *Queue) IsStackedOnQueued(mr *MR) bool {
+	if mr.ParentMR == "" || mr.ParentMR == mr.ID {
+		return false
+	}
+	_, err := os.Stat(filepath.Join(q.dir, mr.ParentMR+".json"))
+	return err == nil
+}
+
+// Restack moves the MRs stacked on a merged parent onto the parent's
+// target: each child's parent becomes the merged MR's own parent (usually
+// none) and its target becomes the parent's target. Returns the updated
+// children so the caller can rebase their branches.
+func (q *Queue) Restack(parent *MR) ([]*MR, error) {
+	children, err := q.Children(parent.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, child := range children {
+		child.ParentMR = parent.ParentMR
+		if parent.Target != "" {
+			child.Target = parent.Target
+		}
+		if err := q.save(child); err != nil {
+			return nil, fmt.Errorf("restacking %s: %w", child.ID, err)
+		}
+	}
+	return children, nil
+}
+
+// RemoveStack removes an MR and everything stacked on it, for when the MR
+// leaves the queue without merging. Returns the removed MRs, parent-first.
+func (q *Queue) RemoveStack(id string) ([]*MR, error) {
+	stack, err := q.Stack(id)
+	if err != nil {
+		return nil, err
+	}
+	for _, mr := range stack {
+		if err := q.Remove(mr.ID); err != nil {
+			return nil, fmt.Errorf("removing %s: %w", mr.ID, err)
+		}
+	}
+	return stack, nil
+}
+
+// save writes an MR back to its queue file atomically.
+func (q *Queue) save(mr *MR) error {
+	data, err := json.MarshalIndent(mr, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling MR: %w", err)
+	}
+
+	path := filepath.Join(q.dir, mr.ID+".json")
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("writing temp file: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("renaming temp file: %w", err)
+	}
+	return nil
+}
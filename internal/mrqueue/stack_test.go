@@ -0,0 +1,147 @@
+package mrqueue
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// submitStack queues root <- mid <- leaf plus an unrelated MR.
+func submitStack(t *testing.T) *Queue {
+	t.Helper()
+	q := New(t.TempDir())
+	base := time.Now().Add(-time.Hour)
+	for i, mr := range []*MR{
+		{ID: "mr-root", Branch: "polecat/a", Target: "main"},
+		{ID: "mr-mid", Branch: "polecat/b", Target: "main", ParentMR: "mr-root"},
+		{ID: "mr-leaf", Branch: "polecat/c", Target: "main", ParentMR: "mr-mid"},
+		{ID: "mr-solo", Branch: "polecat/d", Target: "main"},
+	} {
+		mr.CreatedAt = base.Add(time.Duration(i) * time.Minute)
+		if err := q.Submit(mr); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return q
+}
+
+func ids(mrs []*MR) []string {
+	var out []string
+	for _, mr := range mrs {
+		out = append(out, mr.ID)
+	}
+	return out
+}
+
+func TestBuildStacks(t *testing.T) {
+	mrs := []*MR{
+		{ID: "c", ParentMR: "a", CreatedAt: time.Now()},
+		{ID: "a"},
+		{ID: "b", ParentMR: "a", CreatedAt: time.Now().Add(-time.Hour)},
+		{ID: "orphan", ParentMR: "merged-already"},
+		{ID: "x", ParentMR: "y"},
+		{ID: "y", ParentMR: "x"},
+	}
+	roots := BuildStacks(mrs)
+
+	var got []string
+	for _, root := range roots {
+		root.Walk(func(mr *MR, depth int) {
+			got = append(got, fmt.Sprintf("%s:%d", mr.ID, depth))
+		})
+	}
+	want := []string{"a:0", "b:1", "c:1", "orphan:0", "x:0", "y:1"}
+	if len(got) != len(want) {
+		t.Fatalf("walk = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("walk = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestQueueSetParent(t *testing.T) {
+	q := submitStack(t)
+
+	if err := q.SetParent("mr-root", "mr-leaf"); !errors.Is(err, ErrStackCycle) {
+		t.Errorf("SetParent(cycle) = %v, want ErrStackCycle", err)
+	}
+	if err := q.SetParent("mr-solo", "mr-gone"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("SetParent(missing parent) = %v, want ErrNotFound", err)
+	}
+	if err := q.SetParent("mr-solo", "mr-leaf"); err != nil {
+		t.Fatalf("SetParent() = %v", err)
+	}
+	if mr, _ := q.Get("mr-solo"); mr.ParentMR != "mr-leaf" {
+		t.Errorf("ParentMR = %q, want mr-leaf", mr.ParentMR)
+	}
+
+	stack, err := q.Stack("mr-root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ids(stack); len(got) != 4 || got[0] != "mr-root" || got[3] != "mr-solo" {
+		t.Errorf("Stack(mr-root) = %v, want root first and solo last", got)
+	}
+}
+
+func TestQueueStackGatesReady(t *testing.T) {
+	q := submitStack(t)
+
+	ready, err := q.ListReady(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ids(ready); len(got) != 2 {
+		t.Fatalf("ListReady() = %v, want only the stack root and mr-solo", got)
+	}
+	blocked, err := q.ListBlocked(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ids(blocked); len(got) != 2 {
+		t.Errorf("ListBlocked() = %v, want mr-mid and mr-leaf", got)
+	}
+
+	// The root merges: its child moves up and becomes ready, the leaf waits.
+	root, _ := q.Get("mr-root")
+	root.Target = "integration/gt-epic"
+	if err := q.Remove(root.ID); err != nil {
+		t.Fatal(err)
+	}
+	children, err := q.Restack(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(children) != 1 || children[0].ID != "mr-mid" {
+		t.Fatalf("Restack() = %v, want mr-mid", ids(children))
+	}
+	mid, _ := q.Get("mr-mid")
+	if mid.ParentMR != "" || mid.Target != "integration/gt-epic" {
+		t.Errorf("restacked MR = parent %q target %q, want no parent and the root's target", mid.ParentMR, mid.Target)
+	}
+	ready, _ = q.ListReady(nil)
+	if got := ids(ready); len(got) != 2 || (got[0] != "mr-mid" && got[1] != "mr-mid") {
+		t.Errorf("ListReady() after restack = %v, want mr-mid ready", got)
+	}
+}
+
+func TestQueueRemoveStack(t *testing.T) {
+	q := submitStack(t)
+
+	removed, err := q.RemoveStack("mr-mid")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ids(removed); len(got) != 2 || got[0] != "mr-mid" || got[1] != "mr-leaf" {
+		t.Errorf("RemoveStack() = %v, want [mr-mid mr-leaf]", got)
+	}
+	if q.Count() != 2 {
+		t.Errorf("Count() = %d after removing the stack, want 2", q.Count())
+	}
+	if _, err := q.RemoveStack("mr-mid"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("RemoveStack(removed) = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,365 @@
+// Package notify delivers outbound notifications about escalations and
+// convoy events to humans outside tmux: generic webhooks (HMAC-signed),
+// Slack-compatible incoming webhooks, SMTP email, and desktop notify-send.
+//
+// Sinks and routing rules live in town settings (config.NotificationsConfig).
+// Delivery is best-effort: failures are retried with backoff and reported to
+// the caller. gt commands deliver from a detached gt notify deliver process,
+// so the retries don't hold up the operation that raised the notification.
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// Event types raised by Gas Town.
+const (
+	EventEscalation    = "escalation"
+	EventConvoyCreated = "convoy_created"
+	EventConvoyLanded  = "convoy_landed"
+	EventTest          = "test"
+)
+
+// Severity orders notifications by urgency.
+type Severity string
+
+// Severity levels, lowest to highest.
+const (
+	SeverityLow      Severity = "low"
+	SeverityMedium   Severity = "medium"
+	SeverityHigh     Severity = "high"
+	SeverityCritical Severity = "critical"
+)
+
+// rank returns the ordering of a severity (unknown values rank as medium).
+func (s Severity) rank() int {
+	switch s {
+	case SeverityLow:
+		return 0
+	case SeverityHigh:
+		return 2
+	case SeverityCritical:
+		return 3
+	default:
+		return 1
+	}
+}
+
+// AtLeast reports whether s is at least as severe as min.
+func (s Severity) AtLeast(min Severity) bool {
+	return s.rank() >= min.rank()
+}
+
+// ParseSeverity parses a severity name case-insensitively.
+func ParseSeverity(s string) (Severity, error) {
+	switch sev := Severity(strings.ToLower(strings.TrimSpace(s))); sev {
+	case SeverityLow, SeverityMedium, SeverityHigh, SeverityCritical:
+		return sev, nil
+	default:
+		return "", fmt.Errorf("invalid severity %q: use low, medium, high, or critical", s)
+	}
+}
+
+// Notification is a single outbound message. It is also the JSON body
+// posted to generic webhook sinks.
+type Notification struct {
+	Event    string    `json:"event"`
+	Severity Severity  `json:"severity"`
+	Title    string    `json:"title"`
+	Body     string    `json:"body,omitempty"`
+	Rig      string    `json:"rig,omitempty"`
+	Source   string    `json:"source,omitempty"` // Agent that raised it
+	Bead     string    `json:"bead,omitempty"`
+	Convoy   string    `json:"convoy,omitempty"`
+	Time     time.Time `json:"time"`
+}
+
+// Notification levels, matching beads.Notify* (gt notify / gt dnd).
+const (
+	LevelVerbose = "verbose"
+	LevelNormal  = "normal"
+	LevelMuted   = "muted"
+)
+
+// allowedAtLevel reports whether a notification passes the recipient's
+// notification level. Verbose receives everything, normal drops low-severity
+// status chatter, and muted (DND) only lets critical notifications through.
+func allowedAtLevel(level string, sev Severity) bool {
+	switch level {
+	case LevelVerbose:
+		return true
+	case LevelMuted:
+		return sev == SeverityCritical
+	default:
+		return sev.AtLeast(SeverityMedium)
+	}
+}
+
+// Delivery records the outcome for one sink.
+type Delivery struct {
+	Sink     string
+	Attempts int
+	Skipped  string // Reason delivery was skipped (rate limited, ...)
+	Err      error
+}
+
+// Dispatcher routes notifications to sinks.
+type Dispatcher struct {
+	sinks   map[string]Sink
+	order   []string
+	routes  []config.NotificationRoute
+	limiter *RateLimiter
+	retries int
+	backoff time.Duration
+	level   string
+
+	// sleep is replaced in tests to avoid real backoff delays.
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// Default retry policy.
+const (
+	DefaultRetries      = 2
+	DefaultRetryBackoff = time.Second
+)
+
+// New builds a dispatcher from configuration. Rate-limit state is kept
+// under <townRoot>/.runtime so limits hold across gt invocations; an empty
+// townRoot keeps it in memory only.
+func New(cfg *config.NotificationsConfig, townRoot string) (*Dispatcher, error) {
+	d := &Dispatcher{
+		sinks:   make(map[string]Sink),
+		retries: DefaultRetries,
+		backoff: DefaultRetryBackoff,
+		level:   LevelNormal,
+		sleep:   sleepContext,
+	}
+	if cfg == nil {
+		return d, nil
+	}
+
+	for _, sc := range cfg.Sinks {
+		if sc.Name == "" {
+			return nil, fmt.Errorf("notification sink missing name")
+		}
+		if _, dup := d.sinks[sc.Name]; dup {
+			return nil, fmt.Errorf("duplicate notification sink %q", sc.Name)
+		}
+		sink, err := NewSink(sc)
+		if err != nil {
+			return nil, fmt.Errorf("sink %q: %w", sc.Name, err)
+		}
+		d.sinks[sc.Name] = sink
+		d.order = append(d.order, sc.Name)
+	}
+
+	for i, r := range cfg.Routes {
+		for _, name := range r.Sinks {
+			if _, ok := d.sinks[name]; !ok {
+				return nil, fmt.Errorf("route %d references unknown sink %q", i, name)
+			}
+		}
+		if r.MinSeverity != "" {
+			if _, err := ParseSeverity(r.MinSeverity); err != nil {
+				return nil, fmt.Errorf("route %d: %w", i, err)
+			}
+		}
+	}
+	d.routes = cfg.Routes
+
+	if cfg.Retries != nil {
+		if *cfg.Retries < 0 {
+			return nil, fmt.Errorf("retries must be >= 0")
+		}
+		d.retries = *cfg.Retries
+	}
+	if cfg.RetryBackoff != "" {
+		backoff, err := time.ParseDuration(cfg.RetryBackoff)
+		if err != nil {
+			return nil, fmt.Errorf("invalid retry_backoff: %w", err)
+		}
+		d.backoff = backoff
+	}
+
+	if cfg.RateLimitPerMinute > 0 {
+		statePath := ""
+		if townRoot != "" {
+			statePath = filepath.Join(townRoot, ".runtime", "notify-ratelimit.json")
+		}
+		d.limiter = NewRateLimiter(cfg.RateLimitPerMinute, time.Minute, statePath)
+	}
+
+	return d, nil
+}
+
+// Load builds a dispatcher from the town's settings/config.json.
+// Returns a dispatcher with no sinks if notifications aren't configured.
+func Load(townRoot string) (*Dispatcher, error) {
+	settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot))
+	if err != nil {
+		return nil, fmt.Errorf("loading town settings: %w", err)
+	}
+	return New(settings.Notifications, townRoot)
+}
+
+// SetLevel sets the recipient's notification level (verbose, normal, muted).
+func (d *Dispatcher) SetLevel(level string) {
+	if level == "" {
+		level = LevelNormal
+	}
+	d.level = level
+}
+
+// HasSinks reports whether any sinks are configured.
+func (d *Dispatcher) HasSinks() bool {
+	return len(d.sinks) > 0
+}
+
+// Route returns the sink names a notification would be delivered to,
+// in configuration order.
+func (d *Dispatcher) Route(n Notification) []string {
+	if len(d.routes) == 0 {
+		return append([]string(nil), d.order...)
+	}
+
+	selected := make(map[string]bool)
+	for _, r := range d.routes {
+		if routeMatches(r, n) {
+			for _, name := range r.Sinks {
+				selected[name] = true
+			}
+		}
+	}
+
+	var names []string
+	for _, name := range d.order {
+		if selected[name] {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func routeMatches(r config.NotificationRoute, n Notification) bool {
+	if len(r.Events) > 0 && !containsFold(r.Events, n.Event) {
+		return false
+	}
+	if len(r.Rigs) > 0 && !containsFold(r.Rigs, n.Rig) {
+		return false
+	}
+	if r.MinSeverity != "" {
+		min, _ := ParseSeverity(r.MinSeverity)
+		if !n.Severity.AtLeast(min) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// Send delivers n to every routed sink. Notifications below the recipient's
+// level are dropped without error. The returned error joins all sink failures.
+func (d *Dispatcher) Send(ctx context.Context, n Notification) ([]Delivery, error) {
+	if n.Time.IsZero() {
+		n.Time = time.Now().UTC()
+	}
+	if n.Severity == "" {
+		n.Severity = SeverityMedium
+	}
+	if !allowedAtLevel(d.level, n.Severity) {
+		return nil, nil
+	}
+	return d.sendTo(ctx, n, d.Route(n))
+}
+
+// SendAll delivers n to every configured sink regardless of routes.
+// Used by gt notify --test to check each endpoint.
+func (d *Dispatcher) SendAll(ctx context.Context, n Notification) ([]Delivery, error) {
+	if n.Time.IsZero() {
+		n.Time = time.Now().UTC()
+	}
+	return d.sendTo(ctx, n, d.order)
+}
+
+func (d *Dispatcher) sendTo(ctx context.Context, n Notification, names []string) ([]Delivery, error) {
+	var deliveries []Delivery
+	var errs []error
+	for _, name := range names {
+		del := Delivery{Sink: name}
+
+		if d.limiter != nil && n.Severity != SeverityCritical && !d.limiter.Allow(name) {
+			del.Skipped = "rate limited"
+			deliveries = append(deliveries, del)
+			continue
+		}
+
+		del.Attempts, del.Err = d.deliver(ctx, d.sinks[name], n)
+		if del.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, del.Err))
+		}
+		deliveries = append(deliveries, del)
+	}
+
+	return deliveries, errors.Join(errs...)
+}
+
+// deliver sends to one sink, retrying transient failures with
+// exponential backoff.
+func (d *Dispatcher) deliver(ctx context.Context, sink Sink, n Notification) (int, error) {
+	backoff := d.backoff
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = sink.Send(ctx, n)
+		if err == nil || attempt > d.retries || isPermanent(err) {
+			return attempt, err
+		}
+		if d.sleep(ctx, backoff) != nil {
+			return attempt, err
+		}
+		backoff *= 2
+	}
+}
+
+// sleepContext waits for d, returning early with ctx's error if it is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// permanentError marks failures that retrying cannot fix (bad request,
+// auth rejected, misconfiguration).
+type permanentError struct{ err error }
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// permanent wraps err so the dispatcher does not retry it.
+func permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+func isPermanent(err error) bool {
+	var pe *permanentError
+	return errors.As(err, &pe)
+}
@@ -0,0 +1,388 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// standIn is a local HTTP endpoint recording requests and replying with
+// a scripted sequence of status codes (200 once the script runs out).
+type standIn struct {
+	*httptest.Server
+	mu       sync.Mutex
+	statuses []int
+	bodies   [][]byte
+	headers  []http.Header
+}
+
+func newStandIn(t *testing.T, statuses ...int) *standIn {
+	t.Helper()
+	s := &standIn{statuses: statuses}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		s.mu.Lock()
+		s.bodies = append(s.bodies, body)
+		s.headers = append(s.headers, r.Header.Clone())
+		status := http.StatusOK
+		if len(s.statuses) > 0 {
+			status, s.statuses = s.statuses[0], s.statuses[1:]
+		}
+		s.mu.Unlock()
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *standIn) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.bodies)
+}
+
+func intPtr(i int) *int { return &i }
+
+func newTestDispatcher(t *testing.T, cfg *config.NotificationsConfig) *Dispatcher {
+	t.Helper()
+	d, err := New(cfg, "")
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	d.sleep = func(context.Context, time.Duration) error { return nil }
+	return d
+}
+
+func TestWebhookSink_SignsBody(t *testing.T) {
+	srv := newStandIn(t)
+	t.Setenv("GT_TEST_HOOK_SECRET", "s3cret")
+
+	d := newTestDispatcher(t, &config.NotificationsConfig{
+		Sinks: []config.NotificationSinkConfig{
+			{Name: "hook", Type: config.NotifySinkWebhook, URL: srv.URL, SecretEnv: "GT_TEST_HOOK_SECRET",
+				Headers: map[string]string{"X-Team": "core"}},
+		},
+	})
+
+	n := Notification{Event: EventEscalation, Severity: SeverityHigh, Title: "Merge conflict", Bead: "gt-abc"}
+	if _, err := d.Send(context.Background(), n); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	if srv.count() != 1 {
+		t.Fatalf("got %d requests, want 1", srv.count())
+	}
+	h, body := srv.headers[0], srv.bodies[0]
+	if got, want := h.Get(HeaderSignature), Sign("s3cret", h.Get(HeaderTimestamp), body); got != want {
+		t.Errorf("signature = %q, want %q", got, want)
+	}
+	if h.Get(HeaderEvent) != EventEscalation || h.Get("X-Team") != "core" {
+		t.Errorf("headers = %v", h)
+	}
+
+	var got Notification
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatalf("decoding body: %v", err)
+	}
+	if got.Title != "Merge conflict" || got.Bead != "gt-abc" || got.Time.IsZero() {
+		t.Errorf("body = %+v", got)
+	}
+}
+
+func TestSlackSink_Text(t *testing.T) {
+	srv := newStandIn(t)
+	d := newTestDispatcher(t, &config.NotificationsConfig{
+		Sinks: []config.NotificationSinkConfig{{Name: "chat", Type: config.NotifySinkSlack, URL: srv.URL}},
+	})
+
+	n := Notification{Event: EventConvoyLanded, Title: "Convoy landed: Release prep", Convoy: "hq-cv-1", Body: "All tracked issues closed."}
+	if _, err := d.Send(context.Background(), n); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	var msg map[string]string
+	if err := json.Unmarshal(srv.bodies[0], &msg); err != nil {
+		t.Fatalf("decoding body: %v", err)
+	}
+	want := "📢 *Convoy landed: Release prep* (hq-cv-1)\nAll tracked issues closed."
+	if msg["text"] != want {
+		t.Errorf("text = %q, want %q", msg["text"], want)
+	}
+}
+
+func TestDispatcher_RetriesTransientFailures(t *testing.T) {
+	srv := newStandIn(t, http.StatusBadGateway, http.StatusServiceUnavailable)
+	d := newTestDispatcher(t, &config.NotificationsConfig{
+		Sinks:   []config.NotificationSinkConfig{{Name: "hook", Type: config.NotifySinkWebhook, URL: srv.URL}},
+		Retries: intPtr(2),
+	})
+
+	dels, err := d.Send(context.Background(), Notification{Event: EventEscalation, Title: "x"})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if dels[0].Attempts != 3 || srv.count() != 3 {
+		t.Errorf("attempts = %d, requests = %d, want 3", dels[0].Attempts, srv.count())
+	}
+}
+
+func TestDispatcher_NoRetryOnClientError(t *testing.T) {
+	srv := newStandIn(t, http.StatusUnauthorized)
+	d := newTestDispatcher(t, &config.NotificationsConfig{
+		Sinks: []config.NotificationSinkConfig{{Name: "hook", Type: config.NotifySinkWebhook, URL: srv.URL}},
+	})
+
+	dels, err := d.Send(context.Background(), Notification{Event: EventEscalation, Title: "x"})
+	if err == nil || !strings.Contains(err.Error(), "hook: ") {
+		t.Fatalf("err = %v, want hook failure", err)
+	}
+	if dels[0].Attempts != 1 {
+		t.Errorf("attempts = %d, want 1", dels[0].Attempts)
+	}
+}
+
+func TestDispatcher_BackoffStopsOnCancel(t *testing.T) {
+	srv := newStandIn(t, http.StatusBadGateway, http.StatusBadGateway)
+	d, err := New(&config.NotificationsConfig{
+		Sinks:        []config.NotificationSinkConfig{{Name: "hook", Type: config.NotifySinkWebhook, URL: srv.URL}},
+		Retries:      intPtr(2),
+		RetryBackoff: "1m",
+	}, "")
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	dels, err := d.Send(ctx, Notification{Event: EventEscalation, Title: "x"})
+	if err == nil {
+		t.Fatal("Send succeeded, want the 502")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Send took %s, want it to stop backing off once ctx is done", elapsed)
+	}
+	if dels[0].Attempts != 1 {
+		t.Errorf("attempts = %d, want 1", dels[0].Attempts)
+	}
+}
+
+func TestDispatcher_Routing(t *testing.T) {
+	ops, chat := newStandIn(t), newStandIn(t)
+	d := newTestDispatcher(t, &config.NotificationsConfig{
+		Sinks: []config.NotificationSinkConfig{
+			{Name: "ops", Type: config.NotifySinkWebhook, URL: ops.URL},
+			{Name: "chat", Type: config.NotifySinkSlack, URL: chat.URL},
+		},
+		Routes: []config.NotificationRoute{
+			{Sinks: []string{"ops"}, Events: []string{EventEscalation}, MinSeverity: "high"},
+			{Sinks: []string{"chat"}, Rigs: []string{"gastown"}},
+		},
+	})
+
+	tests := []struct {
+		name string
+		n    Notification
+		want []string
+	}{
+		{"critical escalation in gastown", Notification{Event: EventEscalation, Severity: SeverityCritical, Rig: "gastown"}, []string{"ops", "chat"}},
+		{"medium escalation elsewhere", Notification{Event: EventEscalation, Severity: SeverityMedium, Rig: "beads"}, nil},
+		{"high escalation elsewhere", Notification{Event: EventEscalation, Severity: SeverityHigh, Rig: "beads"}, []string{"ops"}},
+		{"convoy in gastown", Notification{Event: EventConvoyLanded, Severity: SeverityMedium, Rig: "gastown"}, []string{"chat"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := d.Route(tt.n)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Route = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDispatcher_HonorsNotificationLevel(t *testing.T) {
+	srv := newStandIn(t)
+	d := newTestDispatcher(t, &config.NotificationsConfig{
+		Sinks: []config.NotificationSinkConfig{{Name: "hook", Type: config.NotifySinkWebhook, URL: srv.URL}},
+	})
+
+	send := func(level string, sev Severity) {
+		d.SetLevel(level)
+		if _, err := d.Send(context.Background(), Notification{Event: EventEscalation, Severity: sev}); err != nil {
+			t.Fatalf("Send: %v", err)
+		}
+	}
+
+	send(LevelMuted, SeverityHigh)     // DND: dropped
+	send(LevelMuted, SeverityCritical) // DND: critical still delivered
+	send(LevelNormal, SeverityLow)     // normal: status chatter dropped
+	send(LevelNormal, SeverityMedium)
+	send(LevelVerbose, SeverityLow)
+
+	if srv.count() != 3 {
+		t.Errorf("delivered %d, want 3", srv.count())
+	}
+}
+
+func TestDispatcher_RateLimit(t *testing.T) {
+	srv := newStandIn(t)
+	d := newTestDispatcher(t, &config.NotificationsConfig{
+		Sinks:              []config.NotificationSinkConfig{{Name: "hook", Type: config.NotifySinkWebhook, URL: srv.URL}},
+		RateLimitPerMinute: 2,
+	})
+
+	var skipped int
+	for i := 0; i < 4; i++ {
+		dels, _ := d.Send(context.Background(), Notification{Event: EventConvoyLanded, Severity: SeverityMedium})
+		if dels[0].Skipped != "" {
+			skipped++
+		}
+	}
+	// Critical bypasses the limit
+	_, _ = d.Send(context.Background(), Notification{Event: EventEscalation, Severity: SeverityCritical})
+
+	if srv.count() != 3 || skipped != 2 {
+		t.Errorf("delivered %d, skipped %d; want 3 delivered, 2 skipped", srv.count(), skipped)
+	}
+}
+
+func TestRateLimiter_PersistsAndExpires(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".runtime", "notify-ratelimit.json")
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	first := NewRateLimiter(1, time.Minute, path)
+	first.now = func() time.Time { return now }
+	if !first.Allow("hook") {
+		t.Fatal("first send should be allowed")
+	}
+
+	// A separate process sees the persisted send
+	second := NewRateLimiter(1, time.Minute, path)
+	second.now = func() time.Time { return now.Add(30 * time.Second) }
+	if second.Allow("hook") {
+		t.Error("second send within window should be limited")
+	}
+	if !second.Allow("other") {
+		t.Error("limits are per sink")
+	}
+
+	second.now = func() time.Time { return now.Add(61 * time.Second) }
+	if !second.Allow("hook") {
+		t.Error("send after window should be allowed")
+	}
+}
+
+func TestEmailSink(t *testing.T) {
+	var gotAddr, gotFrom string
+	var gotTo []string
+	var gotMsg []byte
+	sink := &EmailSink{
+		Addr: "smtp.example.com:587", Username: "bot", Password: "pw",
+		From: "gt@example.com", To: []string{"ops@example.com"},
+		sendMail: func(_ context.Context, addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+			gotAddr, gotFrom, gotTo, gotMsg = addr, from, to, msg
+			if a == nil {
+				return errors.New("expected auth")
+			}
+			return nil
+		},
+	}
+
+	n := Notification{Event: EventEscalation, Severity: SeverityCritical, Title: "Data\ncorruption", Bead: "hq-1", Body: "line1\nline2", Time: time.Now()}
+	if err := sink.Send(context.Background(), n); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	msg := string(gotMsg)
+	if gotAddr != "smtp.example.com:587" || gotFrom != "gt@example.com" || len(gotTo) != 1 {
+		t.Errorf("envelope = %s %s %v", gotAddr, gotFrom, gotTo)
+	}
+	if !strings.Contains(msg, "Subject: [Gas Town CRITICAL] Data corruption\r\n") {
+		t.Errorf("subject not sanitized:\n%s", msg)
+	}
+	if !strings.Contains(msg, "Bead:     hq-1\r\n") || !strings.Contains(msg, "line1\r\nline2") {
+		t.Errorf("body missing fields:\n%s", msg)
+	}
+}
+
+func TestEmailSink_HonorsContext(t *testing.T) {
+	// An SMTP server that accepts connections but never sends its greeting.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		<-done
+		conn.Close()
+	}()
+
+	sink := &EmailSink{Addr: ln.Addr().String(), From: "gt@example.com", To: []string{"ops@example.com"}}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := sink.Send(ctx, Notification{Title: "t", Time: time.Now()}); err == nil {
+		t.Fatal("Send succeeded against a silent server")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Send took %s, want it to give up once ctx is done", elapsed)
+	}
+}
+
+func TestDesktopSink(t *testing.T) {
+	var args []string
+	sink := &DesktopSink{run: func(_ context.Context, name string, a ...string) error {
+		args = append([]string{name}, a...)
+		return nil
+	}}
+
+	if err := sink.Send(context.Background(), Notification{Severity: SeverityCritical, Title: "t", Body: "b"}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if strings.Join(args, " ") != "notify-send --app-name=Gas Town --urgency=critical t b" {
+		t.Errorf("args = %q", args)
+	}
+}
+
+func TestNew_ValidatesConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.NotificationsConfig
+	}{
+		{"unknown type", config.NotificationsConfig{Sinks: []config.NotificationSinkConfig{{Name: "x", Type: "pager"}}}},
+		{"webhook without url", config.NotificationsConfig{Sinks: []config.NotificationSinkConfig{{Name: "x", Type: "webhook"}}}},
+		{"duplicate name", config.NotificationsConfig{Sinks: []config.NotificationSinkConfig{
+			{Name: "x", Type: "desktop"}, {Name: "x", Type: "desktop"}}}},
+		{"unknown route sink", config.NotificationsConfig{Routes: []config.NotificationRoute{{Sinks: []string{"nope"}}}}},
+		{"bad severity", config.NotificationsConfig{
+			Sinks:  []config.NotificationSinkConfig{{Name: "x", Type: "desktop"}},
+			Routes: []config.NotificationRoute{{Sinks: []string{"x"}, MinSeverity: "urgent"}}}},
+		{"bad backoff", config.NotificationsConfig{RetryBackoff: "soon"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tt.cfg
+			if _, err := New(&cfg, ""); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package notify
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// RateLimiter caps deliveries per sink within a sliding window.
+// When a state path is set, recent send times are persisted so the limit
+// holds across short-lived gt processes.
+type RateLimiter struct {
+	limit  int
+	window time.Duration
+	path   string
+
+	mu    sync.Mutex
+	sends map[string][]time.Time // in-memory state when path is empty
+
+	// now is replaced in tests.
+	now func() time.Time
+}
+
+// NewRateLimiter creates a limiter allowing limit sends per window per sink.
+func NewRateLimiter(limit int, window time.Duration, path string) *RateLimiter {
+	return &RateLimiter{
+		limit:  limit,
+		window: window,
+		path:   path,
+		sends:  make(map[string][]time.Time),
+		now:    time.Now,
+	}
+}
+
+// Allow records a send for sink and reports whether it is within the limit.
+func (r *RateLimiter) Allow(sink string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sends := r.load()
+	now := r.now()
+	cutoff := now.Add(-r.window)
+
+	var recent []time.Time
+	for _, t := range sends[sink] {
+		if t.After(cutoff) {
+			recent = append(recent, t)
+		}
+	}
+
+	allowed := len(recent) < r.limit
+	if allowed {
+		recent = append(recent, now)
+	}
+	sends[sink] = recent
+	r.save(sends)
+	return allowed
+}
+
+func (r *RateLimiter) load() map[string][]time.Time {
+	if r.path == "" {
+		return r.sends
+	}
+	sends := make(map[string][]time.Time)
+	data, err := os.ReadFile(r.path)
+	if err != nil {
+		return sends
+	}
+	_ = json.Unmarshal(data, &sends) // Corrupt state just resets the window
+	return sends
+}
+
+func (r *RateLimiter) save(sends map[string][]time.Time) {
+	if r.path == "" {
+		r.sends = sends
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return
+	}
+	_ = util.AtomicWriteJSON(r.path, sends)
+}
@@ -0,0 +1,346 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/smtp"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// Webhook signature headers. The signature is HMAC-SHA256 over
+// "<timestamp>.<body>" so receivers can reject replays.
+const (
+	HeaderSignature = "X-Gastown-Signature"
+	HeaderTimestamp = "X-Gastown-Timestamp"
+	HeaderEvent     = "X-Gastown-Event"
+)
+
+// sendTimeout bounds a single delivery attempt.
+const sendTimeout = 5 * time.Second
+
+// Sink delivers notifications to one destination.
+type Sink interface {
+	Send(ctx context.Context, n Notification) error
+}
+
+// NewSink builds a sink from its configuration.
+func NewSink(sc config.NotificationSinkConfig) (Sink, error) {
+	switch sc.Type {
+	case config.NotifySinkWebhook:
+		if sc.URL == "" {
+			return nil, fmt.Errorf("webhook sink requires url")
+		}
+		return &WebhookSink{URL: sc.URL, Secret: envValue(sc.SecretEnv), Headers: sc.Headers}, nil
+	case config.NotifySinkSlack:
+		if sc.URL == "" {
+			return nil, fmt.Errorf("slack sink requires url")
+		}
+		return &SlackSink{URL: sc.URL}, nil
+	case config.NotifySinkEmail:
+		if sc.SMTPAddr == "" || sc.From == "" || len(sc.To) == 0 {
+			return nil, fmt.Errorf("email sink requires smtp_addr, from, and to")
+		}
+		return &EmailSink{
+			Addr:     sc.SMTPAddr,
+			Username: sc.Username,
+			Password: envValue(sc.PasswordEnv),
+			From:     sc.From,
+			To:       sc.To,
+		}, nil
+	case config.NotifySinkDesktop:
+		return &DesktopSink{}, nil
+	default:
+		return nil, fmt.Errorf("unknown sink type %q (want webhook, slack, email, or desktop)", sc.Type)
+	}
+}
+
+func envValue(name string) string {
+	if name == "" {
+		return ""
+	}
+	return os.Getenv(name)
+}
+
+// Sign returns the hex HMAC-SHA256 signature for a webhook body.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// WebhookSink posts the notification as JSON.
+type WebhookSink struct {
+	URL     string
+	Secret  string // HMAC key; empty disables signing
+	Headers map[string]string
+	Client  *http.Client
+}
+
+// Send posts n to the webhook URL.
+func (w *WebhookSink) Send(ctx context.Context, n Notification) error {
+	body, err := json.Marshal(n)
+	if err != nil {
+		return permanent(fmt.Errorf("marshaling notification: %w", err))
+	}
+
+	headers := map[string]string{HeaderEvent: n.Event}
+	for k, v := range w.Headers {
+		headers[k] = v
+	}
+	if w.Secret != "" {
+		ts := strconv.FormatInt(time.Now().Unix(), 10)
+		headers[HeaderTimestamp] = ts
+		headers[HeaderSignature] = Sign(w.Secret, ts, body)
+	}
+	return postJSON(ctx, w.Client, w.URL, body, headers)
+}
+
+// SlackSink posts to a Slack-compatible incoming webhook
+// (Slack, Mattermost, Rocket.Chat, Discord's /slack endpoint).
+type SlackSink struct {
+	URL    string
+	Client *http.Client
+}
+
+// Send posts a formatted message to the incoming webhook.
+func (s *SlackSink) Send(ctx context.Context, n Notification) error {
+	body, err := json.Marshal(map[string]string{"text": formatText(n)})
+	if err != nil {
+		return permanent(fmt.Errorf("marshaling message: %w", err))
+	}
+	return postJSON(ctx, s.Client, s.URL, body, nil)
+}
+
+// formatText renders a notification as short chat text.
+func formatText(n Notification) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s *%s*", severityIcon(n.Severity), n.Title)
+	var refs []string
+	for _, ref := range []string{n.Rig, n.Bead, n.Convoy} {
+		if ref != "" {
+			refs = append(refs, ref)
+		}
+	}
+	if len(refs) > 0 {
+		fmt.Fprintf(&sb, " (%s)", strings.Join(refs, ", "))
+	}
+	if n.Body != "" {
+		sb.WriteString("\n")
+		sb.WriteString(n.Body)
+	}
+	return sb.String()
+}
+
+func severityIcon(s Severity) string {
+	switch s {
+	case SeverityCritical:
+		return "🚨"
+	case SeverityHigh:
+		return "⚠️"
+	case SeverityLow:
+		return "ℹ️"
+	default:
+		return "📢"
+	}
+}
+
+// postJSON POSTs body and classifies the response: 4xx (except 408/429)
+// is permanent, anything else non-2xx is retryable.
+func postJSON(ctx context.Context, client *http.Client, url string, body []byte, headers map[string]string) error {
+	if client == nil {
+		client = &http.Client{Timeout: sendTimeout}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return permanent(fmt.Errorf("building request: %w", err))
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("posting: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	err = fmt.Errorf("endpoint returned %s", resp.Status)
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 &&
+		resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests {
+		return permanent(err)
+	}
+	return err
+}
+
+// EmailSink sends plain-text email over SMTP.
+type EmailSink struct {
+	Addr     string // host:port
+	Username string
+	Password string
+	From     string
+	To       []string
+
+	// sendMail is sendMailContext; replaced in tests.
+	sendMail func(ctx context.Context, addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// Send emails the notification.
+func (e *EmailSink) Send(ctx context.Context, n Notification) error {
+	var auth smtp.Auth
+	if e.Username != "" {
+		host, _, err := net.SplitHostPort(e.Addr)
+		if err != nil {
+			return permanent(fmt.Errorf("invalid smtp_addr: %w", err))
+		}
+		auth = smtp.PlainAuth("", e.Username, e.Password, host)
+	}
+
+	send := e.sendMail
+	if send == nil {
+		send = sendMailContext
+	}
+	return send(ctx, e.Addr, auth, e.From, e.To, e.message(n))
+}
+
+// sendMailContext is smtp.SendMail bounded by ctx and sendTimeout, which
+// smtp.SendMail itself has no way to express.
+func sendMailContext(ctx context.Context, addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return permanent(fmt.Errorf("invalid smtp_addr: %w", err))
+	}
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("connecting to %s: %w", addr, err)
+	}
+	defer conn.Close()
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+	// Unblock the SMTP exchange if ctx is canceled before the deadline.
+	stop := context.AfterFunc(ctx, func() { _ = conn.SetDeadline(time.Now()) })
+	defer stop()
+
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return err
+		}
+	}
+	if a != nil {
+		if ok, _ := c.Extension("AUTH"); !ok {
+			return permanent(fmt.Errorf("smtp server %s does not support AUTH", addr))
+		}
+		if err := c.Auth(a); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(from); err != nil {
+		return err
+	}
+	for _, rcpt := range to {
+		if err := c.Rcpt(rcpt); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// message builds the RFC 5322 message.
+func (e *EmailSink) message(n Notification) []byte {
+	subject := fmt.Sprintf("[Gas Town %s] %s", strings.ToUpper(string(n.Severity)), n.Title)
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "From: %s\r\n", e.From)
+	fmt.Fprintf(&sb, "To: %s\r\n", strings.Join(e.To, ", "))
+	fmt.Fprintf(&sb, "Subject: %s\r\n", strings.NewReplacer("\r", " ", "\n", " ").Replace(subject))
+	fmt.Fprintf(&sb, "Date: %s\r\n", n.Time.Format(time.RFC1123Z))
+	sb.WriteString("MIME-Version: 1.0\r\n")
+	sb.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	sb.WriteString("\r\n")
+
+	fmt.Fprintf(&sb, "Event:    %s\r\n", n.Event)
+	fmt.Fprintf(&sb, "Severity: %s\r\n", n.Severity)
+	for _, field := range []struct{ label, value string }{
+		{"Rig", n.Rig}, {"Source", n.Source}, {"Bead", n.Bead}, {"Convoy", n.Convoy},
+	} {
+		if field.value != "" {
+			fmt.Fprintf(&sb, "%-9s %s\r\n", field.label+":", field.value)
+		}
+	}
+	if n.Body != "" {
+		sb.WriteString("\r\n")
+		sb.WriteString(strings.ReplaceAll(n.Body, "\n", "\r\n"))
+		sb.WriteString("\r\n")
+	}
+	return []byte(sb.String())
+}
+
+// DesktopSink shows a desktop notification via notify-send.
+type DesktopSink struct {
+	// run executes the command; replaced in tests.
+	run func(ctx context.Context, name string, args ...string) error
+}
+
+// Send runs notify-send with an urgency matching the severity.
+func (s *DesktopSink) Send(ctx context.Context, n Notification) error {
+	urgency := "normal"
+	switch n.Severity {
+	case SeverityCritical:
+		urgency = "critical"
+	case SeverityLow:
+		urgency = "low"
+	}
+
+	run := s.run
+	if run == nil {
+		if _, err := exec.LookPath("notify-send"); err != nil {
+			return permanent(fmt.Errorf("notify-send not found"))
+		}
+		run = func(ctx context.Context, name string, args ...string) error {
+			return exec.CommandContext(ctx, name, args...).Run()
+		}
+	}
+	return run(ctx, "notify-send", "--app-name=Gas Town", "--urgency="+urgency, n.Title, n.Body)
+}
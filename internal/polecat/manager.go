@@ -43,6 +43,8 @@ type Manager struct {
 	git      *git.Git
 	beads    *beads.Beads
 	namePool *NamePool
+	warmSize int                  // configured warm pool size (0 = disabled)
+	sparse   *config.SparseConfig // sparse checkout / partial clone settings (nil = full)
 }
 
 // NewManager creates a new polecat manager.
@@ -74,11 +76,22 @@ func NewManager(r *rig.Rig, g *git.Git) *Manager {
 	}
 	_ = pool.Load() // non-fatal: state file may not exist for new rigs
 
+	warmSize := 0
+	var sparse *config.SparseConfig
+	if err == nil {
+		if settings.WarmPool != nil {
+			warmSize = settings.WarmPool.Size
+		}
+		sparse = settings.Sparse
+	}
+
 	return &Manager{
 		rig:      r,
 		git:      g,
 		beads:    beads.New(beadsPath),
 		namePool: pool,
+		warmSize: warmSize,
+		sparse:   sparse,
 	}
 }
 
@@ -223,7 +236,7 @@ func (m *Manager) AddWithOptions(name string, opts AddOptions) (*Polecat, error)
 
 	// Always create fresh branch - unique name guarantees no collision
 	// git worktree add -b polecat/<name>-<timestamp> <path>
-	if err := repoGit.WorktreeAdd(polecatPath, branchName); err != nil {
+	if err := m.addWorktree(repoGit, polecatPath, branchName, "", m.sparseCone(opts.HookBead)); err != nil {
 		return nil, fmt.Errorf("creating worktree: %w", err)
 	}
 
@@ -244,20 +257,8 @@ func (m *Manager) AddWithOptions(name string, opts AddOptions) (*Polecat, error)
 	// All agents inherit them via Claude's directory traversal - no per-workspace copies needed.
 
 	// Create agent bead for ZFC compliance (self-report state).
-	// State starts as "spawning" - will be updated to "working" when Claude starts.
-	// HookBead is set atomically at creation time if provided (avoids cross-beads routing issues).
-	agentID := m.agentBeadID(name)
-	_, err = m.beads.CreateAgentBead(agentID, agentID, &beads.AgentFields{
-		RoleType:   "polecat",
-		Rig:        m.rig.Name,
-		AgentState: "spawning",
-		RoleBead:   beads.RoleBeadIDTown("polecat"),
-		HookBead:   opts.HookBead, // Set atomically at spawn time
-	})
-	if err != nil {
-		// Non-fatal - log warning but continue
-		fmt.Printf("Warning: could not create agent bead: %v\n", err)
-	}
+	m.createAgentBead(name, opts)
+	m.recordSpawn(false)
 
 	// Return polecat with working state (transient model: polecats are spawned with work)
 	// State is derived from beads, not stored in state.json
@@ -446,20 +447,15 @@ func (m *Manager) RepairWorktreeWithOptions(name string, force bool, opts AddOpt
 	// Fetch latest from origin to ensure we have fresh commits (non-fatal: may be offline)
 	_ = repoGit.Fetch("origin")
 
-	// Determine the start point for the new worktree
 	// Use origin/<default-branch> to ensure we start from latest fetched commits
-	defaultBranch := "main"
-	if rigCfg, err := rig.LoadRigConfig(m.rig.Path); err == nil && rigCfg.DefaultBranch != "" {
-		defaultBranch = rigCfg.DefaultBranch
-	}
-	startPoint := fmt.Sprintf("origin/%s", defaultBranch)
+	startPoint := m.defaultStartPoint()
 
 	// Create fresh worktree with unique branch name, starting from origin's default branch
 	// Old branches are left behind - they're ephemeral (never pushed to origin)
 	// and will be cleaned up by garbage collection
 	// Use base36 encoding for shorter branch names (8 chars vs 13 digits)
 	branchName := fmt.Sprintf("polecat/%s-%s", name, strconv.FormatInt(time.Now().UnixMilli(), 36))
-	if err := repoGit.WorktreeAddFromRef(polecatPath, branchName, startPoint); err != nil {
+	if err := m.addWorktree(repoGit, polecatPath, branchName, startPoint, m.sparseCone(opts.HookBead)); err != nil {
 		return nil, fmt.Errorf("creating fresh worktree from %s: %w", startPoint, err)
 	}
 
@@ -474,17 +470,7 @@ func (m *Manager) RepairWorktreeWithOptions(name string, force bool, opts AddOpt
 	// NOTE: Slash commands inherited from town level - no per-workspace copies needed.
 
 	// Create fresh agent bead for ZFC compliance
-	// HookBead is set atomically at recreation time if provided.
-	_, err = m.beads.CreateAgentBead(agentID, agentID, &beads.AgentFields{
-		RoleType:   "polecat",
-		Rig:        m.rig.Name,
-		AgentState: "spawning",
-		RoleBead:   beads.RoleBeadIDTown("polecat"),
-		HookBead:   opts.HookBead, // Set atomically at spawn time
-	})
-	if err != nil {
-		fmt.Printf("Warning: could not create agent bead: %v\n", err)
-	}
+	m.createAgentBead(name, opts)
 
 	// Return fresh polecat in working state (transient model: polecats are spawned with work)
 	now := time.Now()
@@ -499,6 +485,92 @@ func (m *Manager) RepairWorktreeWithOptions(name string, force bool, opts AddOpt
 	}, nil
 }
 
+// createAgentBead creates the polecat's agent bead for ZFC compliance (self-report state).
+// State starts as "spawning" - will be updated to "working" when Claude starts.
+// HookBead is set atomically at creation time if provided (avoids cross-beads routing issues).
+// Non-fatal: failures are logged as warnings.
+func (m *Manager) createAgentBead(name string, opts AddOptions) {
+	agentID := m.agentBeadID(name)
+	_, err := m.beads.CreateAgentBead(agentID, agentID, &beads.AgentFields{
+		RoleType:   "polecat",
+		Rig:        m.rig.Name,
+		AgentState: "spawning",
+		RoleBead:   beads.RoleBeadIDTown("polecat"),
+		HookBead:   opts.HookBead, // Set atomically at spawn time
+		// Publish the rig's capabilities so routing sees them per agent
+		Capabilities: config.RigCapabilities(filepath.Dir(m.rig.Path), m.rig.Name),
+	})
+	if err != nil {
+		fmt.Printf("Warning: could not create agent bead: %v\n", err)
+	}
+}
+
+// sparseCone returns the cone directories for a polecat worktree: the rig's
+// configured cone plus, with per_bead set, the paths the hooked bead names.
+// Returns nil when the worktree should check out the whole tree.
+func (m *Manager) sparseCone(hookBead string) []string {
+	if m.sparse == nil {
+		return nil
+	}
+	var beadPaths []string
+	if m.sparse.PerBead && hookBead != "" {
+		if issue, err := m.beads.Show(hookBead); err == nil {
+			beadPaths = beads.ParseSparsePaths(issue)
+		}
+	}
+	return m.sparse.ConePaths(beadPaths)
+}
+
+// addWorktree creates a worktree from the repo base: on a new branch when
+// branch is set (else detached), at startPoint (else HEAD), and sparse when
+// cone is non-empty. The rig's partial clone filter is applied first so
+// fetches for the checkout stay small.
+func (m *Manager) addWorktree(repoGit *git.Git, path, branch, startPoint string, cone []string) error {
+	if m.sparse != nil && m.sparse.Filter != "" {
+		if err := repoGit.SetPartialCloneFilter("origin", m.sparse.Filter); err != nil {
+			// Non-fatal - a full fetch still works, it's just bigger
+			fmt.Printf("Warning: could not apply partial clone filter %s: %v\n", m.sparse.Filter, err)
+		}
+	}
+
+	switch {
+	case len(cone) > 0:
+		return repoGit.WorktreeAddSparse(path, branch, startPoint, cone)
+	case branch == "":
+		return repoGit.WorktreeAddDetached(path, startPoint)
+	case startPoint == "":
+		return repoGit.WorktreeAdd(path, branch)
+	default:
+		return repoGit.WorktreeAddFromRef(path, branch, startPoint)
+	}
+}
+
+// SparseProfile returns a polecat worktree's sparse checkout cone (nil for a
+// full checkout) and the partial clone filter of the rig's repo base.
+func (m *Manager) SparseProfile(name string) (cone []string, filter string, err error) {
+	if !m.exists(name) {
+		return nil, "", ErrPolecatNotFound
+	}
+	cone, err = git.NewGit(m.polecatDir(name)).SparseCheckoutList()
+	if err != nil {
+		return nil, "", err
+	}
+	if repoGit, repoErr := m.repoBase(); repoErr == nil {
+		filter = repoGit.PartialCloneFilter("origin")
+	}
+	return cone, filter, nil
+}
+
+// defaultStartPoint returns the ref fresh polecat work starts from:
+// origin/<default-branch>, using the rig's configured default branch.
+func (m *Manager) defaultStartPoint() string {
+	defaultBranch := "main"
+	if rigCfg, err := rig.LoadRigConfig(m.rig.Path); err == nil && rigCfg.DefaultBranch != "" {
+		defaultBranch = rigCfg.DefaultBranch
+	}
+	return fmt.Sprintf("origin/%s", defaultBranch)
+}
+
 // ReconcilePool syncs pool state with existing polecat directories.
 // This should be called to recover from crashes or stale state.
 func (m *Manager) ReconcilePool() {
@@ -516,9 +588,29 @@ func (m *Manager) ReconcilePool() {
 	_ = m.namePool.Save() // non-fatal: state file update
 }
 
-// PoolStatus returns information about the name pool.
-func (m *Manager) PoolStatus() (active int, names []string) {
-	return m.namePool.ActiveCount(), m.namePool.ActiveNames()
+// PoolStatus describes a rig's polecat pool: names in use, idle warm
+// worktrees, and how many spawns were served warm vs. created cold.
+type PoolStatus struct {
+	Active     int      `json:"active"`
+	Names      []string `json:"names"`
+	Warm       int      `json:"warm"`        // idle pre-provisioned worktrees ready to claim
+	WarmTarget int      `json:"warm_target"` // configured warm pool size
+	WarmClaims int      `json:"warm_claims"` // spawns that claimed a warm worktree
+	ColdSpawns int      `json:"cold_spawns"` // spawns that created a worktree from scratch
+}
+
+// PoolStatus returns information about the name pool and the warm pool.
+func (m *Manager) PoolStatus() PoolStatus {
+	warm, _ := m.WarmWorktrees()
+	stats := m.loadSpawnStats()
+	return PoolStatus{
+		Active:     m.namePool.ActiveCount(),
+		Names:      m.namePool.ActiveNames(),
+		Warm:       len(warm),
+		WarmTarget: m.warmSize,
+		WarmClaims: stats.WarmClaims,
+		ColdSpawns: stats.ColdSpawns,
+	}
 }
 
 // List returns all polecats in the rig.
@@ -268,3 +268,23 @@ func TestClearIssueWithoutAssignment(t *testing.T) {
 // We no longer write CLAUDE.md to worktrees - Gas Town context is injected
 // ephemerally via SessionStart hook (gt prime) to prevent leaking internal
 // architecture into project repos.
+
+func TestAddSparseWorktree(t *testing.T) {
+	m := setupGitRig(t, `{"type":"rig-settings","version":1,"sparse":{"cone":["a"]}}`)
+
+	p, err := m.Add("Toast")
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(p.ClonePath, "a", "f.txt")); err != nil {
+		t.Errorf("cone dir a/ not checked out: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(p.ClonePath, "b")); !os.IsNotExist(err) {
+		t.Errorf("b/ checked out outside the cone")
+	}
+
+	cone, _, err := m.SparseProfile("Toast")
+	if err != nil || len(cone) != 1 || cone[0] != "a" {
+		t.Errorf("SparseProfile = %v, %v; want [a]", cone, err)
+	}
+}
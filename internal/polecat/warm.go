@@ -0,0 +1,302 @@
+package polecat
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/claude"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// Warm pool: idle, fully-provisioned polecat worktrees waiting to be claimed.
+//
+// A cold spawn adds a worktree, sets up shared beads and writes runtime
+// settings, which is slow on large repos. A rig can keep a few worktrees
+// ready (settings/config.json: warm_pool.size) that the daemon refreshes to
+// origin/<default-branch> on every heartbeat.
+//
+// Warm worktrees live in <rig>/.warm/ on a detached HEAD, not in polecats/,
+// so List, the name pool and the Witness never mistake them for idle
+// polecats. Sling claims one by moving it to polecats/<name> and checking out
+// a fresh polecat branch, and falls back to a cold spawn when none is ready.
+//
+// Structure:
+//
+//	rig/
+//	  .warm/
+//	    warm-<id>/        <- detached at origin/<default-branch>
+//	  polecats/
+//	    <name>/           <- claimed: polecat/<name>-<timestamp>
+
+// ErrWarmPoolEmpty is returned by ClaimWarm when no warm worktree is ready.
+var ErrWarmPoolEmpty = errors.New("warm pool is empty")
+
+// warmDir returns the directory holding the rig's warm worktrees.
+func (m *Manager) warmDir() string {
+	return filepath.Join(m.rig.Path, ".warm")
+}
+
+// WarmSize returns the configured warm pool size (0 = disabled).
+func (m *Manager) WarmSize() int {
+	return m.warmSize
+}
+
+// WarmWorktrees returns the paths of the rig's warm worktrees, oldest first.
+func (m *Manager) WarmWorktrees() ([]string, error) {
+	entries, err := os.ReadDir(m.warmDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading warm dir: %w", err)
+	}
+
+	var paths []string
+	for _, entry := range entries {
+		if entry.IsDir() && strings.HasPrefix(entry.Name(), "warm-") {
+			paths = append(paths, filepath.Join(m.warmDir(), entry.Name()))
+		}
+	}
+	// IDs are fixed-width base36 timestamps, so name order is age order
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// ProvisionWarm creates one warm worktree at the start point fresh polecat
+// work uses, with shared beads and runtime settings in place.
+// Callers are expected to have fetched origin recently (FillWarmPool does).
+func (m *Manager) ProvisionWarm() (string, error) {
+	repoGit, err := m.repoBase()
+	if err != nil {
+		return "", fmt.Errorf("finding repo base: %w", err)
+	}
+
+	if err := os.MkdirAll(m.warmDir(), 0755); err != nil {
+		return "", fmt.Errorf("creating warm dir: %w", err)
+	}
+
+	id := "warm-" + strconv.FormatInt(time.Now().UnixNano(), 36)
+	path := filepath.Join(m.warmDir(), id)
+	startPoint := m.defaultStartPoint()
+	if err := m.addWorktree(repoGit, path, "", startPoint, m.sparseCone("")); err != nil {
+		return "", fmt.Errorf("creating warm worktree from %s: %w", startPoint, err)
+	}
+
+	// The redirect is depth-relative, so it stays valid after the move to
+	// polecats/<name>; ClaimWarm rewrites it anyway in case the rig changed.
+	if err := m.setupSharedBeads(path); err != nil {
+		fmt.Printf("Warning: could not set up shared beads: %v\n", err)
+	}
+
+	if err := claude.EnsureSettingsForRole(path, "polecat"); err != nil {
+		_ = repoGit.WorktreeRemove(path, true)
+		return "", fmt.Errorf("writing runtime settings: %w", err)
+	}
+
+	return path, nil
+}
+
+// FillWarmPool fetches origin, moves every warm worktree to the latest start
+// point, and provisions or removes worktrees until the pool matches its
+// configured size. Worktrees that can't be refreshed are replaced.
+// Returns how many worktrees were added and removed.
+func (m *Manager) FillWarmPool() (added, removed int, err error) {
+	repoGit, err := m.repoBase()
+	if err != nil {
+		return 0, 0, fmt.Errorf("finding repo base: %w", err)
+	}
+
+	warm, err := m.WarmWorktrees()
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(warm) == 0 && m.warmSize == 0 {
+		return 0, 0, nil
+	}
+
+	// Non-fatal: may be offline, worktrees just stay at the last fetch
+	_ = repoGit.Fetch("origin")
+
+	// Hold the pool lock while worktrees are refreshed, removed and
+	// provisioned, so ClaimWarm never takes one mid-change.
+	err = util.WithFileLock(m.warmDir(), func() error {
+		var ferr error
+		added, removed, ferr = m.fillWarmPoolLocked(repoGit)
+		return ferr
+	})
+	return added, removed, err
+}
+
+// fillWarmPoolLocked does FillWarmPool's work under the pool lock.
+func (m *Manager) fillWarmPoolLocked(repoGit *git.Git) (added, removed int, err error) {
+	warm, err := m.WarmWorktrees()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	startPoint := m.defaultStartPoint()
+	var fresh []string
+	for _, path := range warm {
+		if err := git.NewGit(path).Checkout(startPoint); err != nil {
+			m.removeWarm(repoGit, path)
+			removed++
+			continue
+		}
+		fresh = append(fresh, path)
+	}
+
+	// Pool shrank (or was disabled): drop the oldest first
+	for len(fresh) > m.warmSize {
+		m.removeWarm(repoGit, fresh[0])
+		fresh = fresh[1:]
+		removed++
+	}
+
+	for i := len(fresh); i < m.warmSize; i++ {
+		if _, err := m.ProvisionWarm(); err != nil {
+			return added, removed, err
+		}
+		added++
+	}
+
+	return added, removed, nil
+}
+
+// ClaimWarm turns a warm worktree into polecat name: the worktree moves to
+// polecats/<name>, checks out a fresh branch (polecat/<name>-<timestamp>) at
+// its refreshed HEAD, and gets an agent bead like a cold spawn.
+// Returns ErrWarmPoolEmpty when no warm worktree is ready.
+func (m *Manager) ClaimWarm(name string, opts AddOptions) (*Polecat, error) {
+	if m.exists(name) {
+		return nil, ErrPolecatExists
+	}
+
+	warm, err := m.WarmWorktrees()
+	if err != nil {
+		return nil, err
+	}
+	if len(warm) == 0 {
+		return nil, ErrWarmPoolEmpty
+	}
+
+	repoGit, err := m.repoBase()
+	if err != nil {
+		return nil, fmt.Errorf("finding repo base: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(m.rig.Path, "polecats"), 0755); err != nil {
+		return nil, fmt.Errorf("creating polecats dir: %w", err)
+	}
+
+	// Take the worktree under the pool lock: a fill may be refreshing or
+	// provisioning it, and a concurrent sling may be claiming the same one.
+	polecatPath := m.polecatDir(name)
+	claimed := false
+	err = util.WithFileLock(m.warmDir(), func() error {
+		warm, err := m.WarmWorktrees()
+		if err != nil {
+			return err
+		}
+		for _, path := range warm {
+			if err := repoGit.WorktreeMove(path, polecatPath); err == nil {
+				claimed = true
+				return nil
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, ErrWarmPoolEmpty
+	}
+
+	branchName := fmt.Sprintf("polecat/%s-%s", name, strconv.FormatInt(time.Now().UnixMilli(), 36))
+	polecatGit := git.NewGit(polecatPath)
+	if err := polecatGit.CreateBranchFrom(branchName, "HEAD"); err != nil {
+		m.removeWarm(repoGit, polecatPath)
+		return nil, fmt.Errorf("creating branch %s: %w", branchName, err)
+	}
+	if err := polecatGit.Checkout(branchName); err != nil {
+		m.removeWarm(repoGit, polecatPath)
+		return nil, fmt.Errorf("checking out %s: %w", branchName, err)
+	}
+
+	// Warm worktrees carry the rig's base cone; widen it to the bead's paths
+	if m.sparse != nil && m.sparse.PerBead && opts.HookBead != "" {
+		if cone := m.sparseCone(opts.HookBead); len(cone) > 0 {
+			if err := polecatGit.SparseCheckoutSet(cone); err != nil {
+				fmt.Printf("Warning: could not apply sparse checkout for %s: %v\n", opts.HookBead, err)
+			}
+		}
+	}
+
+	if err := m.setupSharedBeads(polecatPath); err != nil {
+		fmt.Printf("Warning: could not set up shared beads: %v\n", err)
+	}
+
+	m.createAgentBead(name, opts)
+	m.recordSpawn(true)
+
+	now := time.Now()
+	return &Polecat{
+		Name:      name,
+		Rig:       m.rig.Name,
+		State:     StateWorking,
+		ClonePath: polecatPath,
+		Branch:    branchName,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// removeWarm deletes a warm (or half-claimed) worktree.
+func (m *Manager) removeWarm(repoGit *git.Git, path string) {
+	if err := repoGit.WorktreeRemove(path, true); err != nil {
+		_ = os.RemoveAll(path)
+	}
+	_ = repoGit.WorktreePrune()
+}
+
+// spawnStats counts how polecat worktrees were created.
+type spawnStats struct {
+	WarmClaims int `json:"warm_claims"`
+	ColdSpawns int `json:"cold_spawns"`
+}
+
+func (m *Manager) spawnStatsFile() string {
+	return filepath.Join(m.rig.Path, ".runtime", "spawn-stats.json")
+}
+
+func (m *Manager) loadSpawnStats() spawnStats {
+	var stats spawnStats
+	data, err := os.ReadFile(m.spawnStatsFile())
+	if err != nil {
+		return stats // no spawns recorded yet
+	}
+	_ = json.Unmarshal(data, &stats)
+	return stats
+}
+
+// recordSpawn counts a warm claim or cold spawn (non-fatal: stats only).
+func (m *Manager) recordSpawn(warm bool) {
+	stats := m.loadSpawnStats()
+	if warm {
+		stats.WarmClaims++
+	} else {
+		stats.ColdSpawns++
+	}
+	if err := os.MkdirAll(filepath.Dir(m.spawnStatsFile()), 0755); err != nil {
+		return
+	}
+	_ = util.AtomicWriteJSON(m.spawnStatsFile(), stats)
+}
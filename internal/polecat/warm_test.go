@@ -0,0 +1,188 @@
+package polecat
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// setupGitRig creates a rig whose mayor/rig clone has an origin with one
+// commit on main (top-level dirs a/ and b/), and the given rig settings.
+func setupGitRig(t *testing.T, settingsJSON string) *Manager {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	root := t.TempDir()
+	origin := filepath.Join(root, "origin")
+	runGit(t, root, "init", "-q", "-b", "main", origin)
+	for _, dir := range []string{"a", "b"} {
+		if err := os.MkdirAll(filepath.Join(origin, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(origin, dir, "f.txt"), []byte(dir), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	runGit(t, origin, "add", ".")
+	runGit(t, origin, "-c", "user.name=t", "-c", "user.email=t@t", "commit", "-q", "-m", "init")
+
+	rigPath := filepath.Join(root, "rig")
+	runGit(t, root, "clone", "-q", origin, filepath.Join(rigPath, "mayor", "rig"))
+
+	settings := filepath.Join(rigPath, "settings", "config.json")
+	if err := os.MkdirAll(filepath.Dir(settings), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(settings, []byte(settingsJSON), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	r := &rig.Rig{Name: "test-rig", Path: rigPath}
+	return NewManager(r, git.NewGit(rigPath))
+}
+
+// setupWarmRig creates a git-backed rig with a warm pool of size.
+func setupWarmRig(t *testing.T, size int) *Manager {
+	t.Helper()
+	return setupGitRig(t, fmt.Sprintf(`{"type":"rig-settings","version":1,"warm_pool":{"size":%d}}`, size))
+}
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func TestWarmPoolFillAndClaim(t *testing.T) {
+	m := setupWarmRig(t, 2)
+	if m.WarmSize() != 2 {
+		t.Fatalf("WarmSize = %d, want 2", m.WarmSize())
+	}
+
+	if _, err := m.ClaimWarm("Toast", AddOptions{}); err != ErrWarmPoolEmpty {
+		t.Fatalf("ClaimWarm on empty pool = %v, want ErrWarmPoolEmpty", err)
+	}
+
+	added, removed, err := m.FillWarmPool()
+	if err != nil {
+		t.Fatalf("FillWarmPool: %v", err)
+	}
+	if added != 2 || removed != 0 {
+		t.Errorf("FillWarmPool = +%d -%d, want +2 -0", added, removed)
+	}
+
+	warm, _ := m.WarmWorktrees()
+	if len(warm) != 2 {
+		t.Fatalf("warm worktrees = %d, want 2", len(warm))
+	}
+	for _, path := range warm {
+		if _, err := os.Stat(filepath.Join(path, ".beads", "redirect")); err != nil {
+			t.Errorf("%s: missing beads redirect: %v", path, err)
+		}
+		if _, err := os.Stat(filepath.Join(path, ".claude", "settings.json")); err != nil {
+			t.Errorf("%s: missing runtime settings: %v", path, err)
+		}
+	}
+
+	// Warm worktrees are not polecats
+	if polecats, _ := m.List(); len(polecats) != 0 {
+		t.Errorf("List = %d polecats, want 0", len(polecats))
+	}
+
+	p, err := m.ClaimWarm("Toast", AddOptions{})
+	if err != nil {
+		t.Fatalf("ClaimWarm: %v", err)
+	}
+	if p.ClonePath != m.polecatDir("Toast") || !strings.HasPrefix(p.Branch, "polecat/Toast-") {
+		t.Errorf("claimed polecat = %+v", p)
+	}
+	if branch := runGit(t, p.ClonePath, "rev-parse", "--abbrev-ref", "HEAD"); branch != p.Branch {
+		t.Errorf("worktree on %q, want %q", branch, p.Branch)
+	}
+
+	// A newer origin commit reaches the remaining warm worktree on refill
+	origin := filepath.Join(filepath.Dir(m.rig.Path), "origin")
+	runGit(t, origin, "-c", "user.name=t", "-c", "user.email=t@t", "commit", "-q", "--allow-empty", "-m", "next")
+	head := runGit(t, origin, "rev-parse", "HEAD")
+
+	if added, _, err := m.FillWarmPool(); err != nil || added != 1 {
+		t.Fatalf("refill = +%d, %v; want +1", added, err)
+	}
+	warm, _ = m.WarmWorktrees()
+	for _, path := range warm {
+		if got := runGit(t, path, "rev-parse", "HEAD"); got != head {
+			t.Errorf("%s at %s, want origin head %s", path, got, head)
+		}
+	}
+
+	status := m.PoolStatus()
+	if status.Warm != 2 || status.WarmTarget != 2 || status.WarmClaims != 1 || status.ColdSpawns != 0 {
+		t.Errorf("PoolStatus = %+v", status)
+	}
+}
+
+func TestWarmPoolShrinks(t *testing.T) {
+	m := setupWarmRig(t, 2)
+	if _, _, err := m.FillWarmPool(); err != nil {
+		t.Fatalf("FillWarmPool: %v", err)
+	}
+
+	m.warmSize = 0
+	added, removed, err := m.FillWarmPool()
+	if err != nil || added != 0 || removed != 2 {
+		t.Errorf("FillWarmPool = +%d -%d, %v; want +0 -2", added, removed, err)
+	}
+	if warm, _ := m.WarmWorktrees(); len(warm) != 0 {
+		t.Errorf("warm worktrees = %d, want 0", len(warm))
+	}
+}
+
+func TestClaimWarmWaitsForFill(t *testing.T) {
+	m := setupWarmRig(t, 1)
+	if _, _, err := m.FillWarmPool(); err != nil {
+		t.Fatalf("FillWarmPool: %v", err)
+	}
+
+	// Hold the pool lock the way an in-progress fill does
+	locked := make(chan struct{})
+	release := make(chan struct{})
+	go func() {
+		_ = util.WithFileLock(m.warmDir(), func() error {
+			close(locked)
+			<-release
+			return nil
+		})
+	}()
+	<-locked
+
+	claimed := make(chan error, 1)
+	go func() {
+		_, err := m.ClaimWarm("Toast", AddOptions{})
+		claimed <- err
+	}()
+	select {
+	case err := <-claimed:
+		t.Fatalf("ClaimWarm returned %v while the pool was locked", err)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	close(release)
+	if err := <-claimed; err != nil {
+		t.Fatalf("ClaimWarm after unlock: %v", err)
+	}
+}
@@ -10,13 +10,17 @@ import (
 
 // NewMergeReadyMessage creates a MERGE_READY protocol message.
 // Sent by Witness to Refinery when a polecat's work is verified and ready.
-func NewMergeReadyMessage(rig, polecat, branch, issue string) *mail.Message {
+// mrID and parentMR are the MR bead and the MR it is stacked on; either may
+// be empty.
+func NewMergeReadyMessage(rig, polecat, branch, issue, mrID, parentMR string) *mail.Message {
 	payload := MergeReadyPayload{
 		Branch:    branch,
 		Issue:     issue,
 		Polecat:   polecat,
 		Rig:       rig,
 		Verified:  "clean git state, issue closed",
+		MRID:      mrID,
+		ParentMR:  parentMR,
 		Timestamp: time.Now(),
 	}
 
@@ -41,6 +45,12 @@ func formatMergeReadyBody(p MergeReadyPayload) string {
 	sb.WriteString(fmt.Sprintf("Issue: %s\n", p.Issue))
 	sb.WriteString(fmt.Sprintf("Polecat: %s\n", p.Polecat))
 	sb.WriteString(fmt.Sprintf("Rig: %s\n", p.Rig))
+	if p.MRID != "" {
+		sb.WriteString(fmt.Sprintf("MR: %s\n", p.MRID))
+	}
+	if p.ParentMR != "" {
+		sb.WriteString(fmt.Sprintf("Parent-MR: %s\n", p.ParentMR))
+	}
 	if p.Verified != "" {
 		sb.WriteString(fmt.Sprintf("Verified: %s\n", p.Verified))
 	}
@@ -49,7 +59,8 @@ func formatMergeReadyBody(p MergeReadyPayload) string {
 
 // NewMergedMessage creates a MERGED protocol message.
 // Sent by Refinery to Witness when a branch is successfully merged.
-func NewMergedMessage(rig, polecat, branch, issue, targetBranch, mergeCommit string) *mail.Message {
+// traceParent is the MR's trace context and may be empty.
+func NewMergedMessage(rig, polecat, branch, issue, targetBranch, mergeCommit, traceParent string) *mail.Message {
 	payload := MergedPayload{
 		Branch:       branch,
 		Issue:        issue,
@@ -58,6 +69,7 @@ func NewMergedMessage(rig, polecat, branch, issue, targetBranch, mergeCommit str
 		MergedAt:     time.Now(),
 		MergeCommit:  mergeCommit,
 		TargetBranch: targetBranch,
+		TraceParent:  traceParent,
 	}
 
 	body := formatMergedBody(payload)
@@ -86,6 +98,9 @@ func formatMergedBody(p MergedPayload) string {
 	if p.MergeCommit != "" {
 		sb.WriteString(fmt.Sprintf("Merge-Commit: %s\n", p.MergeCommit))
 	}
+	if p.TraceParent != "" {
+		sb.WriteString(fmt.Sprintf("Trace-Parent: %s\n", p.TraceParent))
+	}
 	return sb.String()
 }
 
@@ -199,6 +214,8 @@ func ParseMergeReadyPayload(body string) *MergeReadyPayload {
 		Polecat:   parseField(body, "Polecat"),
 		Rig:       parseField(body, "Rig"),
 		Verified:  parseField(body, "Verified"),
+		MRID:      parseField(body, "MR"),
+		ParentMR:  parseField(body, "Parent-MR"),
 		Timestamp: time.Now(), // Use current time if not parseable
 	}
 }
@@ -212,6 +229,7 @@ func ParseMergedPayload(body string) *MergedPayload {
 		Rig:          parseField(body, "Rig"),
 		TargetBranch: parseField(body, "Target"),
 		MergeCommit:  parseField(body, "Merge-Commit"),
+		TraceParent:  parseField(body, "Trace-Parent"),
 	}
 
 	// Parse timestamp
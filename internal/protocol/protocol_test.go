@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/mrqueue"
+	"github.com/steveyegge/gastown/internal/witness"
 )
 
 func TestParseMessageType(t *testing.T) {
@@ -83,7 +85,7 @@ func TestIsProtocolMessage(t *testing.T) {
 }
 
 func TestNewMergeReadyMessage(t *testing.T) {
-	msg := NewMergeReadyMessage("gastown", "nux", "polecat/nux/gt-abc", "gt-abc")
+	msg := NewMergeReadyMessage("gastown", "nux", "polecat/nux/gt-abc", "gt-abc", "gt-mr2", "gt-mr1")
 
 	if msg.Subject != "MERGE_READY nux" {
 		t.Errorf("Subject = %q, want %q", msg.Subject, "MERGE_READY nux")
@@ -103,10 +105,14 @@ func TestNewMergeReadyMessage(t *testing.T) {
 	if !strings.Contains(msg.Body, "Issue: gt-abc") {
 		t.Errorf("Body missing issue: %s", msg.Body)
 	}
+	if p := ParseMergeReadyPayload(msg.Body); p.MRID != "gt-mr2" || p.ParentMR != "gt-mr1" {
+		t.Errorf("parsed MR = %q, parent = %q; want gt-mr2 stacked on gt-mr1", p.MRID, p.ParentMR)
+	}
 }
 
 func TestNewMergedMessage(t *testing.T) {
-	msg := NewMergedMessage("gastown", "nux", "polecat/nux/gt-abc", "gt-abc", "main", "abc123")
+	tp := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	msg := NewMergedMessage("gastown", "nux", "polecat/nux/gt-abc", "gt-abc", "main", "abc123", tp)
 
 	if msg.Subject != "MERGED nux" {
 		t.Errorf("Subject = %q, want %q", msg.Subject, "MERGED nux")
@@ -120,6 +126,23 @@ func TestNewMergedMessage(t *testing.T) {
 	if !strings.Contains(msg.Body, "Merge-Commit: abc123") {
 		t.Errorf("Body missing merge commit: %s", msg.Body)
 	}
+
+	// The witness continues the MR's trace from the message
+	if got := ParseMergedPayload(msg.Body).TraceParent; got != tp {
+		t.Errorf("ParseMergedPayload TraceParent = %q, want %q", got, tp)
+	}
+	payload, err := witness.ParseMerged(msg.Subject, msg.Body)
+	if err != nil {
+		t.Fatalf("witness.ParseMerged() error = %v", err)
+	}
+	if payload.TraceParent != tp {
+		t.Errorf("witness TraceParent = %q, want %q", payload.TraceParent, tp)
+	}
+
+	msg = NewMergedMessage("gastown", "nux", "polecat/nux/gt-abc", "gt-abc", "main", "abc123", "")
+	if strings.Contains(msg.Body, "Trace-Parent") {
+		t.Errorf("Body has Trace-Parent without a trace: %s", msg.Body)
+	}
 }
 
 func TestNewMergeFailedMessage(t *testing.T) {
@@ -285,6 +308,36 @@ func TestWrapRefineryHandlers(t *testing.T) {
 	}
 }
 
+func TestDefaultRefineryHandler_StackedMergeReady(t *testing.T) {
+	rigPath := t.TempDir()
+	handler := NewRefineryHandler("gastown", rigPath)
+	handler.SetOutput(&bytes.Buffer{})
+
+	for _, msg := range []*mail.Message{
+		NewMergeReadyMessage("gastown", "nux", "polecat/nux/gt-a", "gt-a", "gt-mr1", ""),
+		NewMergeReadyMessage("gastown", "toast", "polecat/toast/gt-b", "gt-b", "gt-mr2", "gt-mr1"),
+	} {
+		if err := handler.HandleMergeReady(ParseMergeReadyPayload(msg.Body)); err != nil {
+			t.Fatalf("HandleMergeReady() error = %v", err)
+		}
+	}
+
+	q := mrqueue.New(rigPath)
+	child, err := q.Get("gt-mr2")
+	if err != nil {
+		t.Fatalf("child not queued under its MR bead ID: %v", err)
+	}
+	if child.ParentMR != "gt-mr1" || !q.IsStackedOnQueued(child) {
+		t.Errorf("child parent = %q, want stacked on queued gt-mr1", child.ParentMR)
+	}
+
+	// Rejecting the parent by its bead ID takes the stack with it.
+	removed, err := q.RemoveStack("gt-mr1")
+	if err != nil || len(removed) != 2 {
+		t.Errorf("RemoveStack(gt-mr1) = %d MRs, %v; want parent and child", len(removed), err)
+	}
+}
+
 func TestDefaultWitnessHandler(t *testing.T) {
 	tmpDir := t.TempDir()
 	handler := NewWitnessHandler("gastown", tmpDir)
@@ -64,8 +64,12 @@ func (h *DefaultRefineryHandler) HandleMergeReady(payload *MergeReadyPayload) er
 		return fmt.Errorf("missing polecat in MERGE_READY payload")
 	}
 
-	// Create merge request (ID is generated by Submit if empty)
+	// Create merge request. It is queued under the MR bead's ID when the
+	// message names one (Submit generates an ID otherwise), so the parent a
+	// stacked MR names is found in the queue.
 	mr := &mrqueue.MR{
+		ID:          payload.MRID,
+		ParentMR:    payload.ParentMR,
 		Branch:      payload.Branch,
 		Worker:      payload.Polecat,
 		SourceIssue: payload.Issue,
@@ -82,6 +86,9 @@ func (h *DefaultRefineryHandler) HandleMergeReady(payload *MergeReadyPayload) er
 	}
 
 	_, _ = fmt.Fprintf(h.Output, "[Refinery] ✓ Added to merge queue: %s\n", mr.ID)
+	if mr.ParentMR != "" {
+		_, _ = fmt.Fprintf(h.Output, "  Stacked on: %s\n", mr.ParentMR)
+	}
 	_, _ = fmt.Fprintf(h.Output, "  Queue length: %d\n", h.Queue.Count())
 
 	return nil
@@ -89,8 +96,8 @@ func (h *DefaultRefineryHandler) HandleMergeReady(payload *MergeReadyPayload) er
 
 // SendMerged sends a MERGED message to the Witness.
 // Called by the Refinery after successfully merging a branch.
-func (h *DefaultRefineryHandler) SendMerged(polecat, branch, issue, targetBranch, mergeCommit string) error {
-	msg := NewMergedMessage(h.Rig, polecat, branch, issue, targetBranch, mergeCommit)
+func (h *DefaultRefineryHandler) SendMerged(polecat, branch, issue, targetBranch, mergeCommit, traceParent string) error {
+	msg := NewMergedMessage(h.Rig, polecat, branch, issue, targetBranch, mergeCommit, traceParent)
 	return h.Router.Send(msg)
 }
 
@@ -128,12 +135,15 @@ type MergeOutcome struct {
 
 	// ConflictFiles lists files with conflicts (if Conflict is true).
 	ConflictFiles []string
+
+	// TraceParent is the MR's W3C traceparent, passed on in MERGED.
+	TraceParent string
 }
 
 // NotifyMergeOutcome sends the appropriate protocol message based on the outcome.
 func (h *DefaultRefineryHandler) NotifyMergeOutcome(polecat, branch, issue, targetBranch string, outcome MergeOutcome) error {
 	if outcome.Success {
-		return h.SendMerged(polecat, branch, issue, targetBranch, outcome.MergeCommit)
+		return h.SendMerged(polecat, branch, issue, targetBranch, outcome.MergeCommit, outcome.TraceParent)
 	}
 
 	if outcome.Conflict {
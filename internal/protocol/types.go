@@ -80,6 +80,14 @@ type MergeReadyPayload struct {
 	// Verified contains verification notes.
 	Verified string `json:"verified,omitempty"`
 
+	// MRID is the merge-request bead created by gt done or gt mq submit.
+	// The refinery queues the MR under this ID so bead updates, rejections
+	// and stacks refer to the same MR.
+	MRID string `json:"mr_id,omitempty"`
+
+	// ParentMR is the MR bead this one is stacked on (its parent_mr field).
+	ParentMR string `json:"parent_mr,omitempty"`
+
 	// Timestamp is when the message was created.
 	Timestamp time.Time `json:"timestamp"`
 }
@@ -107,6 +115,10 @@ type MergedPayload struct {
 
 	// TargetBranch is the branch merged into (e.g., "main").
 	TargetBranch string `json:"target_branch"`
+
+	// TraceParent is the W3C traceparent of the merged MR, so the witness
+	// cleanup joins its trace.
+	TraceParent string `json:"trace_parent,omitempty"`
 }
 
 // MergeFailedPayload contains the data for a MERGE_FAILED message.
@@ -18,6 +18,8 @@ import (
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/mrqueue"
 	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/testhistory"
+	"github.com/steveyegge/gastown/internal/tracing"
 )
 
 // MergeQueueConfig holds configuration for the merge queue processor.
@@ -44,8 +46,40 @@ type MergeQueueConfig struct {
 	DeleteMergedBranches bool `json:"delete_merged_branches"`
 
 	// RetryFlakyTests is the number of times to retry flaky tests.
+	// When test results can be parsed, only the failing tests are rerun.
 	RetryFlakyTests int `json:"retry_flaky_tests"`
 
+	// TestResultsFormat is how to parse test results: "go-json" or "junit".
+	// Empty detects go test -json output, or JUnit if TestResultsPath is set.
+	TestResultsFormat string `json:"test_results_format"`
+
+	// TestResultsPath is the JUnit XML report the test command writes,
+	// relative to the rig.
+	TestResultsPath string `json:"test_results_path"`
+
+	// RerunCommand reruns the failing tests. {tests} expands to their
+	// quoted names and {run} to a regexp matching them. Empty uses
+	// go test -run for go-json results and TestCommand otherwise.
+	RerunCommand string `json:"rerun_command"`
+
+	// FlakyThreshold is the recent flip rate at which a test is quarantined.
+	FlakyThreshold float64 `json:"flaky_threshold"`
+
+	// FlakyMinRuns is how many recent runs a test needs before it can be
+	// classified as flaky.
+	FlakyMinRuns int `json:"flaky_min_runs"`
+
+	// AllowQuarantinedFailures lets an MR merge when its only failing
+	// tests are quarantined.
+	AllowQuarantinedFailures bool `json:"allow_quarantined_failures"`
+
+	// PostMergeCheck runs TestCommand on the target after each merge and,
+	// if it fails, bisects recent merges and reverts the one that broke it.
+	PostMergeCheck bool `json:"post_merge_check"`
+
+	// PostMergeWindow is how many recent merges the post-merge bisect searches.
+	PostMergeWindow int `json:"post_merge_window"`
+
 	// PollInterval is how often to check for new MRs.
 	PollInterval time.Duration `json:"poll_interval"`
 
@@ -64,6 +98,9 @@ func DefaultMergeQueueConfig() *MergeQueueConfig {
 		TestCommand:          "",
 		DeleteMergedBranches: true,
 		RetryFlakyTests:      1,
+		FlakyThreshold:       0.2,
+		FlakyMinRuns:         10,
+		PostMergeWindow:      10,
 		PollInterval:         30 * time.Second,
 		MaxConcurrent:        1,
 	}
@@ -110,6 +147,13 @@ func (e *Engineer) SetOutput(w io.Writer) {
 	e.output = w
 }
 
+// SetWorkDir points git operations and test runs at dir, a checkout of
+// the rig's repo.
+func (e *Engineer) SetWorkDir(dir string) {
+	e.workDir = dir
+	e.git = git.NewGit(dir)
+}
+
 // LoadConfig loads merge queue configuration from the rig's config.json.
 func (e *Engineer) LoadConfig() error {
 	configPath := filepath.Join(e.rig.Path, "config.json")
@@ -148,6 +192,15 @@ func (e *Engineer) LoadConfig() error {
 		RetryFlakyTests      *int    `json:"retry_flaky_tests"`
 		PollInterval         *string `json:"poll_interval"`
 		MaxConcurrent        *int    `json:"max_concurrent"`
+
+		TestResultsFormat        *string  `json:"test_results_format"`
+		TestResultsPath          *string  `json:"test_results_path"`
+		RerunCommand             *string  `json:"rerun_command"`
+		FlakyThreshold           *float64 `json:"flaky_threshold"`
+		FlakyMinRuns             *int     `json:"flaky_min_runs"`
+		AllowQuarantinedFailures *bool    `json:"allow_quarantined_failures"`
+		PostMergeCheck           *bool    `json:"post_merge_check"`
+		PostMergeWindow          *int     `json:"post_merge_window"`
 	}
 
 	if err := json.Unmarshal(rawConfig.MergeQueue, &mqRaw); err != nil {
@@ -182,6 +235,33 @@ func (e *Engineer) LoadConfig() error {
 	if mqRaw.MaxConcurrent != nil {
 		e.config.MaxConcurrent = *mqRaw.MaxConcurrent
 	}
+	if mqRaw.TestResultsFormat != nil {
+		e.config.TestResultsFormat = *mqRaw.TestResultsFormat
+	}
+	if mqRaw.TestResultsPath != nil {
+		e.config.TestResultsPath = *mqRaw.TestResultsPath
+	}
+	if mqRaw.RerunCommand != nil {
+		e.config.RerunCommand = *mqRaw.RerunCommand
+	}
+	if mqRaw.FlakyThreshold != nil {
+		if t := *mqRaw.FlakyThreshold; t <= 0 || t > 1 {
+			return fmt.Errorf("invalid flaky_threshold %v: must be above 0 and at most 1", t)
+		}
+		e.config.FlakyThreshold = *mqRaw.FlakyThreshold
+	}
+	if mqRaw.FlakyMinRuns != nil {
+		e.config.FlakyMinRuns = *mqRaw.FlakyMinRuns
+	}
+	if mqRaw.AllowQuarantinedFailures != nil {
+		e.config.AllowQuarantinedFailures = *mqRaw.AllowQuarantinedFailures
+	}
+	if mqRaw.PostMergeCheck != nil {
+		e.config.PostMergeCheck = *mqRaw.PostMergeCheck
+	}
+	if mqRaw.PostMergeWindow != nil {
+		e.config.PostMergeWindow = *mqRaw.PostMergeWindow
+	}
 	if mqRaw.PollInterval != nil {
 		dur, err := time.ParseDuration(*mqRaw.PollInterval)
 		if err != nil {
@@ -205,6 +285,8 @@ type ProcessResult struct {
 	Error       string
 	Conflict    bool
 	TestsFailed bool
+	FailureType FailureType         // Why the merge failed (FailureNone on success)
+	Tests       *testhistory.Report // Per-test outcome, when test results were parsed
 }
 
 // ProcessMR processes a single merge request from a beads issue.
@@ -224,18 +306,19 @@ func (e *Engineer) ProcessMR(ctx context.Context, mr *beads.Issue) ProcessResult
 	_, _ = fmt.Fprintf(e.output, "  Target: %s\n", mrFields.Target)
 	_, _ = fmt.Fprintf(e.output, "  Worker: %s\n", mrFields.Worker)
 
-	return e.doMerge(ctx, mrFields.Branch, mrFields.Target, mrFields.SourceIssue)
+	return e.doMerge(ctx, mr.ID, mrFields.Branch, mrFields.Target, mrFields.SourceIssue)
 }
 
 // doMerge performs the actual git merge operation.
 // This is the core merge logic shared by ProcessMR and ProcessMRFromQueue.
-func (e *Engineer) doMerge(ctx context.Context, branch, target, sourceIssue string) ProcessResult {
+func (e *Engineer) doMerge(ctx context.Context, mrID, branch, target, sourceIssue string) ProcessResult {
 	// Step 1: Fetch the source branch from origin
 	_, _ = fmt.Fprintf(e.output, "[Engineer] Fetching branch %s from origin...\n", branch)
 	if err := e.git.FetchBranch("origin", branch); err != nil {
 		return ProcessResult{
-			Success: false,
-			Error:   fmt.Sprintf("failed to fetch branch %s: %v", branch, err),
+			Success:     false,
+			Error:       fmt.Sprintf("failed to fetch branch %s: %v", branch, err),
+			FailureType: FailureFetch,
 		}
 	}
 
@@ -243,8 +326,9 @@ func (e *Engineer) doMerge(ctx context.Context, branch, target, sourceIssue stri
 	_, _ = fmt.Fprintf(e.output, "[Engineer] Checking out target branch %s...\n", target)
 	if err := e.git.Checkout(target); err != nil {
 		return ProcessResult{
-			Success: false,
-			Error:   fmt.Sprintf("failed to checkout target %s: %v", target, err),
+			Success:     false,
+			Error:       fmt.Sprintf("failed to checkout target %s: %v", target, err),
+			FailureType: FailureCheckout,
 		}
 	}
 
@@ -260,30 +344,36 @@ func (e *Engineer) doMerge(ctx context.Context, branch, target, sourceIssue stri
 	conflicts, err := e.git.CheckConflicts(remoteBranch, target)
 	if err != nil {
 		return ProcessResult{
-			Success:  false,
-			Conflict: true,
-			Error:    fmt.Sprintf("conflict check failed: %v", err),
+			Success:     false,
+			Conflict:    true,
+			Error:       fmt.Sprintf("conflict check failed: %v", err),
+			FailureType: FailureConflict,
 		}
 	}
 	if len(conflicts) > 0 {
 		return ProcessResult{
-			Success:  false,
-			Conflict: true,
-			Error:    fmt.Sprintf("merge conflicts in: %v", conflicts),
+			Success:     false,
+			Conflict:    true,
+			Error:       fmt.Sprintf("merge conflicts in: %v", conflicts),
+			FailureType: FailureConflict,
 		}
 	}
 
 	// Step 4: Run tests if configured
+	var tests *testhistory.Report
 	if e.config.RunTests && e.config.TestCommand != "" {
 		_, _ = fmt.Fprintf(e.output, "[Engineer] Running tests: %s\n", e.config.TestCommand)
-		result := e.runTests(ctx)
+		result := e.runTests(ctx, mrID)
 		if !result.Success {
 			return ProcessResult{
 				Success:     false,
 				TestsFailed: true,
 				Error:       result.Error,
+				FailureType: FailureTestsFail,
+				Tests:       result.Tests,
 			}
 		}
+		tests = result.Tests
 		_, _ = fmt.Fprintln(e.output, "[Engineer] Tests passed")
 	}
 
@@ -297,14 +387,16 @@ func (e *Engineer) doMerge(ctx context.Context, branch, target, sourceIssue stri
 		if errors.Is(err, git.ErrMergeConflict) {
 			_ = e.git.AbortMerge()
 			return ProcessResult{
-				Success:  false,
-				Conflict: true,
-				Error:    "merge conflict during actual merge",
+				Success:     false,
+				Conflict:    true,
+				Error:       "merge conflict during actual merge",
+				FailureType: FailureConflict,
 			}
 		}
 		return ProcessResult{
-			Success: false,
-			Error:   fmt.Sprintf("merge failed: %v", err),
+			Success:     false,
+			Error:       fmt.Sprintf("merge failed: %v", err),
+			FailureType: FailureMergeFail,
 		}
 	}
 
@@ -312,8 +404,9 @@ func (e *Engineer) doMerge(ctx context.Context, branch, target, sourceIssue stri
 	mergeCommit, err := e.git.Rev("HEAD")
 	if err != nil {
 		return ProcessResult{
-			Success: false,
-			Error:   fmt.Sprintf("failed to get merge commit SHA: %v", err),
+			Success:     false,
+			Error:       fmt.Sprintf("failed to get merge commit SHA: %v", err),
+			FailureType: FailureMergeFail,
 		}
 	}
 
@@ -321,8 +414,9 @@ func (e *Engineer) doMerge(ctx context.Context, branch, target, sourceIssue stri
 	_, _ = fmt.Fprintf(e.output, "[Engineer] Pushing to origin/%s...\n", target)
 	if err := e.git.Push("origin", target, false); err != nil {
 		return ProcessResult{
-			Success: false,
-			Error:   fmt.Sprintf("failed to push to origin: %v", err),
+			Success:     false,
+			Error:       fmt.Sprintf("failed to push to origin: %v", err),
+			FailureType: FailurePushFail,
 		}
 	}
 
@@ -330,11 +424,15 @@ func (e *Engineer) doMerge(ctx context.Context, branch, target, sourceIssue stri
 	return ProcessResult{
 		Success:     true,
 		MergeCommit: mergeCommit,
+		Tests:       tests,
 	}
 }
 
 // runTests runs the configured test command and returns the result.
-func (e *Engineer) runTests(ctx context.Context) ProcessResult {
+// When the output holds parsable test results, failing tests are rerun on
+// their own and classified against the rig's test history (see
+// runParsedTests); otherwise the whole command is retried.
+func (e *Engineer) runTests(ctx context.Context, mrID string) ProcessResult {
 	if e.config.TestCommand == "" {
 		return ProcessResult{Success: true}
 	}
@@ -351,15 +449,12 @@ func (e *Engineer) runTests(ctx context.Context) ProcessResult {
 			_, _ = fmt.Fprintf(e.output, "[Engineer] Retrying tests (attempt %d/%d)...\n", attempt, maxRetries)
 		}
 
-		// Note: TestCommand comes from rig's config.json (trusted infrastructure config),
-		// not from PR branches. Shell execution is intentional for flexibility (pipes, etc).
-		cmd := exec.CommandContext(ctx, "sh", "-c", e.config.TestCommand) //nolint:gosec // G204: TestCommand is from trusted rig config
-		cmd.Dir = e.workDir
-		var stdout, stderr bytes.Buffer
-		cmd.Stdout = &stdout
-		cmd.Stderr = &stderr
-
-		err := cmd.Run()
+		output, err := e.runTestCommand(ctx, e.config.TestCommand)
+		if attempt == 1 {
+			if run, perr := e.parseTestResults(output); perr == nil {
+				return e.runParsedTests(ctx, mrID, run, err)
+			}
+		}
 		if err == nil {
 			return ProcessResult{Success: true}
 		}
@@ -381,6 +476,27 @@ func (e *Engineer) runTests(ctx context.Context) ProcessResult {
 	}
 }
 
+// RunTests runs the test command for an MR in the work dir, like the
+// pre-merge gate: failing tests are rerun and the outcomes recorded in the
+// rig's test history under mrID.
+func (e *Engineer) RunTests(ctx context.Context, mrID string) ProcessResult {
+	return e.runTests(ctx, mrID)
+}
+
+// runTestCommand runs a test command in the work dir and returns its stdout.
+func (e *Engineer) runTestCommand(ctx context.Context, command string) ([]byte, error) {
+	e.removeTestResults()
+	// Note: TestCommand comes from rig's config.json (trusted infrastructure config),
+	// not from PR branches. Shell execution is intentional for flexibility (pipes, etc).
+	cmd := exec.CommandContext(ctx, "sh", "-c", command) //nolint:gosec // G204: TestCommand is from trusted rig config
+	cmd.Dir = e.workDir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	return stdout.Bytes(), err
+}
+
 // handleSuccess handles a successful merge completion.
 // Steps:
 // 1. Update MR with merge_commit SHA
@@ -452,7 +568,27 @@ func (e *Engineer) handleFailure(mr *beads.Issue, result ProcessResult) {
 }
 
 // ProcessMRFromQueue processes a merge request from wisp queue.
-func (e *Engineer) ProcessMRFromQueue(ctx context.Context, mr *mrqueue.MR) ProcessResult {
+// The merge is traced as a child of the span that submitted the MR.
+func (e *Engineer) ProcessMRFromQueue(ctx context.Context, mr *mrqueue.MR) (result ProcessResult) {
+	ctx, span := tracing.Start(tracing.ContextWithParent(ctx, e.mrTraceParent(mr)), "refinery.process_mr")
+	span.SetAttr(tracing.AttrMR, mr.ID)
+	span.SetAttr(tracing.AttrBead, mr.SourceIssue)
+	span.SetAttr(tracing.AttrConvoy, mr.ConvoyID)
+	span.SetAttr(tracing.AttrRig, e.rig.Name)
+	span.SetAttr(tracing.AttrPolecat, mr.Worker)
+	span.SetAttr(tracing.AttrBranch, mr.Branch)
+	span.SetAttr(tracing.AttrTarget, mr.Target)
+	defer func() {
+		span.SetAttr("gt.merge_commit", result.MergeCommit)
+		if result.Success {
+			span.SetOK()
+		} else {
+			span.SetAttr("gt.failure_type", string(result.FailureType))
+			span.SetError(errors.New(result.Error))
+		}
+		span.End()
+	}()
+
 	// MR fields are directly on the struct (no parsing needed)
 	_, _ = fmt.Fprintln(e.output, "[Engineer] Processing MR from queue:")
 	_, _ = fmt.Fprintf(e.output, "  Branch: %s\n", mr.Branch)
@@ -460,17 +596,47 @@ func (e *Engineer) ProcessMRFromQueue(ctx context.Context, mr *mrqueue.MR) Proce
 	_, _ = fmt.Fprintf(e.output, "  Worker: %s\n", mr.Worker)
 	_, _ = fmt.Fprintf(e.output, "  Source: %s\n", mr.SourceIssue)
 
+	// Stacked MRs merge only after their parent
+	if e.mrQueue.IsStackedOnQueued(mr) {
+		return ProcessResult{
+			Success:     false,
+			Error:       fmt.Sprintf("stacked on %s, which has not merged yet", mr.ParentMR),
+			FailureType: FailureStackParent,
+		}
+	}
+
 	// Emit merge_started event
 	if err := e.eventLogger.LogMergeStarted(mr); err != nil {
 		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to log merge_started event: %v\n", err)
 	}
 
 	// Use the shared merge logic
-	return e.doMerge(ctx, mr.Branch, mr.Target, mr.SourceIssue)
+	return e.doMerge(ctx, mr.ID, mr.Branch, mr.Target, mr.SourceIssue)
+}
+
+// mrTraceParent returns the trace context an MR was submitted under.
+// Queue entries carry it directly; otherwise it is read from the MR bead's
+// trace_parent field (written by gt done). The bead lookup is skipped when
+// tracing is not exporting anywhere.
+func (e *Engineer) mrTraceParent(mr *mrqueue.MR) string {
+	if mr.TraceParent != "" {
+		return mr.TraceParent
+	}
+	if mr.ID == "" || !tracing.Enabled() {
+		return ""
+	}
+	mrBead, err := e.beads.Show(mr.ID)
+	if err != nil {
+		return ""
+	}
+	if fields := beads.ParseMRFields(mrBead); fields != nil {
+		return fields.TraceParent
+	}
+	return ""
 }
 
 // handleSuccessFromQueue handles a successful merge from wisp queue.
-func (e *Engineer) handleSuccessFromQueue(mr *mrqueue.MR, result ProcessResult) {
+func (e *Engineer) handleSuccessFromQueue(ctx context.Context, mr *mrqueue.MR, result ProcessResult) {
 	// Emit merged event
 	if err := e.eventLogger.LogMerged(mr, result.MergeCommit); err != nil {
 		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to log merged event: %v\n", err)
@@ -549,16 +715,33 @@ func (e *Engineer) handleSuccessFromQueue(mr *mrqueue.MR, result ProcessResult)
 		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to remove MR from queue: %v\n", err)
 	}
 
+	// 3.5. Move MRs stacked on this one onto its target
+	e.restackChildren(mr)
+
 	// 4. Log success
 	_, _ = fmt.Fprintf(e.output, "[Engineer] ✓ Merged: %s (commit: %s)\n", mr.ID, result.MergeCommit)
+
+	// 5. Check the target still passes with this merge on top of recent ones
+	if e.config.PostMergeCheck {
+		e.VerifyTarget(ctx, mr.Target)
+	}
 }
 
 // handleFailureFromQueue handles a failed merge from wisp queue.
 // For conflicts, creates a resolution task and blocks the MR until resolved.
 // This enables non-blocking delegation: the queue continues to the next MR.
 func (e *Engineer) handleFailureFromQueue(mr *mrqueue.MR, result ProcessResult) {
+	// A stacked MR whose parent hasn't merged just waits; it has not failed
+	if result.FailureType == FailureStackParent {
+		if err := e.eventLogger.LogMergeSkipped(mr, result.Error); err != nil {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to log merge_skipped event: %v\n", err)
+		}
+		_, _ = fmt.Fprintf(e.output, "[Engineer] MR %s waits for %s to merge\n", mr.ID, mr.ParentMR)
+		return
+	}
+
 	// Emit merge_failed event
-	if err := e.eventLogger.LogMergeFailed(mr, result.Error); err != nil {
+	if err := e.eventLogger.LogMergeFailed(mr, string(result.FailureType), result.Error); err != nil {
 		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to log merge_failed event: %v\n", err)
 	}
 
@@ -581,6 +764,9 @@ func (e *Engineer) handleFailureFromQueue(mr *mrqueue.MR, result ProcessResult)
 
 	// Log the failure - MR stays in queue but may be blocked
 	_, _ = fmt.Fprintf(e.output, "[Engineer] ✗ Failed: %s - %s\n", mr.ID, result.Error)
+	if stack, err := e.mrQueue.Stack(mr.ID); err == nil && len(stack) > 1 {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] %d stacked MR(s) wait on %s\n", len(stack)-1, mr.ID)
+	}
 	if mr.BlockedBy != "" {
 		_, _ = fmt.Fprintln(e.output, "[Engineer] MR blocked pending conflict resolution - queue continues to next MR")
 	} else {
@@ -671,7 +857,7 @@ func (e *Engineer) createConflictResolutionTask(mr *mrqueue.MR, _ ProcessResult)
 5. Force-push the resolved branch: git push -f
 6. Close this task: bd close <this-task-id>
 
-The Refinery will automatically retry the merge after you force-push.`,
+The Refinery will automatically retry the merge after you force-push.%s`,
 		mr.Branch,
 		mr.ID,
 		mr.Branch,
@@ -680,6 +866,7 @@ The Refinery will automatically retry the merge after you force-push.`,
 		retryCount,
 		mr.Branch,
 		mr.Target,
+		e.stackNote(mr),
 	)
 
 	// Create the conflict resolution task
@@ -706,6 +893,117 @@ The Refinery will automatically retry the merge after you force-push.`,
 	return task.ID, nil
 }
 
+// stackNote describes the MRs stacked on mr for its conflict-resolution
+// task, or returns "" when nothing is stacked on it.
+func (e *Engineer) stackNote(mr *mrqueue.MR) string {
+	stack, err := e.mrQueue.Stack(mr.ID)
+	if err != nil || len(stack) < 2 {
+		return ""
+	}
+	var ids []string
+	for _, child := range stack[1:] {
+		ids = append(ids, child.ID)
+	}
+	return fmt.Sprintf(`
+
+Stacked on this branch: %s
+They merge after this MR and are rebased onto %s by the Refinery.`,
+		strings.Join(ids, ", "), mr.Target)
+}
+
+// Restack moves the MRs stacked on the merged MR mrID onto its target (see
+// restackChildren) and returns them. The patrol runs it through gt refinery
+// restack after each merge, before the merged branch is deleted. The merged
+// MR is read from the MR bead if it has already left the queue.
+func (e *Engineer) Restack(mrID string) ([]*mrqueue.MR, error) {
+	parent, err := e.mrQueue.Get(mrID)
+	if err != nil {
+		mrBead, berr := e.beads.Show(mrID)
+		if berr != nil {
+			return nil, fmt.Errorf("looking up MR %s: %w", mrID, berr)
+		}
+		fields := beads.ParseMRFields(mrBead)
+		if fields == nil || fields.Branch == "" {
+			return nil, fmt.Errorf("MR %s has no branch", mrID)
+		}
+		parent = &mrqueue.MR{ID: mrID, Branch: fields.Branch, Target: fields.Target, ParentMR: fields.ParentMR}
+	}
+	return e.restackChildren(parent), nil
+}
+
+// restackChildren moves the MRs stacked on a merged parent onto the
+// parent's target and rebases their branches so they carry only their own
+// commits. A child that fails to rebase is blocked on a conflict-resolution
+// task, like any other conflicting MR; its own children keep waiting on it.
+// Returns the children that were moved.
+func (e *Engineer) restackChildren(parent *mrqueue.MR) []*mrqueue.MR {
+	children, err := e.mrQueue.Restack(parent)
+	if err != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to restack MRs on %s: %v\n", parent.ID, err)
+		return nil
+	}
+
+	for _, child := range children {
+		e.updateStackedMRBead(child)
+
+		if err := e.rebaseStacked(child, parent.Branch); err != nil {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to rebase stacked MR %s onto %s: %v\n", child.ID, child.Target, err)
+			result := ProcessResult{Conflict: true, Error: err.Error(), FailureType: FailureConflict}
+			e.handleFailureFromQueue(child, result)
+			continue
+		}
+
+		if err := e.eventLogger.LogRestacked(child, parent.ID); err != nil {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to log restacked event: %v\n", err)
+		}
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Restacked %s onto %s\n", child.ID, child.Target)
+	}
+	return children
+}
+
+// rebaseStacked replays a stacked MR's own commits onto its new target and
+// force-pushes the branch. The parent's commits are already in the target
+// through the parent's merge, so they are dropped.
+func (e *Engineer) rebaseStacked(child *mrqueue.MR, parentBranch string) error {
+	if err := e.git.FetchBranch("origin", child.Branch); err != nil {
+		return fmt.Errorf("fetching %s: %w", child.Branch, err)
+	}
+	if err := e.git.ResetBranch(child.Branch, "origin/"+child.Branch); err != nil {
+		return fmt.Errorf("resetting %s: %w", child.Branch, err)
+	}
+
+	// Return to the target whatever happens, ready for the next MR
+	defer func() { _ = e.git.Checkout(child.Target) }()
+
+	if err := e.git.RebaseOnto(child.Target, "origin/"+parentBranch, child.Branch); err != nil {
+		_ = e.git.AbortRebase()
+		return err
+	}
+	if err := e.git.Push("origin", child.Branch, true); err != nil {
+		return fmt.Errorf("pushing %s: %w", child.Branch, err)
+	}
+	return nil
+}
+
+// updateStackedMRBead records a restacked MR's new parent and target on its
+// MR bead, so gt mq list shows it in its new place.
+func (e *Engineer) updateStackedMRBead(mr *mrqueue.MR) {
+	mrBead, err := e.beads.Show(mr.ID)
+	if err != nil {
+		return // Queue-only MR
+	}
+	fields := beads.ParseMRFields(mrBead)
+	if fields == nil {
+		fields = &beads.MRFields{}
+	}
+	fields.ParentMR = mr.ParentMR
+	fields.Target = mr.Target
+	newDesc := beads.SetMRFields(mrBead, fields)
+	if err := e.beads.Update(mr.ID, beads.UpdateOptions{Description: &newDesc}); err != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to update MR %s after restack: %v\n", mr.ID, err)
+	}
+}
+
 // IsBeadOpen checks if a bead is still open (not closed).
 // This is used as a status checker for mrqueue.ListReady to filter blocked MRs.
 func (e *Engineer) IsBeadOpen(beadID string) (bool, error) {
@@ -1,12 +1,14 @@
 package refinery
 
 import (
+	"bytes"
 	"encoding/json"
 	"os"
 	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/steveyegge/gastown/internal/mrqueue"
 	"github.com/steveyegge/gastown/internal/rig"
 )
 
@@ -76,6 +78,12 @@ func TestEngineer_LoadConfig_WithMergeQueue(t *testing.T) {
 			"max_concurrent": 2,
 			"run_tests":      false,
 			"test_command":   "make test",
+
+			"test_results_format":        "junit",
+			"test_results_path":          "report.xml",
+			"allow_quarantined_failures": true,
+			"flaky_threshold":            0.5,
+			"post_merge_check":           true,
 		},
 	}
 
@@ -112,10 +120,29 @@ func TestEngineer_LoadConfig_WithMergeQueue(t *testing.T) {
 		t.Errorf("expected TestCommand 'make test', got %q", e.config.TestCommand)
 	}
 
+	if e.config.TestResultsFormat != "junit" || e.config.TestResultsPath != "report.xml" {
+		t.Errorf("expected junit results at report.xml, got %q at %q", e.config.TestResultsFormat, e.config.TestResultsPath)
+	}
+	if !e.config.AllowQuarantinedFailures {
+		t.Error("expected AllowQuarantinedFailures true")
+	}
+	if e.config.FlakyThreshold != 0.5 {
+		t.Errorf("expected FlakyThreshold 0.5, got %v", e.config.FlakyThreshold)
+	}
+	if !e.config.PostMergeCheck {
+		t.Error("expected PostMergeCheck true")
+	}
+
 	// Check that defaults are preserved for unspecified fields
 	if e.config.OnConflict != "assign_back" {
 		t.Errorf("expected OnConflict default 'assign_back', got %q", e.config.OnConflict)
 	}
+	if e.config.FlakyMinRuns != 10 {
+		t.Errorf("expected FlakyMinRuns default 10, got %d", e.config.FlakyMinRuns)
+	}
+	if e.config.PostMergeWindow != 10 {
+		t.Errorf("expected PostMergeWindow default 10, got %d", e.config.PostMergeWindow)
+	}
 }
 
 func TestEngineer_LoadConfig_NoMergeQueueSection(t *testing.T) {
@@ -186,6 +213,19 @@ func TestEngineer_LoadConfig_InvalidPollInterval(t *testing.T) {
 	}
 }
 
+func TestEngineer_LoadConfig_ZeroFlakyThreshold(t *testing.T) {
+	tmpDir := t.TempDir()
+	data := []byte(`{"merge_queue": {"flaky_threshold": 0}}`)
+	if err := os.WriteFile(filepath.Join(tmpDir, "config.json"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	e := NewEngineer(&rig.Rig{Name: "test-rig", Path: tmpDir})
+	if err := e.LoadConfig(); err == nil {
+		t.Error("expected error for flaky_threshold 0, which would quarantine every test")
+	}
+}
+
 func TestNewEngineer(t *testing.T) {
 	r := &rig.Rig{
 		Name: "test-rig",
@@ -215,3 +255,62 @@ func TestEngineer_DeleteMergedBranchesConfig(t *testing.T) {
 		t.Error("expected DeleteMergedBranches to be true by default")
 	}
 }
+
+func TestEngineer_Restack(t *testing.T) {
+	origin := filepath.Join(t.TempDir(), "origin.git")
+	runGit(t, t.TempDir(), "init", "--bare", "-b", "main", origin)
+	rigPath := filepath.Join(t.TempDir(), "rig")
+	runGit(t, filepath.Dir(rigPath), "clone", origin, rigPath)
+	runGit(t, rigPath, "config", "user.email", "test@test.com")
+	runGit(t, rigPath, "config", "user.name", "Test User")
+	runGit(t, rigPath, "checkout", "-b", "main")
+	runGit(t, rigPath, "commit", "--allow-empty", "-m", "initial")
+	runGit(t, rigPath, "push", "origin", "main")
+
+	// The child branch is built on the parent's unmerged commit
+	commit := func(name string) {
+		if err := os.WriteFile(filepath.Join(rigPath, name), []byte(name+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		runGit(t, rigPath, "add", name)
+		runGit(t, rigPath, "commit", "-m", "add "+name)
+	}
+	runGit(t, rigPath, "checkout", "-b", "polecat/parent", "main")
+	commit("parent")
+	runGit(t, rigPath, "checkout", "-b", "polecat/child")
+	commit("child")
+	runGit(t, rigPath, "push", "origin", "polecat/parent", "polecat/child")
+
+	// Squash-merge the parent, so main doesn't share its commit
+	runGit(t, rigPath, "checkout", "main")
+	runGit(t, rigPath, "merge", "--squash", "polecat/parent")
+	runGit(t, rigPath, "commit", "-m", "Merge polecat/parent")
+	runGit(t, rigPath, "push", "origin", "main")
+
+	e := NewEngineer(&rig.Rig{Name: "gastown", Path: rigPath})
+	e.SetOutput(&bytes.Buffer{})
+	for _, mr := range []*mrqueue.MR{
+		{ID: "gt-mr1", Branch: "polecat/parent", Target: "main"},
+		{ID: "gt-mr2", Branch: "polecat/child", Target: "main", ParentMR: "gt-mr1"},
+	} {
+		if err := e.mrQueue.Submit(mr); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	children, err := e.Restack("gt-mr1")
+	if err != nil {
+		t.Fatalf("Restack() error = %v", err)
+	}
+	if len(children) != 1 || children[0].ID != "gt-mr2" || children[0].ParentMR != "" {
+		t.Fatalf("Restack() = %+v, want gt-mr2 with no parent", children)
+	}
+
+	runGit(t, rigPath, "fetch", "origin")
+	if log := runGit(t, rigPath, "log", "--format=%s", "origin/main..origin/polecat/child"); log != "add child" {
+		t.Errorf("child branch carries %q beyond main, want only its own commit", log)
+	}
+	if child, err := e.mrQueue.Get("gt-mr2"); err != nil || child.ParentMR != "" {
+		t.Errorf("queued child = %+v, %v; want unstacked", child, err)
+	}
+}
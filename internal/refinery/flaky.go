@@ -0,0 +1,210 @@
+package refinery
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/testhistory"
+)
+
+// Flaky test handling.
+//
+// When the test command's results can be parsed, the refinery reruns only
+// the failing tests, records every outcome in the rig's test history, and
+// quarantines tests whose recent outcomes keep flipping. A newly quarantined
+// test gets a bug bead. With allow_quarantined_failures set, an MR whose
+// only failing tests are quarantined still merges.
+
+// parseTestResults parses the test command's results per the rig config.
+func (e *Engineer) parseTestResults(output []byte) (*testhistory.Run, error) {
+	return testhistory.Parse(e.config.TestResultsFormat, output, e.testResultsPath())
+}
+
+func (e *Engineer) testResultsPath() string {
+	if e.config.TestResultsPath == "" || filepath.IsAbs(e.config.TestResultsPath) {
+		return e.config.TestResultsPath
+	}
+	return filepath.Join(e.workDir, e.config.TestResultsPath)
+}
+
+// removeTestResults removes a JUnit report left by an earlier run, so a
+// command that dies before writing one is not judged by stale results.
+func (e *Engineer) removeTestResults() {
+	path := e.testResultsPath()
+	if path == "" {
+		return
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: removing stale test report: %v\n", err)
+	}
+}
+
+// rerunCommand returns the command that reruns the failed tests.
+func (e *Engineer) rerunCommand(run *testhistory.Run, failed []testhistory.Result) string {
+	switch {
+	case e.config.RerunCommand != "":
+		return testhistory.ExpandRerunCommand(e.config.RerunCommand, failed)
+	case run.Format == testhistory.FormatGoJSON:
+		return testhistory.GoRerunCommand(failed)
+	default:
+		return e.config.TestCommand
+	}
+}
+
+// runParsedTests finishes a test run whose results were parsed: it reruns
+// the failing tests, records the outcomes in the rig's test history, and
+// decides whether the MR may merge. cmdErr is the test command's exit
+// error; a non-zero exit that no failing test explains (a lint step, a
+// build error outside go test) fails the MR.
+func (e *Engineer) runParsedTests(ctx context.Context, mrID string, run *testhistory.Run, cmdErr error) ProcessResult {
+	failed := run.Failed()
+	var flaky []string
+	unexplained := cmdErr != nil && len(failed) == 0
+
+	for attempt := 1; attempt <= e.config.RetryFlakyTests && len(failed) > 0; attempt++ {
+		if ctx.Err() != nil {
+			return ProcessResult{
+				Success: false,
+				Error:   "test run canceled",
+			}
+		}
+		command := e.rerunCommand(run, failed)
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Rerunning %d failing test(s) (attempt %d/%d): %s\n",
+			len(failed), attempt, e.config.RetryFlakyTests, command)
+		output, _ := e.runTestCommand(ctx, command)
+		rerun, err := e.parseTestResults(output)
+		if err != nil {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: parsing rerun results: %v\n", err)
+			break
+		}
+
+		outcomes := make(map[string]testhistory.Outcome, len(rerun.Results))
+		for _, res := range rerun.Results {
+			outcomes[res.ID()] = res.Outcome
+		}
+		var still []testhistory.Result
+		for _, res := range failed {
+			if outcomes[res.ID()] == testhistory.Pass {
+				flaky = append(flaky, res.ID())
+			} else {
+				still = append(still, res)
+			}
+		}
+		failed = still
+	}
+
+	now := time.Now()
+	report := &testhistory.Report{
+		MR:     mrID,
+		Time:   now,
+		Format: run.Format,
+		Flaky:  flaky,
+	}
+	for _, res := range failed {
+		report.Failed = append(report.Failed, res.ID())
+	}
+
+	err := testhistory.Update(e.rig.Path, func(h *testhistory.History) error {
+		h.Record(run, flaky, now)
+		report.NewlyFlaky = h.Classify(e.config.FlakyThreshold, e.config.FlakyMinRuns, now)
+		for _, id := range report.Failed {
+			if h.IsQuarantined(id) {
+				report.Quarantined = append(report.Quarantined, id)
+			}
+		}
+		report.Allowed = len(report.Failed) > 0 &&
+			e.config.AllowQuarantinedFailures &&
+			len(report.Quarantined) == len(report.Failed)
+		if mrID != "" {
+			h.SetReport(report)
+		}
+		return nil
+	})
+	if err != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: updating test history: %v\n", err)
+	}
+
+	e.fileFlakyTestBeads(report.NewlyFlaky)
+
+	if len(flaky) > 0 {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Passed on rerun: %s\n", strings.Join(flaky, ", "))
+	}
+	if unexplained {
+		return ProcessResult{
+			Success:     false,
+			TestsFailed: true,
+			Error:       fmt.Sprintf("test command failed with no failing tests reported: %v", cmdErr),
+			Tests:       report,
+		}
+	}
+	if len(report.Failed) == 0 {
+		return ProcessResult{Success: true, Tests: report}
+	}
+	if report.Allowed {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Only quarantined tests failed, allowing merge: %s\n",
+			strings.Join(report.Failed, ", "))
+		return ProcessResult{Success: true, Tests: report}
+	}
+	return ProcessResult{
+		Success:     false,
+		TestsFailed: true,
+		Error:       "tests failed: " + summarizeTests(report.Failed, 5),
+		Tests:       report,
+	}
+}
+
+// fileFlakyTestBeads files a bug bead for each newly quarantined test and
+// links it from the test's history record.
+func (e *Engineer) fileFlakyTestBeads(ids []string) {
+	if len(ids) == 0 {
+		return
+	}
+	filed := make(map[string]string, len(ids))
+	for _, id := range ids {
+		description := fmt.Sprintf("Test %s flips between passing and failing in the %s refinery "+
+			"and has been quarantined.\n\n"+
+			"See .runtime/test-history.json in the rig for its recent outcomes. "+
+			"The quarantine lifts after %d consecutive passes.",
+			id, e.rig.Name, testhistory.ReleaseAfter)
+		bead, err := e.beads.Create(beads.CreateOptions{
+			Title:       "Flaky test: " + id,
+			Type:        "bug",
+			Priority:    2,
+			Description: description,
+			Actor:       e.rig.Name + "/refinery",
+		})
+		if err != nil {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: filing flaky test bead for %s: %v\n", id, err)
+			continue
+		}
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Quarantined flaky test %s (%s)\n", id, bead.ID)
+		filed[id] = bead.ID
+	}
+	if len(filed) == 0 {
+		return
+	}
+	err := testhistory.Update(e.rig.Path, func(h *testhistory.History) error {
+		for id, beadID := range filed {
+			if rec := h.Tests[id]; rec != nil {
+				rec.Bead = beadID
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: updating test history: %v\n", err)
+	}
+}
+
+// summarizeTests joins test IDs, eliding all but the first limit.
+func summarizeTests(ids []string, limit int) string {
+	if len(ids) <= limit {
+		return strings.Join(ids, ", ")
+	}
+	return fmt.Sprintf("%s (+%d more)", strings.Join(ids[:limit], ", "), len(ids)-limit)
+}
@@ -0,0 +1,131 @@
+package refinery
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/testhistory"
+)
+
+// newFlakyEngineer returns an engineer whose test command prints first and
+// whose rerun command prints rerun, both as go test -json output.
+func newFlakyEngineer(t *testing.T, first, rerun []string) *Engineer {
+	t.Helper()
+	dir := t.TempDir()
+	write := func(name string, lines []string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("first.json", first)
+	write("rerun.json", rerun)
+
+	e := NewEngineer(&rig.Rig{Name: "test-rig", Path: dir})
+	e.SetOutput(&bytes.Buffer{})
+	e.config.TestCommand = "cat first.json; exit 1"
+	e.config.RerunCommand = "cat rerun.json # {run}"
+	return e
+}
+
+func TestRunTests_RerunsOnlyFailingTests(t *testing.T) {
+	e := newFlakyEngineer(t,
+		[]string{
+			`{"Action":"pass","Package":"example.com/a","Test":"TestStable"}`,
+			`{"Action":"fail","Package":"example.com/a","Test":"TestFlaky"}`,
+			`{"Action":"fail","Package":"example.com/a"}`,
+		},
+		[]string{
+			`{"Action":"pass","Package":"example.com/a","Test":"TestFlaky"}`,
+		})
+
+	result := e.runTests(context.Background(), "gt-mr1")
+	if !result.Success {
+		t.Fatalf("runTests() failed: %s", result.Error)
+	}
+	if result.Tests == nil || len(result.Tests.Flaky) != 1 || result.Tests.Flaky[0] != "example.com/a.TestFlaky" {
+		t.Fatalf("report = %+v, want TestFlaky passing on rerun", result.Tests)
+	}
+
+	h, err := testhistory.Load(e.rig.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec := h.Tests["example.com/a.TestFlaky"]; rec == nil || rec.Recent != "R" {
+		t.Errorf("TestFlaky history = %+v, want R", rec)
+	}
+	if h.MRs["gt-mr1"] == nil {
+		t.Error("MR report not saved")
+	}
+}
+
+func TestRunTests_QuarantinedFailures(t *testing.T) {
+	failing := []string{`{"Action":"fail","Package":"example.com/a","Test":"TestFlaky"}`}
+
+	for _, allow := range []bool{false, true} {
+		e := newFlakyEngineer(t, failing, failing)
+		e.config.AllowQuarantinedFailures = allow
+		err := testhistory.Update(e.rig.Path, func(h *testhistory.History) error {
+			h.Tests["example.com/a.TestFlaky"] = &testhistory.TestRecord{Recent: "PRPR", Quarantined: true, QuarantinedAt: time.Now()}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		result := e.runTests(context.Background(), "gt-mr1")
+		if result.Success != allow {
+			t.Errorf("allow_quarantined_failures=%v: Success = %v (%s)", allow, result.Success, result.Error)
+		}
+		if result.Tests == nil || len(result.Tests.Quarantined) != 1 || result.Tests.Allowed != allow {
+			t.Errorf("allow_quarantined_failures=%v: report = %+v", allow, result.Tests)
+		}
+	}
+}
+
+func TestRunTests_UnparsableOutputRetriesCommand(t *testing.T) {
+	e := newFlakyEngineer(t, nil, nil)
+	e.config.TestCommand = "echo FAIL; exit 1"
+	e.config.RetryFlakyTests = 2
+
+	result := e.runTests(context.Background(), "gt-mr1")
+	if result.Success || result.Tests != nil || !strings.Contains(result.Error, "after 2 attempts") {
+		t.Errorf("runTests() = %+v, want whole-command retry failure", result)
+	}
+}
+
+func TestRunTests_FailsOnUnexplainedExit(t *testing.T) {
+	// go test passes, then a later step in the command (a linter) fails.
+	e := newFlakyEngineer(t,
+		[]string{`{"Action":"pass","Package":"example.com/a","Test":"TestStable"}`}, nil)
+
+	result := e.runTests(context.Background(), "gt-mr1")
+	if result.Success || !result.TestsFailed || !strings.Contains(result.Error, "no failing tests reported") {
+		t.Errorf("runTests() = %+v, want failure from the exit status", result)
+	}
+}
+
+func TestRunTests_IgnoresStaleJUnitReport(t *testing.T) {
+	e := newFlakyEngineer(t, nil, nil)
+	e.config.TestResultsFormat = testhistory.FormatJUnit
+	e.config.TestResultsPath = "report.xml"
+	stale := `<testsuite name="a"><testcase classname="a" name="TestStable"/></testsuite>`
+	if err := os.WriteFile(filepath.Join(e.rig.Path, "report.xml"), []byte(stale), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// The command dies before writing a report.
+	e.config.TestCommand = "exit 1"
+
+	result := e.runTests(context.Background(), "gt-mr1")
+	if result.Success || result.Tests != nil {
+		t.Errorf("runTests() = %+v, want failure without reading the stale report", result)
+	}
+	if _, err := os.Stat(filepath.Join(e.rig.Path, "report.xml")); !os.IsNotExist(err) {
+		t.Error("stale report should be removed before the run")
+	}
+}
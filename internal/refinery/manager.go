@@ -17,6 +17,7 @@ import (
 	"github.com/steveyegge/gastown/internal/claude"
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/limits"
 	"github.com/steveyegge/gastown/internal/mail"
 	"github.com/steveyegge/gastown/internal/mrqueue"
 	"github.com/steveyegge/gastown/internal/rig"
@@ -204,7 +205,8 @@ func (m *Manager) Start(foreground bool) error {
 	// NOTE: No gt prime injection needed - SessionStart hook handles it automatically
 	// Restarts are handled by daemon via LIFECYCLE mail, not shell loops
 	// Export GT_ROLE and BD_ACTOR in the command since tmux SetEnvironment only affects new panes
-	command := config.BuildAgentStartupCommand("refinery", bdActor, "", "")
+	command := limits.WrapCommand(filepath.Dir(m.rig.Path), m.rig.Name, "refinery", sessionID,
+		config.BuildAgentStartupCommand("refinery", bdActor, "", ""))
 	if err := t.SendKeys(sessionID, command); err != nil {
 		// Clean up the session on failure (best-effort cleanup)
 		_ = t.KillSession(sessionID)
@@ -386,6 +388,7 @@ func (m *Manager) issueToMR(issue *beads.Issue) *MergeRequest {
 		Worker:       fields.Worker,
 		IssueID:      fields.SourceIssue,
 		TargetBranch: target,
+		ParentMR:     fields.ParentMR,
 		Status:       MROpen,
 		CreatedAt:    parseTime(issue.CreatedAt),
 	}
@@ -757,30 +760,82 @@ func (m *Manager) RegisterMR(mr *MergeRequest) error {
 
 // RejectMR manually rejects a merge request.
 // It closes the MR with rejected status and optionally notifies the worker.
-// Returns the rejected MR for display purposes.
-func (m *Manager) RejectMR(idOrBranch string, reason string, notify bool) (*MergeRequest, error) {
+// MRs stacked on it are rejected too, since their branches carry its
+// commits. Returns the rejected MR and the stacked MRs for display purposes.
+func (m *Manager) RejectMR(idOrBranch string, reason string, notify bool) (*MergeRequest, []*MergeRequest, error) {
 	mr, err := m.FindMR(idOrBranch)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	queue, err := m.Queue()
+	if err != nil {
+		return nil, nil, err
 	}
 
 	// Verify MR is open or in_progress (can't reject already closed)
 	if mr.IsClosed() {
-		return nil, fmt.Errorf("%w: MR is already closed with reason: %s", ErrClosedImmutable, mr.CloseReason)
+		return nil, nil, fmt.Errorf("%w: MR is already closed with reason: %s", ErrClosedImmutable, mr.CloseReason)
 	}
 
 	// Close with rejected reason
 	if err := mr.Close(CloseReasonRejected); err != nil {
-		return nil, fmt.Errorf("failed to close MR: %w", err)
+		return nil, nil, fmt.Errorf("failed to close MR: %w", err)
 	}
 	mr.Error = reason
-
-	// Optionally notify worker
 	if notify {
 		m.notifyWorkerRejected(mr, reason)
 	}
 
-	return mr, nil
+	// Reject the stack above it
+	var stacked []*MergeRequest
+	for _, child := range stackedOn(queue, mr.ID) {
+		if child.IsClosed() {
+			continue
+		}
+		if err := child.Close(CloseReasonRejected); err != nil {
+			_, _ = fmt.Fprintf(m.output, "Warning: failed to close stacked MR %s: %v\n", child.ID, err)
+			continue
+		}
+		child.Error = fmt.Sprintf("stacked on rejected %s: %s", mr.ID, reason)
+		if notify {
+			m.notifyWorkerRejected(child, child.Error)
+		}
+		stacked = append(stacked, child)
+	}
+
+	// Drop the stack from the refinery's work queue
+	if _, err := mrqueue.New(m.rig.Path).RemoveStack(mr.ID); err != nil && !errors.Is(err, mrqueue.ErrNotFound) {
+		_, _ = fmt.Fprintf(m.output, "Warning: failed to remove stack from queue: %v\n", err)
+	}
+
+	return mr, stacked, nil
+}
+
+// stackedOn returns the MRs in queue stacked, directly or not, on id,
+// parent-first.
+func stackedOn(queue []QueueItem, id string) []*MergeRequest {
+	children := make(map[string][]*MergeRequest)
+	for _, item := range queue {
+		if item.MR.ParentMR != "" && item.MR.ParentMR != item.MR.ID {
+			children[item.MR.ParentMR] = append(children[item.MR.ParentMR], item.MR)
+		}
+	}
+
+	var stacked []*MergeRequest
+	seen := map[string]bool{id: true}
+	var walk func(parent string)
+	walk = func(parent string) {
+		for _, child := range children[parent] {
+			if seen[child.ID] {
+				continue
+			}
+			seen[child.ID] = true
+			stacked = append(stacked, child)
+			walk(child.ID)
+		}
+	}
+	walk(id)
+	return stacked
 }
 
 // notifyWorkerRejected sends a rejection notification to a polecat.
@@ -170,3 +170,31 @@ func TestManager_RegisterMR(t *testing.T) {
 		t.Errorf("saved MR worker = %s, want Cheedo", saved.Worker)
 	}
 }
+
+func TestStackedOn(t *testing.T) {
+	queue := []QueueItem{
+		{MR: &MergeRequest{ID: "mr-root"}},
+		{MR: &MergeRequest{ID: "mr-mid", ParentMR: "mr-root"}},
+		{MR: &MergeRequest{ID: "mr-leaf", ParentMR: "mr-mid"}},
+		{MR: &MergeRequest{ID: "mr-side", ParentMR: "mr-root"}},
+		{MR: &MergeRequest{ID: "mr-other"}},
+	}
+
+	var got []string
+	for _, mr := range stackedOn(queue, "mr-root") {
+		got = append(got, mr.ID)
+	}
+	want := []string{"mr-mid", "mr-leaf", "mr-side"}
+	if len(got) != len(want) {
+		t.Fatalf("stackedOn(mr-root) = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("stackedOn(mr-root) = %v, want %v", got, want)
+		}
+	}
+
+	if stacked := stackedOn(queue, "mr-other"); len(stacked) != 0 {
+		t.Errorf("stackedOn(mr-other) = %d MRs, want none", len(stacked))
+	}
+}
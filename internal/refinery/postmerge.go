@@ -0,0 +1,368 @@
+package refinery
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/mrqueue"
+	"github.com/steveyegge/gastown/internal/protocol"
+	"github.com/steveyegge/gastown/internal/testhistory"
+)
+
+// Post-merge verification.
+//
+// Pre-merge tests only see one MR against the target as it was. Once
+// several MRs land, their combination can still break the target. With
+// post_merge_check set, the refinery runs the test command on the target's
+// new head after each merge. On failure it bisects the recent merges from
+// the MQ event log, reverts the first one whose merge commit fails, reopens
+// that MR's source issue with the failure log, and sends MERGE_FAILED to
+// the witness and the polecat.
+
+// maxFailureLog bounds the test output kept for notifications and beads.
+const maxFailureLog = 4000
+
+// PostMergeResult is the outcome of a post-merge check of a target branch.
+type PostMergeResult struct {
+	Target       string         `json:"target"`
+	Head         string         `json:"head"`
+	Passed       bool           `json:"passed"`
+	Culprit      *mrqueue.Event `json:"culprit,omitempty"`       // Merged event of the MR that broke the target
+	RevertCommit string         `json:"revert_commit,omitempty"` // Revert of the culprit, pushed to the target
+	Log          string         `json:"log,omitempty"`           // Tail of the failing test output
+	Error        string         `json:"error,omitempty"`         // Why the failure could not be reverted
+	Reopened     bool           `json:"reopened,omitempty"`      // Culprit's source issue was reopened
+	Notified     []string       `json:"notified,omitempty"`      // Addresses sent MERGE_FAILED
+}
+
+// VerifyTarget runs the test command on the target's head. If it fails,
+// the merge that broke it is found, reverted and reported. The target is
+// left checked out.
+func (e *Engineer) VerifyTarget(ctx context.Context, target string) *PostMergeResult {
+	if target == "" {
+		target = e.config.TargetBranch
+	}
+	result := &PostMergeResult{Target: target}
+	if e.config.TestCommand == "" {
+		result.Passed = true
+		return result
+	}
+
+	_, _ = fmt.Fprintf(e.output, "[Engineer] Post-merge check of %s...\n", target)
+	if err := e.git.Checkout(target); err != nil {
+		result.Error = fmt.Sprintf("checking out %s: %v", target, err)
+		return result
+	}
+	defer func() { _ = e.git.Checkout(target) }()
+	if err := e.git.Pull("origin", target); err != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: pull from origin/%s: %v (continuing)\n", target, err)
+	}
+	head, err := e.git.Rev("HEAD")
+	if err != nil {
+		result.Error = fmt.Sprintf("resolving %s: %v", target, err)
+		return result
+	}
+	result.Head = head
+
+	passed, log := e.testAt(ctx, head)
+	if passed {
+		result.Passed = true
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Post-merge check passed\n")
+		return result
+	}
+	result.Log = log
+	if ctx.Err() != nil {
+		result.Error = "post-merge check canceled"
+		return result
+	}
+
+	culprit, culpritLog, err := e.bisectMerges(ctx, target, head)
+	if err != nil {
+		result.Error = err.Error()
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Post-merge check failed, not reverting: %s\n", result.Error)
+		return result
+	}
+	result.Culprit = culprit
+	result.Log = culpritLog
+	_, _ = fmt.Fprintf(e.output, "[Engineer] Post-merge check failed; %s (%s) broke %s\n",
+		culprit.MRID, shortSHA(culprit.MergeCommit), target)
+
+	if err := e.git.Checkout(target); err != nil {
+		result.Error = fmt.Sprintf("checking out %s: %v", target, err)
+		return result
+	}
+	message := fmt.Sprintf("Revert merge of %s into %s (%s)\n\nThe post-merge check of %s failed; bisect found merge %s.",
+		culprit.Branch, target, culprit.MRID, target, culprit.MergeCommit)
+	if err := e.git.RevertMerge(culprit.MergeCommit, message); err != nil {
+		_ = e.git.AbortRevert()
+		result.Error = fmt.Sprintf("reverting %s: %v", shortSHA(culprit.MergeCommit), err)
+		return result
+	}
+	revert, err := e.git.Rev("HEAD")
+	if err != nil {
+		result.Error = fmt.Sprintf("resolving revert commit: %v", err)
+		return result
+	}
+	if err := e.git.Push("origin", target, false); err != nil {
+		result.Error = fmt.Sprintf("pushing revert: %v", err)
+		// Drop the unpushed revert so the next merge doesn't carry it.
+		if rerr := e.git.ResetHard("origin/" + target); rerr != nil {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: resetting %s to origin/%s: %v\n", target, target, rerr)
+		}
+		return result
+	}
+	result.RevertCommit = revert
+	_, _ = fmt.Fprintf(e.output, "[Engineer] Reverted %s in %s\n", culprit.MRID, shortSHA(revert))
+
+	reason := fmt.Sprintf("post-merge check of %s failed", target)
+	if err := e.eventLogger.LogReverted(*culprit, revert, reason); err != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to log reverted event: %v\n", err)
+	}
+	e.reopenReverted(result)
+	e.notifyReverted(result)
+	return result
+}
+
+// bisectMerges finds the first of the target's recent merges whose merge
+// commit fails the tests, given that head fails. It returns the merge's
+// event and the failing test output.
+func (e *Engineer) bisectMerges(ctx context.Context, target, head string) (*mrqueue.Event, string, error) {
+	merges, err := e.recentMerges(target, head)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(merges) == 0 {
+		return nil, "", fmt.Errorf("no recent merges into %s to bisect", target)
+	}
+
+	// The target before the oldest merge must pass, or the breakage is older.
+	base, err := e.git.Rev(merges[0].MergeCommit + "^1")
+	if err != nil {
+		return nil, "", fmt.Errorf("resolving parent of %s: %v", shortSHA(merges[0].MergeCommit), err)
+	}
+	_, _ = fmt.Fprintf(e.output, "[Engineer] Bisecting %d merge(s) into %s...\n", len(merges), target)
+	if passed, _ := e.testAt(ctx, base); !passed {
+		return nil, "", fmt.Errorf("%s already failed before the last %d merge(s)", target, len(merges))
+	}
+
+	// Invariant: merges[good] passes (-1 is the base), merges[bad] fails
+	// (len(merges) stands for head).
+	good, bad := -1, len(merges)
+	for bad-good > 1 {
+		if ctx.Err() != nil {
+			return nil, "", fmt.Errorf("bisect canceled")
+		}
+		mid := (good + bad) / 2
+		passed, _ := e.testAt(ctx, merges[mid].MergeCommit)
+		_, _ = fmt.Fprintf(e.output, "[Engineer]   %s (%s): %s\n",
+			merges[mid].MRID, shortSHA(merges[mid].MergeCommit), passFail(passed))
+		if passed {
+			good = mid
+		} else {
+			bad = mid
+		}
+	}
+	if bad == len(merges) {
+		return nil, "", fmt.Errorf("every recent merge passes; %s broke after %s", target, shortSHA(merges[len(merges)-1].MergeCommit))
+	}
+	culprit := merges[bad]
+
+	// A flaky run can steer the bisect to an innocent merge. Before
+	// reverting, confirm the culprit fails and the target just before it
+	// passes.
+	if passed, _ := e.testAt(ctx, culprit.MergeCommit+"^1"); !passed {
+		return nil, "", fmt.Errorf("bisect found %s but the target before it also fails; not confident enough to revert", culprit.MRID)
+	}
+	passed, log := e.testAt(ctx, culprit.MergeCommit)
+	if passed {
+		return nil, "", fmt.Errorf("bisect found %s but it passed on a retest; failure looks flaky", culprit.MRID)
+	}
+	return &culprit, log, nil
+}
+
+// recentMerges returns the last PostMergeWindow merges into target from the
+// MQ event log that are on head and have not been reverted, oldest first.
+func (e *Engineer) recentMerges(target, head string) ([]mrqueue.Event, error) {
+	events, err := e.eventLogger.ReadEvents()
+	if err != nil {
+		return nil, fmt.Errorf("reading MQ events: %w", err)
+	}
+
+	// A reverted event undoes the MR's latest merge before it.
+	reverted := make(map[int]bool)
+	lastMerge := make(map[string]int)
+	for i, ev := range events {
+		switch ev.Type {
+		case mrqueue.EventMerged:
+			lastMerge[ev.MRID] = i
+		case mrqueue.EventReverted:
+			if j, ok := lastMerge[ev.MRID]; ok {
+				reverted[j] = true
+			}
+		}
+	}
+
+	window := e.config.PostMergeWindow
+	if window < 1 {
+		window = 1
+	}
+	var merges []mrqueue.Event
+	for i := len(events) - 1; i >= 0 && len(merges) < window; i-- {
+		ev := events[i]
+		if ev.Type != mrqueue.EventMerged || ev.Target != target || ev.MergeCommit == "" || reverted[i] {
+			continue
+		}
+		if ok, err := e.git.IsAncestor(ev.MergeCommit, head); err != nil || !ok {
+			continue
+		}
+		merges = append(merges, ev)
+	}
+	for i, j := 0, len(merges)-1; i < j; i, j = i+1, j-1 {
+		merges[i], merges[j] = merges[j], merges[i]
+	}
+	return merges, nil
+}
+
+// testAt checks out ref and runs the test command, returning whether it
+// passed and the tail of its output. Like the pre-merge gate, a failing run
+// is retried up to retry_flaky_tests times, and failures confined to
+// quarantined tests pass when allow_quarantined_failures is set.
+func (e *Engineer) testAt(ctx context.Context, ref string) (bool, string) {
+	if err := e.git.Checkout(ref); err != nil {
+		return false, fmt.Sprintf("checking out %s: %v", ref, err)
+	}
+	attempts := e.config.RetryFlakyTests
+	if attempts < 1 {
+		attempts = 1
+	}
+	var log string
+	for attempt := 1; attempt <= attempts && ctx.Err() == nil; attempt++ {
+		var passed bool
+		passed, log = e.runPostMergeTests(ctx)
+		if passed {
+			return true, log
+		}
+	}
+	return false, log
+}
+
+// runPostMergeTests runs the test command once in the work dir.
+func (e *Engineer) runPostMergeTests(ctx context.Context) (bool, string) {
+	e.removeTestResults()
+	cmd := exec.CommandContext(ctx, "sh", "-c", e.config.TestCommand) //nolint:gosec // G204: TestCommand is from trusted rig config
+	cmd.Dir = e.workDir
+	out, err := cmd.CombinedOutput()
+	log := tailLog(string(out))
+	if err == nil {
+		return true, log
+	}
+	return e.onlyQuarantinedFailures(out), log
+}
+
+// onlyQuarantinedFailures reports whether a failing run's parsed failures
+// are all quarantined and allowed to fail.
+func (e *Engineer) onlyQuarantinedFailures(output []byte) bool {
+	if !e.config.AllowQuarantinedFailures {
+		return false
+	}
+	run, err := e.parseTestResults(output)
+	if err != nil {
+		return false
+	}
+	failed := run.Failed()
+	if len(failed) == 0 {
+		return false
+	}
+	h, err := testhistory.Load(e.rig.Path)
+	if err != nil {
+		return false
+	}
+	for _, res := range failed {
+		if !h.IsQuarantined(res.ID()) {
+			return false
+		}
+	}
+	return true
+}
+
+// reopenReverted reopens the reverted MR's source issue with the failure log.
+func (e *Engineer) reopenReverted(result *PostMergeResult) {
+	issueID := result.Culprit.SourceIssue
+	if issueID == "" {
+		return
+	}
+	issue, err := e.beads.Show(issueID)
+	if err != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to fetch source issue %s: %v\n", issueID, err)
+		return
+	}
+
+	status := "open"
+	description := strings.TrimRight(issue.Description, "\n") +
+		fmt.Sprintf("\n\n## Reverted after merge\n\nMerge %s (%s) broke %s and was reverted in %s.\n\n```\n%s\n```\n",
+			shortSHA(result.Culprit.MergeCommit), result.Culprit.MRID, result.Target,
+			shortSHA(result.RevertCommit), strings.TrimRight(result.Log, "\n"))
+	if err := e.beads.Update(issueID, beads.UpdateOptions{Status: &status, Description: &description}); err != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to reopen source issue %s: %v\n", issueID, err)
+		return
+	}
+	result.Reopened = true
+	_, _ = fmt.Fprintf(e.output, "[Engineer] Reopened source issue: %s\n", issueID)
+}
+
+// notifyReverted sends MERGE_FAILED to the witness and the MR's polecat.
+func (e *Engineer) notifyReverted(result *PostMergeResult) {
+	culprit := result.Culprit
+	errorMsg := fmt.Sprintf("merged, then reverted in %s: post-merge check of %s failed\n\n%s",
+		shortSHA(result.RevertCommit), result.Target, result.Log)
+	msg := protocol.NewMergeFailedMessage(e.rig.Name, culprit.Worker, culprit.Branch,
+		culprit.SourceIssue, result.Target, string(FailurePostMerge), errorMsg)
+
+	router := mail.NewRouter(e.rig.Path)
+	if err := router.Send(msg); err != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to notify witness: %v\n", err)
+	} else {
+		result.Notified = append(result.Notified, msg.To)
+	}
+	if culprit.Worker == "" {
+		return
+	}
+	polecatMsg := protocol.NewMergeFailedMessage(e.rig.Name, culprit.Worker, culprit.Branch,
+		culprit.SourceIssue, result.Target, string(FailurePostMerge), errorMsg)
+	polecatMsg.To = fmt.Sprintf("%s/%s", e.rig.Name, culprit.Worker)
+	if err := router.Send(polecatMsg); err != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to notify %s: %v\n", culprit.Worker, err)
+	} else {
+		result.Notified = append(result.Notified, polecatMsg.To)
+	}
+}
+
+// tailLog keeps the end of a test log, where failures are reported.
+func tailLog(log string) string {
+	if len(log) <= maxFailureLog {
+		return log
+	}
+	log = log[len(log)-maxFailureLog:]
+	if i := strings.IndexByte(log, '\n'); i >= 0 {
+		log = log[i+1:]
+	}
+	return "...\n" + log
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 8 {
+		return sha[:8]
+	}
+	return sha
+}
+
+func passFail(passed bool) string {
+	if passed {
+		return "pass"
+	}
+	return "fail"
+}
@@ -0,0 +1,192 @@
+package refinery
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/mrqueue"
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// setupMergedRig creates a rig clone whose main has one merge per MR, each
+// adding a file of the same name, pushed to a bare origin and recorded as
+// merged events.
+func setupMergedRig(t *testing.T, mrs ...string) *Engineer {
+	t.Helper()
+	origin := filepath.Join(t.TempDir(), "origin.git")
+	runGit(t, t.TempDir(), "init", "--bare", "-b", "main", origin)
+	rigPath := filepath.Join(t.TempDir(), "rig")
+	runGit(t, filepath.Dir(rigPath), "clone", origin, rigPath)
+	runGit(t, rigPath, "config", "user.email", "test@test.com")
+	runGit(t, rigPath, "config", "user.name", "Test User")
+	runGit(t, rigPath, "checkout", "-b", "main")
+	runGit(t, rigPath, "commit", "--allow-empty", "-m", "initial")
+
+	e := NewEngineer(&rig.Rig{Name: "gastown", Path: rigPath})
+	e.SetOutput(&bytes.Buffer{})
+	for _, id := range mrs {
+		branch := "polecat/" + id
+		runGit(t, rigPath, "checkout", "-b", branch, "main")
+		if err := os.WriteFile(filepath.Join(rigPath, id), []byte(id+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		runGit(t, rigPath, "add", id)
+		runGit(t, rigPath, "commit", "-m", "work on "+id)
+		runGit(t, rigPath, "checkout", "main")
+		runGit(t, rigPath, "merge", "--no-ff", "-m", "Merge "+branch, branch)
+		sha := runGit(t, rigPath, "rev-parse", "HEAD")
+		mr := &mrqueue.MR{ID: id, Branch: branch, Target: "main", Worker: "toast"}
+		if err := e.eventLogger.LogMerged(mr, sha); err != nil {
+			t.Fatal(err)
+		}
+	}
+	runGit(t, rigPath, "push", "origin", "main")
+	return e
+}
+
+func TestVerifyTarget_RevertsBreakingMerge(t *testing.T) {
+	e := setupMergedRig(t, "gt-mr1", "gt-mr2", "gt-mr3", "gt-mr4")
+	e.config.TestCommand = "test ! -f gt-mr2 || { echo 'gt-mr2 is broken'; exit 1; }"
+
+	result := e.VerifyTarget(context.Background(), "main")
+	if result.Passed || result.Error != "" {
+		t.Fatalf("VerifyTarget() = passed %v, error %q", result.Passed, result.Error)
+	}
+	if result.Culprit == nil || result.Culprit.MRID != "gt-mr2" {
+		t.Fatalf("culprit = %+v, want gt-mr2", result.Culprit)
+	}
+	if !strings.Contains(result.Log, "gt-mr2 is broken") {
+		t.Errorf("log = %q, want the failing output", result.Log)
+	}
+
+	rigPath := e.rig.Path
+	if _, err := os.Stat(filepath.Join(rigPath, "gt-mr2")); !os.IsNotExist(err) {
+		t.Error("revert should remove the culprit's changes")
+	}
+	if _, err := os.Stat(filepath.Join(rigPath, "gt-mr3")); err != nil {
+		t.Error("revert should keep later merges")
+	}
+	if pushed := runGit(t, rigPath, "rev-parse", "origin/main"); pushed != result.RevertCommit {
+		t.Errorf("origin/main = %s, want revert %s", pushed, result.RevertCommit)
+	}
+
+	events, err := e.eventLogger.ReadEvents()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if last := events[len(events)-1]; last.Type != mrqueue.EventReverted || last.MRID != "gt-mr2" {
+		t.Errorf("last event = %+v, want reverted gt-mr2", last)
+	}
+
+	if again := e.VerifyTarget(context.Background(), "main"); !again.Passed {
+		t.Errorf("VerifyTarget() after revert = %+v, want passed", again)
+	}
+}
+
+func TestVerifyTarget_FailureOutsideWindow(t *testing.T) {
+	e := setupMergedRig(t, "gt-mr1", "gt-mr2")
+	e.config.TestCommand = "test ! -f gt-mr1"
+	e.config.PostMergeWindow = 1
+
+	result := e.VerifyTarget(context.Background(), "main")
+	if result.Passed || result.Culprit != nil || result.RevertCommit != "" {
+		t.Fatalf("VerifyTarget() = %+v, want a failure without revert", result)
+	}
+	if !strings.Contains(result.Error, "already failed") {
+		t.Errorf("error = %q, want breakage older than the window", result.Error)
+	}
+}
+
+func TestVerifyTarget_PushFailureDropsRevert(t *testing.T) {
+	e := setupMergedRig(t, "gt-mr1", "gt-mr2")
+	e.config.TestCommand = "test ! -f gt-mr2"
+	rigPath := e.rig.Path
+	head := runGit(t, rigPath, "rev-parse", "HEAD")
+
+	origin := runGit(t, rigPath, "remote", "get-url", "origin")
+	hook := filepath.Join(origin, "hooks", "pre-receive")
+	if err := os.WriteFile(hook, []byte("#!/bin/sh\nexit 1\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	result := e.VerifyTarget(context.Background(), "main")
+	if !strings.Contains(result.Error, "pushing revert") || result.RevertCommit != "" {
+		t.Fatalf("VerifyTarget() = %+v, want a push failure", result)
+	}
+	if got := runGit(t, rigPath, "rev-parse", "HEAD"); got != head {
+		t.Errorf("local main = %s, want reset to origin/main %s", got, head)
+	}
+}
+
+func TestVerifyTarget_FlakyBisectStep(t *testing.T) {
+	// gt-mr3 is broken; the first run with gt-mr2 in the tree flakes.
+	testCommand := func(marker string) string {
+		return "if [ -f gt-mr3 ]; then echo 'gt-mr3 is broken'; exit 1; fi; " +
+			"if [ -f gt-mr2 ] && [ ! -f " + marker + " ]; then touch " + marker + "; exit 1; fi"
+	}
+
+	t.Run("no retries", func(t *testing.T) {
+		e := setupMergedRig(t, "gt-mr1", "gt-mr2", "gt-mr3", "gt-mr4")
+		e.config.TestCommand = testCommand(filepath.Join(t.TempDir(), "flaked"))
+
+		result := e.VerifyTarget(context.Background(), "main")
+		if result.Culprit != nil || result.RevertCommit != "" {
+			t.Fatalf("VerifyTarget() reverted %s, want no revert of an unconfirmed culprit", result.Culprit.MRID)
+		}
+		if !strings.Contains(result.Error, "gt-mr2") || !strings.Contains(result.Error, "flaky") {
+			t.Errorf("error = %q, want the unconfirmed gt-mr2", result.Error)
+		}
+	})
+
+	t.Run("with retries", func(t *testing.T) {
+		e := setupMergedRig(t, "gt-mr1", "gt-mr2", "gt-mr3", "gt-mr4")
+		e.config.TestCommand = testCommand(filepath.Join(t.TempDir(), "flaked"))
+		e.config.RetryFlakyTests = 2
+
+		result := e.VerifyTarget(context.Background(), "main")
+		if result.Culprit == nil || result.Culprit.MRID != "gt-mr3" || result.RevertCommit == "" {
+			t.Fatalf("VerifyTarget() = %+v, want gt-mr3 reverted", result)
+		}
+	})
+}
+
+func TestVerifyTarget_SeparateCheckout(t *testing.T) {
+	e := setupMergedRig(t, "gt-mr1", "gt-mr2")
+	e.config.TestCommand = "test ! -f gt-mr2"
+	rigPath := e.rig.Path
+	head := runGit(t, rigPath, "rev-parse", "HEAD")
+
+	origin := runGit(t, rigPath, "remote", "get-url", "origin")
+	checkout := filepath.Join(t.TempDir(), "verify")
+	runGit(t, filepath.Dir(checkout), "clone", origin, checkout)
+	runGit(t, checkout, "config", "user.email", "test@test.com")
+	runGit(t, checkout, "config", "user.name", "Test User")
+	e.SetWorkDir(checkout)
+
+	result := e.VerifyTarget(context.Background(), "main")
+	if result.RevertCommit == "" {
+		t.Fatalf("VerifyTarget() = %+v, want gt-mr2 reverted", result)
+	}
+	if pushed := runGit(t, checkout, "ls-remote", "origin", "refs/heads/main"); !strings.HasPrefix(pushed, result.RevertCommit) {
+		t.Errorf("origin main = %s, want revert %s", pushed, result.RevertCommit)
+	}
+	if got := runGit(t, rigPath, "rev-parse", "HEAD"); got != head {
+		t.Errorf("rig checkout moved to %s, want it left at %s", got, head)
+	}
+}
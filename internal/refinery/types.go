@@ -66,6 +66,9 @@ type MergeRequest struct {
 	// TargetBranch is where this should merge (usually integration or main).
 	TargetBranch string `json:"target_branch"`
 
+	// ParentMR is the MR this one is stacked on, if any.
+	ParentMR string `json:"parent_mr,omitempty"`
+
 	// CreatedAt is when the MR was queued.
 	CreatedAt time.Time `json:"created_at"`
 
@@ -284,6 +287,16 @@ const (
 
 	// FailureCheckout indicates checkout of target branch failed.
 	FailureCheckout FailureType = "checkout_fail"
+
+	// FailureMergeFail indicates the merge itself failed for a non-conflict reason.
+	FailureMergeFail FailureType = "merge_fail"
+
+	// FailureStackParent indicates the MR is stacked on an MR that has not merged yet.
+	FailureStackParent FailureType = "stack_parent"
+
+	// FailurePostMerge indicates the MR merged but broke the target's
+	// post-merge check and was reverted.
+	FailurePostMerge FailureType = "post_merge"
 )
 
 // FailureLabel returns the beads label for this failure type.
@@ -291,7 +304,7 @@ func (f FailureType) FailureLabel() string {
 	switch f {
 	case FailureConflict:
 		return "needs-rebase"
-	case FailureTestsFail, FailureBuildFail, FailureFlakyTest:
+	case FailureTestsFail, FailureBuildFail, FailureFlakyTest, FailurePostMerge:
 		return "needs-fix"
 	case FailurePushFail:
 		return "needs-retry"
@@ -303,7 +316,7 @@ func (f FailureType) FailureLabel() string {
 // ShouldAssignToWorker returns true if this failure should be assigned back to the worker.
 func (f FailureType) ShouldAssignToWorker() bool {
 	switch f {
-	case FailureConflict, FailureTestsFail, FailureBuildFail, FailureFlakyTest:
+	case FailureConflict, FailureTestsFail, FailureBuildFail, FailureFlakyTest, FailurePostMerge:
 		return true
 	default:
 		return false
@@ -266,6 +266,7 @@ func TestFailureType_FailureLabel(t *testing.T) {
 		{FailureTestsFail, "needs-fix"},
 		{FailureBuildFail, "needs-fix"},
 		{FailureFlakyTest, "needs-fix"},
+		{FailurePostMerge, "needs-fix"},
 		{FailurePushFail, "needs-retry"},
 		{FailureFetch, ""},
 		{FailureCheckout, ""},
@@ -291,6 +292,7 @@ func TestFailureType_ShouldAssignToWorker(t *testing.T) {
 		{FailureTestsFail, true},
 		{FailureBuildFail, true},
 		{FailureFlakyTest, true},
+		{FailurePostMerge, true},
 		{FailurePushFail, false},
 		{FailureFetch, false},
 		{FailureCheckout, false},
@@ -13,6 +13,8 @@ import (
 	"github.com/steveyegge/gastown/internal/agent"
 	"github.com/steveyegge/gastown/internal/auditor"
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/tracing"
 )
 
 // ErrVerificationRequired is returned when verification is required but cannot proceed.
@@ -157,7 +159,23 @@ func (g *VerificationGate) IsIndependent() bool {
 
 // VerifyMR performs mandatory verification on a merge request.
 // Returns the verification result or an error. Verification cannot be skipped.
-func (g *VerificationGate) VerifyMR(ctx context.Context, mr *MergeRequest, workdir string) (*VerificationInfo, error) {
+func (g *VerificationGate) VerifyMR(ctx context.Context, mr *MergeRequest, workdir string) (info *VerificationInfo, err error) {
+	ctx, span := tracing.Start(ctx, "refinery.verify_mr")
+	span.SetAttr(tracing.AttrMR, mr.ID)
+	span.SetAttr(tracing.AttrBead, mr.IssueID)
+	span.SetAttr(tracing.AttrBranch, mr.Branch)
+	span.SetAttr(tracing.AttrTarget, mr.TargetBranch)
+	defer func() {
+		if info != nil {
+			span.SetAttr("gt.verification_status", string(info.Status))
+			span.SetAttr("gt.reviewed_by", info.ReviewedBy)
+			_ = events.LogAudit(events.TypeVerification, "refinery",
+				events.VerificationPayload(mr.ID, mr.IssueID, string(info.Status), info.ReviewedBy))
+		}
+		span.SetError(err)
+		span.End()
+	}()
+
 	if g.auditor == nil {
 		return nil, ErrVerificationRequired
 	}
@@ -187,7 +205,7 @@ func (g *VerificationGate) VerifyMR(ctx context.Context, mr *MergeRequest, workd
 	}
 
 	// Convert result to VerificationInfo
-	info := &VerificationInfo{
+	info = &VerificationInfo{
 		ReviewedBy:    result.ReviewedBy,
 		IsIndependent: result.IsIndependent,
 		Confidence:    result.Confidence,
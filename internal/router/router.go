@@ -0,0 +1,211 @@
+// Package router picks the agent best able to work a bead, for
+// gt sling --auto. Like the convoy scheduler it is pure planning: callers
+// gather candidates (from rig settings, agent beads and tmux) and perform
+// the resulting sling.
+//
+// A bead's needs:<capability> labels are hard requirements: a candidate
+// missing any of them is ineligible. Among eligible candidates the router
+// only picks human-managed crew when no polecat or dog qualifies (or the bead
+// asks for crew), then prefers the rig that owns the bead's prefix (so
+// routes-based lookups stay local), then the role suited to the work - a
+// fresh polecat for rig work, a dog for town-level work - and finally the
+// candidate with the most spare capacity.
+package router
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Roles a bead can be routed to.
+const (
+	RolePolecat = "polecat"
+	RoleCrew    = "crew"
+	RoleDog     = "dog"
+)
+
+// RoleLabelPrefix pins a bead to a role ("role:crew"), overriding the
+// router's preference.
+const RoleLabelPrefix = "role:"
+
+// Score weights. Avoiding crew outweighs the home rig, which outweighs role
+// preference, which outweighs spare capacity.
+const (
+	crewScore      = -1000
+	homeRigScore   = 100
+	preferredScore = 10
+	fallbackScore  = 5
+)
+
+// Request describes the bead being routed.
+type Request struct {
+	IssueID string `json:"issue_id"`
+
+	// Needs are the capabilities the bead requires (from needs: labels).
+	Needs []string `json:"needs,omitempty"`
+
+	// HomeRig is the rig that owns the bead's prefix, or "" for town beads.
+	HomeRig string `json:"home_rig,omitempty"`
+
+	// Role pins the bead to one role; "" lets the router choose.
+	Role string `json:"role,omitempty"`
+}
+
+// Candidate is an agent (or, for polecats, a rig that can spawn one) that
+// could take the bead.
+type Candidate struct {
+	// Target is the gt sling target: a rig name for a fresh polecat,
+	// "<rig>/crew/<name>", or "deacon/dogs/<name>".
+	Target string `json:"target"`
+
+	Rig  string `json:"rig,omitempty"` // Empty for dogs
+	Role string `json:"role"`
+	Name string `json:"name,omitempty"` // Empty for a fresh polecat
+
+	Capabilities []string `json:"capabilities,omitempty"`
+
+	// Busy names the work already on the agent's hook, if any.
+	Busy string `json:"busy,omitempty"`
+
+	// Free is how many more polecats the rig can run (polecats only).
+	Free int `json:"free,omitempty"`
+}
+
+// Decision is the router's verdict for one candidate.
+type Decision struct {
+	Candidate
+	Eligible bool     `json:"eligible"`
+	Score    int      `json:"score"`
+	Reasons  []string `json:"reasons"`
+}
+
+// Route scores every candidate for req and returns the decisions, best
+// first. The first decision is the pick if it is eligible; otherwise no
+// candidate can take the bead and the reasons say why.
+func Route(req Request, candidates []Candidate) []Decision {
+	decisions := make([]Decision, 0, len(candidates))
+	for _, c := range candidates {
+		decisions = append(decisions, decide(req, c))
+	}
+	sort.SliceStable(decisions, func(i, j int) bool {
+		a, b := decisions[i], decisions[j]
+		if a.Eligible != b.Eligible {
+			return a.Eligible
+		}
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		return a.Target < b.Target
+	})
+	return decisions
+}
+
+// Best returns the chosen decision, or nil if no candidate is eligible.
+func Best(decisions []Decision) *Decision {
+	if len(decisions) == 0 || !decisions[0].Eligible {
+		return nil
+	}
+	return &decisions[0]
+}
+
+func decide(req Request, c Candidate) Decision {
+	d := Decision{Candidate: c, Eligible: true}
+	reject := func(format string, args ...interface{}) {
+		d.Eligible = false
+		d.Reasons = append(d.Reasons, fmt.Sprintf(format, args...))
+	}
+
+	if missing := Missing(req.Needs, c.Capabilities); len(missing) > 0 {
+		reject("missing %s", strings.Join(missing, ", "))
+	}
+	if req.Role != "" && c.Role != req.Role {
+		reject("bead requires role %s", req.Role)
+	}
+	if c.Busy != "" {
+		reject("busy with %s", c.Busy)
+	}
+	if c.Role == RolePolecat && c.Free <= 0 {
+		reject("rig at polecat capacity")
+	}
+	if !d.Eligible {
+		return d
+	}
+
+	if len(req.Needs) > 0 {
+		d.Reasons = append(d.Reasons, "has "+strings.Join(req.Needs, ", "))
+	}
+	if req.HomeRig != "" && c.Rig == req.HomeRig {
+		d.Score += homeRigScore
+		d.Reasons = append(d.Reasons, fmt.Sprintf("rig owns %s", req.IssueID))
+	}
+
+	switch {
+	case preferredRole(req) == c.Role:
+		d.Score += preferredScore
+		d.Reasons = append(d.Reasons, roleReason(req, c.Role))
+	case c.Role == RoleCrew:
+		d.Score += crewScore
+		d.Reasons = append(d.Reasons, "crew are human-managed; only used when no polecat or dog qualifies")
+	default:
+		d.Score += fallbackScore
+	}
+
+	if c.Role == RolePolecat {
+		d.Score += c.Free
+		d.Reasons = append(d.Reasons, fmt.Sprintf("%d polecat slot(s) free", c.Free))
+	}
+	return d
+}
+
+// preferredRole is the role the router favors when the bead doesn't pin
+// one: dogs handle town-level work, polecats handle rig work. Crew are
+// human-managed and only chosen when nothing else qualifies.
+func preferredRole(req Request) string {
+	if req.Role != "" {
+		return req.Role
+	}
+	if req.HomeRig == "" {
+		return RoleDog
+	}
+	return RolePolecat
+}
+
+func roleReason(req Request, role string) string {
+	switch {
+	case req.Role != "":
+		return "bead requires role " + role
+	case role == RoleDog:
+		return "town-level work suits a dog"
+	default:
+		return "rig work suits a fresh polecat"
+	}
+}
+
+// Missing returns the needs not covered by caps.
+func Missing(needs, caps []string) []string {
+	have := make(map[string]bool, len(caps))
+	for _, c := range caps {
+		have[strings.ToLower(c)] = true
+	}
+	var missing []string
+	for _, n := range needs {
+		if !have[strings.ToLower(n)] {
+			missing = append(missing, n)
+		}
+	}
+	return missing
+}
+
+// RoleFromLabels returns the role a role:<role> label pins, or "".
+func RoleFromLabels(labels []string) string {
+	for _, l := range labels {
+		if role, ok := strings.CutPrefix(l, RoleLabelPrefix); ok {
+			switch role {
+			case RolePolecat, RoleCrew, RoleDog:
+				return role
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,83 @@
+package router
+
+import (
+	"reflect"
+	"testing"
+)
+
+func candidates() []Candidate {
+	return []Candidate{
+		{Target: "gastown", Rig: "gastown", Role: RolePolecat, Capabilities: []string{"go", "runtime:claude"}, Free: 2},
+		{Target: "beads", Rig: "beads", Role: RolePolecat, Capabilities: []string{"go", "docker", "runtime:claude"}, Free: 3},
+		{Target: "gastown/crew/max", Rig: "gastown", Role: RoleCrew, Name: "max", Capabilities: []string{"go", "docker", "account:work"}},
+		{Target: "deacon/dogs/alpha", Role: RoleDog, Name: "alpha", Capabilities: []string{"go"}},
+	}
+}
+
+func TestRoute_PrefersHomeRigPolecat(t *testing.T) {
+	decisions := Route(Request{IssueID: "gt-abc", Needs: []string{"go"}, HomeRig: "gastown"}, candidates())
+	best := Best(decisions)
+	if best == nil || best.Target != "gastown" {
+		t.Fatalf("best = %+v, want gastown polecat", best)
+	}
+	want := []string{"has go", "rig owns gt-abc", "rig work suits a fresh polecat", "2 polecat slot(s) free"}
+	if !reflect.DeepEqual(best.Reasons, want) {
+		t.Errorf("reasons = %v, want %v", best.Reasons, want)
+	}
+}
+
+func TestRoute_CrossRigWhenHomeLacksCapability(t *testing.T) {
+	decisions := Route(Request{IssueID: "gt-abc", Needs: []string{"docker"}, HomeRig: "gastown"}, candidates())
+
+	// Crew in the home rig has docker but is human-managed; a polecat in
+	// another rig wins on role preference
+	if best := Best(decisions); best == nil || best.Target != "beads" {
+		t.Fatalf("best = %+v, want beads polecat", best)
+	}
+	for _, d := range decisions {
+		if d.Target == "gastown" && (d.Eligible || d.Reasons[0] != "missing docker") {
+			t.Errorf("gastown polecat = %+v, want ineligible for missing docker", d)
+		}
+	}
+}
+
+func TestRoute_TownWorkPrefersDogs(t *testing.T) {
+	best := Best(Route(Request{IssueID: "hq-xyz"}, candidates()))
+	if best == nil || best.Target != "deacon/dogs/alpha" {
+		t.Fatalf("best = %+v, want dog", best)
+	}
+}
+
+func TestRoute_RoleLabelAndBusyAgents(t *testing.T) {
+	cands := candidates()
+	req := Request{IssueID: "gt-abc", HomeRig: "gastown", Role: RoleFromLabels([]string{"bug", "role:crew"})}
+	if best := Best(Route(req, cands)); best == nil || best.Target != "gastown/crew/max" {
+		t.Fatalf("best = %+v, want crew max", best)
+	}
+
+	cands[2].Busy = "gt-other"
+	decisions := Route(req, cands)
+	if Best(decisions) != nil {
+		t.Fatalf("no candidate should qualify: %+v", decisions)
+	}
+	for _, d := range decisions {
+		if d.Target == "gastown/crew/max" && !reflect.DeepEqual(d.Reasons, []string{"busy with gt-other"}) {
+			t.Errorf("busy crew reasons = %v", d.Reasons)
+		}
+	}
+}
+
+func TestRoute_FullRigIsIneligible(t *testing.T) {
+	cands := candidates()
+	cands[0].Free = 0
+	best := Best(Route(Request{IssueID: "gt-abc", Needs: []string{"go"}, HomeRig: "gastown"}, cands))
+	if best == nil || best.Target != "beads" {
+		t.Fatalf("best = %+v, want beads polecat when gastown is full", best)
+	}
+}
+
+func TestMissing(t *testing.T) {
+	if got := Missing([]string{"Go", "docker", "runtime:gemini"}, []string{"go", "runtime:claude"}); !reflect.DeepEqual(got, []string{"docker", "runtime:gemini"}) {
+		t.Errorf("Missing = %v", got)
+	}
+}
@@ -0,0 +1,258 @@
+// Package sandbox runs polecat sessions inside a rootless container (podman)
+// or user namespace (bubblewrap).
+//
+// A sandboxed polecat only sees its own worktree, the repo's git directory,
+// the town and rig beads, the rig's knowledge store, the town's mayor/ config
+// (read-only, so gt can find the workspace), its Claude account's config
+// directory and any extra mounts the rig configures. Paths are mounted at the same location as on the host,
+// so the startup command, BEADS_DIR and beads redirects work unchanged.
+//
+// The package only builds command lines; the session is still a tmux pane
+// running the wrapped command, so launching and attaching behave as for host
+// polecats. tmux reports the runtime (bwrap, podman) as the pane's command;
+// tmux.IsClaudeRunning looks inside it for the agent.
+package sandbox
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/knowledge"
+	"github.com/steveyegge/gastown/internal/limits"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// Mount is a host path bound into the sandbox at the same path.
+type Mount struct {
+	Path     string
+	ReadOnly bool
+}
+
+// Spec describes one sandboxed session.
+type Spec struct {
+	// Name identifies the sandbox (the podman container name).
+	Name string
+
+	// WorkDir is the directory the command starts in.
+	WorkDir string
+
+	// Mounts are the host paths visible inside the sandbox.
+	Mounts []Mount
+}
+
+// systemPaths are bound read-only into bwrap sandboxes so host binaries run.
+var systemPaths = []string{"/usr", "/bin", "/sbin", "/lib", "/lib64", "/etc"}
+
+// Args returns the argv that runs command (a shell command line) inside the
+// sandbox described by cfg and spec.
+func Args(cfg *config.SandboxConfig, spec Spec, command string) ([]string, error) {
+	if problems := cfg.Validate(); len(problems) > 0 {
+		return nil, fmt.Errorf("invalid sandbox settings: %s", strings.Join(problems, "; "))
+	}
+	memory, _ := cfg.MemoryBytes() // validated above
+
+	// Parents before children so nested binds aren't shadowed
+	mounts := append([]Mount(nil), spec.Mounts...)
+	sort.SliceStable(mounts, func(i, j int) bool { return len(mounts[i].Path) < len(mounts[j].Path) })
+
+	switch cfg.Runtime {
+	case config.SandboxPodman:
+		args := []string{"podman", "run", "--rm", "-it", "--replace",
+			"--userns=keep-id", "--security-opt", "label=disable"}
+		if spec.Name != "" {
+			args = append(args, "--name", spec.Name)
+		}
+		switch cfg.Network {
+		case "", config.SandboxNetworkHost:
+			args = append(args, "--network", "host")
+		case config.SandboxNetworkNone:
+			args = append(args, "--network", "none")
+		}
+		if memory > 0 {
+			args = append(args, "--memory", fmt.Sprintf("%d", memory))
+		}
+		if cfg.CPUs > 0 {
+			args = append(args, "--cpus", formatCPUs(cfg.CPUs))
+		}
+		if cfg.PIDs > 0 {
+			args = append(args, "--pids-limit", fmt.Sprintf("%d", cfg.PIDs))
+		}
+		for _, m := range mounts {
+			v := m.Path + ":" + m.Path
+			if m.ReadOnly {
+				v += ":ro"
+			}
+			args = append(args, "-v", v)
+		}
+		if spec.WorkDir != "" {
+			args = append(args, "-w", spec.WorkDir)
+		}
+		return append(args, cfg.Image, "sh", "-c", command), nil
+
+	case config.SandboxBwrap:
+		// bwrap has no resource controls; a transient systemd scope puts the
+		// sandbox in its own cgroup instead.
+		var args []string
+		if props := limits.SystemdProperties(&cfg.ResourceLimits); len(props) > 0 {
+			args = []string{"systemd-run", "--user", "--scope", "--quiet", "--collect",
+				"--unit=" + limits.UnitName(spec.Name)}
+			for _, prop := range props {
+				args = append(args, "-p", prop)
+			}
+			args = append(args, "--")
+		}
+		args = append(args, "bwrap", "--die-with-parent", "--unshare-all")
+		if cfg.Network == "" || cfg.Network == config.SandboxNetworkHost {
+			args = append(args, "--share-net")
+		}
+		for _, p := range systemPaths {
+			args = append(args, "--ro-bind-try", p, p)
+		}
+		args = append(args, "--proc", "/proc", "--dev", "/dev", "--tmpfs", "/tmp")
+		for _, m := range mounts {
+			if m.ReadOnly {
+				args = append(args, "--ro-bind", m.Path, m.Path)
+			} else {
+				args = append(args, "--bind", m.Path, m.Path)
+			}
+		}
+		if spec.WorkDir != "" {
+			args = append(args, "--chdir", spec.WorkDir)
+		}
+		return append(args, "sh", "-c", command), nil
+	}
+
+	return nil, fmt.Errorf("unknown sandbox runtime %q", cfg.Runtime)
+}
+
+// Wrap returns a shell command line that execs command inside the sandbox.
+func Wrap(cfg *config.SandboxConfig, spec Spec, command string) (string, error) {
+	args, err := Args(cfg, spec, command)
+	if err != nil {
+		return "", err
+	}
+	return "exec " + util.ShellJoin(args), nil
+}
+
+// Available reports whether the binaries a sandbox runtime needs are installed.
+func Available(cfg *config.SandboxConfig) error {
+	bins := []string{cfg.Runtime}
+	if cfg.Runtime == config.SandboxBwrap && !cfg.ResourceLimits.IsZero() {
+		bins = append(bins, "systemd-run")
+	}
+	for _, bin := range bins {
+		if _, err := exec.LookPath(bin); err != nil {
+			return fmt.Errorf("%s not installed", bin)
+		}
+	}
+	return nil
+}
+
+// PolecatSpec returns the sandbox spec for a polecat: its worktree, the
+// repo's git directory, the town and rig beads and the Claude config are
+// writable; the town's mayor/ config is read-only; extra mounts come from
+// cfg. configDir is the session's CLAUDE_CONFIG_DIR; without one, Claude's
+// default ~/.claude and ~/.claude.json are mounted. Paths that don't exist on
+// the host are skipped.
+func PolecatSpec(cfg *config.SandboxConfig, townRoot, rigName, polecatName, configDir string) Spec {
+	workDir := filepath.Join(townRoot, rigName, "polecats", polecatName)
+	spec := Spec{
+		Name:    fmt.Sprintf("gt-%s-%s", rigName, polecatName),
+		WorkDir: workDir,
+	}
+
+	seen := make(map[string]bool)
+	add := func(path string, readOnly bool) {
+		path = filepath.Clean(path)
+		if seen[path] {
+			return
+		}
+		if _, err := os.Stat(path); err != nil {
+			return
+		}
+		seen[path] = true
+		spec.Mounts = append(spec.Mounts, Mount{Path: path, ReadOnly: readOnly})
+	}
+
+	add(workDir, false)
+	if commonDir, err := git.NewGit(workDir).CommonDir(); err == nil {
+		add(commonDir, false)
+	}
+	add(filepath.Join(townRoot, ".beads"), false)
+	add(beads.ResolveBeadsDir(workDir), false)
+	add(filepath.Join(townRoot, "mayor"), true)
+
+	// The rig's knowledge store, for gt learn and gt prime. A missing file
+	// can't be mounted, so create it (and its lock, which must be the
+	// host's for writers on both sides to exclude each other).
+	rigPath := filepath.Join(townRoot, rigName)
+	for _, path := range []string{knowledge.Path(rigPath), knowledge.LockPath(rigPath)} {
+		if f, err := os.OpenFile(path, os.O_CREATE|os.O_RDONLY, 0644); err == nil {
+			_ = f.Close()
+		}
+		add(path, false)
+	}
+
+	// Claude's credentials and settings
+	home, _ := os.UserHomeDir()
+	if configDir != "" {
+		add(configDir, false)
+	} else if home != "" {
+		add(filepath.Join(home, ".claude"), false)
+		add(filepath.Join(home, ".claude.json"), false)
+	}
+
+	for _, m := range cfg.Mounts {
+		path, readOnly := m, true
+		if strings.HasSuffix(path, ":rw") {
+			path, readOnly = strings.TrimSuffix(path, ":rw"), false
+		} else {
+			path = strings.TrimSuffix(path, ":ro")
+		}
+		if strings.HasPrefix(path, "~/") && home != "" {
+			path = filepath.Join(home, path[2:])
+		}
+		add(path, readOnly)
+	}
+
+	return spec
+}
+
+// WrapPolecat wraps a polecat's startup command in its rig's sandbox, with
+// the rig's polecat resource limits filling in any the sandbox leaves unset.
+// Without a sandbox, only the resource limits are applied. Fails (rather
+// than falling back to the host) when the sandbox is misconfigured or its
+// runtime is missing. configDir is the session's CLAUDE_CONFIG_DIR, or "".
+func WrapPolecat(townRoot, rigName, polecatName, configDir, command string) (string, error) {
+	cfg := RigConfig(filepath.Join(townRoot, rigName))
+	if !cfg.Enabled() {
+		return limits.WrapCommand(townRoot, rigName, "polecat", rigName+"-"+polecatName, command), nil
+	}
+	if err := Available(cfg); err != nil {
+		return "", fmt.Errorf("sandbox for %s: %w", rigName, err)
+	}
+	effective := *cfg
+	effective.ResourceLimits = *cfg.ResourceLimits.Merge(config.ResolveResourceLimits(townRoot, rigName, "polecat"))
+	return Wrap(&effective, PolecatSpec(cfg, townRoot, rigName, polecatName, configDir), command)
+}
+
+// RigConfig returns a rig's sandbox settings, or nil when it has none.
+func RigConfig(rigPath string) *config.SandboxConfig {
+	settings, err := config.LoadRigSettings(filepath.Join(rigPath, "settings", "config.json"))
+	if err != nil {
+		return nil
+	}
+	return settings.Sandbox
+}
+
+// formatCPUs formats a core count without trailing zeros (1.5, 2).
+func formatCPUs(cpus float64) string {
+	return strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.2f", cpus), "0"), ".")
+}
@@ -0,0 +1,194 @@
+package sandbox
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// containsSeq reports whether args contains seq as a contiguous run.
+func containsSeq(args []string, seq ...string) bool {
+	for i := 0; i+len(seq) <= len(args); i++ {
+		if reflect.DeepEqual(args[i:i+len(seq)], seq) {
+			return true
+		}
+	}
+	return false
+}
+
+func testSpec() Spec {
+	return Spec{
+		Name:    "gt-gastown-Toast",
+		WorkDir: "/town/gastown/polecats/Toast",
+		Mounts: []Mount{
+			{Path: "/town/gastown/polecats/Toast"},
+			{Path: "/town/mayor", ReadOnly: true},
+		},
+	}
+}
+
+func TestArgsPodman(t *testing.T) {
+	cfg := &config.SandboxConfig{Runtime: config.SandboxPodman, Image: "gt-agent", Network: config.SandboxNetworkNone,
+		ResourceLimits: config.ResourceLimits{Memory: "1g", CPUs: 1.5, PIDs: 256}}
+	args, err := Args(cfg, testSpec(), "exec claude")
+	if err != nil {
+		t.Fatalf("Args: %v", err)
+	}
+
+	for _, seq := range [][]string{
+		{"podman", "run", "--rm", "-it"},
+		{"--userns=keep-id"},
+		{"--name", "gt-gastown-Toast"},
+		{"--network", "none"},
+		{"--memory", "1073741824"},
+		{"--cpus", "1.5"},
+		{"--pids-limit", "256"},
+		{"-v", "/town/mayor:/town/mayor:ro"},
+		{"-v", "/town/gastown/polecats/Toast:/town/gastown/polecats/Toast"},
+		{"-w", "/town/gastown/polecats/Toast"},
+		{"gt-agent", "sh", "-c", "exec claude"},
+	} {
+		if !containsSeq(args, seq...) {
+			t.Errorf("args missing %v:\n%v", seq, args)
+		}
+	}
+
+	// Private network is podman's default namespace
+	cfg.Network = config.SandboxNetworkPrivate
+	args, _ = Args(cfg, testSpec(), "exec claude")
+	if containsSeq(args, "--network") {
+		t.Errorf("private network should not pass --network: %v", args)
+	}
+}
+
+func TestArgsBwrap(t *testing.T) {
+	cfg := &config.SandboxConfig{Runtime: config.SandboxBwrap}
+	args, err := Args(cfg, testSpec(), "exec claude")
+	if err != nil {
+		t.Fatalf("Args: %v", err)
+	}
+	if args[0] != "bwrap" {
+		t.Errorf("unlimited bwrap should not need systemd-run: %v", args)
+	}
+	for _, seq := range [][]string{
+		{"--unshare-all", "--share-net"},
+		{"--ro-bind-try", "/usr", "/usr"},
+		{"--ro-bind", "/town/mayor", "/town/mayor"},
+		{"--bind", "/town/gastown/polecats/Toast", "/town/gastown/polecats/Toast"},
+		{"--chdir", "/town/gastown/polecats/Toast", "sh", "-c", "exec claude"},
+	} {
+		if !containsSeq(args, seq...) {
+			t.Errorf("args missing %v:\n%v", seq, args)
+		}
+	}
+
+	// Limits go through a systemd scope; no network drops --share-net
+	cfg = &config.SandboxConfig{Runtime: config.SandboxBwrap, Network: config.SandboxNetworkNone,
+		ResourceLimits: config.ResourceLimits{Memory: "512m", CPUs: 2, PIDs: 100}}
+	args, err = Args(cfg, testSpec(), "exec claude")
+	if err != nil {
+		t.Fatalf("Args: %v", err)
+	}
+	want := []string{"systemd-run", "--user", "--scope", "--quiet", "--collect"}
+	if !reflect.DeepEqual(args[:len(want)], want) {
+		t.Errorf("args prefix = %v, want %v", args[:len(want)], want)
+	}
+	// The scope is named like a limits scope so usage sampling finds it
+	if unit := args[len(want)]; !strings.HasPrefix(unit, "--unit=gastown-gt-gastown-Toast-") {
+		t.Errorf("scope unit = %q", unit)
+	}
+	if !containsSeq(args, "-p", "MemoryMax=536870912", "-p", "CPUQuota=200%", "-p", "TasksMax=100", "--", "bwrap") {
+		t.Errorf("args missing scope properties: %v", args)
+	}
+	if containsSeq(args, "--share-net") {
+		t.Errorf("network none should not share the network: %v", args)
+	}
+}
+
+func TestArgsInvalid(t *testing.T) {
+	if _, err := Args(&config.SandboxConfig{Runtime: config.SandboxPodman}, testSpec(), "true"); err == nil {
+		t.Error("podman without an image should fail")
+	}
+}
+
+func TestWrapQuotes(t *testing.T) {
+	cfg := &config.SandboxConfig{Runtime: config.SandboxBwrap}
+	got, err := Wrap(cfg, Spec{WorkDir: "/w"}, `export GT_ROLE=polecat && exec claude "it's"`)
+	if err != nil {
+		t.Fatalf("Wrap: %v", err)
+	}
+	if !strings.HasPrefix(got, "exec bwrap ") {
+		t.Errorf("Wrap = %q, want exec bwrap prefix", got)
+	}
+	if !strings.HasSuffix(got, `sh -c 'export GT_ROLE=polecat && exec claude "it'\''s"'`) {
+		t.Errorf("Wrap did not quote the command: %q", got)
+	}
+}
+
+func TestPolecatSpec(t *testing.T) {
+	town := t.TempDir()
+	workDir := filepath.Join(town, "gastown", "polecats", "Toast")
+	rigBeads := filepath.Join(town, "gastown", "mayor", "rig", ".beads")
+	tools := filepath.Join(town, "tools")
+	for _, dir := range []string{workDir, rigBeads, tools, filepath.Join(town, ".beads"), filepath.Join(town, "mayor"), filepath.Join(workDir, ".beads")} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(workDir, ".beads", "redirect"), []byte("../../mayor/rig/.beads\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if err := os.MkdirAll(filepath.Join(home, ".claude"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(home, ".claude.json"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	account := filepath.Join(home, "accounts", "work")
+	if err := os.MkdirAll(account, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config.SandboxConfig{Runtime: config.SandboxBwrap, Mounts: []string{tools, "/nonexistent/path:rw"}}
+	spec := PolecatSpec(cfg, town, "gastown", "Toast", "")
+
+	if spec.WorkDir != workDir || spec.Name != "gt-gastown-Toast" {
+		t.Errorf("spec = %+v", spec)
+	}
+	want := []Mount{
+		{Path: workDir},
+		{Path: filepath.Join(town, ".beads")},
+		{Path: rigBeads},
+		{Path: filepath.Join(town, "mayor"), ReadOnly: true},
+		{Path: filepath.Join(town, "gastown", "knowledge.json")},
+		{Path: filepath.Join(town, "gastown", "knowledge.json.lock")},
+		{Path: filepath.Join(home, ".claude")},
+		{Path: filepath.Join(home, ".claude.json")},
+		{Path: tools, ReadOnly: true},
+	}
+	if !reflect.DeepEqual(spec.Mounts, want) {
+		t.Errorf("mounts = %+v\nwant %+v", spec.Mounts, want)
+	}
+
+	// An account's config dir replaces the default one
+	spec = PolecatSpec(cfg, town, "gastown", "Toast", account)
+	want = append(append(want[:6:6], Mount{Path: account}), Mount{Path: tools, ReadOnly: true})
+	if !reflect.DeepEqual(spec.Mounts, want) {
+		t.Errorf("mounts with account = %+v\nwant %+v", spec.Mounts, want)
+	}
+}
+
+func TestWrapPolecatDisabled(t *testing.T) {
+	town := t.TempDir()
+	got, err := WrapPolecat(town, "gastown", "Toast", "", "exec claude")
+	if err != nil || got != "exec claude" {
+		t.Errorf("WrapPolecat without settings = %q, %v; want command unchanged", got, err)
+	}
+}
@@ -0,0 +1,129 @@
+// Package scheduler decides which ready convoy issues to dispatch to which
+// rigs. It is pure planning: callers gather candidates and rig capacity
+// (from beads and tmux) and perform the resulting slings.
+//
+// Candidates are ordered with the same inputs the merge queue uses
+// (mrqueue.ScoreMR): issue priority and convoy age, with issue age as a
+// FIFO tiebreaker. Each rig receives at most its free polecat capacity.
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/mrqueue"
+)
+
+// Candidate is a ready issue tracked by an open convoy.
+type Candidate struct {
+	IssueID         string    `json:"issue_id"`
+	Title           string    `json:"title"`
+	Rig             string    `json:"rig"` // Empty if the issue prefix has no rig route
+	Priority        int       `json:"priority"`
+	IssueCreatedAt  time.Time `json:"issue_created_at"`
+	ConvoyID        string    `json:"convoy_id"`
+	ConvoyCreatedAt time.Time `json:"convoy_created_at"`
+	ConvoyPaused    bool      `json:"convoy_paused,omitempty"`
+}
+
+// Capacity is a rig's polecat concurrency.
+type Capacity struct {
+	Active int `json:"active"` // Polecats with live sessions
+	Max    int `json:"max"`    // Configured concurrency limit
+}
+
+// Free returns how many more polecats the rig can run.
+func (c Capacity) Free() int {
+	if c.Active >= c.Max {
+		return 0
+	}
+	return c.Max - c.Active
+}
+
+// Decision actions.
+const (
+	ActionSling = "sling"
+	ActionSkip  = "skip"
+)
+
+// Decision is the scheduler's verdict for one candidate.
+type Decision struct {
+	Candidate
+	Score  float64 `json:"score"`
+	Action string  `json:"action"`
+	Reason string  `json:"reason,omitempty"`
+}
+
+// Score ranks a candidate; higher dispatches first.
+func Score(c Candidate, now time.Time) float64 {
+	input := mrqueue.ScoreInput{
+		Priority:    c.Priority,
+		MRCreatedAt: c.IssueCreatedAt,
+		Now:         now,
+	}
+	if input.MRCreatedAt.IsZero() {
+		input.MRCreatedAt = now
+	}
+	if !c.ConvoyCreatedAt.IsZero() {
+		convoyCreated := c.ConvoyCreatedAt
+		input.ConvoyCreatedAt = &convoyCreated
+	}
+	return mrqueue.ScoreMRWithDefaults(input)
+}
+
+// Plan orders candidates by score and assigns them to rigs with free
+// capacity. An issue tracked by several convoys is considered once, via its
+// highest-scoring unpaused convoy. capacity is not modified.
+func Plan(candidates []Candidate, capacity map[string]Capacity, now time.Time) []Decision {
+	decisions := make([]Decision, 0, len(candidates))
+	for _, c := range candidates {
+		decisions = append(decisions, Decision{Candidate: c, Score: Score(c, now)})
+	}
+
+	sort.SliceStable(decisions, func(i, j int) bool {
+		// Unpaused first so a paused convoy can't shadow an issue another
+		// convoy would dispatch.
+		if decisions[i].ConvoyPaused != decisions[j].ConvoyPaused {
+			return !decisions[i].ConvoyPaused
+		}
+		if decisions[i].Score != decisions[j].Score {
+			return decisions[i].Score > decisions[j].Score
+		}
+		return decisions[i].IssueID < decisions[j].IssueID
+	})
+
+	free := make(map[string]int, len(capacity))
+	for rig, c := range capacity {
+		free[rig] = c.Free()
+	}
+
+	seen := make(map[string]bool)
+	result := decisions[:0]
+	for _, d := range decisions {
+		if seen[d.IssueID] {
+			continue
+		}
+		seen[d.IssueID] = true
+
+		switch {
+		case d.ConvoyPaused:
+			d.Action, d.Reason = ActionSkip, "convoy paused"
+		case d.Rig == "":
+			d.Action, d.Reason = ActionSkip, "no rig route for issue prefix"
+		default:
+			c, ok := capacity[d.Rig]
+			switch {
+			case !ok:
+				d.Action, d.Reason = ActionSkip, fmt.Sprintf("rig %s not registered", d.Rig)
+			case free[d.Rig] <= 0:
+				d.Action, d.Reason = ActionSkip, fmt.Sprintf("rig at capacity (%d/%d)", c.Active+c.Free()-free[d.Rig], c.Max)
+			default:
+				free[d.Rig]--
+				d.Action = ActionSling
+			}
+		}
+		result = append(result, d)
+	}
+	return result
+}
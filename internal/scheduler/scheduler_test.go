@@ -0,0 +1,82 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPlan_OrdersByPriorityAndConvoyAge(t *testing.T) {
+	now := time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC)
+	candidates := []Candidate{
+		{IssueID: "gt-low", Rig: "gastown", Priority: 3, ConvoyID: "hq-cv-new", ConvoyCreatedAt: now.Add(-time.Hour)},
+		{IssueID: "gt-high", Rig: "gastown", Priority: 0, ConvoyID: "hq-cv-new", ConvoyCreatedAt: now.Add(-time.Hour)},
+		// Same priority as gt-low but its convoy is two days old (+480 pts)
+		{IssueID: "gt-old", Rig: "gastown", Priority: 3, ConvoyID: "hq-cv-old", ConvoyCreatedAt: now.Add(-48 * time.Hour)},
+	}
+	capacity := map[string]Capacity{"gastown": {Active: 1, Max: 3}}
+
+	plan := Plan(candidates, capacity, now)
+	if len(plan) != 3 {
+		t.Fatalf("got %d decisions, want 3", len(plan))
+	}
+
+	order := []string{plan[0].IssueID, plan[1].IssueID, plan[2].IssueID}
+	want := []string{"gt-old", "gt-high", "gt-low"}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("order = %v, want %v", order, want)
+		}
+	}
+
+	if plan[0].Action != ActionSling || plan[1].Action != ActionSling {
+		t.Errorf("top two should sling: %+v", plan[:2])
+	}
+	if plan[2].Action != ActionSkip || plan[2].Reason != "rig at capacity (3/3)" {
+		t.Errorf("third should skip at capacity, got %s %q", plan[2].Action, plan[2].Reason)
+	}
+	if capacity["gastown"].Active != 1 {
+		t.Error("Plan must not modify capacity")
+	}
+}
+
+func TestPlan_SkipsPausedUnroutedAndDuplicates(t *testing.T) {
+	now := time.Now()
+	candidates := []Candidate{
+		{IssueID: "gt-a", Rig: "gastown", ConvoyID: "hq-cv-paused", ConvoyPaused: true, ConvoyCreatedAt: now.Add(-100 * time.Hour)},
+		{IssueID: "gt-a", Rig: "gastown", ConvoyID: "hq-cv-live"},
+		{IssueID: "gt-b", Rig: "gastown", ConvoyID: "hq-cv-paused", ConvoyPaused: true},
+		{IssueID: "zz-c", Rig: "", ConvoyID: "hq-cv-live"},
+		{IssueID: "bd-d", Rig: "beads", ConvoyID: "hq-cv-live"},
+	}
+	capacity := map[string]Capacity{"gastown": {Max: 5}}
+
+	byID := make(map[string]Decision)
+	for _, d := range Plan(candidates, capacity, now) {
+		if _, dup := byID[d.IssueID]; dup {
+			t.Fatalf("issue %s decided twice", d.IssueID)
+		}
+		byID[d.IssueID] = d
+	}
+
+	if d := byID["gt-a"]; d.Action != ActionSling || d.ConvoyID != "hq-cv-live" {
+		t.Errorf("gt-a should sling via the unpaused convoy, got %+v", d)
+	}
+	if d := byID["gt-b"]; d.Action != ActionSkip || d.Reason != "convoy paused" {
+		t.Errorf("gt-b: %+v", d)
+	}
+	if d := byID["zz-c"]; d.Action != ActionSkip || d.Reason != "no rig route for issue prefix" {
+		t.Errorf("zz-c: %+v", d)
+	}
+	if d := byID["bd-d"]; d.Action != ActionSkip || d.Reason != "rig beads not registered" {
+		t.Errorf("bd-d: %+v", d)
+	}
+}
+
+func TestCapacityFree(t *testing.T) {
+	if got := (Capacity{Active: 5, Max: 3}).Free(); got != 0 {
+		t.Errorf("over capacity Free() = %d, want 0", got)
+	}
+	if got := (Capacity{Active: 1, Max: 3}).Free(); got != 2 {
+		t.Errorf("Free() = %d, want 2", got)
+	}
+}
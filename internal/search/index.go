@@ -0,0 +1,283 @@
+// Package search is an offline full-text index over a town's history:
+// session transcripts, mail, bead descriptions and handoff notes. Results
+// are ranked with BM25 and carry the session, bead and convoy they belong
+// to, so gt seance --search can point at the predecessor worth talking to.
+//
+// The index is cached in .runtime/search-index.json and rebuilt
+// incrementally: only sources whose size or modification time changed are
+// re-read.
+package search
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// Document kinds.
+const (
+	KindSession = "session"
+	KindMail    = "mail"
+	KindBead    = "bead"
+	KindHandoff = "handoff"
+)
+
+// indexVersion is bumped when the tokenizer or document layout changes, so
+// stale caches are rebuilt from scratch.
+const indexVersion = 1
+
+// BM25 parameters.
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// titleWeight is how many times title terms count relative to body terms.
+const titleWeight = 3
+
+// excerptLen caps the stored excerpt shown with a hit.
+const excerptLen = 240
+
+// Document is one searchable item.
+type Document struct {
+	Kind    string    `json:"kind"`
+	ID      string    `json:"id"` // Session ID or bead ID
+	Title   string    `json:"title"`
+	Actor   string    `json:"actor,omitempty"`
+	Time    time.Time `json:"time"`
+	Session string    `json:"session,omitempty"`
+	Bead    string    `json:"bead,omitempty"`
+	Convoy  string    `json:"convoy,omitempty"`
+	Thread  string    `json:"thread,omitempty"`
+	Excerpt string    `json:"excerpt,omitempty"`
+
+	// Source is the file the document was read from.
+	Source string `json:"source"`
+
+	Terms  map[string]int `json:"terms"`
+	Length int            `json:"length"`
+}
+
+// NewDocument tokenizes title and text into a document of kind.
+func NewDocument(kind, id, title, text string) *Document {
+	d := &Document{Kind: kind, ID: id, Title: title, Terms: make(map[string]int)}
+	for _, t := range Tokenize(title) {
+		d.Terms[t] += titleWeight
+		d.Length += titleWeight
+	}
+	for _, t := range Tokenize(text) {
+		d.Terms[t]++
+		d.Length++
+	}
+	d.Excerpt = excerpt(text)
+	return d
+}
+
+// Index is a set of documents with the statistics BM25 needs.
+type Index struct {
+	Version int                    `json:"version"`
+	Built   time.Time              `json:"built"`
+	Sources map[string]SourceStamp `json:"sources"`
+	Docs    []*Document            `json:"docs"`
+
+	docFreq map[string]int
+	avgLen  float64
+}
+
+// SourceStamp identifies a version of a source file.
+type SourceStamp struct {
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mod_time"`
+}
+
+// NewIndex builds an index over docs.
+func NewIndex(docs []*Document) *Index {
+	idx := &Index{Version: indexVersion, Sources: make(map[string]SourceStamp), Docs: docs}
+	idx.computeStats()
+	return idx
+}
+
+func (idx *Index) computeStats() {
+	idx.docFreq = make(map[string]int)
+	total := 0
+	for _, d := range idx.Docs {
+		for t := range d.Terms {
+			idx.docFreq[t]++
+		}
+		total += d.Length
+	}
+	idx.avgLen = 0
+	if len(idx.Docs) > 0 {
+		idx.avgLen = float64(total) / float64(len(idx.Docs))
+	}
+}
+
+// Query is a search request.
+type Query struct {
+	Text  string
+	Kinds []string // Empty means all kinds
+	Limit int      // <= 0 means no limit
+}
+
+// Hit is a ranked search result.
+type Hit struct {
+	*Document
+	Score float64 `json:"score"`
+}
+
+// Search ranks the documents matching q by BM25, best first. Ties go to
+// the more recent document.
+func (idx *Index) Search(q Query) []Hit {
+	terms := Tokenize(q.Text)
+	if len(terms) == 0 {
+		return nil
+	}
+	kinds := make(map[string]bool, len(q.Kinds))
+	for _, k := range q.Kinds {
+		kinds[k] = true
+	}
+
+	n := float64(len(idx.Docs))
+	var hits []Hit
+	for _, d := range idx.Docs {
+		if len(kinds) > 0 && !kinds[d.Kind] {
+			continue
+		}
+		score := 0.0
+		for _, t := range terms {
+			tf := float64(d.Terms[t])
+			if tf == 0 {
+				continue
+			}
+			df := float64(idx.docFreq[t])
+			idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+			norm := 1 - bm25B
+			if idx.avgLen > 0 {
+				norm += bm25B * float64(d.Length) / idx.avgLen
+			}
+			score += idf * tf * (bm25K1 + 1) / (tf + bm25K1*norm)
+		}
+		if score > 0 {
+			hits = append(hits, Hit{Document: d, Score: score})
+		}
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].Time.After(hits[j].Time)
+	})
+	if q.Limit > 0 && len(hits) > q.Limit {
+		hits = hits[:q.Limit]
+	}
+	return hits
+}
+
+// IndexPath returns the path of a town's cached index.
+func IndexPath(townRoot string) string {
+	return filepath.Join(constants.TownRuntimePath(townRoot), "search-index.json")
+}
+
+// Load reads a town's cached index. A missing or outdated cache returns
+// nil without error.
+func Load(townRoot string) (*Index, error) {
+	data, err := os.ReadFile(IndexPath(townRoot))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading search index: %w", err)
+	}
+	var idx Index
+	if err := json.Unmarshal(data, &idx); err != nil {
+		return nil, fmt.Errorf("parsing search index: %w", err)
+	}
+	if idx.Version != indexVersion {
+		return nil, nil
+	}
+	if idx.Sources == nil {
+		idx.Sources = make(map[string]SourceStamp)
+	}
+	idx.computeStats()
+	return &idx, nil
+}
+
+// Save writes the index to the town's cache.
+func (idx *Index) Save(townRoot string) error {
+	path := IndexPath(townRoot)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating runtime dir: %w", err)
+	}
+	return util.AtomicWriteJSON(path, idx)
+}
+
+// stopWords are too common to help ranking.
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true,
+	"be": true, "but": true, "by": true, "for": true, "from": true, "has": true,
+	"have": true, "i": true, "if": true, "in": true, "into": true, "is": true,
+	"it": true, "its": true, "of": true, "on": true, "or": true, "so": true,
+	"that": true, "the": true, "then": true, "there": true, "this": true,
+	"to": true, "was": true, "we": true, "were": true, "will": true,
+	"with": true, "you": true,
+}
+
+// Tokenize splits text into lowercase, lightly stemmed terms without stop
+// words. Queries and documents go through the same tokenizer.
+func Tokenize(text string) []string {
+	var terms []string
+	for _, w := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len(w) < 2 || stopWords[w] {
+			continue
+		}
+		terms = append(terms, stem(w))
+	}
+	return terms
+}
+
+// stem strips common English suffixes so "retries", "retried" and
+// "retrying" all match "retry".
+func stem(w string) string {
+	switch {
+	case len(w) > 4 && strings.HasSuffix(w, "ies"):
+		return w[:len(w)-3] + "y"
+	case len(w) > 4 && strings.HasSuffix(w, "ied"):
+		return w[:len(w)-3] + "y"
+	case len(w) > 5 && strings.HasSuffix(w, "ing"):
+		return w[:len(w)-3]
+	case len(w) > 4 && strings.HasSuffix(w, "ed"):
+		return w[:len(w)-2]
+	case len(w) > 3 && strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss"):
+		return w[:len(w)-1]
+	}
+	return w
+}
+
+// excerpt returns the start of text on one line, for display.
+func excerpt(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if len(text) <= excerptLen {
+		return text
+	}
+	cut := strings.LastIndex(text[:excerptLen], " ")
+	if cut < excerptLen/2 {
+		cut = excerptLen
+		for cut > 0 && !utf8.RuneStart(text[cut]) {
+			cut--
+		}
+	}
+	return text[:cut] + "…"
+}
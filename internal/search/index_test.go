@@ -0,0 +1,69 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTokenize(t *testing.T) {
+	got := Tokenize("Retrying the auth retries; it retried: TestAuthRetry is flaky!")
+	want := []string{"retry", "auth", "retry", "retry", "testauthretry", "flaky"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Tokenize() = %v, want %v", got, want)
+	}
+}
+
+func TestSearchRanksByBM25(t *testing.T) {
+	docs := []*Document{
+		NewDocument(KindBead, "gt-1", "Fix auth retry backoff", "The auth client retries too fast after a 429."),
+		NewDocument(KindMail, "hq-2", "Status", "Finished the docs pass; auth is next."),
+		NewDocument(KindBead, "gt-3", "Speed up CI", "Cache the module download between jobs."),
+		NewDocument(KindSession, "abc", "gastown/polecats/toast", "Looked at retry logic in the auth client and added jitter to retries."),
+	}
+	docs[1].Time = time.Now()
+	idx := NewIndex(docs)
+
+	hits := idx.Search(Query{Text: "auth retry"})
+	if len(hits) != 3 {
+		t.Fatalf("Search() = %d hits, want 3", len(hits))
+	}
+	if hits[0].ID != "gt-1" || hits[2].ID != "hq-2" {
+		t.Errorf("ranking = %s, %s, %s; want gt-1 first (title match), hq-2 last (one term)",
+			hits[0].ID, hits[1].ID, hits[2].ID)
+	}
+	for i := 1; i < len(hits); i++ {
+		if hits[i].Score > hits[i-1].Score {
+			t.Errorf("hits not sorted by score: %v", hits)
+		}
+	}
+
+	if hits := idx.Search(Query{Text: "auth retry", Kinds: []string{KindSession}}); len(hits) != 1 || hits[0].ID != "abc" {
+		t.Errorf("Search(kind session) = %v", hits)
+	}
+	if hits := idx.Search(Query{Text: "auth", Limit: 1}); len(hits) != 1 {
+		t.Errorf("Search(limit 1) = %d hits", len(hits))
+	}
+	if hits := idx.Search(Query{Text: "the of"}); hits != nil {
+		t.Errorf("stop-word query should match nothing, got %v", hits)
+	}
+}
+
+func TestIndexSaveLoad(t *testing.T) {
+	townRoot := t.TempDir()
+	if idx, err := Load(townRoot); idx != nil || err != nil {
+		t.Fatalf("Load() without cache = %v, %v", idx, err)
+	}
+
+	idx := NewIndex([]*Document{NewDocument(KindBead, "gt-1", "Flaky sync test", "")})
+	if err := idx.Save(townRoot); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	loaded, err := Load(townRoot)
+	if err != nil || loaded == nil {
+		t.Fatalf("Load() = %v, %v", loaded, err)
+	}
+	if hits := loaded.Search(Query{Text: "sync"}); len(hits) != 1 || hits[0].ID != "gt-1" {
+		t.Errorf("loaded index search = %v", hits)
+	}
+}
@@ -0,0 +1,482 @@
+package search
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+// maxLineSize bounds a single JSONL line; transcript lines carrying tool
+// output can be large.
+const maxLineSize = 16 * 1024 * 1024
+
+// BuildOptions configures where Build looks for sources.
+type BuildOptions struct {
+	// ConfigDirs are Claude config directories whose projects/ hold session
+	// transcripts. Nil uses TranscriptDirs.
+	ConfigDirs []string
+}
+
+// Update loads the town's cached index, refreshes it from sources that
+// changed, and saves it. With rebuild, the cache is ignored.
+func Update(townRoot string, rebuild bool) (*Index, error) {
+	var prev *Index
+	if !rebuild {
+		// A corrupt cache is rebuilt rather than reported.
+		prev, _ = Load(townRoot)
+	}
+	idx, err := Build(townRoot, prev, BuildOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if err := idx.Save(townRoot); err != nil {
+		return nil, err
+	}
+	return idx, nil
+}
+
+// TranscriptDirs returns the Claude config directories that may hold
+// transcripts of the town's sessions: CLAUDE_CONFIG_DIR, ~/.claude and
+// every account in mayor/accounts.json.
+func TranscriptDirs(townRoot string) []string {
+	var dirs []string
+	if dir := os.Getenv("CLAUDE_CONFIG_DIR"); dir != "" {
+		dirs = append(dirs, dir)
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, filepath.Join(home, ".claude"))
+	}
+	if accounts, err := config.LoadAccountsConfig(constants.MayorAccountsPath(townRoot)); err == nil {
+		handles := make([]string, 0, len(accounts.Accounts))
+		for handle := range accounts.Accounts {
+			handles = append(handles, handle)
+		}
+		sort.Strings(handles)
+		for _, handle := range handles {
+			if dir := accounts.Accounts[handle].ConfigDir; dir != "" {
+				dirs = append(dirs, dir)
+			}
+		}
+	}
+	return dirs
+}
+
+// Build indexes the town's sessions, mail, beads and handoffs. Documents
+// from sources unchanged since prev was built are reused; links between
+// sessions, beads and convoys are recomputed every time.
+func Build(townRoot string, prev *Index, opts BuildOptions) (*Index, error) {
+	configDirs := opts.ConfigDirs
+	if configDirs == nil {
+		configDirs = TranscriptDirs(townRoot)
+	}
+
+	reuse := make(map[string][]*Document)
+	if prev != nil {
+		for _, d := range prev.Docs {
+			if stamp, ok := prev.Sources[d.Source]; ok && stampMatches(d.Source, stamp) {
+				reuse[d.Source] = append(reuse[d.Source], d)
+			}
+		}
+	}
+
+	idx := &Index{Version: indexVersion, Built: time.Now(), Sources: make(map[string]SourceStamp)}
+	read := func(path string, parse func(string) []*Document) {
+		info, err := os.Stat(path)
+		if err != nil {
+			return
+		}
+		idx.Sources[path] = SourceStamp{Size: info.Size(), ModTime: info.ModTime()}
+		if docs, ok := reuse[path]; ok {
+			idx.Docs = append(idx.Docs, docs...)
+			return
+		}
+		for _, d := range parse(path) {
+			d.Source = path
+			idx.Docs = append(idx.Docs, d)
+		}
+	}
+
+	// Beads: mail, handoffs and work beads, from the town and every rig.
+	beadsDirs := []string{filepath.Join(townRoot, ".beads")}
+	if rigs, err := config.LoadRigsConfig(constants.MayorRigsPath(townRoot)); err == nil {
+		names := make([]string, 0, len(rigs.Rigs))
+		for name := range rigs.Rigs {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			beadsDirs = append(beadsDirs, beads.ResolveBeadsDir(filepath.Join(townRoot, name)))
+		}
+	}
+	var issues []*jsonlIssue
+	seenDirs := make(map[string]bool)
+	for _, dir := range beadsDirs {
+		if seenDirs[dir] {
+			continue
+		}
+		seenDirs[dir] = true
+		path := filepath.Join(dir, "issues.jsonl")
+		fileIssues := readIssues(path)
+		issues = append(issues, fileIssues...)
+		read(path, func(string) []*Document { return issueDocuments(fileIssues) })
+	}
+
+	// Sessions: session_start events, with their transcripts when found.
+	evts, _ := events.ReadEvents(townRoot)
+	sessions := sessionsFromEvents(evts)
+	eventsPath := filepath.Join(townRoot, events.EventsFile)
+	delete(reuse, eventsPath) // Which sessions lack a transcript can change without the log changing
+	var untranscribed []*sessionInfo
+	for _, s := range sessions {
+		if path := findTranscript(configDirs, s.ID); path != "" {
+			s := s
+			read(path, func(path string) []*Document { return []*Document{transcriptDocument(s, path)} })
+		} else {
+			untranscribed = append(untranscribed, s)
+		}
+	}
+	read(eventsPath, func(string) []*Document {
+		var docs []*Document
+		for _, s := range untranscribed {
+			docs = append(docs, sessionDocument(s, ""))
+		}
+		return docs
+	})
+
+	link(idx.Docs, sessions, issues)
+	idx.computeStats()
+	return idx, nil
+}
+
+func stampMatches(path string, stamp SourceStamp) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Size() == stamp.Size && info.ModTime().Equal(stamp.ModTime)
+}
+
+// jsonlIssue is the part of a bd issues.jsonl record the index uses.
+type jsonlIssue struct {
+	ID           string   `json:"id"`
+	Title        string   `json:"title"`
+	Description  string   `json:"description"`
+	Notes        string   `json:"notes"`
+	Type         string   `json:"issue_type"`
+	Status       string   `json:"status"`
+	Assignee     string   `json:"assignee"`
+	CreatedBy    string   `json:"created_by"`
+	CreatedAt    string   `json:"created_at"`
+	UpdatedAt    string   `json:"updated_at"`
+	Labels       []string `json:"labels"`
+	Dependencies []struct {
+		IssueID     string `json:"issue_id"`
+		DependsOnID string `json:"depends_on_id"`
+		Type        string `json:"type"`
+	} `json:"dependencies"`
+}
+
+func readIssues(path string) []*jsonlIssue {
+	var issues []*jsonlIssue
+	scanJSONL(path, func(line []byte) {
+		var issue jsonlIssue
+		if json.Unmarshal(line, &issue) == nil && issue.ID != "" {
+			issues = append(issues, &issue)
+		}
+	})
+	return issues
+}
+
+// issueDocuments turns beads into mail, handoff and bead documents.
+// Agent, role and other bookkeeping beads are skipped.
+func issueDocuments(issues []*jsonlIssue) []*Document {
+	var docs []*Document
+	for _, issue := range issues {
+		switch issue.Type {
+		case "agent", "role", "molecule", "wisp", "event", "merge-request":
+			continue
+		}
+
+		kind := KindBead
+		if issue.Type == "message" {
+			kind = KindMail
+		}
+		if strings.HasSuffix(issue.Title, " Handoff") || strings.Contains(issue.Title, "HANDOFF") {
+			kind = KindHandoff
+		}
+
+		text := issue.Description
+		if issue.Notes != "" {
+			text += "\n" + issue.Notes
+		}
+		d := NewDocument(kind, issue.ID, issue.Title, text)
+		d.Actor = issue.CreatedBy
+		d.Time = parseTime(firstNonEmpty(issue.UpdatedAt, issue.CreatedAt))
+		if kind == KindBead {
+			d.Bead = issue.ID
+		}
+		for _, label := range issue.Labels {
+			switch {
+			case strings.HasPrefix(label, "from:"):
+				d.Actor = strings.TrimPrefix(label, "from:")
+			case strings.HasPrefix(label, "thread:"):
+				d.Thread = strings.TrimPrefix(label, "thread:")
+			}
+		}
+		if d.Actor == "" {
+			d.Actor = issue.Assignee
+		}
+		docs = append(docs, d)
+	}
+	return docs
+}
+
+// sessionInfo is a session discovered from the event stream.
+type sessionInfo struct {
+	ID    string
+	Actor string
+	Topic string
+	Cwd   string
+	Start time.Time
+	Bead  string // Work on the agent's hook during the session
+}
+
+// sessionsFromEvents collects session_start events and the bead each
+// session worked: the one hooked or slung to the actor when it started,
+// else the first one hooked before the actor's next session.
+func sessionsFromEvents(evts []events.Event) []*sessionInfo {
+	sort.SliceStable(evts, func(i, j int) bool { return evts[i].Time().Before(evts[j].Time()) })
+
+	var sessions []*sessionInfo
+	current := make(map[string]*sessionInfo) // Actor -> latest session
+	hooked := make(map[string]string)        // Actor -> bead on hook
+	for _, e := range evts {
+		bead := payloadString(e.Payload, "bead")
+		switch e.Type {
+		case events.TypeSessionStart:
+			id := payloadString(e.Payload, "session_id")
+			if id == "" {
+				continue
+			}
+			s := &sessionInfo{
+				ID:    id,
+				Actor: e.Actor,
+				Topic: payloadString(e.Payload, "topic"),
+				Cwd:   payloadString(e.Payload, "cwd"),
+				Start: e.Time(),
+				Bead:  hooked[e.Actor],
+			}
+			sessions = append(sessions, s)
+			current[e.Actor] = s
+		case events.TypeHook:
+			hooked[e.Actor] = bead
+			if s := current[e.Actor]; s != nil && s.Bead == "" {
+				s.Bead = bead
+			}
+		case events.TypeSling:
+			if target := payloadString(e.Payload, "target"); target != "" {
+				hooked[target] = bead
+			}
+		case events.TypeDone, events.TypeUnhook:
+			delete(hooked, e.Actor)
+		}
+	}
+	return sessions
+}
+
+// findTranscript looks for a session's Claude transcript, stored as
+// projects/<encoded cwd>/<session-id>.jsonl under a config directory.
+func findTranscript(configDirs []string, sessionID string) string {
+	if sessionID == "" || strings.ContainsAny(sessionID, `/\*?[`) {
+		return ""
+	}
+	for _, dir := range configDirs {
+		matches, _ := filepath.Glob(filepath.Join(dir, "projects", "*", sessionID+".jsonl"))
+		if len(matches) > 0 {
+			return matches[0]
+		}
+	}
+	return ""
+}
+
+func sessionDocument(s *sessionInfo, text string) *Document {
+	title := s.Actor
+	if s.Topic != "" {
+		title += " " + s.Topic
+	}
+	d := NewDocument(KindSession, s.ID, title, text)
+	d.Actor = s.Actor
+	d.Time = s.Start
+	d.Session = s.ID
+	return d
+}
+
+// transcriptDocument indexes the conversation text of a transcript: user
+// and assistant messages, without tool calls and tool output. The title is
+// the first user message, which usually says what the session was for.
+func transcriptDocument(s *sessionInfo, path string) *Document {
+	var b strings.Builder
+	var firstPrompt string
+	scanJSONL(path, func(line []byte) {
+		var entry struct {
+			Type    string `json:"type"`
+			Message struct {
+				Content json.RawMessage `json:"content"`
+			} `json:"message"`
+		}
+		if json.Unmarshal(line, &entry) != nil || (entry.Type != "user" && entry.Type != "assistant") {
+			return
+		}
+		text := messageText(entry.Message.Content)
+		if text == "" {
+			return
+		}
+		if entry.Type == "user" && firstPrompt == "" {
+			firstPrompt = text
+		}
+		b.WriteString(text)
+		b.WriteString("\n")
+	})
+
+	d := sessionDocument(s, b.String())
+	if firstPrompt != "" {
+		d.Excerpt = excerpt(firstPrompt)
+	}
+	return d
+}
+
+// messageText extracts the text of a message whose content is either a
+// string or a list of content blocks.
+func messageText(content json.RawMessage) string {
+	var s string
+	if json.Unmarshal(content, &s) == nil {
+		return strings.TrimSpace(s)
+	}
+	var blocks []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	}
+	if json.Unmarshal(content, &blocks) != nil {
+		return ""
+	}
+	var parts []string
+	for _, block := range blocks {
+		if block.Type == "text" && strings.TrimSpace(block.Text) != "" {
+			parts = append(parts, strings.TrimSpace(block.Text))
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// link fills in the session, bead and convoy each document belongs to:
+// sessions get the bead they worked; beads the latest session that worked
+// them; mail and handoffs the sender's session at the time they were sent;
+// and everything the convoy tracking its bead.
+func link(docs []*Document, sessions []*sessionInfo, issues []*jsonlIssue) {
+	convoyOf := make(map[string]string)
+	for _, issue := range issues {
+		if issue.Type == "convoy" {
+			convoyOf[issue.ID] = issue.ID
+		}
+		for _, dep := range issue.Dependencies {
+			if dep.Type == "tracks" && dep.DependsOnID != "" {
+				convoyOf[dep.DependsOnID] = issue.ID
+			}
+		}
+	}
+	beadOf := make(map[string]string)
+	sessionOfBead := make(map[string]string) // Sessions are in start order
+	for _, s := range sessions {
+		beadOf[s.ID] = s.Bead
+		if s.Bead != "" {
+			sessionOfBead[s.Bead] = s.ID
+		}
+	}
+
+	for _, d := range docs {
+		switch d.Kind {
+		case KindSession:
+			d.Bead = beadOf[d.ID]
+		case KindBead:
+			d.Session = sessionOfBead[d.ID]
+		case KindMail, KindHandoff:
+			d.Session = senderSession(sessions, d.Actor, d.Time)
+		}
+		d.Convoy = ""
+		if d.Bead != "" {
+			d.Convoy = convoyOf[d.Bead]
+		}
+	}
+}
+
+// senderSession returns the session actor was running at t: its latest
+// session started at or before t.
+func senderSession(sessions []*sessionInfo, actor string, t time.Time) string {
+	if actor == "" || t.IsZero() {
+		return ""
+	}
+	actor = normalizeActor(actor)
+	id := ""
+	for _, s := range sessions {
+		if s.Start.After(t) {
+			break
+		}
+		if normalizeActor(s.Actor) == actor {
+			id = s.ID
+		}
+	}
+	return id
+}
+
+// normalizeActor reduces agent identities and mail addresses to a common
+// form: "gastown/polecats/toast", "gastown/toast" and "mayor/" compare
+// equal to their counterparts.
+func normalizeActor(actor string) string {
+	actor = strings.TrimSuffix(actor, "/")
+	actor = strings.Replace(actor, "/polecats/", "/", 1)
+	return strings.Replace(actor, "/crew/", "/", 1)
+}
+
+func scanJSONL(path string, fn func([]byte)) {
+	f, err := os.Open(path) //nolint:gosec // G304: paths come from town config and event logs
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	for scanner.Scan() {
+		fn(scanner.Bytes())
+	}
+}
+
+func payloadString(payload map[string]interface{}, key string) string {
+	if s, ok := payload[key].(string); ok {
+		return s
+	}
+	return ""
+}
+
+func parseTime(s string) time.Time {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,138 @@
+package search
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeLines(t *testing.T, path string, lines ...string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// setupSearchTown creates a town with one transcribed session, one session
+// without a transcript, and town beads holding a convoy, a work bead, mail
+// and a handoff.
+func setupSearchTown(t *testing.T) (townRoot, configDir string) {
+	t.Helper()
+	townRoot = t.TempDir()
+	configDir = t.TempDir()
+
+	writeLines(t, filepath.Join(townRoot, ".events.jsonl"),
+		`{"ts":"2026-01-05T10:00:00Z","type":"sling","actor":"mayor","payload":{"bead":"gt-42","target":"gastown/polecats/toast"}}`,
+		`{"ts":"2026-01-05T10:01:00Z","type":"session_start","actor":"gastown/polecats/toast","payload":{"session_id":"sess-1","cwd":"/town/gastown/polecats/toast"}}`,
+		`{"ts":"2026-01-05T12:00:00Z","type":"session_start","actor":"gastown/witness","payload":{"session_id":"sess-2","topic":"patrol"}}`,
+	)
+	writeLines(t, filepath.Join(configDir, "projects", "-town-gastown-polecats-toast", "sess-1.jsonl"),
+		`{"type":"user","message":{"role":"user","content":"Work on gt-42: the auth client retries without backoff"}}`,
+		`{"type":"assistant","message":{"role":"assistant","content":[{"type":"text","text":"I'll add exponential backoff to the retry loop."},{"type":"tool_use","name":"Bash","input":{"command":"go test ./..."}}]}}`,
+		`{"type":"user","message":{"role":"user","content":[{"type":"tool_result","content":"ok zebra"}]}}`,
+	)
+	writeLines(t, filepath.Join(townRoot, ".beads", "issues.jsonl"),
+		`{"id":"hq-cv-1","title":"Auth hardening","issue_type":"convoy","dependencies":[{"issue_id":"hq-cv-1","depends_on_id":"gt-42","type":"tracks"}]}`,
+		`{"id":"gt-42","title":"Auth client retry storm","description":"Retries hammer the token endpoint.","issue_type":"bug","updated_at":"2026-01-05T09:00:00Z"}`,
+		`{"id":"hq-m1","title":"Backoff landed","description":"Exponential backoff is in; see gt-42.","issue_type":"message","created_at":"2026-01-05T11:00:00Z","updated_at":"2026-01-05T11:00:00Z","labels":["from:gastown/toast","thread:thread-9"]}`,
+		`{"id":"hq-h1","title":"🤝 HANDOFF: retry work","description":"Backoff done, jitter still TODO.","issue_type":"message","labels":["from:gastown/toast"]}`,
+		`{"id":"hq-a1","title":"gastown/polecats/toast","description":"retry retry retry","issue_type":"agent"}`,
+	)
+	return townRoot, configDir
+}
+
+func findDoc(idx *Index, kind, id string) *Document {
+	for _, d := range idx.Docs {
+		if d.Kind == kind && d.ID == id {
+			return d
+		}
+	}
+	return nil
+}
+
+func TestBuild(t *testing.T) {
+	townRoot, configDir := setupSearchTown(t)
+
+	idx, err := Build(townRoot, nil, BuildOptions{ConfigDirs: []string{configDir}})
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if len(idx.Docs) != 6 {
+		t.Fatalf("Build() = %d docs, want 6 (agent bead skipped)", len(idx.Docs))
+	}
+
+	session := findDoc(idx, KindSession, "sess-1")
+	if session == nil || session.Bead != "gt-42" || session.Convoy != "hq-cv-1" {
+		t.Fatalf("transcribed session = %+v, want bead gt-42 in convoy hq-cv-1", session)
+	}
+	if !strings.HasPrefix(session.Excerpt, "Work on gt-42") {
+		t.Errorf("session excerpt = %q, want first prompt", session.Excerpt)
+	}
+	if session.Terms["zebra"] != 0 || session.Terms["bash"] != 0 {
+		t.Error("tool calls and tool output should not be indexed")
+	}
+	if patrol := findDoc(idx, KindSession, "sess-2"); patrol == nil || patrol.Title != "gastown/witness patrol" {
+		t.Errorf("untranscribed session = %+v", patrol)
+	}
+
+	if bead := findDoc(idx, KindBead, "gt-42"); bead == nil || bead.Session != "sess-1" || bead.Convoy != "hq-cv-1" {
+		t.Errorf("bead = %+v, want session sess-1, convoy hq-cv-1", bead)
+	}
+	mail := findDoc(idx, KindMail, "hq-m1")
+	if mail == nil || mail.Actor != "gastown/toast" || mail.Thread != "thread-9" || mail.Session != "sess-1" {
+		t.Errorf("mail = %+v, want sender's session sess-1", mail)
+	}
+	if findDoc(idx, KindHandoff, "hq-h1") == nil {
+		t.Error("handoff mail should be indexed as a handoff")
+	}
+
+	hits := idx.Search(Query{Text: "auth backoff retry", Kinds: []string{KindSession}})
+	if len(hits) != 1 || hits[0].ID != "sess-1" {
+		t.Errorf("session search = %v", hits)
+	}
+}
+
+func TestBuildReusesUnchangedSources(t *testing.T) {
+	townRoot, configDir := setupSearchTown(t)
+	opts := BuildOptions{ConfigDirs: []string{configDir}}
+
+	prev, err := Build(townRoot, nil, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Mark the previous documents so reuse is visible.
+	for _, d := range prev.Docs {
+		d.Excerpt = "cached"
+	}
+
+	beadsPath := filepath.Join(townRoot, ".beads", "issues.jsonl")
+	later := time.Now().Add(time.Minute)
+	writeLines(t, beadsPath,
+		`{"id":"gt-42","title":"Auth client retry storm","description":"Retries hammer the token endpoint.","issue_type":"bug"}`,
+	)
+	if err := os.Chtimes(beadsPath, later, later); err != nil {
+		t.Fatal(err)
+	}
+
+	idx, err := Build(townRoot, prev, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := findDoc(idx, KindSession, "sess-1"); d == nil || d.Excerpt != "cached" {
+		t.Errorf("unchanged transcript should be reused, got %+v", d)
+	}
+	if d := findDoc(idx, KindBead, "gt-42"); d == nil || d.Excerpt == "cached" {
+		t.Errorf("changed beads file should be re-read, got %+v", d)
+	}
+	if findDoc(idx, KindMail, "hq-m1") != nil {
+		t.Error("mail removed from the beads file should leave the index")
+	}
+	if d := findDoc(idx, KindSession, "sess-1"); d.Convoy != "" {
+		t.Errorf("links should be recomputed: convoy = %q after the convoy was removed", d.Convoy)
+	}
+}
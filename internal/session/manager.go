@@ -10,11 +10,15 @@ import (
 	"strings"
 	"time"
 
+	"github.com/steveyegge/gastown/internal/account"
 	"github.com/steveyegge/gastown/internal/claude"
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/limits"
 	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/sandbox"
 	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/tracing"
 )
 
 // Common errors
@@ -55,6 +59,11 @@ type StartOptions struct {
 	// ClaudeConfigDir is resolved CLAUDE_CONFIG_DIR for the account.
 	// If set, this is injected as an environment variable.
 	ClaudeConfigDir string
+
+	// TraceParent is a W3C traceparent for the work being started.
+	// If set, it is exported as TRACEPARENT so gt commands run by the
+	// agent (e.g., gt done) continue the trace.
+	TraceParent string
 }
 
 // Info contains information about a running session.
@@ -82,6 +91,9 @@ type Info struct {
 
 	// LastActivity is when the session last had activity.
 	LastActivity time.Time `json:"last_activity,omitempty"`
+
+	// Resources is the session's current CPU, memory and process usage.
+	Resources *limits.Usage `json:"resources,omitempty"`
 }
 
 // SessionName generates the tmux session name for a polecat.
@@ -133,6 +145,27 @@ func (m *Manager) Start(polecat string, opts StartOptions) error {
 		return fmt.Errorf("ensuring Claude settings: %w", err)
 	}
 
+	// Build the startup command with env vars exported inline
+	// NOTE: tmux SetEnvironment only affects NEW panes, not the current shell.
+	// We must export GT_ROLE, GT_RIG, GT_POLECAT inline for Claude to detect identity.
+	command := opts.Command
+	if command == "" {
+		// Polecats run with full permissions - Gas Town is for grownups
+		// Export env vars inline so Claude's role detection works
+		command = config.BuildPolecatStartupCommand(m.rig.Name, polecat, m.rig.Path, "")
+	}
+	if opts.TraceParent != "" {
+		command = fmt.Sprintf("export %s=%s && %s", tracing.EnvTraceParent, opts.TraceParent, command)
+	}
+	command = account.ExportCommand(opts.ClaudeConfigDir, command)
+
+	// Run inside the rig's sandbox, if it has one. Resolved before the
+	// session exists so a broken sandbox never leaves a host-side agent.
+	command, err = sandbox.WrapPolecat(filepath.Dir(m.rig.Path), m.rig.Name, polecat, opts.ClaudeConfigDir, command)
+	if err != nil {
+		return err
+	}
+
 	// Create session
 	if err := m.tmux.NewSession(sessionID, workDir); err != nil {
 		return fmt.Errorf("creating session: %w", err)
@@ -147,6 +180,11 @@ func (m *Manager) Start(polecat string, opts StartOptions) error {
 		_ = m.tmux.SetEnvironment(sessionID, "CLAUDE_CONFIG_DIR", opts.ClaudeConfigDir)
 	}
 
+	// Propagate trace context for end-to-end work tracing (non-fatal)
+	if opts.TraceParent != "" {
+		_ = m.tmux.SetEnvironment(sessionID, tracing.EnvTraceParent, opts.TraceParent)
+	}
+
 	// CRITICAL: Set beads environment for worktree polecats (non-fatal: session works without)
 	// Polecats need access to TOWN-level beads (parent of rig) for hooks and convoys.
 	// Town beads use hq- prefix and store hooks, mail, and cross-rig coordination.
@@ -176,14 +214,6 @@ func (m *Manager) Start(polecat string, opts StartOptions) error {
 	_ = m.tmux.SetPaneDiedHook(sessionID, agentID)
 
 	// Send initial command with env vars exported inline
-	// NOTE: tmux SetEnvironment only affects NEW panes, not the current shell.
-	// We must export GT_ROLE, GT_RIG, GT_POLECAT inline for Claude to detect identity.
-	command := opts.Command
-	if command == "" {
-		// Polecats run with full permissions - Gas Town is for grownups
-		// Export env vars inline so Claude's role detection works
-		command = config.BuildPolecatStartupCommand(m.rig.Name, polecat, m.rig.Path, "")
-	}
 	if err := m.tmux.SendKeys(sessionID, command); err != nil {
 		return fmt.Errorf("sending command: %w", err)
 	}
@@ -300,6 +330,10 @@ func (m *Manager) Status(polecat string) (*Info, error) {
 		return info, nil
 	}
 
+	// Resource usage against the rig's polecat limits (non-fatal)
+	townRoot := filepath.Dir(m.rig.Path)
+	info.Resources, _ = limits.ForSession(m.tmux, sessionID, config.ResolveResourceLimits(townRoot, m.rig.Name, "polecat"))
+
 	// Get detailed session info
 	tmuxInfo, err := m.tmux.GetSessionInfo(sessionID)
 	if err != nil {
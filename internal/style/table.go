@@ -108,7 +108,7 @@ func (t *Table) Render() string {
 				val = row[i]
 			}
 			// Truncate if too long
-			plainVal := stripAnsi(val)
+			plainVal := StripAnsi(val)
 			if len(plainVal) > col.Width {
 				val = plainVal[:col.Width-3] + "..."
 			}
@@ -149,8 +149,8 @@ func (t *Table) pad(styledText, plainText string, width int, align Alignment) st
 	}
 }
 
-// stripAnsi removes ANSI escape sequences from a string.
-func stripAnsi(s string) string {
+// StripAnsi removes ANSI escape sequences from a string.
+func StripAnsi(s string) string {
 	var result strings.Builder
 	inEscape := false
 	for i := 0; i < len(s); i++ {
@@ -120,16 +120,20 @@ func (m *Manager) ExecuteLanding(swarmID string, config LandingConfig) (*Landing
 
 // auditWorkerGit checks a worker's git state for uncommitted/unpushed work.
 func (m *Manager) auditWorkerGit(worker string) GitAuditResult {
+	return AuditClone(worker, fmt.Sprintf("%s/polecats/%s", m.rig.Path, worker))
+}
+
+// AuditClone checks a worker clone for uncommitted, unpushed, or stashed
+// work before it is destroyed. Changes confined to .beads/ don't put code
+// at risk.
+func AuditClone(worker, clonePath string) GitAuditResult {
 	result := GitAuditResult{
-		Worker: worker,
+		Worker:    worker,
+		ClonePath: clonePath,
 	}
 
-	// Get polecat clone path
-	clonePath := fmt.Sprintf("%s/polecats/%s", m.rig.Path, worker)
-	result.ClonePath = clonePath
-
 	// Check for uncommitted changes
-	statusOutput, err := m.gitRunOutput(clonePath, "status", "--porcelain")
+	statusOutput, err := gitRunOutput(clonePath, "status", "--porcelain")
 	if err == nil && strings.TrimSpace(statusOutput) != "" {
 		result.HasUncommitted = true
 		// Check if only .beads changes
@@ -137,13 +141,13 @@ func (m *Manager) auditWorkerGit(worker string) GitAuditResult {
 	}
 
 	// Check for unpushed commits
-	unpushed, err := m.gitRunOutput(clonePath, "log", "--oneline", "@{u}..", "--")
+	unpushed, err := gitRunOutput(clonePath, "log", "--oneline", "@{u}..", "--")
 	if err == nil && strings.TrimSpace(unpushed) != "" {
 		result.HasUnpushed = true
 	}
 
 	// Check for stashes
-	stashes, err := m.gitRunOutput(clonePath, "stash", "list")
+	stashes, err := gitRunOutput(clonePath, "stash", "list")
 	if err == nil && strings.TrimSpace(stashes) != "" {
 		result.HasStashes = true
 	}
@@ -179,7 +183,7 @@ func isBeadsOnlyChanges(statusOutput string) bool {
 }
 
 // gitRunOutput runs a git command and returns stdout.
-func (m *Manager) gitRunOutput(dir string, args ...string) (string, error) {
+func gitRunOutput(dir string, args ...string) (string, error) {
 	cmd := exec.Command("git", args...)
 	cmd.Dir = dir
 
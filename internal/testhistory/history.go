@@ -0,0 +1,210 @@
+package testhistory
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// FileName is the history file, relative to the rig root.
+const FileName = ".runtime/test-history.json"
+
+const (
+	// Window is how many recent outcomes are kept per test.
+	Window = 50
+
+	// ReleaseAfter is how many consecutive passes release a test from
+	// quarantine.
+	ReleaseAfter = 20
+
+	// maxReports bounds the per-MR reports kept in the history.
+	maxReports = 200
+)
+
+// History is a rig's per-test outcome history and the test report of each
+// recently processed MR.
+type History struct {
+	Tests map[string]*TestRecord `json:"tests"`
+	MRs   map[string]*Report     `json:"mrs,omitempty"`
+}
+
+// TestRecord is one test's history.
+type TestRecord struct {
+	Runs          int       `json:"runs"`
+	Failures      int       `json:"failures"`
+	Recent        string    `json:"recent"` // Last Window outcomes, oldest first: P, F or R (failed, then passed on rerun)
+	LastOutcome   Outcome   `json:"last_outcome"`
+	LastRun       time.Time `json:"last_run"`
+	Quarantined   bool      `json:"quarantined,omitempty"`
+	QuarantinedAt time.Time `json:"quarantined_at,omitempty"`
+	Bead          string    `json:"bead,omitempty"` // Bead filed when the test was found flaky
+}
+
+// FlipRate is the fraction of recent runs in which the test failed and
+// then passed on a rerun of the same commit. Outcomes that change between
+// commits don't count: a test that broke and was fixed is not flaky.
+func (r *TestRecord) FlipRate() float64 {
+	if len(r.Recent) == 0 {
+		return 0
+	}
+	return float64(strings.Count(r.Recent, "R")) / float64(len(r.Recent))
+}
+
+// Report is the test outcome of one MR's merge attempt.
+type Report struct {
+	MR          string    `json:"mr"`
+	Time        time.Time `json:"time"`
+	Format      string    `json:"format,omitempty"`
+	Failed      []string  `json:"failed,omitempty"`      // Still failing after reruns
+	Flaky       []string  `json:"flaky,omitempty"`       // Failed, then passed on rerun
+	Quarantined []string  `json:"quarantined,omitempty"` // Failing tests that are quarantined
+	NewlyFlaky  []string  `json:"newly_flaky,omitempty"` // Quarantined by this run
+	Allowed     bool      `json:"allowed,omitempty"`     // Merged despite quarantined failures
+}
+
+// Path returns the history file path for a rig.
+func Path(rigPath string) string {
+	return filepath.Join(rigPath, FileName)
+}
+
+// Load reads a rig's test history, returning an empty history if there is none.
+func Load(rigPath string) (*History, error) {
+	h := &History{}
+	data, err := os.ReadFile(Path(rigPath))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("reading test history: %w", err)
+		}
+	} else if err := json.Unmarshal(data, h); err != nil {
+		return nil, fmt.Errorf("parsing test history: %w", err)
+	}
+	if h.Tests == nil {
+		h.Tests = make(map[string]*TestRecord)
+	}
+	if h.MRs == nil {
+		h.MRs = make(map[string]*Report)
+	}
+	return h, nil
+}
+
+// Update applies fn to a rig's test history under an exclusive lock and
+// saves the result.
+func Update(rigPath string, fn func(*History) error) error {
+	path := Path(rigPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating runtime dir: %w", err)
+	}
+	return util.WithFileLock(path, func() error {
+		h, err := Load(rigPath)
+		if err != nil {
+			return err
+		}
+		if err := fn(h); err != nil {
+			return err
+		}
+		return util.AtomicWriteJSON(path, h)
+	})
+}
+
+// Record adds a run's pass and fail outcomes to the history. flaky lists
+// the run's failing tests that passed when rerun; they are recorded as
+// passing after a flip. Skipped tests are not recorded.
+func (h *History) Record(run *Run, flaky []string, at time.Time) {
+	for _, res := range run.Results {
+		var mark byte
+		outcome := res.Outcome
+		switch {
+		case outcome == Pass:
+			mark = 'P'
+		case outcome == Fail && slices.Contains(flaky, res.ID()):
+			mark = 'R'
+			outcome = Pass
+		case outcome == Fail:
+			mark = 'F'
+		default:
+			continue
+		}
+		rec := h.Tests[res.ID()]
+		if rec == nil {
+			rec = &TestRecord{}
+			h.Tests[res.ID()] = rec
+		}
+		rec.Runs++
+		if res.Outcome == Fail {
+			rec.Failures++
+		}
+		rec.Recent += string(mark)
+		if len(rec.Recent) > Window {
+			rec.Recent = rec.Recent[len(rec.Recent)-Window:]
+		}
+		rec.LastOutcome = outcome
+		rec.LastRun = at
+	}
+}
+
+// Classify quarantines tests with at least minRuns recent outcomes whose
+// flip rate reaches threshold, and releases quarantined tests that have
+// passed ReleaseAfter times in a row without a rerun. Returns the newly quarantined tests,
+// sorted.
+func (h *History) Classify(threshold float64, minRuns int, at time.Time) []string {
+	var newly []string
+	for id, rec := range h.Tests {
+		if rec.Quarantined {
+			if len(rec.Recent) >= ReleaseAfter &&
+				!strings.ContainsAny(rec.Recent[len(rec.Recent)-ReleaseAfter:], "FR") {
+				rec.Quarantined = false
+				rec.QuarantinedAt = time.Time{}
+			}
+			continue
+		}
+		if len(rec.Recent) >= minRuns && rec.FlipRate() > 0 && rec.FlipRate() >= threshold {
+			rec.Quarantined = true
+			rec.QuarantinedAt = at
+			newly = append(newly, id)
+		}
+	}
+	sort.Strings(newly)
+	return newly
+}
+
+// IsQuarantined reports whether a test is quarantined.
+func (h *History) IsQuarantined(id string) bool {
+	rec := h.Tests[id]
+	return rec != nil && rec.Quarantined
+}
+
+// Quarantined returns the quarantined tests, sorted.
+func (h *History) Quarantined() []string {
+	var ids []string
+	for id, rec := range h.Tests {
+		if rec.Quarantined {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// SetReport stores an MR's test report, dropping the oldest reports beyond
+// the retention limit.
+func (h *History) SetReport(r *Report) {
+	h.MRs[r.MR] = r
+	if len(h.MRs) <= maxReports {
+		return
+	}
+	reports := make([]*Report, 0, len(h.MRs))
+	for _, rep := range h.MRs {
+		reports = append(reports, rep)
+	}
+	sort.Slice(reports, func(i, j int) bool { return reports[i].Time.Before(reports[j].Time) })
+	for _, rep := range reports[:len(reports)-maxReports] {
+		delete(h.MRs, rep.MR)
+	}
+}
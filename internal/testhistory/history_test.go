@@ -0,0 +1,121 @@
+package testhistory
+
+import (
+	"maps"
+	"strings"
+	"testing"
+	"time"
+)
+
+func runOf(outcomes map[string]Outcome) *Run {
+	run := &Run{Format: FormatGoJSON}
+	for name, o := range outcomes {
+		run.Results = append(run.Results, Result{Package: "pkg", Name: name, Outcome: o})
+	}
+	return run
+}
+
+func TestFlipRate(t *testing.T) {
+	tests := []struct {
+		recent string
+		want   float64
+	}{
+		{"", 0},
+		{"PPPP", 0},
+		{"FFFF", 0},
+		{"PFPF", 0},
+		{"PPPPPPPPFFP", 0}, // Broke, then fixed: not flaky
+		{"PRPR", 0.5},
+		{"PPPR", 0.25},
+	}
+	for _, tt := range tests {
+		if got := (&TestRecord{Recent: tt.recent}).FlipRate(); got != tt.want {
+			t.Errorf("FlipRate(%q) = %v, want %v", tt.recent, got, tt.want)
+		}
+	}
+}
+
+func TestRecordAndClassify(t *testing.T) {
+	h, err := Load(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+
+	all := map[string]Outcome{"TestFlaky": Fail, "TestFixed": Fail, "TestStable": Pass, "TestBroken": Fail, "TestSkip": Skip}
+	for i := 0; i < 6; i++ {
+		outcomes := maps.Clone(all)
+		var flaky []string
+		if i%2 == 0 {
+			flaky = []string{"pkg.TestFlaky"} // Failed, then passed on rerun
+		} else {
+			outcomes["TestFlaky"] = Pass
+		}
+		if i >= 3 {
+			outcomes["TestFixed"] = Pass
+		}
+		h.Record(runOf(outcomes), flaky, now)
+	}
+	if _, ok := h.Tests["pkg.TestSkip"]; ok {
+		t.Error("skipped tests should not be recorded")
+	}
+	if rec := h.Tests["pkg.TestFlaky"]; rec.Runs != 6 || rec.Failures != 3 || rec.Recent != "RPRPRP" || rec.LastOutcome != Pass {
+		t.Errorf("TestFlaky record = %+v", rec)
+	}
+
+	if newly := h.Classify(0.2, 10, now); len(newly) != 0 {
+		t.Errorf("Classify() below min runs = %v, want none", newly)
+	}
+	newly := h.Classify(0.2, 5, now)
+	if len(newly) != 1 || newly[0] != "pkg.TestFlaky" {
+		t.Fatalf("Classify() = %v, want only TestFlaky", newly)
+	}
+	if !h.IsQuarantined("pkg.TestFlaky") || h.IsQuarantined("pkg.TestBroken") || h.IsQuarantined("pkg.TestFixed") {
+		t.Error("only the test that passed on rerun should be quarantined")
+	}
+	if again := h.Classify(0.2, 5, now); len(again) != 0 {
+		t.Errorf("Classify() again = %v, want no newly flaky tests", again)
+	}
+
+	for i := 0; i < ReleaseAfter; i++ {
+		h.Record(runOf(map[string]Outcome{"TestFlaky": Pass}), nil, now)
+	}
+	h.Classify(0.2, 5, now)
+	if h.IsQuarantined("pkg.TestFlaky") {
+		t.Errorf("quarantine should lift after %d passes", ReleaseAfter)
+	}
+}
+
+func TestRecordWindow(t *testing.T) {
+	h, _ := Load(t.TempDir())
+	for i := 0; i < Window+10; i++ {
+		h.Record(runOf(map[string]Outcome{"TestX": Pass}), nil, time.Now())
+	}
+	rec := h.Tests["pkg.TestX"]
+	if rec.Runs != Window+10 || len(rec.Recent) != Window || strings.Contains(rec.Recent, "F") {
+		t.Errorf("record = runs %d, recent %d", rec.Runs, len(rec.Recent))
+	}
+}
+
+func TestUpdatePersists(t *testing.T) {
+	rigPath := t.TempDir()
+	err := Update(rigPath, func(h *History) error {
+		h.Record(runOf(map[string]Outcome{"TestX": Fail}), nil, time.Now())
+		h.SetReport(&Report{MR: "gt-mr1", Time: time.Now(), Failed: []string{"pkg.TestX"}})
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	h, err := Load(rigPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec := h.Tests["pkg.TestX"]; rec == nil || rec.LastOutcome != Fail {
+		t.Errorf("loaded record = %+v", rec)
+	}
+	if r := h.MRs["gt-mr1"]; r == nil || len(r.Failed) != 1 {
+		t.Errorf("loaded report = %+v", r)
+	}
+}
@@ -0,0 +1,295 @@
+// Package testhistory tracks per-test outcomes of a rig's refinery test
+// runs. The refinery parses each run's results (go test -json or JUnit XML),
+// records them per test, and classifies tests whose outcome keeps flipping
+// as flaky. Flaky tests are quarantined: with allow_quarantined_failures set,
+// an MR whose only failures are quarantined tests still merges.
+//
+// History lives in .runtime/test-history.json at the rig root.
+package testhistory
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Result formats.
+const (
+	FormatGoJSON = "go-json"
+	FormatJUnit  = "junit"
+)
+
+// ErrNoResults is returned when a test run's output holds no parsable results.
+var ErrNoResults = errors.New("no parsable test results")
+
+// Outcome is how a test ended.
+type Outcome string
+
+// Test outcomes.
+const (
+	Pass Outcome = "pass"
+	Fail Outcome = "fail"
+	Skip Outcome = "skip"
+)
+
+// Result is one test's outcome in a run.
+type Result struct {
+	Package string  `json:"package,omitempty"`
+	Name    string  `json:"name,omitempty"` // Empty for a package-level failure (e.g. build error)
+	Outcome Outcome `json:"outcome"`
+	Elapsed float64 `json:"elapsed,omitempty"` // Seconds
+}
+
+// ID identifies the test across runs.
+func (r Result) ID() string {
+	switch {
+	case r.Package == "":
+		return r.Name
+	case r.Name == "":
+		return r.Package
+	default:
+		return r.Package + "." + r.Name
+	}
+}
+
+// Run is the parsed results of one test command.
+type Run struct {
+	Format  string
+	Results []Result
+}
+
+// Failed returns the failing results.
+func (r *Run) Failed() []Result {
+	var failed []Result
+	for _, res := range r.Results {
+		if res.Outcome == Fail {
+			failed = append(failed, res)
+		}
+	}
+	return failed
+}
+
+// Parse reads a test run's results. format is FormatGoJSON, FormatJUnit or
+// empty to detect: a JUnit report at junitPath if given, else go test -json
+// events in output.
+func Parse(format string, output []byte, junitPath string) (*Run, error) {
+	if format == "" {
+		switch {
+		case junitPath != "":
+			format = FormatJUnit
+		case looksLikeGoJSON(output):
+			format = FormatGoJSON
+		default:
+			return nil, ErrNoResults
+		}
+	}
+
+	switch format {
+	case FormatGoJSON:
+		return ParseGoJSON(output)
+	case FormatJUnit:
+		data := output
+		if junitPath != "" {
+			var err error
+			if data, err = os.ReadFile(junitPath); err != nil {
+				return nil, fmt.Errorf("reading JUnit report: %w", err)
+			}
+		}
+		return ParseJUnit(data)
+	default:
+		return nil, fmt.Errorf("unknown test results format %q (want %s or %s)", format, FormatGoJSON, FormatJUnit)
+	}
+}
+
+func looksLikeGoJSON(output []byte) bool {
+	for _, line := range bytes.Split(output, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if bytes.HasPrefix(line, []byte("{")) && bytes.Contains(line, []byte(`"Action"`)) {
+			return true
+		}
+	}
+	return false
+}
+
+// goTestEvent is one line of go test -json output.
+type goTestEvent struct {
+	Action  string  `json:"Action"`
+	Package string  `json:"Package"`
+	Test    string  `json:"Test"`
+	Elapsed float64 `json:"Elapsed"`
+}
+
+// ParseGoJSON reads go test -json output. Lines that aren't test events
+// (e.g. build output) are ignored. A package that fails without a failing
+// test, such as on a build error, is reported as a package-level failure.
+func ParseGoJSON(output []byte) (*Run, error) {
+	run := &Run{Format: FormatGoJSON}
+	index := make(map[string]int)
+	failedTests := make(map[string]bool) // Packages with a failing test
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if !bytes.HasPrefix(line, []byte("{")) {
+			continue
+		}
+		var ev goTestEvent
+		if err := json.Unmarshal(line, &ev); err != nil {
+			continue
+		}
+		var outcome Outcome
+		switch ev.Action {
+		case "pass":
+			outcome = Pass
+		case "fail":
+			outcome = Fail
+		case "skip":
+			outcome = Skip
+		default:
+			continue
+		}
+		if ev.Test == "" {
+			// Package summary: only interesting when it failed on its own
+			if outcome != Fail || failedTests[ev.Package] {
+				continue
+			}
+		} else if outcome == Fail {
+			failedTests[ev.Package] = true
+		}
+
+		res := Result{Package: ev.Package, Name: ev.Test, Outcome: outcome, Elapsed: ev.Elapsed}
+		if i, ok := index[res.ID()]; ok {
+			run.Results[i] = res
+			continue
+		}
+		index[res.ID()] = len(run.Results)
+		run.Results = append(run.Results, res)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading go test output: %w", err)
+	}
+	if len(run.Results) == 0 {
+		return nil, ErrNoResults
+	}
+	return run, nil
+}
+
+// junitSuite matches both <testsuites> and <testsuite> elements.
+type junitSuite struct {
+	Name   string       `xml:"name,attr"`
+	Cases  []junitCase  `xml:"testcase"`
+	Suites []junitSuite `xml:"testsuite"`
+}
+
+type junitCase struct {
+	ClassName string    `xml:"classname,attr"`
+	Name      string    `xml:"name,attr"`
+	Time      float64   `xml:"time,attr"`
+	Failure   *struct{} `xml:"failure"`
+	Error     *struct{} `xml:"error"`
+	Skipped   *struct{} `xml:"skipped"`
+}
+
+// ParseJUnit reads a JUnit XML report.
+func ParseJUnit(data []byte) (*Run, error) {
+	var root junitSuite
+	if err := xml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("parsing JUnit report: %w", err)
+	}
+
+	run := &Run{Format: FormatJUnit}
+	var walk func(s junitSuite)
+	walk = func(s junitSuite) {
+		for _, c := range s.Cases {
+			pkg := c.ClassName
+			if pkg == "" {
+				pkg = s.Name
+			}
+			res := Result{Package: pkg, Name: c.Name, Outcome: Pass, Elapsed: c.Time}
+			switch {
+			case c.Failure != nil || c.Error != nil:
+				res.Outcome = Fail
+			case c.Skipped != nil:
+				res.Outcome = Skip
+			}
+			run.Results = append(run.Results, res)
+		}
+		for _, child := range s.Suites {
+			walk(child)
+		}
+	}
+	walk(root)
+
+	if len(run.Results) == 0 {
+		return nil, ErrNoResults
+	}
+	return run, nil
+}
+
+// GoRerunCommand returns a go test -json command that reruns only the
+// failed tests. Subtests are rerun through their top-level test.
+func GoRerunCommand(failed []Result) string {
+	var pkgs, names []string
+	seenPkg := make(map[string]bool)
+	seenName := make(map[string]bool)
+	for _, r := range failed {
+		if r.Package != "" && !seenPkg[r.Package] {
+			seenPkg[r.Package] = true
+			pkgs = append(pkgs, r.Package)
+		}
+		if r.Name == "" {
+			continue
+		}
+		name, _, _ := strings.Cut(r.Name, "/")
+		if !seenName[name] {
+			seenName[name] = true
+			names = append(names, regexp.QuoteMeta(name))
+		}
+	}
+	sort.Strings(pkgs)
+	sort.Strings(names)
+
+	cmd := "go test -json"
+	if len(names) > 0 && len(names) == countNamed(failed) {
+		cmd += fmt.Sprintf(" -run '^(%s)$'", strings.Join(names, "|"))
+	}
+	return cmd + " " + strings.Join(pkgs, " ")
+}
+
+// countNamed counts the distinct top-level tests among failed, or returns
+// -1 when a package-level failure means whole packages must rerun.
+func countNamed(failed []Result) int {
+	seen := make(map[string]bool)
+	for _, r := range failed {
+		if r.Name == "" {
+			return -1
+		}
+		name, _, _ := strings.Cut(r.Name, "/")
+		seen[name] = true
+	}
+	return len(seen)
+}
+
+// ExpandRerunCommand fills a rerun command template: {tests} becomes the
+// failed test names, space-separated and single-quoted, and {run} a regular
+// expression matching any of them.
+func ExpandRerunCommand(template string, failed []Result) string {
+	var quoted, patterns []string
+	for _, r := range failed {
+		if r.Name == "" {
+			continue
+		}
+		quoted = append(quoted, "'"+strings.ReplaceAll(r.Name, "'", `'\''`)+"'")
+		patterns = append(patterns, regexp.QuoteMeta(r.Name))
+	}
+	cmd := strings.ReplaceAll(template, "{tests}", strings.Join(quoted, " "))
+	return strings.ReplaceAll(cmd, "{run}", "'^("+strings.Join(patterns, "|")+")$'")
+}
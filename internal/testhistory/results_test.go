@@ -0,0 +1,104 @@
+package testhistory
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseGoJSON(t *testing.T) {
+	output := []byte(`# example.com/b
+b.go:3: undefined: x
+{"Action":"run","Package":"example.com/a","Test":"TestOK"}
+{"Action":"pass","Package":"example.com/a","Test":"TestOK","Elapsed":0.5}
+{"Action":"fail","Package":"example.com/a","Test":"TestBad/case_1"}
+{"Action":"fail","Package":"example.com/a","Test":"TestBad"}
+{"Action":"skip","Package":"example.com/a","Test":"TestSkip"}
+{"Action":"fail","Package":"example.com/a"}
+{"Action":"fail","Package":"example.com/b"}
+`)
+	run, err := Parse("", output, "")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if run.Format != FormatGoJSON || len(run.Results) != 5 {
+		t.Fatalf("Parse() = %s with %d results, want go-json with 5", run.Format, len(run.Results))
+	}
+
+	var failed []string
+	for _, r := range run.Failed() {
+		failed = append(failed, r.ID())
+	}
+	want := []string{"example.com/a.TestBad/case_1", "example.com/a.TestBad", "example.com/b"}
+	if len(failed) != len(want) {
+		t.Fatalf("Failed() = %v, want %v", failed, want)
+	}
+	for i := range want {
+		if failed[i] != want[i] {
+			t.Errorf("Failed()[%d] = %s, want %s", i, failed[i], want[i])
+		}
+	}
+}
+
+func TestParseJUnit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.xml")
+	report := `<?xml version="1.0"?>
+<testsuites>
+  <testsuite name="suite">
+    <testcase classname="pkg.A" name="ok" time="0.1"/>
+    <testcase classname="pkg.A" name="broken"><failure message="boom"/></testcase>
+    <testcase name="errored"><error/></testcase>
+    <testcase classname="pkg.A" name="later"><skipped/></testcase>
+  </testsuite>
+</testsuites>`
+	if err := os.WriteFile(path, []byte(report), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	run, err := Parse("", nil, path)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(run.Results) != 4 {
+		t.Fatalf("Parse() = %d results, want 4", len(run.Results))
+	}
+	failed := run.Failed()
+	if len(failed) != 2 || failed[0].ID() != "pkg.A.broken" || failed[1].ID() != "suite.errored" {
+		t.Errorf("Failed() = %+v", failed)
+	}
+	if run.Results[3].Outcome != Skip {
+		t.Errorf("skipped case outcome = %s", run.Results[3].Outcome)
+	}
+}
+
+func TestParseNoResults(t *testing.T) {
+	if _, err := Parse("", []byte("ok  \texample.com/a\t0.1s\n"), ""); err != ErrNoResults {
+		t.Errorf("Parse(plain output) error = %v, want ErrNoResults", err)
+	}
+	if _, err := Parse("tap", nil, ""); err == nil {
+		t.Error("Parse(unknown format) should fail")
+	}
+}
+
+func TestRerunCommands(t *testing.T) {
+	failed := []Result{
+		{Package: "example.com/a", Name: "TestBad/case_1", Outcome: Fail},
+		{Package: "example.com/a", Name: "TestBad", Outcome: Fail},
+		{Package: "example.com/b", Name: "TestX", Outcome: Fail},
+	}
+	if got, want := GoRerunCommand(failed), "go test -json -run '^(TestBad|TestX)$' example.com/a example.com/b"; got != want {
+		t.Errorf("GoRerunCommand() = %q, want %q", got, want)
+	}
+
+	withBuildFailure := append(failed, Result{Package: "example.com/c", Outcome: Fail})
+	if got, want := GoRerunCommand(withBuildFailure), "go test -json example.com/a example.com/b example.com/c"; got != want {
+		t.Errorf("GoRerunCommand(package failure) = %q, want %q", got, want)
+	}
+
+	if got, want := ExpandRerunCommand("pytest {tests}", failed[2:]), "pytest 'TestX'"; got != want {
+		t.Errorf("ExpandRerunCommand() = %q, want %q", got, want)
+	}
+	if got, want := ExpandRerunCommand("jest -t {run}", failed[2:]), "jest -t '^(TestX)$'"; got != want {
+		t.Errorf("ExpandRerunCommand() = %q, want %q", got, want)
+	}
+}
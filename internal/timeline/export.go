@@ -0,0 +1,154 @@
+package timeline
+
+import (
+	"embed"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"time"
+)
+
+//go:embed templates/swimlane.html
+var templateFS embed.FS
+
+// Export formats supported by Write.
+const (
+	FormatJSONL = "jsonl"
+	FormatCSV   = "csv"
+	FormatHTML  = "html"
+)
+
+// csvHeader is the column order for CSV export.
+var csvHeader = []string{"timestamp", "source", "type", "agent", "rig", "bead", "mr", "convoy", "summary"}
+
+// Write exports entries in the given format.
+func Write(w io.Writer, format, title string, entries []Entry) error {
+	switch format {
+	case FormatJSONL:
+		return WriteJSONL(w, entries)
+	case FormatCSV:
+		return WriteCSV(w, entries)
+	case FormatHTML:
+		return WriteHTML(w, title, entries)
+	default:
+		return fmt.Errorf("unknown format %q (use jsonl, csv, or html)", format)
+	}
+}
+
+// WriteJSONL writes one JSON object per line.
+func WriteJSONL(w io.Writer, entries []Entry) error {
+	enc := json.NewEncoder(w)
+	for _, e := range entries {
+		if err := enc.Encode(e); err != nil {
+			return fmt.Errorf("encoding entry: %w", err)
+		}
+	}
+	return nil
+}
+
+// WriteCSV writes entries as CSV with a header row.
+func WriteCSV(w io.Writer, entries []Entry) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return fmt.Errorf("writing header: %w", err)
+	}
+	for _, e := range entries {
+		record := []string{
+			e.Timestamp.Format(time.RFC3339),
+			e.Source,
+			e.Type,
+			e.Agent,
+			e.Rig,
+			e.Bead,
+			e.MR,
+			e.Convoy,
+			e.Summary,
+		}
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("writing record: %w", err)
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// swimlaneData is passed to the swimlane template.
+type swimlaneData struct {
+	Title   string
+	Start   string
+	End     string
+	Lanes   []swimlane
+	Entries []Entry
+}
+
+// swimlane is one agent's row in the HTML view.
+type swimlane struct {
+	Name  string
+	Marks []swimlaneMark
+}
+
+// swimlaneMark is one event plotted on a lane.
+type swimlaneMark struct {
+	Left  string // Horizontal position as a percentage of the window
+	Class string // Color class: good, warn, bad, or empty
+	Label string // Tooltip text
+}
+
+// WriteHTML writes a self-contained HTML page with one swimlane per agent.
+// The page has no external dependencies so it can be attached to a post-mortem.
+func WriteHTML(w io.Writer, title string, entries []Entry) error {
+	tmpl, err := template.ParseFS(templateFS, "templates/swimlane.html")
+	if err != nil {
+		return fmt.Errorf("parsing template: %w", err)
+	}
+
+	if title == "" {
+		title = "Gas Town Timeline"
+	}
+	data := swimlaneData{Title: title, Entries: entries}
+
+	if len(entries) > 0 {
+		start := entries[0].Timestamp
+		end := entries[len(entries)-1].Timestamp
+		span := end.Sub(start)
+		data.Start = start.Format(time.RFC3339)
+		data.End = end.Format(time.RFC3339)
+
+		laneIdx := make(map[string]int)
+		for _, name := range Agents(entries) {
+			laneIdx[name] = len(data.Lanes)
+			data.Lanes = append(data.Lanes, swimlane{Name: name})
+		}
+
+		for _, e := range entries {
+			left := 0.0
+			if span > 0 {
+				left = float64(e.Timestamp.Sub(start)) / float64(span) * 100
+			}
+			i := laneIdx[laneName(e)]
+			data.Lanes[i].Marks = append(data.Lanes[i].Marks, swimlaneMark{
+				Left:  fmt.Sprintf("%.2f", left),
+				Class: markClass(e.Type),
+				Label: fmt.Sprintf("%s %s", e.Timestamp.Format("15:04:05"), e.Summary),
+			})
+		}
+	}
+
+	return tmpl.Execute(w, data)
+}
+
+// markClass picks a color for an event type.
+func markClass(eventType string) string {
+	switch eventType {
+	case "merged", "done", TypeBeadClosed:
+		return "good"
+	case "merge_failed", "crash", "kill":
+		return "bad"
+	case "nudge", "polecat_nudged", "escalation_sent", "merge_skipped":
+		return "warn"
+	default:
+		return ""
+	}
+}
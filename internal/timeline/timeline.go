@@ -0,0 +1,342 @@
+// Package timeline merges Gas Town's event sources into one normalized,
+// time-ordered stream for post-mortems.
+//
+// Sources and their native formats:
+//   - .events.jsonl (internal/events): RFC3339 UTC "ts", free-form payload
+//   - logs/town.log (internal/townlog): local wall-clock text lines
+//   - <rig>/.beads/mq_events.jsonl (internal/mrqueue): JSON with time.Time
+//   - beads issues: created_at/closed_at strings in several layouts
+//
+// Every entry is normalized to UTC and tagged with the bead, MR, convoy and
+// agent it concerns so it can be filtered uniformly.
+package timeline
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/mrqueue"
+	"github.com/steveyegge/gastown/internal/townlog"
+)
+
+// Source identifiers for timeline entries.
+const (
+	SourceEvents  = "events"
+	SourceTownlog = "townlog"
+	SourceMQ      = "mq"
+	SourceBeads   = "beads"
+)
+
+// Bead lifecycle entry types (beads has no event log of its own).
+const (
+	TypeBeadCreated = "bead_created"
+	TypeBeadClosed  = "bead_closed"
+)
+
+// Entry is a single normalized timeline event.
+type Entry struct {
+	Timestamp time.Time `json:"timestamp"`
+	Source    string    `json:"source"`
+	Type      string    `json:"type"`
+	Agent     string    `json:"agent,omitempty"`
+	Rig       string    `json:"rig,omitempty"`
+	Bead      string    `json:"bead,omitempty"`
+	MR        string    `json:"mr,omitempty"`
+	Convoy    string    `json:"convoy,omitempty"`
+	Summary   string    `json:"summary"`
+}
+
+// FromEvents normalizes activity feed events.
+func FromEvents(evts []events.Event) []Entry {
+	var entries []Entry
+	for _, e := range evts {
+		ts := e.Time()
+		if ts.IsZero() {
+			continue
+		}
+		entry := Entry{
+			Timestamp: ts.UTC(),
+			Source:    SourceEvents,
+			Type:      e.Type,
+			Agent:     e.Actor,
+			Rig:       payloadString(e.Payload, "rig"),
+			Bead:      firstNonEmpty(payloadString(e.Payload, "bead"), payloadString(e.Payload, "issue")),
+			MR:        payloadString(e.Payload, "mr"),
+			Convoy:    payloadString(e.Payload, "convoy"),
+			Summary:   summarizeEvent(e),
+		}
+		if entry.Rig == "" {
+			entry.Rig = rigFromAgent(e.Actor)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// FromTownlog normalizes town log events.
+// The town log records local wall-clock time without a zone, so timestamps
+// are reinterpreted in the local zone before conversion to UTC.
+func FromTownlog(evts []townlog.Event) []Entry {
+	var entries []Entry
+	for _, e := range evts {
+		ts := e.Timestamp
+		ts = time.Date(ts.Year(), ts.Month(), ts.Day(), ts.Hour(), ts.Minute(), ts.Second(), ts.Nanosecond(), time.Local)
+
+		entry := Entry{
+			Timestamp: ts.UTC(),
+			Source:    SourceTownlog,
+			Type:      string(e.Type),
+			Agent:     e.Agent,
+			Rig:       rigFromAgent(e.Agent),
+			Summary:   string(e.Type),
+		}
+		if e.Context != "" {
+			entry.Summary += ": " + e.Context
+			if id := leadingBeadID(e.Context); id != "" {
+				entry.Bead = id
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// FromMQEvents normalizes merge queue lifecycle events for a rig.
+func FromMQEvents(rigName string, evts []mrqueue.Event) []Entry {
+	var entries []Entry
+	for _, e := range evts {
+		rig := e.Rig
+		if rig == "" {
+			rig = rigName
+		}
+		summary := string(e.Type) + " " + e.Branch
+		if e.Target != "" {
+			summary += " → " + e.Target
+		}
+		if e.Reason != "" {
+			summary += ": " + e.Reason
+		}
+		entries = append(entries, Entry{
+			Timestamp: e.Timestamp.UTC(),
+			Source:    SourceMQ,
+			Type:      string(e.Type),
+			Agent:     workerAddress(rig, e.Worker),
+			Rig:       rig,
+			Bead:      e.SourceIssue,
+			MR:        e.MRID,
+			Summary:   summary,
+		})
+	}
+	return entries
+}
+
+// FromIssues derives created/closed entries from beads issues.
+// Convoy issues are tagged with their own ID as the convoy.
+func FromIssues(rigName string, issues []*beads.Issue) []Entry {
+	var entries []Entry
+	for _, issue := range issues {
+		convoy := ""
+		if issue.Type == "convoy" {
+			convoy = issue.ID
+		}
+		if ts := ParseBeadsTime(issue.CreatedAt); !ts.IsZero() {
+			entries = append(entries, Entry{
+				Timestamp: ts,
+				Source:    SourceBeads,
+				Type:      TypeBeadCreated,
+				Agent:     issue.CreatedBy,
+				Rig:       rigName,
+				Bead:      issue.ID,
+				Convoy:    convoy,
+				Summary:   "Created: " + issue.Title,
+			})
+		}
+		if issue.Status == "closed" {
+			if ts := ParseBeadsTime(issue.ClosedAt); !ts.IsZero() {
+				entries = append(entries, Entry{
+					Timestamp: ts,
+					Source:    SourceBeads,
+					Type:      TypeBeadClosed,
+					Agent:     issue.Assignee,
+					Rig:       rigName,
+					Bead:      issue.ID,
+					Convoy:    convoy,
+					Summary:   "Closed: " + issue.Title,
+				})
+			}
+		}
+	}
+	return entries
+}
+
+// ParseBeadsTime parses a beads timestamp in any of the layouts bd emits.
+// Layouts without a zone are taken as UTC. Returns the zero time on failure.
+func ParseBeadsTime(s string) time.Time {
+	layouts := []string{
+		time.RFC3339Nano,
+		time.RFC3339,
+		"2006-01-02T15:04:05",
+		"2006-01-02 15:04:05",
+		"2006-01-02 15:04",
+		"2006-01-02",
+	}
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC()
+		}
+	}
+	return time.Time{}
+}
+
+// Merge combines entry slices into a single stream ordered oldest first.
+// Ties keep their source order so merges are deterministic.
+func Merge(sources ...[]Entry) []Entry {
+	var all []Entry
+	for _, s := range sources {
+		all = append(all, s...)
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].Timestamp.Before(all[j].Timestamp)
+	})
+	return all
+}
+
+// Filter selects entries from a timeline. Zero values match everything.
+type Filter struct {
+	// Beads matches entries concerning any of these bead IDs.
+	// Entries tagged with a matching convoy also match, so a convoy filter
+	// is expressed as the convoy ID plus its tracked issues.
+	Beads map[string]bool
+
+	// Agent matches entries whose agent has this prefix (e.g., "gastown/").
+	Agent string
+
+	// Since and Until bound the window (inclusive).
+	Since time.Time
+	Until time.Time
+}
+
+// Apply returns entries matching the filter.
+func (f Filter) Apply(entries []Entry) []Entry {
+	var result []Entry
+	for _, e := range entries {
+		if f.Matches(e) {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// Matches reports whether a single entry passes the filter.
+func (f Filter) Matches(e Entry) bool {
+	if !f.Since.IsZero() && e.Timestamp.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && e.Timestamp.After(f.Until) {
+		return false
+	}
+	if f.Agent != "" && !strings.HasPrefix(e.Agent, f.Agent) {
+		return false
+	}
+	if len(f.Beads) > 0 && !f.Beads[e.Bead] && !f.Beads[e.Convoy] {
+		return false
+	}
+	return true
+}
+
+// Agents returns the distinct agents in the timeline, in order of first appearance.
+// Entries without an agent are grouped under "(system)".
+func Agents(entries []Entry) []string {
+	seen := make(map[string]bool)
+	var agents []string
+	for _, e := range entries {
+		a := laneName(e)
+		if !seen[a] {
+			seen[a] = true
+			agents = append(agents, a)
+		}
+	}
+	return agents
+}
+
+// laneName returns the swimlane an entry belongs to.
+func laneName(e Entry) string {
+	if e.Agent == "" {
+		return "(system)"
+	}
+	return e.Agent
+}
+
+// summarizeEvent creates a readable summary from an activity feed event.
+func summarizeEvent(e events.Event) string {
+	parts := []string{e.Type}
+	for _, key := range []string{"bead", "issue", "target", "polecat", "branch", "to", "subject", "reason", "message"} {
+		if v := payloadString(e.Payload, key); v != "" {
+			parts = append(parts, key+"="+v)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// payloadString returns a string value from an event payload.
+func payloadString(payload map[string]interface{}, key string) string {
+	if payload == nil {
+		return ""
+	}
+	if v, ok := payload[key].(string); ok {
+		return v
+	}
+	return ""
+}
+
+// rigFromAgent extracts the rig from an agent address like "gastown/polecats/nux".
+// Town-level agents (mayor, deacon) have no rig.
+func rigFromAgent(agent string) string {
+	agent = strings.TrimSuffix(agent, "/")
+	if agent == "" || agent == "mayor" || agent == "deacon" || strings.HasPrefix(agent, "deacon/") {
+		return ""
+	}
+	if idx := strings.Index(agent, "/"); idx > 0 {
+		return agent[:idx]
+	}
+	return ""
+}
+
+// workerAddress expands a bare polecat name into a full agent address.
+func workerAddress(rig, worker string) string {
+	if worker == "" || strings.Contains(worker, "/") || rig == "" {
+		return worker
+	}
+	return rig + "/polecats/" + worker
+}
+
+// leadingBeadID returns the first token of s if it looks like a bead ID (prefix-id).
+func leadingBeadID(s string) string {
+	token := strings.Fields(s)
+	if len(token) == 0 {
+		return ""
+	}
+	id := strings.TrimRight(token[0], ",;:)")
+	idx := strings.Index(id, "-")
+	if idx < 2 || idx > 5 || idx == len(id)-1 {
+		return ""
+	}
+	for _, c := range id[:idx] {
+		if c < 'a' || c > 'z' {
+			return ""
+		}
+	}
+	return id
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,158 @@
+package timeline
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/mrqueue"
+	"github.com/steveyegge/gastown/internal/townlog"
+)
+
+func sampleTimeline() []Entry {
+	evts := []events.Event{
+		{Timestamp: "2025-01-10T10:00:00Z", Type: events.TypeSling, Actor: "mayor",
+			Payload: map[string]interface{}{"bead": "gt-abc", "target": "gastown"}},
+		{Timestamp: "2025-01-10T10:30:00Z", Type: events.TypeDone, Actor: "gastown/polecats/nux",
+			Payload: map[string]interface{}{"bead": "gt-abc", "branch": "polecat/nux"}},
+		{Timestamp: "not-a-time", Type: events.TypeMail, Actor: "mayor"},
+	}
+	mq := []mrqueue.Event{
+		{Timestamp: time.Date(2025, 1, 10, 10, 45, 0, 0, time.UTC), Type: mrqueue.EventMerged,
+			MRID: "mr-1", Branch: "polecat/nux", Target: "main", Worker: "nux", SourceIssue: "gt-abc"},
+	}
+	issues := []*beads.Issue{
+		{ID: "gt-abc", Title: "Fix it", Status: "closed", CreatedAt: "2025-01-10T09:00:00Z",
+			ClosedAt: "2025-01-10T10:46:00Z", CreatedBy: "mayor", Assignee: "gastown/polecats/nux"},
+		{ID: "gt-other", Title: "Other", Status: "open", CreatedAt: "2025-01-10 11:00"},
+	}
+	return Merge(FromEvents(evts), FromMQEvents("gastown", mq), FromIssues("gastown", issues))
+}
+
+func TestMergeOrdersAndNormalizes(t *testing.T) {
+	entries := sampleTimeline()
+
+	// Malformed event timestamp is dropped: 2 events + 1 mq + 3 beads
+	if len(entries) != 6 {
+		t.Fatalf("got %d entries, want 6", len(entries))
+	}
+	for i := 1; i < len(entries); i++ {
+		if entries[i].Timestamp.Before(entries[i-1].Timestamp) {
+			t.Errorf("entries out of order at %d", i)
+		}
+	}
+	if entries[0].Type != TypeBeadCreated {
+		t.Errorf("first entry type = %q, want %q", entries[0].Type, TypeBeadCreated)
+	}
+
+	var merged Entry
+	for _, e := range entries {
+		if e.Source == SourceMQ {
+			merged = e
+		}
+	}
+	if merged.Agent != "gastown/polecats/nux" {
+		t.Errorf("mq agent = %q, want expanded worker address", merged.Agent)
+	}
+	if merged.Bead != "gt-abc" || merged.MR != "mr-1" {
+		t.Errorf("mq entry bead/mr = %q/%q", merged.Bead, merged.MR)
+	}
+}
+
+func TestFromTownlogUsesLocalWallClock(t *testing.T) {
+	// townlog.ParseLogLines yields wall-clock fields in UTC; FromTownlog
+	// must reinterpret them in the local zone.
+	parsed, err := townlog.ParseLogLines("2025-01-10 15:30:45 [spawn] gastown/polecats/nux spawned for gt-abc\n")
+	if err != nil || len(parsed) != 1 {
+		t.Fatalf("ParseLogLines: %v (%d events)", err, len(parsed))
+	}
+	parsed[0].Context = "gt-abc"
+
+	entries := FromTownlog(parsed)
+	want := time.Date(2025, 1, 10, 15, 30, 45, 0, time.Local).UTC()
+	if !entries[0].Timestamp.Equal(want) {
+		t.Errorf("timestamp = %v, want %v", entries[0].Timestamp, want)
+	}
+	if entries[0].Bead != "gt-abc" {
+		t.Errorf("bead = %q, want gt-abc", entries[0].Bead)
+	}
+	if entries[0].Rig != "gastown" {
+		t.Errorf("rig = %q, want gastown", entries[0].Rig)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	entries := sampleTimeline()
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   int
+	}{
+		{"empty", Filter{}, 6},
+		{"bead", Filter{Beads: map[string]bool{"gt-abc": true}}, 5},
+		{"agent", Filter{Agent: "gastown/"}, 3},
+		{"since", Filter{Since: time.Date(2025, 1, 10, 10, 40, 0, 0, time.UTC)}, 3},
+		{"until", Filter{Until: time.Date(2025, 1, 10, 10, 0, 0, 0, time.UTC)}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := len(tt.filter.Apply(entries)); got != tt.want {
+				t.Errorf("got %d entries, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteFormats(t *testing.T) {
+	entries := sampleTimeline()
+
+	var jsonl bytes.Buffer
+	if err := Write(&jsonl, FormatJSONL, "", entries); err != nil {
+		t.Fatalf("jsonl: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(jsonl.String()), "\n")
+	if len(lines) != len(entries) {
+		t.Errorf("jsonl lines = %d, want %d", len(lines), len(entries))
+	}
+	var first Entry
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Errorf("jsonl line not valid JSON: %v", err)
+	}
+
+	var csvBuf bytes.Buffer
+	if err := Write(&csvBuf, FormatCSV, "", entries); err != nil {
+		t.Fatalf("csv: %v", err)
+	}
+	records, err := csv.NewReader(&csvBuf).ReadAll()
+	if err != nil {
+		t.Fatalf("reading csv: %v", err)
+	}
+	if len(records) != len(entries)+1 || records[0][0] != "timestamp" {
+		t.Errorf("csv has %d records (header %v)", len(records), records[0])
+	}
+
+	var html bytes.Buffer
+	if err := Write(&html, FormatHTML, "Convoy <x>", entries); err != nil {
+		t.Fatalf("html: %v", err)
+	}
+	out := html.String()
+	if !strings.Contains(out, "Convoy &lt;x&gt;") {
+		t.Error("html title not escaped")
+	}
+	if strings.Count(out, `class="lane"`) != len(Agents(entries)) {
+		t.Errorf("html lanes = %d, want %d", strings.Count(out, `class="lane"`), len(Agents(entries)))
+	}
+	if strings.Contains(out, "<script src=") {
+		t.Error("html must be self-contained")
+	}
+
+	if err := Write(&html, "xml", "", entries); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
@@ -0,0 +1,125 @@
+package tmux
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// process is one entry of the host process table.
+type process struct {
+	pid  int
+	ppid int
+	comm string
+}
+
+// Process table probes, overridable in tests.
+var (
+	listProcesses     = psProcesses
+	processArgs       = psArgs
+	containerCommands = podmanTop
+)
+
+// psProcesses lists every process with its parent and command name.
+func psProcesses() ([]process, error) {
+	out, err := exec.Command("ps", "-e", "-o", "pid=", "-o", "ppid=", "-o", "comm=").Output()
+	if err != nil {
+		return nil, err
+	}
+	var procs []process
+	for _, line := range strings.Split(string(out), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+		pid, err1 := strconv.Atoi(fields[0])
+		ppid, err2 := strconv.Atoi(fields[1])
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		// macOS reports the executable's path
+		comm := filepath.Base(strings.Join(fields[2:], " "))
+		procs = append(procs, process{pid: pid, ppid: ppid, comm: comm})
+	}
+	return procs, nil
+}
+
+// psArgs returns the command line of pid.
+func psArgs(pid int) ([]string, error) {
+	out, err := exec.Command("ps", "-o", "args=", "-p", strconv.Itoa(pid)).Output()
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(string(out)), nil
+}
+
+// podmanTop returns the command names running in a podman container.
+func podmanTop(container string) ([]string, error) {
+	out, err := exec.Command("podman", "top", container, "comm").Output()
+	if err != nil {
+		return nil, err
+	}
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	if len(lines) < 2 {
+		return nil, nil
+	}
+	return lines[1:], nil // Skip the COMMAND header
+}
+
+// treeCommands returns the command names of pid's descendants (not pid
+// itself). A podman client in the tree contributes the commands of its
+// container, since those run under podman's conmon rather than the client.
+func treeCommands(pid int) []string {
+	procs, err := listProcesses()
+	if err != nil {
+		return nil
+	}
+	children := make(map[int][]process)
+	for _, p := range procs {
+		children[p.ppid] = append(children[p.ppid], p)
+	}
+
+	var comms []string
+	visit := func(p process) {
+		if p.comm == "podman" {
+			if name := podmanContainer(p.pid); name != "" {
+				inside, _ := containerCommands(name)
+				comms = append(comms, inside...)
+			}
+		}
+	}
+	for _, p := range procs {
+		if p.pid == pid {
+			visit(p) // The pane itself may have exec'd podman
+			break
+		}
+	}
+
+	queue := children[pid]
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		queue = append(queue, children[p.pid]...)
+		comms = append(comms, p.comm)
+		visit(p)
+	}
+	return comms
+}
+
+// podmanContainer returns the --name a podman client was started with.
+func podmanContainer(pid int) string {
+	args, err := processArgs(pid)
+	if err != nil {
+		return ""
+	}
+	for i, arg := range args {
+		if name, ok := strings.CutPrefix(arg, "--name="); ok {
+			return name
+		}
+		if arg == "--name" && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return ""
+}
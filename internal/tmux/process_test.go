@@ -0,0 +1,88 @@
+package tmux
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/constants"
+)
+
+// TestIsClaudeRunning_WrappedPane drives a real pane whose startup command
+// is wrapped the way resource limits wrap it: tmux reports the wrapper shell,
+// and the agent ("node") runs beneath it.
+func TestIsClaudeRunning_WrappedPane(t *testing.T) {
+	if !hasTmux() {
+		t.Skip("tmux not installed")
+	}
+	sleepPath, err := exec.LookPath("sleep")
+	if err != nil {
+		t.Skip("sleep not installed")
+	}
+	node := filepath.Join(t.TempDir(), "node")
+	if err := os.Symlink(sleepPath, node); err != nil {
+		t.Fatal(err)
+	}
+
+	tm := NewTmux()
+	sessionName := "gt-test-wrapped-" + t.Name()
+	_ = tm.KillSession(sessionName)
+	if err := tm.NewSession(sessionName, ""); err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+	defer func() { _ = tm.KillSession(sessionName) }()
+
+	if tm.IsClaudeRunning(sessionName) {
+		t.Fatal("IsClaudeRunning = true for an idle shell")
+	}
+
+	if err := tm.SendKeys(sessionName, "exec sh -c 'export A=1 && "+node+" 30'"); err != nil {
+		t.Fatalf("SendKeys: %v", err)
+	}
+	if err := tm.WaitForCommand(sessionName, constants.SupportedShells, 5*time.Second); err != nil {
+		t.Fatalf("WaitForCommand: %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !tm.IsClaudeRunning(sessionName) {
+		if time.Now().After(deadline) {
+			cmd, _ := tm.GetPaneCommand(sessionName)
+			t.Fatalf("IsClaudeRunning = false with node under the wrapper (pane command %q)", cmd)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func TestTreeCommands_Podman(t *testing.T) {
+	oldList, oldArgs, oldTop := listProcesses, processArgs, containerCommands
+	defer func() { listProcesses, processArgs, containerCommands = oldList, oldArgs, oldTop }()
+
+	// 10 (pane, exec'd podman) -> 11 (podman helper); 20 is unrelated
+	listProcesses = func() ([]process, error) {
+		return []process{
+			{pid: 10, ppid: 1, comm: "podman"},
+			{pid: 11, ppid: 10, comm: "slirp4netns"},
+			{pid: 20, ppid: 1, comm: "node"},
+		}, nil
+	}
+	processArgs = func(pid int) ([]string, error) {
+		return []string{"podman", "run", "--rm", "-it", "--name", "gt-gastown-Toast", "img", "sh", "-c", "claude"}, nil
+	}
+	containerCommands = func(name string) ([]string, error) {
+		if name != "gt-gastown-Toast" {
+			t.Errorf("container = %q", name)
+		}
+		return []string{"sh", "node"}, nil
+	}
+
+	got := treeCommands(10)
+	if !slices.Contains(got, "node") || !slices.Contains(got, "slirp4netns") {
+		t.Errorf("treeCommands = %v, want the container's node and the helper", got)
+	}
+	if got := treeCommands(11); slices.Contains(got, "node") {
+		t.Errorf("treeCommands(11) = %v, want no node", got)
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -379,6 +381,21 @@ func (t *Tmux) GetPaneID(session string) (string, error) {
 	return lines[0], nil
 }
 
+// GetPanePID returns the PID of the process started in a session's first
+// pane (the shell, or the agent once it has exec'd).
+func (t *Tmux) GetPanePID(session string) (int, error) {
+	out, err := t.run("list-panes", "-t", session, "-F", "#{pane_pid}")
+	if err != nil {
+		return 0, err
+	}
+	first := strings.SplitN(strings.TrimSpace(out), "\n", 2)[0]
+	pid, err := strconv.Atoi(first)
+	if err != nil {
+		return 0, fmt.Errorf("parsing pane pid %q: %w", first, err)
+	}
+	return pid, nil
+}
+
 // GetPaneWorkDir returns the current working directory of a pane.
 func (t *Tmux) GetPaneWorkDir(session string) (string, error) {
 	out, err := t.run("list-panes", "-t", session, "-F", "#{pane_current_path}")
@@ -527,14 +544,24 @@ Run: gt mail inbox
 }
 
 // IsClaudeRunning checks if Claude appears to be running in the session.
-// Only trusts the pane command - UI markers in scrollback cause false positives.
+// Only trusts process names - UI markers in scrollback cause false positives.
+// Agents started under resource limits or in a sandbox run beneath a wrapper
+// (sh, bwrap, podman) that tmux reports instead, so the pane's process tree
+// is searched as well.
 func (t *Tmux) IsClaudeRunning(session string) bool {
 	// Check pane command - Claude runs as node
 	cmd, err := t.GetPaneCommand(session)
 	if err != nil {
 		return false
 	}
-	return cmd == "node"
+	if cmd == "node" {
+		return true
+	}
+	pid, err := t.GetPanePID(session)
+	if err != nil {
+		return false
+	}
+	return slices.Contains(treeCommands(pid), "node")
 }
 
 // WaitForCommand polls until the pane is NOT running one of the excluded commands.
@@ -549,16 +576,18 @@ func (t *Tmux) WaitForCommand(session string, excludeCommands []string, timeout
 			continue
 		}
 		// Check if current command is NOT in the exclude list
-		excluded := false
-		for _, exc := range excludeCommands {
-			if cmd == exc {
-				excluded = true
-				break
-			}
-		}
-		if !excluded {
+		if !slices.Contains(excludeCommands, cmd) {
 			return nil
 		}
+		// A wrapper shell (resource limits) stays the pane command; look
+		// for what it started.
+		if pid, err := t.GetPanePID(session); err == nil {
+			for _, child := range treeCommands(pid) {
+				if !slices.Contains(excludeCommands, child) {
+					return nil
+				}
+			}
+		}
 		time.Sleep(constants.PollInterval)
 	}
 	return fmt.Errorf("timeout waiting for command (still running excluded command)")
@@ -0,0 +1,346 @@
+package tracing
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// ServiceName is reported as the OTLP service.name resource attribute.
+const ServiceName = "gastown"
+
+// exportTimeout bounds a single OTLP export so a dead collector
+// never stalls the command being traced.
+const exportTimeout = 2 * time.Second
+
+// Exporter sends finished spans somewhere.
+type Exporter interface {
+	Export(spans []*Span) error
+}
+
+var (
+	exporterMu   sync.Mutex
+	exporter     Exporter
+	exporterInit bool
+)
+
+// SetExporter overrides the exporter resolved from configuration.
+// Passing nil disables export. Intended for tests and embedding.
+func SetExporter(e Exporter) {
+	exporterMu.Lock()
+	defer exporterMu.Unlock()
+	exporter = e
+	exporterInit = true
+}
+
+// Enabled reports whether finished spans are exported anywhere.
+// Callers can use this to skip lookups that only feed span attributes.
+func Enabled() bool {
+	return currentExporter() != nil
+}
+
+// currentExporter returns the configured exporter, resolving it on first use.
+func currentExporter() Exporter {
+	exporterMu.Lock()
+	defer exporterMu.Unlock()
+	if !exporterInit {
+		exporter = exporterFromConfig()
+		exporterInit = true
+	}
+	return exporter
+}
+
+// exporterFromConfig builds an exporter from OTEL_* environment variables
+// and the town's settings/config.json. Returns nil if nothing is configured.
+func exporterFromConfig() Exporter {
+	if v := os.Getenv("OTEL_SDK_DISABLED"); strings.EqualFold(v, "true") {
+		return nil
+	}
+
+	var cfg config.TracingConfig
+	townRoot, _ := workspace.FindFromCwd()
+	if townRoot != "" {
+		if settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot)); err == nil && settings.Tracing != nil {
+			cfg = *settings.Tracing
+		}
+	}
+
+	var exporters multiExporter
+
+	endpoint := os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT")
+	if endpoint == "" {
+		base := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+		if base == "" {
+			base = cfg.OTLPEndpoint
+		}
+		if base != "" {
+			endpoint = strings.TrimSuffix(base, "/") + "/v1/traces"
+		}
+	}
+	if endpoint != "" {
+		headers := cfg.Headers
+		if env := os.Getenv("OTEL_EXPORTER_OTLP_HEADERS"); env != "" {
+			headers = parseHeaders(env)
+		}
+		exporters = append(exporters, NewOTLPExporter(endpoint, headers))
+	}
+
+	if cfg.File != "" && townRoot != "" {
+		path := cfg.File
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(townRoot, path)
+		}
+		exporters = append(exporters, NewFileExporter(path))
+	}
+
+	switch len(exporters) {
+	case 0:
+		return nil
+	case 1:
+		return exporters[0]
+	default:
+		return exporters
+	}
+}
+
+// parseHeaders parses OTEL_EXPORTER_OTLP_HEADERS ("k1=v1,k2=v2").
+func parseHeaders(s string) map[string]string {
+	headers := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok {
+			continue
+		}
+		headers[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	return headers
+}
+
+// multiExporter fans spans out to several exporters.
+type multiExporter []Exporter
+
+// Export sends spans to every exporter, returning the first error.
+func (m multiExporter) Export(spans []*Span) error {
+	var firstErr error
+	for _, e := range m {
+		if err := e.Export(spans); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// FileRecord is one span as written by FileExporter.
+type FileRecord struct {
+	TraceID      string            `json:"trace_id"`
+	SpanID       string            `json:"span_id"`
+	ParentSpanID string            `json:"parent_span_id,omitempty"`
+	Name         string            `json:"name"`
+	Start        time.Time         `json:"start"`
+	End          time.Time         `json:"end"`
+	DurationMs   int64             `json:"duration_ms"`
+	Status       string            `json:"status,omitempty"`
+	StatusMsg    string            `json:"status_message,omitempty"`
+	Attributes   map[string]string `json:"attributes,omitempty"`
+}
+
+// FileExporter appends spans to a JSONL file.
+type FileExporter struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileExporter creates an exporter that appends to path.
+func NewFileExporter(path string) *FileExporter {
+	return &FileExporter{path: path}
+}
+
+// Export appends one JSON line per span.
+func (f *FileExporter) Export(spans []*Span) error {
+	var buf bytes.Buffer
+	for _, s := range spans {
+		data, err := json.Marshal(FileRecord{
+			TraceID:      s.TraceID,
+			SpanID:       s.SpanID,
+			ParentSpanID: s.ParentSpanID,
+			Name:         s.Name,
+			Start:        s.StartTime.UTC(),
+			End:          s.EndTime.UTC(),
+			DurationMs:   s.Duration().Milliseconds(),
+			Status:       s.Status,
+			StatusMsg:    s.StatusMsg,
+			Attributes:   s.Attributes,
+		})
+		if err != nil {
+			return fmt.Errorf("marshaling span: %w", err)
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(f.path), 0755); err != nil {
+		return fmt.Errorf("creating trace directory: %w", err)
+	}
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644) //nolint:gosec // G302: trace log is non-sensitive
+	if err != nil {
+		return fmt.Errorf("opening trace file: %w", err)
+	}
+	defer file.Close()
+
+	_, err = file.Write(buf.Bytes())
+	return err
+}
+
+// OTLPExporter posts spans to an OTLP/HTTP collector using the JSON encoding.
+type OTLPExporter struct {
+	endpoint string
+	headers  map[string]string
+	client   *http.Client
+}
+
+// NewOTLPExporter creates an exporter posting to endpoint (a full
+// .../v1/traces URL).
+func NewOTLPExporter(endpoint string, headers map[string]string) *OTLPExporter {
+	return &OTLPExporter{
+		endpoint: endpoint,
+		headers:  headers,
+		client:   &http.Client{Timeout: exportTimeout},
+	}
+}
+
+// Export sends spans as a single ExportTraceServiceRequest.
+func (o *OTLPExporter) Export(spans []*Span) error {
+	body, err := json.Marshal(otlpRequest(spans))
+	if err != nil {
+		return fmt.Errorf("marshaling spans: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, o.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("building request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range o.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("posting spans: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("collector returned %s", resp.Status)
+	}
+	return nil
+}
+
+// OTLP JSON wire types (subset of opentelemetry-proto trace/v1).
+type (
+	otlpExportRequest struct {
+		ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+	}
+	otlpResourceSpans struct {
+		Resource   otlpResource     `json:"resource"`
+		ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+	}
+	otlpResource struct {
+		Attributes []otlpKeyValue `json:"attributes"`
+	}
+	otlpScopeSpans struct {
+		Scope otlpScope  `json:"scope"`
+		Spans []otlpSpan `json:"spans"`
+	}
+	otlpScope struct {
+		Name string `json:"name"`
+	}
+	otlpSpan struct {
+		TraceID           string         `json:"traceId"`
+		SpanID            string         `json:"spanId"`
+		ParentSpanID      string         `json:"parentSpanId,omitempty"`
+		Name              string         `json:"name"`
+		Kind              int            `json:"kind"`
+		StartTimeUnixNano string         `json:"startTimeUnixNano"`
+		EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+		Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+		Status            otlpStatus     `json:"status"`
+	}
+	otlpKeyValue struct {
+		Key   string       `json:"key"`
+		Value otlpAnyValue `json:"value"`
+	}
+	otlpAnyValue struct {
+		StringValue string `json:"stringValue"`
+	}
+	otlpStatus struct {
+		Code    int    `json:"code,omitempty"`
+		Message string `json:"message,omitempty"`
+	}
+)
+
+// otlpRequest converts spans to the OTLP JSON request shape.
+func otlpRequest(spans []*Span) otlpExportRequest {
+	out := make([]otlpSpan, 0, len(spans))
+	for _, s := range spans {
+		span := otlpSpan{
+			TraceID:           s.TraceID,
+			SpanID:            s.SpanID,
+			ParentSpanID:      s.ParentSpanID,
+			Name:              s.Name,
+			Kind:              1, // SPAN_KIND_INTERNAL
+			StartTimeUnixNano: strconv.FormatInt(s.StartTime.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(s.EndTime.UnixNano(), 10),
+			Attributes:        otlpAttributes(s.Attributes),
+		}
+		switch s.Status {
+		case StatusOK:
+			span.Status.Code = 1
+		case StatusError:
+			span.Status.Code = 2
+			span.Status.Message = s.StatusMsg
+		}
+		out = append(out, span)
+	}
+
+	return otlpExportRequest{
+		ResourceSpans: []otlpResourceSpans{{
+			Resource: otlpResource{
+				Attributes: otlpAttributes(map[string]string{"service.name": ServiceName}),
+			},
+			ScopeSpans: []otlpScopeSpans{{
+				Scope: otlpScope{Name: "github.com/steveyegge/gastown/internal/tracing"},
+				Spans: out,
+			}},
+		}},
+	}
+}
+
+// otlpAttributes converts a string map to OTLP key/values in key order.
+func otlpAttributes(attrs map[string]string) []otlpKeyValue {
+	keys := make([]string, 0, len(attrs))
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	kvs := make([]otlpKeyValue, 0, len(keys))
+	for _, k := range keys {
+		kvs = append(kvs, otlpKeyValue{Key: k, Value: otlpAnyValue{StringValue: attrs[k]}})
+	}
+	return kvs
+}
@@ -0,0 +1,243 @@
+// Package tracing records OpenTelemetry-compatible spans for work moving
+// through Gas Town: sling, polecat spawn, gt done, merge queue processing,
+// verification, and witness cleanup.
+//
+// Trace context crosses process boundaries as a W3C traceparent string
+// ("00-<trace-id>-<span-id>-01"). Agent sessions inherit it through the
+// TRACEPARENT environment variable; merge requests carry it in a
+// trace_parent field so the refinery and witness can continue the trace.
+//
+// Spans are exported when they end, to an OTLP/HTTP collector and/or a
+// local JSONL file (see config.TracingConfig). Export is best-effort:
+// tracing never fails the command it observes.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EnvTraceParent is the environment variable carrying the parent span into
+// child processes and agent sessions.
+const EnvTraceParent = "TRACEPARENT"
+
+// Standard span attribute keys.
+const (
+	AttrBead    = "gt.bead"
+	AttrConvoy  = "gt.convoy"
+	AttrMR      = "gt.mr"
+	AttrRig     = "gt.rig"
+	AttrPolecat = "gt.polecat"
+	AttrBranch  = "gt.branch"
+	AttrTarget  = "gt.target"
+)
+
+// Span status codes, matching OTLP semantics.
+const (
+	StatusUnset = ""
+	StatusOK    = "ok"
+	StatusError = "error"
+)
+
+// SpanContext identifies a span within a trace.
+type SpanContext struct {
+	TraceID string // 32 lowercase hex chars
+	SpanID  string // 16 lowercase hex chars
+}
+
+// IsValid reports whether both IDs are well-formed and non-zero.
+func (sc SpanContext) IsValid() bool {
+	return isHexID(sc.TraceID, 32) && isHexID(sc.SpanID, 16)
+}
+
+// TraceParent formats the span context as a W3C traceparent header.
+// Returns "" for an invalid context.
+func (sc SpanContext) TraceParent() string {
+	if !sc.IsValid() {
+		return ""
+	}
+	return fmt.Sprintf("00-%s-%s-01", sc.TraceID, sc.SpanID)
+}
+
+// ParseTraceParent parses a W3C traceparent header.
+// Returns false if the value is empty or malformed.
+func ParseTraceParent(s string) (SpanContext, bool) {
+	parts := strings.Split(strings.TrimSpace(s), "-")
+	if len(parts) != 4 || len(parts[0]) != 2 || len(parts[3]) != 2 {
+		return SpanContext{}, false
+	}
+	sc := SpanContext{TraceID: strings.ToLower(parts[1]), SpanID: strings.ToLower(parts[2])}
+	if !sc.IsValid() {
+		return SpanContext{}, false
+	}
+	return sc, true
+}
+
+// Span is a timed operation within a trace.
+type Span struct {
+	Name         string
+	TraceID      string
+	SpanID       string
+	ParentSpanID string
+	StartTime    time.Time
+	EndTime      time.Time
+	Attributes   map[string]string
+	Status       string
+	StatusMsg    string
+
+	mu    sync.Mutex
+	ended bool
+}
+
+// Context returns the span's identity for propagation.
+func (s *Span) Context() SpanContext {
+	return SpanContext{TraceID: s.TraceID, SpanID: s.SpanID}
+}
+
+// TraceParent returns the W3C traceparent for making this span a parent.
+func (s *Span) TraceParent() string {
+	return s.Context().TraceParent()
+}
+
+// SetAttr records an attribute. Empty values are ignored so callers can set
+// optional IDs unconditionally.
+func (s *Span) SetAttr(key, value string) {
+	if value == "" {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Attributes[key] = value
+}
+
+// SetError marks the span failed. A nil error is ignored.
+func (s *Span) SetError(err error) {
+	if err == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Status = StatusError
+	s.StatusMsg = err.Error()
+}
+
+// SetOK marks the span successful unless it has already failed.
+func (s *Span) SetOK() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Status != StatusError {
+		s.Status = StatusOK
+	}
+}
+
+// End finishes the span and exports it. Calling End more than once is a no-op.
+func (s *Span) End() {
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.EndTime = time.Now()
+	s.mu.Unlock()
+
+	if exp := currentExporter(); exp != nil {
+		_ = exp.Export([]*Span{s})
+	}
+}
+
+// Duration returns how long the span ran (zero until it ends).
+func (s *Span) Duration() time.Duration {
+	if s.EndTime.IsZero() {
+		return 0
+	}
+	return s.EndTime.Sub(s.StartTime)
+}
+
+type contextKey struct{}
+
+// ContextWithParent returns a context whose next span is a child of the
+// given traceparent. Invalid or empty values leave ctx unchanged.
+func ContextWithParent(ctx context.Context, traceParent string) context.Context {
+	sc, ok := ParseTraceParent(traceParent)
+	if !ok {
+		return ctx
+	}
+	return context.WithValue(ctx, contextKey{}, sc)
+}
+
+// ParentFromContext returns the span context stored in ctx, if any.
+func ParentFromContext(ctx context.Context) (SpanContext, bool) {
+	if ctx == nil {
+		return SpanContext{}, false
+	}
+	sc, ok := ctx.Value(contextKey{}).(SpanContext)
+	return sc, ok
+}
+
+// Start begins a span. The parent is taken from ctx, then from the
+// TRACEPARENT environment variable; otherwise a new trace is started.
+// The returned context carries the new span as parent for further children.
+func Start(ctx context.Context, name string) (context.Context, *Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	span := &Span{
+		Name:       name,
+		SpanID:     newID(8),
+		StartTime:  time.Now(),
+		Attributes: make(map[string]string),
+	}
+
+	parent, ok := ParentFromContext(ctx)
+	if !ok {
+		parent, ok = ParseTraceParent(os.Getenv(EnvTraceParent))
+	}
+	if ok {
+		span.TraceID = parent.TraceID
+		span.ParentSpanID = parent.SpanID
+	} else {
+		span.TraceID = newID(16)
+	}
+
+	return context.WithValue(ctx, contextKey{}, span.Context()), span
+}
+
+// newID returns n random bytes as lowercase hex.
+func newID(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		// Fall back to time-derived bytes; IDs only need to be unique-ish
+		now := time.Now().UnixNano()
+		for i := range b {
+			b[i] = byte(now >> (8 * (i % 8)))
+		}
+		b[0] |= 1 // never all zero
+	}
+	return hex.EncodeToString(b)
+}
+
+// isHexID reports whether s is n lowercase hex chars and not all zeros.
+func isHexID(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+	nonZero := false
+	for _, c := range s {
+		switch {
+		case c == '0':
+		case (c >= '1' && c <= '9') || (c >= 'a' && c <= 'f'):
+			nonZero = true
+		default:
+			return false
+		}
+	}
+	return nonZero
+}
@@ -0,0 +1,245 @@
+package tracing
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// recordingExporter captures exported spans in memory.
+type recordingExporter struct {
+	mu    sync.Mutex
+	spans []*Span
+}
+
+func (r *recordingExporter) Export(spans []*Span) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.spans = append(r.spans, spans...)
+	return nil
+}
+
+func withExporter(t *testing.T, e Exporter) {
+	t.Helper()
+	SetExporter(e)
+	t.Cleanup(func() { SetExporter(nil) })
+}
+
+func TestParseTraceParent(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		ok    bool
+	}{
+		{"valid", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", true},
+		{"uppercase normalized", "00-4BF92F3577B34DA6A3CE929D0E0E4736-00F067AA0BA902B7-01", true},
+		{"empty", "", false},
+		{"too few parts", "00-4bf92f3577b34da6a3ce929d0e0e4736-01", false},
+		{"short trace id", "00-4bf92f35-00f067aa0ba902b7-01", false},
+		{"zero trace id", "00-00000000000000000000000000000000-00f067aa0ba902b7-01", false},
+		{"zero span id", "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", false},
+		{"non-hex", "00-4bf92f3577b34da6a3ce929d0e0e473z-00f067aa0ba902b7-01", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc, ok := ParseTraceParent(tt.input)
+			if ok != tt.ok {
+				t.Fatalf("ParseTraceParent(%q) ok = %v, want %v", tt.input, ok, tt.ok)
+			}
+			if ok && sc.TraceParent() != "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01" {
+				t.Errorf("round trip = %q", sc.TraceParent())
+			}
+		})
+	}
+}
+
+func TestStartParentResolution(t *testing.T) {
+	withExporter(t, nil)
+	const envParent = "00-11111111111111111111111111111111-2222222222222222-01"
+	const ctxParent = "00-33333333333333333333333333333333-4444444444444444-01"
+
+	t.Run("new trace without parent", func(t *testing.T) {
+		t.Setenv(EnvTraceParent, "")
+		_, span := Start(context.Background(), "root")
+		if span.ParentSpanID != "" {
+			t.Errorf("ParentSpanID = %q, want empty", span.ParentSpanID)
+		}
+		if !span.Context().IsValid() {
+			t.Errorf("generated context invalid: %+v", span.Context())
+		}
+	})
+
+	t.Run("env parent", func(t *testing.T) {
+		t.Setenv(EnvTraceParent, envParent)
+		_, span := Start(context.Background(), "child")
+		if span.TraceID != "11111111111111111111111111111111" || span.ParentSpanID != "2222222222222222" {
+			t.Errorf("got trace=%s parent=%s", span.TraceID, span.ParentSpanID)
+		}
+	})
+
+	t.Run("context beats env", func(t *testing.T) {
+		t.Setenv(EnvTraceParent, envParent)
+		ctx := ContextWithParent(context.Background(), ctxParent)
+		_, span := Start(ctx, "child")
+		if span.TraceID != "33333333333333333333333333333333" || span.ParentSpanID != "4444444444444444" {
+			t.Errorf("got trace=%s parent=%s", span.TraceID, span.ParentSpanID)
+		}
+	})
+
+	t.Run("nested spans chain", func(t *testing.T) {
+		t.Setenv(EnvTraceParent, "")
+		ctx, parent := Start(context.Background(), "parent")
+		_, child := Start(ctx, "child")
+		if child.TraceID != parent.TraceID || child.ParentSpanID != parent.SpanID {
+			t.Errorf("child not linked to parent: %+v vs %+v", child.Context(), parent.Context())
+		}
+	})
+}
+
+func TestSpanEndExportsOnce(t *testing.T) {
+	rec := &recordingExporter{}
+	withExporter(t, rec)
+	t.Setenv(EnvTraceParent, "")
+
+	_, span := Start(context.Background(), "work")
+	span.SetAttr(AttrBead, "gt-abc")
+	span.SetAttr(AttrConvoy, "") // ignored
+	span.SetError(errors.New("boom"))
+	span.SetOK() // must not clear the error
+	span.End()
+	span.End()
+
+	if len(rec.spans) != 1 {
+		t.Fatalf("exported %d spans, want 1", len(rec.spans))
+	}
+	got := rec.spans[0]
+	if got.Attributes[AttrBead] != "gt-abc" {
+		t.Errorf("bead attr = %q", got.Attributes[AttrBead])
+	}
+	if _, ok := got.Attributes[AttrConvoy]; ok {
+		t.Error("empty attribute should not be recorded")
+	}
+	if got.Status != StatusError || got.StatusMsg != "boom" {
+		t.Errorf("status = %q/%q, want error/boom", got.Status, got.StatusMsg)
+	}
+	if got.EndTime.Before(got.StartTime) {
+		t.Error("end before start")
+	}
+}
+
+func TestFileExporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "traces.jsonl")
+	withExporter(t, NewFileExporter(path))
+	t.Setenv(EnvTraceParent, "")
+
+	ctx, parent := Start(context.Background(), "gt.sling")
+	parent.SetAttr(AttrBead, "gt-abc")
+	_, child := Start(ctx, "polecat.spawn")
+	child.End()
+	parent.End()
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("opening trace file: %v", err)
+	}
+	defer f.Close()
+
+	var records []FileRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var r FileRecord
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			t.Fatalf("bad line %q: %v", scanner.Text(), err)
+		}
+		records = append(records, r)
+	}
+
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want 2", len(records))
+	}
+	if records[0].Name != "polecat.spawn" || records[0].ParentSpanID != parent.SpanID {
+		t.Errorf("child record = %+v", records[0])
+	}
+	if records[1].Attributes[AttrBead] != "gt-abc" {
+		t.Errorf("parent attributes = %v", records[1].Attributes)
+	}
+}
+
+func TestOTLPExporter(t *testing.T) {
+	var (
+		gotBody   []byte
+		gotHeader string
+		gotPath   string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotHeader = r.Header.Get("X-Token")
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	exp := NewOTLPExporter(srv.URL+"/v1/traces", map[string]string{"X-Token": "secret"})
+	span := &Span{
+		Name:       "refinery.process_mr",
+		TraceID:    "4bf92f3577b34da6a3ce929d0e0e4736",
+		SpanID:     "00f067aa0ba902b7",
+		Attributes: map[string]string{AttrMR: "gt-mr1"},
+		Status:     StatusError,
+		StatusMsg:  "tests failed",
+	}
+	if err := exp.Export([]*Span{span}); err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+
+	if gotPath != "/v1/traces" || gotHeader != "secret" {
+		t.Errorf("path=%q header=%q", gotPath, gotHeader)
+	}
+
+	var req otlpExportRequest
+	if err := json.Unmarshal(gotBody, &req); err != nil {
+		t.Fatalf("decoding body: %v", err)
+	}
+	spans := req.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans", len(spans))
+	}
+	s := spans[0]
+	if s.TraceID != span.TraceID || s.Name != span.Name {
+		t.Errorf("span = %+v", s)
+	}
+	if s.Status.Code != 2 || s.Status.Message != "tests failed" {
+		t.Errorf("status = %+v", s.Status)
+	}
+	if len(s.Attributes) != 1 || s.Attributes[0].Key != AttrMR || s.Attributes[0].Value.StringValue != "gt-mr1" {
+		t.Errorf("attributes = %+v", s.Attributes)
+	}
+}
+
+func TestOTLPExporterErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	exp := NewOTLPExporter(srv.URL, nil)
+	if err := exp.Export([]*Span{{Name: "x", Attributes: map[string]string{}}}); err == nil {
+		t.Error("expected error for 503 response")
+	}
+}
+
+func TestParseHeaders(t *testing.T) {
+	got := parseHeaders("api-key=abc, x-team = core ,bogus")
+	if len(got) != 2 || got["api-key"] != "abc" || got["x-team"] != "core" {
+		t.Errorf("parseHeaders = %v", got)
+	}
+}
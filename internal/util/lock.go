@@ -0,0 +1,23 @@
+package util
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// WithFileLock runs fn while holding an exclusive lock on path + ".lock",
+// so processes doing a load, modify and save of path don't lose each
+// other's changes.
+func WithFileLock(path string, fn func() error) error {
+	lock, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return fmt.Errorf("opening lock: %w", err)
+	}
+	defer lock.Close()
+	if err := syscall.Flock(int(lock.Fd()), syscall.LOCK_EX); err != nil {
+		return fmt.Errorf("locking %s: %w", path, err)
+	}
+	defer func() { _ = syscall.Flock(int(lock.Fd()), syscall.LOCK_UN) }()
+	return fn()
+}
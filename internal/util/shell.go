@@ -0,0 +1,26 @@
+package util
+
+import (
+	"regexp"
+	"strings"
+)
+
+// safeShellRe matches arguments that need no quoting.
+var safeShellRe = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
+// ShellQuote single-quotes s for sh unless it is already safe.
+func ShellQuote(s string) string {
+	if safeShellRe.MatchString(s) {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// ShellJoin quotes each argument and joins them into a sh command line.
+func ShellJoin(args []string) string {
+	quoted := make([]string, len(args))
+	for i, a := range args {
+		quoted[i] = ShellQuote(a)
+	}
+	return strings.Join(quoted, " ")
+}
@@ -0,0 +1,36 @@
+package util
+
+import (
+	"os/exec"
+	"testing"
+)
+
+func TestShellQuote(t *testing.T) {
+	tests := map[string]string{
+		"plain":       "plain",
+		"/a/b-c.d":    "/a/b-c.d",
+		"MemoryMax=1": "MemoryMax=1",
+		"two words":   "'two words'",
+		"it's":        `'it'\''s'`,
+		"":            "''",
+	}
+	for in, want := range tests {
+		if got := ShellQuote(in); got != want {
+			t.Errorf("ShellQuote(%q) = %s, want %s", in, got, want)
+		}
+	}
+}
+
+func TestShellJoinRoundTrip(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	args := []string{`echo "a b"`, `'c'`, "$HOME"}
+	out, err := exec.Command("sh", "-c", "printf '%s|' "+ShellJoin(args)).Output()
+	if err != nil {
+		t.Fatalf("sh: %v", err)
+	}
+	if want := `echo "a b"|'c'|$HOME|`; string(out) != want {
+		t.Errorf("round trip = %q, want %q", out, want)
+	}
+}
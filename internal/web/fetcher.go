@@ -7,15 +7,26 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/steveyegge/gastown/internal/activity"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/forecast"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
+// historyTTL is how long forecast history is reused between refreshes.
+// Loading it reads every rig's event logs and closed issues.
+const historyTTL = 5 * time.Minute
+
 // LiveConvoyFetcher fetches convoy data from beads.
 type LiveConvoyFetcher struct {
 	townBeads string
+
+	historyMu sync.Mutex
+	history   *forecast.History
+	historyAt time.Time
 }
 
 // NewLiveConvoyFetcher creates a fetcher for the current workspace.
@@ -122,6 +133,8 @@ func (f *LiveConvoyFetcher) FetchConvoys() ([]ConvoyRow, error) {
 
 		// Calculate work status based on progress and activity
 		row.WorkStatus = calculateWorkStatus(row.Completed, row.Total, row.LastActivity.ColorClass)
+		row.WaitingOn = beads.ConvoyWaitingOn(f.townBeads, c.ID)
+		row.ETA = f.getConvoyETA(tracked)
 
 		// Get tracked issues for expandable view
 		row.TrackedIssues = make([]TrackedIssue, len(tracked))
@@ -140,6 +153,36 @@ func (f *LiveConvoyFetcher) FetchConvoys() ([]ConvoyRow, error) {
 	return rows, nil
 }
 
+// getConvoyETA forecasts when a convoy's unfinished issues will land.
+// Returns "" when there is nothing left or no history to forecast from.
+func (f *LiveConvoyFetcher) getConvoyETA(tracked []trackedIssueInfo) string {
+	townRoot := filepath.Dir(f.townBeads)
+	remaining := make(map[string]int)
+	for _, t := range tracked {
+		if t.Status != "closed" && t.Status != "tombstone" {
+			remaining[beads.GetRigForIssue(townRoot, t.ID)]++
+		}
+	}
+
+	eta := f.forecastHistory().Estimate(remaining)
+	if eta.Remaining == 0 || !eta.Known {
+		return ""
+	}
+	return eta.String()
+}
+
+// forecastHistory returns cached forecast history, reloading it after historyTTL.
+func (f *LiveConvoyFetcher) forecastHistory() *forecast.History {
+	f.historyMu.Lock()
+	defer f.historyMu.Unlock()
+
+	if f.history == nil || time.Since(f.historyAt) > historyTTL {
+		f.history = forecast.Load(filepath.Dir(f.townBeads), forecast.DefaultWindow, time.Now())
+		f.historyAt = time.Now()
+	}
+	return f.history
+}
+
 // trackedIssueInfo holds info about an issue being tracked by a convoy.
 type trackedIssueInfo struct {
 	ID           string
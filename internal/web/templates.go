@@ -49,6 +49,8 @@ type ConvoyRow struct {
 	Completed     int
 	Total         int
 	LastActivity  activity.Info
+	WaitingOn     []string // Unlanded convoys this convoy starts after
+	ETA           string   // Forecast landing range, e.g. "2h–5h" (empty if unknown)
 	TrackedIssues []TrackedIssue
 }
 
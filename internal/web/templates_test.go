@@ -236,3 +236,74 @@ func TestConvoyTemplate_EmptyState(t *testing.T) {
 		t.Error("Template should show empty state message when no convoys")
 	}
 }
+
+func TestConvoyTemplate_WaitingOn(t *testing.T) {
+	tmpl, err := LoadTemplates()
+	if err != nil {
+		t.Fatalf("LoadTemplates() error = %v", err)
+	}
+
+	data := ConvoyData{
+		Convoys: []ConvoyRow{
+			{
+				ID:           "hq-cv-web",
+				Title:        "Frontend",
+				Status:       "open",
+				WorkStatus:   "waiting",
+				Progress:     "0/3",
+				Total:        3,
+				WaitingOn:    []string{"hq-cv-api", "hq-cv-schema"},
+				LastActivity: activity.Info{FormattedAge: "unassigned", ColorClass: activity.ColorUnknown},
+			},
+			{
+				ID:           "hq-cv-api",
+				Title:        "Backend",
+				Status:       "open",
+				Progress:     "1/2",
+				Completed:    1,
+				Total:        2,
+				LastActivity: activity.Calculate(time.Now().Add(-1 * time.Minute)),
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, "convoy.html", data); err != nil {
+		t.Fatalf("ExecuteTemplate() error = %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "waiting on hq-cv-api, hq-cv-schema") {
+		t.Error("Template should show the convoys hq-cv-web is waiting on")
+	}
+	if strings.Count(output, `class="convoy-waiting-on"`) != 1 {
+		t.Error("Only convoys with dependencies should show a waiting-on line")
+	}
+}
+
+func TestConvoyTemplate_ETA(t *testing.T) {
+	tmpl, err := LoadTemplates()
+	if err != nil {
+		t.Fatalf("LoadTemplates() error = %v", err)
+	}
+
+	data := ConvoyData{
+		Convoys: []ConvoyRow{
+			{ID: "hq-cv-abc", Title: "Feature X", Status: "open", Progress: "1/4", Completed: 1, Total: 4, ETA: "2h–5h"},
+			{ID: "hq-cv-def", Title: "Bugfix Y", Status: "open", Progress: "0/1", Total: 1},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, "convoy.html", data); err != nil {
+		t.Fatalf("ExecuteTemplate() error = %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "ETA 2h–5h") {
+		t.Error("Template should show the forecast ETA")
+	}
+	if strings.Count(output, `class="eta"`) != 1 {
+		t.Error("Convoys without a forecast should not show an ETA")
+	}
+}
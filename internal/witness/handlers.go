@@ -2,6 +2,7 @@ package witness
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"os/exec"
@@ -14,6 +15,7 @@ import (
 	"github.com/steveyegge/gastown/internal/mail"
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/tracing"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
@@ -52,6 +54,12 @@ func HandlePolecatDone(workDir, rigName string, msg *mail.Message) *HandlerResul
 		return result
 	}
 
+	span := startCleanupSpan(rigName, payload.PolecatName, payload.TraceParent)
+	span.SetAttr(tracing.AttrBead, payload.IssueID)
+	span.SetAttr(tracing.AttrMR, payload.MRID)
+	span.SetAttr("gt.exit", payload.Exit)
+	defer endCleanupSpan(span, result)
+
 	// Handle PHASE_COMPLETE: recycle polecat (session ends but worktree stays)
 	// The polecat is registered as a waiter on the gate and will be re-dispatched
 	// when the gate closes via gt gate wake.
@@ -206,6 +214,11 @@ func HandleMerged(workDir, rigName string, msg *mail.Message) *HandlerResult {
 		return result
 	}
 
+	span := startCleanupSpan(rigName, payload.PolecatName, payload.TraceParent)
+	span.SetAttr(tracing.AttrBead, payload.IssueID)
+	span.SetAttr(tracing.AttrBranch, payload.Branch)
+	defer endCleanupSpan(span, result)
+
 	// Find the cleanup wisp for this polecat
 	wispID, err := findCleanupWisp(workDir, payload.PolecatName)
 	if err != nil {
@@ -295,6 +308,27 @@ func HandleMerged(workDir, rigName string, msg *mail.Message) *HandlerResult {
 	return result
 }
 
+// startCleanupSpan begins a span for a witness cleanup decision.
+// It continues the polecat's trace when the message carried a traceparent.
+func startCleanupSpan(rigName, polecatName, traceParent string) *tracing.Span {
+	_, span := tracing.Start(tracing.ContextWithParent(context.Background(), traceParent), "witness.cleanup")
+	span.SetAttr(tracing.AttrRig, rigName)
+	span.SetAttr(tracing.AttrPolecat, polecatName)
+	return span
+}
+
+// endCleanupSpan records the handler outcome on the span and ends it.
+func endCleanupSpan(span *tracing.Span, result *HandlerResult) {
+	span.SetAttr("gt.action", result.Action)
+	span.SetAttr("gt.wisp", result.WispCreated)
+	if result.Error != nil {
+		span.SetError(result.Error)
+	} else {
+		span.SetOK()
+	}
+	span.End()
+}
+
 // HandleSwarmStart processes a SWARM_START message from the Mayor.
 // Creates a swarm tracking wisp to monitor batch polecat work.
 func HandleSwarmStart(workDir string, msg *mail.Message) *HandlerResult {
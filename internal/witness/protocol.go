@@ -50,6 +50,7 @@ type PolecatDonePayload struct {
 	MRID        string
 	Branch      string
 	Gate        string // Gate ID when Exit is PHASE_COMPLETE
+	TraceParent string // W3C traceparent of the gt done span (optional)
 }
 
 // HelpPayload contains parsed data from a HELP message.
@@ -68,6 +69,7 @@ type MergedPayload struct {
 	Branch      string
 	IssueID     string
 	MergedAt    time.Time
+	TraceParent string // W3C traceparent to continue (optional)
 }
 
 // SwarmStartPayload contains parsed data from a SWARM_START message.
@@ -130,6 +132,8 @@ func ParsePolecatDone(subject, body string) (*PolecatDonePayload, error) {
 			payload.Gate = strings.TrimSpace(strings.TrimPrefix(line, "Gate:"))
 		} else if strings.HasPrefix(line, "Branch:") {
 			payload.Branch = strings.TrimSpace(strings.TrimPrefix(line, "Branch:"))
+		} else if strings.HasPrefix(line, "Trace-Parent:") {
+			payload.TraceParent = strings.TrimSpace(strings.TrimPrefix(line, "Trace-Parent:"))
 		}
 	}
 
@@ -201,6 +205,8 @@ func ParseMerged(subject, body string) (*MergedPayload, error) {
 			if t, err := time.Parse(time.RFC3339, ts); err == nil {
 				payload.MergedAt = t
 			}
+		} else if strings.HasPrefix(line, "Trace-Parent:") {
+			payload.TraceParent = strings.TrimSpace(strings.TrimPrefix(line, "Trace-Parent:"))
 		}
 	}
 
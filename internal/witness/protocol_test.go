@@ -62,6 +62,24 @@ Branch: feature-branch`
 	}
 }
 
+func TestParsePolecatDone_TraceParent(t *testing.T) {
+	subject := "POLECAT_DONE nux"
+	body := `Exit: COMPLETED
+Issue: gt-abc123
+Branch: polecat/nux/gt-abc123
+Trace-Parent: 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01`
+
+	payload, err := ParsePolecatDone(subject, body)
+	if err != nil {
+		t.Fatalf("ParsePolecatDone() error = %v", err)
+	}
+
+	want := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	if payload.TraceParent != want {
+		t.Errorf("TraceParent = %q, want %q", payload.TraceParent, want)
+	}
+}
+
 func TestParsePolecatDone_MinimalBody(t *testing.T) {
 	subject := "POLECAT_DONE ace"
 	body := "Exit: DEFERRED"